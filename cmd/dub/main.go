@@ -2,16 +2,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"os"
 
 	"github.com/salmonumbrella/dub-cli/internal/cmd"
 )
 
 func main() {
-	if err := cmd.Execute(os.Args[1:]); err != nil {
+	ctx, stop := cmd.NotifyContext(context.Background())
+	err := cmd.ExecuteContext(ctx, os.Args[1:])
+	stop()
+	if err != nil {
 		if cmd.IsUsageError(err) {
 			os.Exit(2)
 		}
+		if errors.Is(err, cmd.ErrInterrupted) {
+			os.Exit(130)
+		}
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// AuditEntry describes a completed POST, PUT, PATCH, or DELETE request.
+type AuditEntry struct {
+	Method string
+	Path   string
+	// BodyDigest is the hex SHA-256 of the request body, or "" without one
+	BodyDigest string
+	// ResourceID is the "id" of the response, or else the path segment
+	// after the resource name, e.g. "link_123" in /links/link_123
+	ResourceID string
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Err        error
+}
+
+// Auditor receives an entry for every mutating request the client makes.
+type Auditor interface {
+	Audit(AuditEntry)
+}
+
+// SetAuditor reports every POST, PUT, PATCH, and DELETE request to a once
+// it completes. a may be called from several goroutines at once.
+func (c *Client) SetAuditor(a Auditor) {
+	c.auditor = a
+}
+
+// audit reports a mutating request to the auditor. A successful response
+// body is restored after its ID is read so callers can still read it.
+func (c *Client) audit(req *http.Request, resp *http.Response, err error) {
+	if c.auditor == nil {
+		return
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return
+	}
+
+	entry := AuditEntry{
+		Method:     req.Method,
+		Path:       strings.TrimPrefix(req.URL.Path, c.basePath()),
+		BodyDigest: requestDigest(req),
+		Err:        err,
+	}
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+		if resp.StatusCode < 300 {
+			entry.ResourceID = responseID(resp)
+		}
+	}
+	if entry.ResourceID == "" {
+		entry.ResourceID = pathResourceID(entry.Path)
+	}
+	c.auditor.Audit(entry)
+}
+
+// basePath is the path prefix of the base URL, e.g. "/api" for
+// https://example.com/api.
+func (c *Client) basePath() string {
+	_, rest, ok := strings.Cut(c.baseURL, "://")
+	if !ok {
+		return ""
+	}
+	if i := strings.Index(rest, "/"); i >= 0 {
+		return rest[i:]
+	}
+	return ""
+}
+
+func requestDigest(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = body.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseID reads the "id" of a JSON object response and puts the body
+// back, including any error reading it.
+func responseID(resp *http.Response) string {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+		return ""
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var v struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(body, &v) != nil {
+		return ""
+	}
+	return v.ID
+}
+
+// pathResourceID returns the segment after the resource name in path.
+func pathResourceID(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// errReader fails every read with err.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type recordingAuditor []AuditEntry
+
+func (r *recordingAuditor) Audit(e AuditEntry) {
+	*r = append(*r, e)
+}
+
+func TestAudit_RecordsMutatingRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			_, _ = w.Write([]byte(`{"id": "link_new"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": "not_found", "message": "Link not found"}}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	var entries recordingAuditor
+	client := NewClient("dub_test123")
+	client.SetBaseURL(server.URL)
+	client.SetAuditor(&entries)
+	ctx := context.Background()
+
+	resp, err := client.Post(ctx, "/links", map[string]string{"url": "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != `{"id": "link_new"}` {
+		t.Errorf("response body not restored: %q", body)
+	}
+
+	for _, path := range []string{"/links", "/links/link_gone"} {
+		var resp *http.Response
+		if path == "/links" {
+			resp, err = client.Get(ctx, path)
+		} else {
+			resp, err = client.Delete(ctx, path)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d audit entries, want 2 (GETs are not audited): %+v", len(entries), entries)
+	}
+	created := entries[0]
+	if created.Method != "POST" || created.Path != "/links" || created.ResourceID != "link_new" || created.StatusCode != 200 || len(created.BodyDigest) != 64 {
+		t.Errorf("unexpected POST entry: %+v", created)
+	}
+	deleted := entries[1]
+	if deleted.ResourceID != "link_gone" || deleted.StatusCode != 404 || deleted.BodyDigest != "" {
+		t.Errorf("unexpected DELETE entry: %+v", deleted)
+	}
+}
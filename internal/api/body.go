@@ -0,0 +1,90 @@
+package api
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxResponseSize is the largest decompressed response body the
+// client reads before failing.
+const DefaultMaxResponseSize int64 = 64 << 20
+
+// ResponseTooLargeError is returned while reading a response body that
+// exceeds the client's maximum response size.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds the %d byte limit (raise it with DUB_MAX_RESPONSE_SIZE)", e.Limit)
+}
+
+// prepareBody transparently decompresses gzip responses and caps how much of
+// the body can be read.
+func (c *Client) prepareBody(resp *http.Response) error {
+	limit := c.maxResponseSize
+	if limit > 0 && resp.ContentLength > limit && !isGzip(resp) {
+		closeBody(resp)
+		return &ResponseTooLargeError{Limit: limit}
+	}
+
+	if isGzip(resp) {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			closeBody(resp)
+			return fmt.Errorf("failed to decompress response: %w", err)
+		}
+		resp.Body = &gzipBody{Reader: gz, raw: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+
+	if limit > 0 {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: limit, limit: limit}
+	}
+	return nil
+}
+
+func isGzip(resp *http.Response) bool {
+	return strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip")
+}
+
+// gzipBody closes both the gzip reader and the underlying body.
+type gzipBody struct {
+	*gzip.Reader
+	raw io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	_ = b.Reader.Close()
+	return b.raw.Close()
+}
+
+// limitedBody fails reads once more than limit bytes have been read, rather
+// than silently truncating like io.LimitReader.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, &ResponseTooLargeError{Limit: b.limit}
+	}
+	// Read one byte past the limit to detect oversized bodies
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), &ResponseTooLargeError{Limit: b.limit}
+	}
+	return n, err
+}
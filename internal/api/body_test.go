@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_DecompressesGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected Accept-Encoding: gzip, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, _ = gz.Write([]byte(`{"id":"123"}`))
+		_ = gz.Close()
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test")
+	client.baseURL = server.URL
+
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"id":"123"}` {
+		t.Errorf("expected decompressed body, got %q", body)
+	}
+}
+
+func TestClient_MaxResponseSize(t *testing.T) {
+	payload := strings.Repeat("x", 100)
+
+	tests := []struct {
+		name    string
+		gzipped bool
+		chunked bool
+	}{
+		{"content length", false, false},
+		{"chunked", false, true},
+		{"gzip", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.gzipped {
+					w.Header().Set("Content-Encoding", "gzip")
+					gz := gzip.NewWriter(w)
+					_, _ = gz.Write([]byte(payload))
+					_ = gz.Close()
+					return
+				}
+				if tt.chunked {
+					_, _ = w.Write([]byte(payload[:50]))
+					w.(http.Flusher).Flush()
+					_, _ = w.Write([]byte(payload[50:]))
+					return
+				}
+				_, _ = w.Write([]byte(payload))
+			}))
+			defer server.Close()
+
+			client := NewClient("dub_test")
+			client.baseURL = server.URL
+			client.SetMaxResponseSize(64)
+
+			var tooLarge *ResponseTooLargeError
+			resp, err := client.Get(context.Background(), "/test")
+			if err == nil {
+				_, err = io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+			}
+			if !errors.As(err, &tooLarge) || tooLarge.Limit != 64 {
+				t.Errorf("expected ResponseTooLargeError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_ResponseAtLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 64)))
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test")
+	client.baseURL = server.URL
+	client.SetMaxResponseSize(64)
+
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || len(body) != 64 {
+		t.Errorf("expected full 64-byte body, got %d bytes, err %v", len(body), err)
+	}
+}
@@ -13,7 +13,9 @@ import (
 	"log/slog"
 	mathrand "math/rand"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -48,14 +50,50 @@ type Client struct {
 	apiKey     string
 	httpClient *http.Client
 
-	// Circuit breaker state
-	cbMu               sync.RWMutex
-	cbState            CircuitState
-	cbConsecutive5xx   int
-	cbOpenedAt         time.Time
-	cbCooldown         time.Duration
-	cbThreshold        int
-	cbHalfOpenInFlight bool
+	// Circuit breaker settings, with state tracked separately per host so
+	// failures on one host don't block requests to another
+	cbMu        sync.RWMutex
+	cbHosts     map[string]*hostBreaker
+	cbCooldown  time.Duration
+	cbThreshold int
+	cbOnOpen    func(host string, cooldown time.Duration)
+
+	// Rate limit pause shared by every request on this client, so concurrent
+	// workers back off together when any of them is rate limited
+	rlMu          sync.Mutex
+	rlPausedUntil time.Time
+
+	// Optional client-side limiter applied before every request attempt
+	limiter *RateLimiter
+
+	// Largest decompressed response body accepted; 0 disables the limit
+	maxResponseSize int64
+
+	// Optional record of the key's scopes, used to fail fast on requests the
+	// key is known to lack permission for
+	permissions Permissions
+
+	// Optional recipient of every mutating request, for audit logging
+	auditor Auditor
+
+	// Optional callback for every response received, e.g. to show headers
+	onResponse func(req *http.Request, resp *http.Response)
+
+	// Identical GETs in flight, keyed by URL, so concurrent callers share
+	// one request
+	inflightMu sync.Mutex
+	inflight   map[string]*inflightGet
+}
+
+// sharedTransport is used by every client so that long-lived processes
+// such as the daemon reuse warm connections across commands.
+var sharedTransport = &http.Transport{
+	MaxIdleConns:    100,
+	MaxConnsPerHost: 10,
+	IdleConnTimeout: 90 * time.Second,
+	TLSClientConfig: &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	},
 }
 
 func NewClient(apiKey string) *Client {
@@ -63,26 +101,46 @@ func NewClient(apiKey string) *Client {
 		baseURL: BaseURL,
 		apiKey:  apiKey,
 		httpClient: &http.Client{
-			Timeout: DefaultHTTPTimeout,
-			Transport: &http.Transport{
-				MaxIdleConns:    100,
-				MaxConnsPerHost: 10,
-				IdleConnTimeout: 90 * time.Second,
-				TLSClientConfig: &tls.Config{
-					MinVersion: tls.VersionTLS12,
-				},
-			},
+			Timeout:   DefaultHTTPTimeout,
+			Transport: sharedTransport,
 		},
-		cbState:     CircuitClosed,
-		cbCooldown:  CircuitBreakerCooldown,
-		cbThreshold: CircuitBreakerThreshold,
+		cbHosts:         map[string]*hostBreaker{},
+		cbCooldown:      CircuitBreakerCooldown,
+		cbThreshold:     CircuitBreakerThreshold,
+		maxResponseSize: DefaultMaxResponseSize,
+		inflight:        map[string]*inflightGet{},
 	}
 }
 
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Content-Type", "application/json")
-	return c.doWithRetry(ctx, req)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := c.send(ctx, req)
+	c.audit(req, resp, err)
+	if resp != nil && c.onResponse != nil {
+		c.onResponse(req, resp)
+	}
+	return resp, err
+}
+
+// send makes the request, applying permission checks and response limits.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	scope := RequiredScope(req.Method, req.URL.Path)
+	if err := c.checkPermission(scope); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.prepareBody(resp); err != nil {
+		return nil, err
+	}
+	c.learnPermission(scope, resp)
+	return resp, nil
 }
 
 func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
@@ -96,8 +154,19 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 	reqID := generateRequestID()
 
 	for {
+		// Wait out any rate limit pause triggered by another request
+		if err := c.waitForRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
 		// Check circuit breaker before making request
-		if err := c.checkCircuitBreaker(); err != nil {
+		if err := c.checkCircuitBreaker(req.URL.Host); err != nil {
 			return nil, err
 		}
 
@@ -113,13 +182,13 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 
 		// 2xx: success, reset circuit breaker
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			c.recordSuccess()
+			c.recordSuccess(req.URL.Host)
 			return resp, nil
 		}
 
 		// 4xx (except 429): no retry, but reset consecutive 5xx counter
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
-			c.recordSuccess() // 4xx is not a server error, reset counter
+			c.recordSuccess(req.URL.Host) // 4xx is not a server error, reset counter
 			return resp, nil
 		}
 
@@ -133,10 +202,8 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 			jitter := time.Duration(mathrand.Int63n(int64(baseDelay / 2)))
 			delay := baseDelay + jitter
 
-			if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-				if seconds, err := strconv.Atoi(retryAfter); err == nil {
-					delay = time.Duration(seconds) * time.Second
-				}
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
 			}
 
 			slog.Info("rate limited, retrying", "req_id", reqID, "delay", delay, "attempt", retries429+1)
@@ -149,19 +216,15 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 				}
 			}
 
-			select {
-			case <-time.After(delay):
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			}
-
+			// Pause every request on this client, then wait at the top of the loop
+			c.pauseFor(delay)
 			retries429++
 			continue
 		}
 
 		// 5xx: record error and retry once for idempotent
 		if resp.StatusCode >= 500 {
-			c.record5xxError()
+			c.record5xxError(req.URL.Host)
 
 			if !isIdempotent || retries5xx >= Max5xxRetries {
 				return resp, nil
@@ -191,12 +254,10 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 	}
 }
 
+// Get fetches path. Identical GETs issued concurrently on the same client
+// are coalesced into a single request.
 func (c *Client) Get(ctx context.Context, path string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
-	if err != nil {
-		return nil, err
-	}
-	return c.Do(ctx, req)
+	return c.getCoalesced(ctx, c.baseURL+path)
 }
 
 func (c *Client) Post(ctx context.Context, path string, body interface{}) (*http.Response, error) {
@@ -291,92 +352,249 @@ func (c *Client) DeleteWithBody(ctx context.Context, path string, body interface
 	return c.Do(ctx, req)
 }
 
+// SetBaseURL overrides the API base URL, e.g. for self-hosted deployments or tests.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// BaseURL returns the API base URL requests are sent to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetCircuitBreaker configures how many consecutive 5xx responses open the
+// circuit and how long it stays open. A threshold of zero or less disables
+// the breaker.
+func (c *Client) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	c.cbMu.Lock()
+	defer c.cbMu.Unlock()
+	c.cbThreshold = threshold
+	c.cbCooldown = cooldown
+}
+
+// OnCircuitOpen registers fn to be called whenever the circuit breaker for a
+// host opens, with the cooldown before requests to it are allowed again. fn
+// must not call back into the client.
+func (c *Client) OnCircuitOpen(fn func(host string, cooldown time.Duration)) {
+	c.cbMu.Lock()
+	defer c.cbMu.Unlock()
+	c.cbOnOpen = fn
+}
+
+// OnResponse registers fn to be called with the final response to every
+// request, after retries. fn must not read the body and may be called from
+// several goroutines at once.
+func (c *Client) OnResponse(fn func(req *http.Request, resp *http.Response)) {
+	c.onResponse = fn
+}
+
+// SetRateLimiter throttles every request made by this client, including
+// retries, through l. Share one limiter between clients to limit them jointly.
+func (c *Client) SetRateLimiter(l *RateLimiter) {
+	c.limiter = l
+}
+
+// SetPermissions records the key's scopes in p as responses reveal them and
+// rejects requests for scopes p says the key lacks.
+func (c *Client) SetPermissions(p Permissions) {
+	c.permissions = p
+}
+
+// SetMaxResponseSize sets the largest decompressed response body the client
+// will read. Zero or less disables the limit.
+func (c *Client) SetMaxResponseSize(n int64) {
+	c.maxResponseSize = n
+}
+
 // APIKey returns the API key used by this client (for testing).
 func (c *Client) APIKey() string {
 	return c.apiKey
 }
 
+// Rate limit methods
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// pauseFor holds back all requests on this client for at least d. Overlapping
+// pauses extend to the latest deadline rather than stacking.
+func (c *Client) pauseFor(d time.Duration) {
+	c.rlMu.Lock()
+	defer c.rlMu.Unlock()
+
+	if until := time.Now().Add(d); until.After(c.rlPausedUntil) {
+		c.rlPausedUntil = until
+	}
+}
+
+// waitForRateLimit blocks until any active rate limit pause has elapsed.
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	for {
+		c.rlMu.Lock()
+		wait := time.Until(c.rlPausedUntil)
+		c.rlMu.Unlock()
+
+		if wait <= 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // Circuit breaker methods
 
-// checkCircuitBreaker checks if a request should be allowed through.
-// Returns nil if allowed, ErrCircuitOpen if the circuit is open and cooldown hasn't elapsed.
-func (c *Client) checkCircuitBreaker() error {
+// hostBreaker is the circuit breaker state for a single host.
+type hostBreaker struct {
+	state            CircuitState
+	consecutive5xx   int
+	openedAt         time.Time
+	halfOpenInFlight bool
+}
+
+// breakerFor returns the breaker state for host, creating it if needed.
+// The caller must hold cbMu.
+func (c *Client) breakerFor(host string) *hostBreaker {
+	b, ok := c.cbHosts[host]
+	if !ok {
+		b = &hostBreaker{state: CircuitClosed}
+		c.cbHosts[host] = b
+	}
+	return b
+}
+
+// checkCircuitBreaker checks if a request to host should be allowed through.
+// Returns nil if allowed, ErrCircuitOpen if the host's circuit is open and cooldown hasn't elapsed.
+func (c *Client) checkCircuitBreaker(host string) error {
 	c.cbMu.Lock()
 	defer c.cbMu.Unlock()
 
-	switch c.cbState {
+	if c.cbThreshold <= 0 {
+		return nil
+	}
+
+	b := c.breakerFor(host)
+	switch b.state {
 	case CircuitClosed:
 		return nil
 	case CircuitOpen:
-		if time.Since(c.cbOpenedAt) >= c.cbCooldown {
-			c.cbState = CircuitHalfOpen
-			slog.Info("circuit breaker transitioning to half-open", "cooldown_elapsed", c.cbCooldown)
+		if time.Since(b.openedAt) >= c.cbCooldown {
+			b.state = CircuitHalfOpen
+			slog.Info("circuit breaker transitioning to half-open", "host", host, "cooldown_elapsed", c.cbCooldown)
 			return nil
 		}
-		remaining := c.cbCooldown - time.Since(c.cbOpenedAt)
-		slog.Debug("circuit breaker is open", "remaining_cooldown", remaining)
-		return ErrCircuitOpen
+		remaining := c.cbCooldown - time.Since(b.openedAt)
+		slog.Debug("circuit breaker is open", "host", host, "remaining_cooldown", remaining)
+		return fmt.Errorf("%w (retry in %s)", ErrCircuitOpen, remaining.Round(time.Second))
 	case CircuitHalfOpen:
-		if c.cbHalfOpenInFlight {
+		if b.halfOpenInFlight {
 			return ErrCircuitOpen // Only one probe at a time
 		}
-		c.cbHalfOpenInFlight = true
+		b.halfOpenInFlight = true
 		return nil
 	}
 	return nil
 }
 
-// recordSuccess records a successful request, resetting the circuit breaker to closed.
-func (c *Client) recordSuccess() {
+// recordSuccess records a successful request to host, resetting its circuit breaker to closed.
+func (c *Client) recordSuccess(host string) {
 	c.cbMu.Lock()
 	defer c.cbMu.Unlock()
 
-	if c.cbState == CircuitHalfOpen {
-		slog.Info("circuit breaker closing after successful half-open request")
+	b := c.breakerFor(host)
+	if b.state == CircuitHalfOpen {
+		slog.Info("circuit breaker closing after successful half-open request", "host", host)
 	}
-	c.cbState = CircuitClosed
-	c.cbConsecutive5xx = 0
-	c.cbHalfOpenInFlight = false
+	b.state = CircuitClosed
+	b.consecutive5xx = 0
+	b.halfOpenInFlight = false
 }
 
-// record5xxError records a 5xx error and potentially opens the circuit breaker.
-func (c *Client) record5xxError() {
+// record5xxError records a 5xx error from host and potentially opens its circuit breaker.
+func (c *Client) record5xxError(host string) {
 	c.cbMu.Lock()
 	defer c.cbMu.Unlock()
 
-	c.cbConsecutive5xx++
-	c.cbHalfOpenInFlight = false
+	b := c.breakerFor(host)
+	b.consecutive5xx++
+	b.halfOpenInFlight = false
+
+	if c.cbThreshold <= 0 {
+		return
+	}
 
-	if c.cbState == CircuitHalfOpen {
+	if b.state == CircuitHalfOpen {
 		// Half-open test failed, reopen the circuit
-		c.cbState = CircuitOpen
-		c.cbOpenedAt = time.Now()
-		slog.Warn("circuit breaker reopening after failed half-open request", "consecutive_5xx", c.cbConsecutive5xx)
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+		slog.Warn("circuit breaker reopening after failed half-open request", "host", host, "consecutive_5xx", b.consecutive5xx)
+		c.notifyCircuitOpen(host)
 		return
 	}
 
-	if c.cbConsecutive5xx >= c.cbThreshold {
-		c.cbState = CircuitOpen
-		c.cbOpenedAt = time.Now()
-		slog.Warn("circuit breaker opening", "consecutive_5xx", c.cbConsecutive5xx, "threshold", c.cbThreshold)
+	if b.state == CircuitClosed && b.consecutive5xx >= c.cbThreshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+		slog.Warn("circuit breaker opening", "host", host, "consecutive_5xx", b.consecutive5xx, "threshold", c.cbThreshold)
+		c.notifyCircuitOpen(host)
+	}
+}
+
+// notifyCircuitOpen calls the OnCircuitOpen hook. The caller must hold cbMu.
+func (c *Client) notifyCircuitOpen(host string) {
+	if c.cbOnOpen != nil {
+		c.cbOnOpen(host, c.cbCooldown)
 	}
 }
 
-// CircuitState returns the current state of the circuit breaker (for testing).
+// CircuitBreakerState returns the circuit breaker state for the client's
+// base URL host (for testing).
 func (c *Client) CircuitBreakerState() CircuitState {
+	return c.CircuitBreakerStateFor(c.baseHost())
+}
+
+// CircuitBreakerStateFor returns the circuit breaker state for host.
+func (c *Client) CircuitBreakerStateFor(host string) CircuitState {
 	c.cbMu.RLock()
 	defer c.cbMu.RUnlock()
-	return c.cbState
+	if b, ok := c.cbHosts[host]; ok {
+		return b.state
+	}
+	return CircuitClosed
 }
 
-// ResetCircuitBreaker resets the circuit breaker to closed state (for testing).
+// ResetCircuitBreaker resets every host's circuit breaker to closed state (for testing).
 func (c *Client) ResetCircuitBreaker() {
 	c.cbMu.Lock()
 	defer c.cbMu.Unlock()
-	c.cbState = CircuitClosed
-	c.cbConsecutive5xx = 0
-	c.cbOpenedAt = time.Time{}
-	c.cbHalfOpenInFlight = false
+	c.cbHosts = map[string]*hostBreaker{}
+}
+
+// baseHost returns the host of the client's base URL.
+func (c *Client) baseHost() string {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
 }
 
 func closeBody(resp *http.Response) {
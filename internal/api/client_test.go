@@ -3,8 +3,11 @@ package api
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -44,6 +47,33 @@ func TestClient_Get(t *testing.T) {
 	}
 }
 
+func TestClient_OnResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": "not_found"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test123")
+	client.baseURL = server.URL
+
+	var got []string
+	client.OnResponse(func(req *http.Request, resp *http.Response) {
+		got = append(got, req.Method+" "+req.URL.Path+" "+resp.Header.Get("X-Request-Id"))
+	})
+
+	resp, err := client.Delete(context.Background(), "/links/link_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(got) != 1 || got[0] != "DELETE /links/link_1 req_1" {
+		t.Errorf("expected one callback for the response, got %v", got)
+	}
+}
+
 // Circuit Breaker Tests
 
 func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
@@ -299,9 +329,10 @@ func TestCircuitBreaker_RejectsRequestsWhenOpen(t *testing.T) {
 
 func TestCircuitBreaker_ResetCircuitBreaker(t *testing.T) {
 	client := NewClient("dub_test123")
-	client.cbState = CircuitOpen
-	client.cbConsecutive5xx = 10
-	client.cbOpenedAt = time.Now()
+	b := client.breakerFor(client.baseHost())
+	b.state = CircuitOpen
+	b.consecutive5xx = 10
+	b.openedAt = time.Now()
 
 	client.ResetCircuitBreaker()
 
@@ -309,3 +340,240 @@ func TestCircuitBreaker_ResetCircuitBreaker(t *testing.T) {
 		t.Errorf("expected circuit to be closed after reset, got %v", client.CircuitBreakerState())
 	}
 }
+
+func TestCircuitBreaker_OnOpenNotifiesOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test123")
+	client.baseURL = server.URL
+	client.SetCircuitBreaker(2, time.Minute)
+
+	var notified []time.Duration
+	client.OnCircuitOpen(func(host string, cooldown time.Duration) {
+		notified = append(notified, cooldown)
+	})
+
+	// POST is not retried, so each call records exactly one 5xx
+	for i := 0; i < 4; i++ {
+		resp, err := client.Post(context.Background(), "/test", nil)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+
+	if len(notified) != 1 || notified[0] != time.Minute {
+		t.Errorf("expected one notification with 1m cooldown, got %v", notified)
+	}
+
+	_, err := client.Post(context.Background(), "/test", nil)
+	if !errors.Is(err, ErrCircuitOpen) || !strings.Contains(err.Error(), "retry in") {
+		t.Errorf("expected ErrCircuitOpen with remaining cooldown, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_PerHost(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+
+	client := NewClient("dub_test123")
+	client.baseURL = failing.URL
+	client.SetCircuitBreaker(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(context.Background(), "/test", nil)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+	if client.CircuitBreakerState() != CircuitOpen {
+		t.Fatalf("expected circuit for failing host to be open, got %v", client.CircuitBreakerState())
+	}
+
+	// Requests to another host on the same client are unaffected
+	client.baseURL = healthy.URL
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("expected healthy host to be reachable, got %v", err)
+	}
+	_ = resp.Body.Close()
+
+	failingHost := strings.TrimPrefix(failing.URL, "http://")
+	if client.CircuitBreakerStateFor(failingHost) != CircuitOpen {
+		t.Errorf("expected failing host to stay open, got %v", client.CircuitBreakerStateFor(failingHost))
+	}
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test123")
+	client.baseURL = server.URL
+	client.SetCircuitBreaker(0, time.Minute)
+
+	for i := 0; i < CircuitBreakerThreshold+2; i++ {
+		resp, err := client.Post(context.Background(), "/test", nil)
+		if err != nil {
+			t.Fatalf("expected requests to pass with breaker disabled, got %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if client.CircuitBreakerState() != CircuitClosed {
+		t.Errorf("expected disabled breaker to stay closed, got %v", client.CircuitBreakerState())
+	}
+}
+
+// Rate Limit Tests
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"Thu, 01 Jan 2026 00:00:05 GMT", 5 * time.Second, true},
+		{"Wed, 31 Dec 2025 23:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRateLimit_PausesAllRequests(t *testing.T) {
+	var limited int32
+	var mu sync.Mutex
+	done := map[string]time.Time{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" && atomic.AddInt32(&limited, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		mu.Lock()
+		done[r.URL.Path] = time.Now()
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test")
+	client.baseURL = server.URL
+
+	get := func(path string) {
+		resp, err := client.Get(context.Background(), path)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		_ = resp.Body.Close()
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); get("/limited") }()
+	go func() {
+		defer wg.Done()
+		// Issued while the first request is paused by its 429
+		time.Sleep(200 * time.Millisecond)
+		get("/other")
+	}()
+	wg.Wait()
+
+	if d := done["/limited"].Sub(start); d < time.Second {
+		t.Errorf("expected retry to honor Retry-After, took %v", d)
+	}
+	if d := done["/other"].Sub(start); d < 900*time.Millisecond {
+		t.Errorf("expected concurrent request to wait for the shared pause, took %v", d)
+	}
+}
+
+func TestRateLimit_WaitRespectsContext(t *testing.T) {
+	client := NewClient("dub_test")
+	client.pauseFor(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := client.waitForRateLimit(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestGet_CoalescesConcurrentRequests(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"id":"link_1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test123")
+	client.SetBaseURL(server.URL)
+
+	const callers = 5
+	bodies := make([]string, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.Get(context.Background(), "/links/link_1")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+			data, _ := io.ReadAll(resp.Body)
+			bodies[i] = string(data)
+		}(i)
+	}
+
+	// Wait until every caller has joined the in-flight request
+	key := server.URL + "/links/link_1"
+	for {
+		client.inflightMu.Lock()
+		call := client.inflight[key]
+		joined := call != nil && call.dups == callers-1
+		client.inflightMu.Unlock()
+		if joined {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected 1 request to the server, got %d", got)
+	}
+	for i, body := range bodies {
+		if body != `{"id":"link_1"}` {
+			t.Errorf("caller %d got body %q", i, body)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// inflightGet is a GET shared by every caller that asked for the same URL
+// while it was running.
+type inflightGet struct {
+	done chan struct{}
+	dups int // callers waiting on the leader, for tests
+
+	status     string
+	statusCode int
+	proto      string
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+// getCoalesced performs a GET, sharing the result with any identical GET
+// already in flight on this client. Each caller receives its own response
+// with an independent copy of the body.
+func (c *Client) getCoalesced(ctx context.Context, url string) (*http.Response, error) {
+	c.inflightMu.Lock()
+	if call, ok := c.inflight[url]; ok {
+		call.dups++
+		c.inflightMu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// The leader's cancellation shouldn't fail callers that are still live
+		if errors.Is(call.err, context.Canceled) && ctx.Err() == nil {
+			return c.getCoalesced(ctx, url)
+		}
+		return call.response()
+	}
+	call := &inflightGet{done: make(chan struct{})}
+	c.inflight[url] = call
+	c.inflightMu.Unlock()
+
+	call.fetch(ctx, c, url)
+
+	c.inflightMu.Lock()
+	delete(c.inflight, url)
+	c.inflightMu.Unlock()
+	close(call.done)
+
+	return call.response()
+}
+
+// fetch runs the request and buffers the response so it can be replayed.
+func (call *inflightGet) fetch(ctx context.Context, c *Client, url string) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		call.err = err
+		return
+	}
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		call.err = err
+		return
+	}
+	defer closeBody(resp)
+
+	call.body, call.err = io.ReadAll(resp.Body)
+	call.status = resp.Status
+	call.statusCode = resp.StatusCode
+	call.proto = resp.Proto
+	call.header = resp.Header
+}
+
+// response builds a fresh response from the shared result.
+func (call *inflightGet) response() (*http.Response, error) {
+	if call.err != nil {
+		return nil, call.err
+	}
+	return &http.Response{
+		Status:        call.status,
+		StatusCode:    call.statusCode,
+		Proto:         call.proto,
+		Header:        call.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(call.body)),
+		ContentLength: int64(len(call.body)),
+	}, nil
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Permissions remembers which scopes an API key has been seen to have or
+// lack, so requests that are certain to be rejected fail before being sent.
+type Permissions interface {
+	// Lookup reports whether scope is granted, and whether that is known.
+	Lookup(scope string) (granted, known bool)
+	Record(scope string, granted bool)
+}
+
+// PermissionError is returned when the API key is known to lack the scope a
+// request needs.
+type PermissionError struct {
+	Scope string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("API key lacks %s (if the key's permissions changed, run: dub cache clear)", e.Scope)
+}
+
+// resourceScopes maps the first path segment of an endpoint to the scope
+// prefix guarding it. Endpoints that only support reads or writes map to a
+// fixed scope.
+var resourceScopes = map[string]string{
+	"links":       "links",
+	"domains":     "domains",
+	"tags":        "tags",
+	"folders":     "folders",
+	"customers":   "customers",
+	"partners":    "partners",
+	"commissions": "commissions",
+	"analytics":   "analytics.read",
+	"events":      "analytics.read",
+	"track":       "conversions.write",
+}
+
+// RequiredScope returns the API key scope needed to call method on path,
+// such as "links.write", or "" when it isn't known.
+func RequiredScope(method, path string) string {
+	resource, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	scope, ok := resourceScopes[resource]
+	if !ok || strings.Contains(scope, ".") {
+		return scope
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return scope + ".read"
+	default:
+		return scope + ".write"
+	}
+}
+
+// missingScopePattern finds the scope named in a 403 message such as
+// "Having the 'links.write' permission would allow you to request this
+// endpoint."
+var missingScopePattern = regexp.MustCompile(`'([a-z_]+\.(?:read|write))'`)
+
+// checkPermission fails fast when the key is known to lack scope.
+func (c *Client) checkPermission(scope string) error {
+	if c.permissions == nil || scope == "" {
+		return nil
+	}
+	if granted, known := c.permissions.Lookup(scope); known && !granted {
+		return &PermissionError{Scope: scope}
+	}
+	return nil
+}
+
+// learnPermission records what resp reveals about the key's scopes. A 403
+// body is restored after being inspected so callers can still read it.
+func (c *Client) learnPermission(scope string, resp *http.Response) {
+	if c.permissions == nil {
+		return
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300 && scope != "":
+		if granted, known := c.permissions.Lookup(scope); !known || !granted {
+			c.permissions.Record(scope, true)
+		}
+	case resp.StatusCode == http.StatusForbidden:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		if m := missingScopePattern.FindSubmatch([]byte(ParseAPIError(body).Message)); m != nil {
+			c.permissions.Record(string(m[1]), false)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type memoryPermissions map[string]bool
+
+func (m memoryPermissions) Lookup(scope string) (bool, bool) {
+	granted, ok := m[scope]
+	return granted, ok
+}
+
+func (m memoryPermissions) Record(scope string, granted bool) {
+	m[scope] = granted
+}
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"GET", "/links", "links.read"},
+		{"PATCH", "/links/link_1", "links.write"},
+		{"DELETE", "/tags/tag_1", "tags.write"},
+		{"GET", "/analytics", "analytics.read"},
+		{"POST", "/track/lead", "conversions.write"},
+		{"GET", "/qr", ""},
+	}
+	for _, tt := range tests {
+		if got := RequiredScope(tt.method, tt.path); got != tt.want {
+			t.Errorf("RequiredScope(%s, %s) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPermissions_LearnsFrom403AndFailsFast(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": "forbidden", "message": "The provided key does not have the required permissions for this endpoint. Having the 'links.write' permission would allow you to request this endpoint."}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	perms := memoryPermissions{}
+	client := NewClient("dub_test123")
+	client.SetBaseURL(server.URL)
+	client.SetPermissions(perms)
+
+	resp, err := client.Post(context.Background(), "/links", map[string]string{"url": "https://example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if !strings.Contains(string(body), "links.write") {
+		t.Errorf("expected 403 body to remain readable, got %s", body)
+	}
+
+	_, err = client.Post(context.Background(), "/links", map[string]string{"url": "https://example.com"})
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) || permErr.Scope != "links.write" {
+		t.Fatalf("expected PermissionError for links.write, got %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("expected the second request not to be sent, got %d requests", got)
+	}
+
+	// Reads are still allowed and recorded as granted
+	resp, err = client.Get(context.Background(), "/links")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if !perms["links.read"] {
+		t.Errorf("expected links.read to be recorded as granted, got %v", perms)
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that spaces out requests shared by every
+// goroutine using a client. It allows bursts of up to the per-period limit.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // time to earn one token
+	burst    float64
+	tokens   float64
+	last     time.Time
+	now      func() time.Time
+}
+
+// NewRateLimiter allows n requests per period.
+func NewRateLimiter(n int, per time.Duration) *RateLimiter {
+	return &RateLimiter{
+		interval: per / time.Duration(n),
+		burst:    float64(n),
+		tokens:   float64(n),
+		now:      time.Now,
+	}
+}
+
+// ParseRate parses a rate such as "5/s", "300/m", or "1000/h" into a
+// limiter. A bare number is treated as requests per second.
+func ParseRate(s string) (*RateLimiter, error) {
+	count, unit, hasUnit := strings.Cut(strings.TrimSpace(s), "/")
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid rate %q: expected a positive count such as 5/s", s)
+	}
+
+	per := time.Second
+	if hasUnit {
+		switch strings.TrimSpace(unit) {
+		case "s", "sec", "second":
+			per = time.Second
+		case "m", "min", "minute":
+			per = time.Minute
+		case "h", "hour":
+			per = time.Hour
+		default:
+			return nil, fmt.Errorf("invalid rate %q: unit must be s, m, or h", s)
+		}
+	}
+
+	return NewRateLimiter(n, per), nil
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token if one is available, or returns how long until one is.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) * float64(l.interval))
+}
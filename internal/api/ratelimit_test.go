@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		value    string
+		interval time.Duration
+		burst    float64
+		wantErr  bool
+	}{
+		{"5/s", 200 * time.Millisecond, 5, false},
+		{"300/m", 200 * time.Millisecond, 300, false},
+		{"60/h", time.Minute, 60, false},
+		{"2", 500 * time.Millisecond, 2, false},
+		{"0/s", 0, 0, true},
+		{"5/d", 0, 0, true},
+		{"fast", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		l, err := ParseRate(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if err == nil && (l.interval != tt.interval || l.burst != tt.burst) {
+			t.Errorf("ParseRate(%q) = interval %v burst %v, want %v %v", tt.value, l.interval, l.burst, tt.interval, tt.burst)
+		}
+	}
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	l := NewRateLimiter(2, time.Second)
+	base := time.Now()
+	l.now = func() time.Time { return base }
+
+	if l.reserve() != 0 || l.reserve() != 0 {
+		t.Fatal("expected burst of 2 to be allowed immediately")
+	}
+	if wait := l.reserve(); wait != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms for the next token, got %v", wait)
+	}
+
+	l.now = func() time.Time { return base.Add(500 * time.Millisecond) }
+	if wait := l.reserve(); wait != 0 {
+		t.Errorf("expected a token after 500ms, got wait %v", wait)
+	}
+}
+
+func TestRateLimiter_WaitRespectsContext(t *testing.T) {
+	l := NewRateLimiter(1, time.Hour)
+	_ = l.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("dub_test")
+	client.baseURL = server.URL
+	client.SetRateLimiter(NewRateLimiter(10, time.Second))
+
+	start := time.Now()
+	for i := 0; i < 12; i++ {
+		resp, err := client.Get(context.Background(), "/test")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	// The first 10 requests use the burst; the remaining 2 wait ~100ms each
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected requests beyond the burst to be throttled, took %v", elapsed)
+	}
+}
@@ -6,23 +6,28 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"net"
 	"net/http"
-	"os/exec"
-	"runtime"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/skip2/go-qrcode"
+
 	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/browser"
 	"github.com/salmonumbrella/dub-cli/internal/secrets"
 )
 
 // SetupResult contains the result of the authentication flow.
 type SetupResult struct {
 	WorkspaceName string
+	Role          string
 	APIKey        string
 	Error         error
 }
@@ -30,13 +35,25 @@ type SetupResult struct {
 // SetupServer handles browser-based authentication.
 type SetupServer struct {
 	store     secrets.Store
+	role      string
 	csrfToken string
+	pathToken string // random first path segment every route lives under
+	addr      string // host:port the browser must address us by
+	bindHost  string
+	printTo   io.Writer // when set, print the URL and a QR code instead of opening a browser
 	listener  net.Listener
 	server    *http.Server
 
+	setupTmpl   *template.Template
+	successTmpl *template.Template
+
 	mu       sync.Mutex
 	result   *SetupResult
 	doneChan chan struct{}
+
+	// submitMu serializes /submit so credentials are saved at most once
+	submitMu  sync.Mutex
+	submitted bool
 }
 
 // NewSetupServer creates a new setup server.
@@ -45,33 +62,54 @@ func NewSetupServer(store secrets.Store) (*SetupServer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate CSRF token: %w", err)
 	}
+	pathToken, err := generateCSRFToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate path token: %w", err)
+	}
 
 	return &SetupServer{
-		store:     store,
-		csrfToken: token,
-		doneChan:  make(chan struct{}),
+		store:       store,
+		csrfToken:   token,
+		pathToken:   pathToken[:32],
+		bindHost:    "127.0.0.1",
+		setupTmpl:   builtinSetupTemplate,
+		successTmpl: builtinSuccessTemplate,
+		doneChan:    make(chan struct{}),
 	}, nil
 }
 
+// SetRole stores the entered key under role instead of as the workspace's
+// default key.
+func (s *SetupServer) SetRole(role string) {
+	s.role = role
+}
+
+// SetBindAddress listens on host instead of 127.0.0.1, e.g. 0.0.0.0 so the
+// setup page can be completed from another device on the same network.
+func (s *SetupServer) SetBindAddress(host string) {
+	s.bindHost = host
+}
+
+// SetPrintURL prints the setup URL and a terminal QR code of it to w instead
+// of opening a browser.
+func (s *SetupServer) SetPrintURL(w io.Writer) {
+	s.printTo = w
+}
+
 // Start launches the HTTP server and opens the browser.
 // It blocks until authentication is complete or the context is cancelled.
 func (s *SetupServer) Start(ctx context.Context) (*SetupResult, error) {
-	// Bind to a random port on localhost
-	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	// Bind to a random port, on localhost unless configured otherwise
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.bindHost, "0"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start server: %w", err)
 	}
 	s.listener = listener
-
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", s.handleSetup)
-	mux.HandleFunc("/validate", s.handleValidate)
-	mux.HandleFunc("/submit", s.handleSubmit)
-	mux.HandleFunc("/success", s.handleSuccess)
-	mux.HandleFunc("/complete", s.handleComplete)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	s.addr = net.JoinHostPort(advertiseHost(s.bindHost), port)
 
 	s.server = &http.Server{
-		Handler:      mux,
+		Handler:      s.routes(),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 	}
@@ -84,9 +122,11 @@ func (s *SetupServer) Start(ctx context.Context) (*SetupResult, error) {
 	}()
 
 	// Open browser
-	url := fmt.Sprintf("http://%s/?csrf=%s", listener.Addr().String(), s.csrfToken)
-	if err := openBrowser(url); err != nil {
-		fmt.Printf("Please open this URL in your browser:\n  %s\n\n", url)
+	setupURL := fmt.Sprintf("http://%s/%s/?csrf=%s", s.addr, s.pathToken, s.csrfToken)
+	if s.printTo != nil {
+		printSetupURL(s.printTo, setupURL)
+	} else if err := browser.Open(setupURL); err != nil {
+		fmt.Printf("Please open this URL in your browser:\n  %s\n\n", setupURL)
 	}
 
 	// Wait for completion or context cancellation
@@ -116,6 +156,73 @@ func (s *SetupServer) Start(ctx context.Context) (*SetupResult, error) {
 	return result, nil
 }
 
+// advertiseHost returns the host to put in the setup URL. When listening on
+// every interface, that is the first non-loopback IPv4 address so another
+// device on the network can reach it.
+func advertiseHost(bindHost string) string {
+	ip := net.ParseIP(bindHost)
+	if ip == nil || !ip.IsUnspecified() {
+		return bindHost
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "127.0.0.1"
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && ipNet.IP.To4() != nil {
+			return ipNet.IP.String()
+		}
+	}
+	return "127.0.0.1"
+}
+
+// printSetupURL writes the setup URL followed by a QR code of it, for
+// completing setup from a phone.
+func printSetupURL(w io.Writer, setupURL string) {
+	_, _ = fmt.Fprintf(w, "Open this URL to finish setup:\n  %s\n\n", setupURL)
+
+	code, err := qrcode.New(setupURL, qrcode.Low)
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Or scan it from a device on the same network:")
+	_, _ = fmt.Fprintln(w, code.ToSmallString(false))
+}
+
+// routes mounts the handlers under the random path segment, behind the
+// origin checks.
+func (s *SetupServer) routes() http.Handler {
+	base := "/" + s.pathToken
+	mux := http.NewServeMux()
+	mux.HandleFunc(base+"/", s.handleSetup)
+	mux.HandleFunc(base+"/validate", s.handleValidate)
+	mux.HandleFunc(base+"/submit", s.handleSubmit)
+	mux.HandleFunc(base+"/success", s.handleSuccess)
+	mux.HandleFunc(base+"/complete", s.handleComplete)
+	return s.checkOrigin(mux)
+}
+
+// checkOrigin rejects requests not addressed to our exact host:port, which
+// defeats DNS rebinding, and cross-origin requests from other pages.
+func (s *SetupServer) checkOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != s.addr {
+			http.Error(w, "Invalid host", http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && origin != "http://"+s.addr {
+			http.Error(w, "Invalid origin", http.StatusForbidden)
+			return
+		}
+		if site := r.Header.Get("Sec-Fetch-Site"); site != "" && site != "same-origin" && site != "none" {
+			http.Error(w, "Invalid origin", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // handleSetup serves the main setup form.
 func (s *SetupServer) handleSetup(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -129,20 +236,8 @@ func (s *SetupServer) handleSetup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tmpl, err := template.New("setup").Parse(setupTemplate)
-	if err != nil {
-		http.Error(w, "Internal error", http.StatusInternalServerError)
-		return
-	}
-
-	data := struct {
-		CSRFToken string
-	}{
-		CSRFToken: s.csrfToken,
-	}
-
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	_ = tmpl.Execute(w, data)
+	_ = s.setupTmpl.Execute(w, setupPage{CSRFToken: s.csrfToken})
 }
 
 // handleValidate tests the API key without saving.
@@ -201,6 +296,13 @@ func (s *SetupServer) handleSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.submitMu.Lock()
+	defer s.submitMu.Unlock()
+	if s.submitted {
+		writeJSON(w, http.StatusConflict, map[string]string{"error": "Credentials were already submitted"})
+		return
+	}
+
 	workspace := strings.TrimSpace(r.FormValue("workspace"))
 	apiKey := strings.TrimSpace(r.FormValue("api_key"))
 
@@ -228,6 +330,7 @@ func (s *SetupServer) handleSubmit(w http.ResponseWriter, r *http.Request) {
 	// Save to keyring
 	creds := secrets.Credentials{
 		Name:      workspace,
+		Role:      s.role,
 		APIKey:    apiKey,
 		CreatedAt: time.Now().UTC(),
 	}
@@ -237,16 +340,19 @@ func (s *SetupServer) handleSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.submitted = true
+
 	// Set result for CLI
 	s.setResult(&SetupResult{
 		WorkspaceName: workspace,
+		Role:          s.role,
 		APIKey:        apiKey,
 	})
 
 	// Return success with redirect URL
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"status":   "saved",
-		"redirect": fmt.Sprintf("/success?csrf=%s&workspace=%s", s.csrfToken, workspace),
+		"redirect": fmt.Sprintf("success?csrf=%s&workspace=%s", s.csrfToken, url.QueryEscape(workspace)),
 	})
 }
 
@@ -265,22 +371,8 @@ func (s *SetupServer) handleSuccess(w http.ResponseWriter, r *http.Request) {
 
 	workspace := r.URL.Query().Get("workspace")
 
-	tmpl, err := template.New("success").Parse(successTemplate)
-	if err != nil {
-		http.Error(w, "Internal error", http.StatusInternalServerError)
-		return
-	}
-
-	data := struct {
-		Workspace string
-		CSRFToken string
-	}{
-		Workspace: workspace,
-		CSRFToken: s.csrfToken,
-	}
-
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	_ = tmpl.Execute(w, data)
+	_ = s.successTmpl.Execute(w, successPage{Workspace: workspace, CSRFToken: s.csrfToken})
 }
 
 // handleComplete signals the CLI that authentication is done.
@@ -313,9 +405,29 @@ func (s *SetupServer) setResult(result *SetupResult) {
 	s.mu.Unlock()
 }
 
-// validateAPIKey tests the API key against the Dub API.
+// Errors returned by ValidateAPIKey for keys the API rejects.
+var (
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	ErrExpiredAPIKey = errors.New("API key has expired")
+)
+
+// ErrNoLinksScope is returned by ValidateAPIKey for a valid key that is
+// restricted to scopes other than links.
+var ErrNoLinksScope = errors.New("API key is valid but lacks the links scope")
+
+// validateAPIKey tests the API key against the Dub API. A key without the
+// links scope is still a valid key, so it is accepted.
 func validateAPIKey(ctx context.Context, apiKey string) error {
-	client := api.NewClient(apiKey)
+	if err := ValidateAPIKey(ctx, api.NewClient(apiKey)); err != nil && !errors.Is(err, ErrNoLinksScope) {
+		return err
+	}
+	return nil
+}
+
+// ValidateAPIKey tests the client's API key with a minimal request. The
+// request reads links, so a 403 means the key authenticated but lacks that
+// scope, reported as ErrNoLinksScope.
+func ValidateAPIKey(ctx context.Context, client *api.Client) error {
 	resp, err := client.Get(ctx, "/links?limit=1")
 	if err != nil {
 		return fmt.Errorf("failed to connect to Dub API: %w", err)
@@ -323,10 +435,13 @@ func validateAPIKey(ctx context.Context, apiKey string) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == 401 {
-		return fmt.Errorf("invalid API key")
+		if apiErr := api.ReadAPIError(resp); strings.Contains(strings.ToLower(apiErr.Message), "expired") {
+			return ErrExpiredAPIKey
+		}
+		return ErrInvalidAPIKey
 	}
 	if resp.StatusCode == 403 {
-		return fmt.Errorf("API key does not have permission to access links")
+		return ErrNoLinksScope
 	}
 	if resp.StatusCode >= 400 {
 		apiErr := api.ReadAPIError(resp)
@@ -351,25 +466,3 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(data)
 }
-
-// openBrowser opens the default browser to the specified URL.
-func openBrowser(url string) error {
-	var cmd string
-	var args []string
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = "open"
-		args = []string{url}
-	case "linux":
-		cmd = "xdg-open"
-		args = []string{url}
-	case "windows":
-		cmd = "cmd"
-		args = []string{"/c", "start", url}
-	default:
-		return fmt.Errorf("unsupported platform")
-	}
-
-	return exec.Command(cmd, args...).Start()
-}
@@ -2,10 +2,14 @@
 package auth
 
 import (
+	"bytes"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -38,15 +42,19 @@ func (m *MockStore) Set(name string, creds secrets.Credentials) error {
 	if m.setErr != nil {
 		return m.setErr
 	}
-	m.credentials[name] = creds
+	m.credentials[mockKey(name, creds.Role)] = creds
 	return nil
 }
 
 func (m *MockStore) Get(name string) (secrets.Credentials, error) {
+	return m.GetRole(name, "")
+}
+
+func (m *MockStore) GetRole(name, role string) (secrets.Credentials, error) {
 	if m.getErr != nil {
 		return secrets.Credentials{}, m.getErr
 	}
-	creds, ok := m.credentials[name]
+	creds, ok := m.credentials[mockKey(name, role)]
 	if !ok {
 		return secrets.Credentials{}, nil
 	}
@@ -54,10 +62,26 @@ func (m *MockStore) Get(name string) (secrets.Credentials, error) {
 }
 
 func (m *MockStore) Delete(name string) error {
-	delete(m.credentials, name)
+	for key := range m.credentials {
+		if ws, _ := secrets.SplitRole(key); ws == name {
+			delete(m.credentials, key)
+		}
+	}
 	return nil
 }
 
+func (m *MockStore) DeleteRole(name, role string) error {
+	delete(m.credentials, mockKey(name, role))
+	return nil
+}
+
+func mockKey(name, role string) string {
+	if role != "" {
+		return name + "#" + role
+	}
+	return name
+}
+
 func (m *MockStore) List() ([]secrets.Credentials, error) {
 	list := make([]secrets.Credentials, 0, len(m.credentials))
 	for _, creds := range m.credentials {
@@ -749,3 +773,170 @@ func TestMockStore_List(t *testing.T) {
 		t.Errorf("expected 2 credentials, got %d", len(list))
 	}
 }
+
+// Test that a second submission after completion is rejected
+func TestHandleSubmit_SingleUse(t *testing.T) {
+	store := NewMockStore()
+	server, _ := NewSetupServer(store)
+	server.submitted = true
+
+	form := url.Values{}
+	form.Set("csrf_token", server.csrfToken)
+	form.Set("workspace", "other")
+	form.Set("api_key", "dub_other123")
+
+	req := httptest.NewRequest(http.MethodPost, "/submit", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	server.handleSubmit(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
+	}
+	if len(store.credentials) != 0 {
+		t.Errorf("expected no credentials to be saved, got %v", store.credentials)
+	}
+}
+
+// Test routing through the random path segment and origin checks
+func TestRoutes_OriginAndPathChecks(t *testing.T) {
+	server, _ := NewSetupServer(NewMockStore())
+	server.addr = "127.0.0.1:4567"
+	handler := server.routes()
+	setupPath := "/" + server.pathToken + "/?csrf=" + server.csrfToken
+
+	tests := []struct {
+		name   string
+		target string
+		host   string
+		header map[string]string
+		want   int
+	}{
+		{"valid", setupPath, "127.0.0.1:4567", nil, http.StatusOK},
+		{"missing path token", "/?csrf=" + server.csrfToken, "127.0.0.1:4567", nil, http.StatusNotFound},
+		{"rebound host", setupPath, "evil.example:4567", nil, http.StatusForbidden},
+		{"foreign origin", setupPath, "127.0.0.1:4567", map[string]string{"Origin": "https://evil.example"}, http.StatusForbidden},
+		{"cross-site fetch", setupPath, "127.0.0.1:4567", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"same origin", setupPath, "127.0.0.1:4567", map[string]string{"Origin": "http://127.0.0.1:4567", "Sec-Fetch-Site": "same-origin"}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Host = tt.host
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
+
+// Test the printed setup URL is followed by a scannable QR code
+func TestPrintSetupURL(t *testing.T) {
+	var buf bytes.Buffer
+	printSetupURL(&buf, "http://192.168.1.20:4567/abc/?csrf=def")
+
+	out := buf.String()
+	if !strings.Contains(out, "http://192.168.1.20:4567/abc/?csrf=def") {
+		t.Errorf("expected URL in output, got:\n%s", out)
+	}
+	if !strings.ContainsAny(out, "█▀▄") {
+		t.Errorf("expected a QR code in output, got:\n%s", out)
+	}
+}
+
+// Test the advertised host for wildcard and specific bind addresses
+func TestAdvertiseHost(t *testing.T) {
+	if got := advertiseHost("127.0.0.1"); got != "127.0.0.1" {
+		t.Errorf("expected loopback bind to be kept, got %q", got)
+	}
+	if got := advertiseHost("192.168.1.20"); got != "192.168.1.20" {
+		t.Errorf("expected specific bind to be kept, got %q", got)
+	}
+	if got := net.ParseIP(advertiseHost("0.0.0.0")); got == nil || got.IsUnspecified() {
+		t.Errorf("expected wildcard bind to advertise a concrete address, got %v", got)
+	}
+}
+
+func TestSetTemplateDir(t *testing.T) {
+	dir := t.TempDir()
+	setup := `<h1>Mit Dub verbinden</h1>
+<form><input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+<input name="workspace"><input name='api_key' type="password"></form>`
+	if err := os.WriteFile(filepath.Join(dir, SetupTemplateFile), []byte(setup), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	server, _ := NewSetupServer(NewMockStore())
+	if err := server.SetTemplateDir(dir); err != nil {
+		t.Fatalf("SetTemplateDir: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	server.handleSetup(w, httptest.NewRequest(http.MethodGet, "/?csrf="+server.csrfToken, nil))
+	body := w.Body.String()
+	if !strings.Contains(body, "Mit Dub verbinden") || !strings.Contains(body, server.csrfToken) {
+		t.Errorf("expected the custom setup page, got %q", body)
+	}
+
+	// success.html was left out, so the built-in page stays
+	w = httptest.NewRecorder()
+	server.handleSuccess(w, httptest.NewRequest(http.MethodGet, "/success?csrf="+server.csrfToken+"&workspace=acme", nil))
+	if !strings.Contains(w.Body.String(), "You're all set!") {
+		t.Error("expected the built-in success page")
+	}
+}
+
+func TestSetTemplateDir_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"no csrf token", SetupTemplateFile, `<input name="csrf_token"><input name="workspace"><input name="api_key">`, "{{.CSRFToken}}"},
+		{"missing field", SetupTemplateFile, `<input name="csrf_token" value="{{.CSRFToken}}"><input name="workspace">`, `"api_key"`},
+		{"success without token", SuccessTemplateFile, `<p>Connected {{.Workspace}}</p>`, "complete?csrf="},
+		{"unknown field", SuccessTemplateFile, `{{.CSRFToken}} {{.Email}}`, "failed to render"},
+		{"parse error", SetupTemplateFile, `{{.CSRFToken`, "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			server, _ := NewSetupServer(NewMockStore())
+			err := server.SetTemplateDir(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if server.setupTmpl != builtinSetupTemplate || server.successTmpl != builtinSuccessTemplate {
+				t.Error("a rejected template directory shouldn't change the pages")
+			}
+		})
+	}
+
+	server, _ := NewSetupServer(NewMockStore())
+	if err := server.SetTemplateDir(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without templates")
+	}
+}
+
+func TestBuiltinTemplatesPassChecks(t *testing.T) {
+	if err := checkSetupTemplate(builtinSetupTemplate); err != nil {
+		t.Errorf("built-in setup page: %v", err)
+	}
+	if err := checkSuccessTemplate(builtinSuccessTemplate); err != nil {
+		t.Errorf("built-in success page: %v", err)
+	}
+}
@@ -626,7 +626,7 @@ const setupTemplate = `<!DOCTYPE html>
                 params.append('csrf_token', csrfVal);
                 params.append('api_key', apiKey);
 
-                const resp = await fetch('/validate', {
+                const resp = await fetch('validate', {
                     method: 'POST',
                     headers: {'Content-Type': 'application/x-www-form-urlencoded'},
                     body: params.toString()
@@ -666,7 +666,7 @@ const setupTemplate = `<!DOCTYPE html>
                 params.append('workspace', workspaceInput.value.trim());
                 params.append('api_key', apiKeyInput.value.trim());
 
-                const resp = await fetch('/submit', {
+                const resp = await fetch('submit', {
                     method: 'POST',
                     headers: {'Content-Type': 'application/x-www-form-urlencoded'},
                     body: params.toString()
@@ -1035,7 +1035,7 @@ const successTemplate = `<!DOCTYPE html>
     </div>
 
     <script>
-        fetch('/complete?csrf={{.CSRFToken}}', { method: 'POST' }).catch(() => {});
+        fetch('complete?csrf={{.CSRFToken}}', { method: 'POST' }).catch(() => {});
     </script>
 </body>
 </html>`
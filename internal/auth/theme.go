@@ -0,0 +1,137 @@
+// internal/auth/theme.go
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Files a template directory may hold; a missing one keeps the built-in page.
+const (
+	SetupTemplateFile   = "setup.html"
+	SuccessTemplateFile = "success.html"
+)
+
+// templateProbeToken stands in for the CSRF token when checking that a
+// custom template renders it.
+const templateProbeToken = "csrf-probe-0123456789abcdef"
+
+var (
+	builtinSetupTemplate   = template.Must(template.New("setup").Parse(setupTemplate))
+	builtinSuccessTemplate = template.Must(template.New("success").Parse(successTemplate))
+)
+
+// setupPage is the data the setup page is rendered with.
+type setupPage struct {
+	CSRFToken string
+}
+
+// successPage is the data the success page is rendered with.
+type successPage struct {
+	Workspace string
+	CSRFToken string
+}
+
+// setupFields are the inputs the setup form must post to validate and submit.
+var setupFields = []string{"csrf_token", "workspace", "api_key"}
+
+// SetTemplateDir replaces the setup and success pages with setup.html and
+// success.html from dir, so the login flow can be branded or translated.
+// Either file may be left out to keep the built-in page. The setup page must
+// render the CSRF token and the csrf_token, workspace, and api_key fields;
+// the success page must render the CSRF token to post to complete.
+func (s *SetupServer) SetTemplateDir(dir string) error {
+	setup, err := loadTemplate(dir, SetupTemplateFile)
+	if err != nil {
+		return err
+	}
+	if setup != nil {
+		if err := checkSetupTemplate(setup); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Join(dir, SetupTemplateFile), err)
+		}
+	}
+
+	success, err := loadTemplate(dir, SuccessTemplateFile)
+	if err != nil {
+		return err
+	}
+	if success != nil {
+		if err := checkSuccessTemplate(success); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Join(dir, SuccessTemplateFile), err)
+		}
+	}
+
+	if setup == nil && success == nil {
+		return fmt.Errorf("%s has neither %s nor %s", dir, SetupTemplateFile, SuccessTemplateFile)
+	}
+	if setup != nil {
+		s.setupTmpl = setup
+	}
+	if success != nil {
+		s.successTmpl = success
+	}
+	return nil
+}
+
+// loadTemplate parses name from dir, returning nil if it doesn't exist.
+func loadTemplate(dir, name string) (*template.Template, error) {
+	path := filepath.Join(dir, name)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	tmpl, err := template.New(name).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+func checkSetupTemplate(tmpl *template.Template) error {
+	out, err := renderProbe(tmpl, setupPage{CSRFToken: templateProbeToken})
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(out, []byte(templateProbeToken)) {
+		return errors.New("template must render {{.CSRFToken}}")
+	}
+	for _, field := range setupFields {
+		if !hasField(out, field) {
+			return fmt.Errorf("template must have a %q form field", field)
+		}
+	}
+	return nil
+}
+
+func checkSuccessTemplate(tmpl *template.Template) error {
+	out, err := renderProbe(tmpl, successPage{Workspace: "acme", CSRFToken: templateProbeToken})
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(out, []byte(templateProbeToken)) {
+		return errors.New("template must render {{.CSRFToken}} to post to complete?csrf=")
+	}
+	return nil
+}
+
+func renderProbe(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// hasField reports whether html has an element named field.
+func hasField(html []byte, field string) bool {
+	re := regexp.MustCompile(`name\s*=\s*["']?` + regexp.QuoteMeta(field) + `["'\s/>]`)
+	return re.Match(html)
+}
@@ -0,0 +1,60 @@
+// Package batch runs independent API operations concurrently with bounded
+// parallelism, for commands that fan out many requests.
+package batch
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the number of workers used when none is specified.
+const DefaultConcurrency = 4
+
+// MaxConcurrency caps the number of workers so a single command cannot
+// exhaust the API rate limit on its own.
+const MaxConcurrency = 16
+
+// Run calls fn for every index in [0, n) using at most concurrency workers.
+// It returns a slice of per-index errors (nil for successes). Once ctx is
+// cancelled no new work is started and the remaining indexes report ctx.Err().
+func Run(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	if n == 0 {
+		return errs
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	if concurrency > MaxConcurrency {
+		concurrency = MaxConcurrency
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = fn(ctx, i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		if ctx.Err() != nil {
+			for j := i; j < n; j++ {
+				errs[j] = ctx.Err()
+			}
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errs
+}
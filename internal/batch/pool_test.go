@@ -0,0 +1,128 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRun_AllIndexes(t *testing.T) {
+	var seen [10]int32
+
+	errs := Run(context.Background(), 10, 3, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&seen[i], 1)
+		return nil
+	})
+
+	if len(errs) != 10 {
+		t.Fatalf("expected 10 results, got %d", len(errs))
+	}
+	for i, n := range seen {
+		if n != 1 {
+			t.Errorf("index %d called %d times, want 1", i, n)
+		}
+		if errs[i] != nil {
+			t.Errorf("index %d: unexpected error %v", i, errs[i])
+		}
+	}
+}
+
+func TestRun_BoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	Run(context.Background(), 20, 2, func(ctx context.Context, i int) error {
+		cur := atomic.AddInt32(&inFlight, 1)
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if cur <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, cur) {
+				break
+			}
+		}
+		atomic.AddInt32(&inFlight, -1)
+		return nil
+	})
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent workers, saw %d", maxInFlight)
+	}
+}
+
+func TestRun_CapsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	release := make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		Run(context.Background(), 100, 1000, func(ctx context.Context, i int) error {
+			cur := atomic.AddInt32(&inFlight, 1)
+			for {
+				prev := atomic.LoadInt32(&maxInFlight)
+				if cur <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, cur) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&inFlight, -1)
+			return nil
+		})
+		close(done)
+	}()
+
+	close(release)
+	<-done
+
+	if maxInFlight > MaxConcurrency {
+		t.Errorf("expected at most %d concurrent workers, saw %d", MaxConcurrency, maxInFlight)
+	}
+}
+
+func TestRun_PerIndexErrors(t *testing.T) {
+	boom := errors.New("boom")
+
+	errs := Run(context.Background(), 4, 2, func(ctx context.Context, i int) error {
+		if i%2 == 1 {
+			return boom
+		}
+		return nil
+	})
+
+	for i, err := range errs {
+		if i%2 == 1 && !errors.Is(err, boom) {
+			t.Errorf("index %d: expected boom, got %v", i, err)
+		}
+		if i%2 == 0 && err != nil {
+			t.Errorf("index %d: expected nil, got %v", i, err)
+		}
+	}
+}
+
+func TestRun_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int32
+	errs := Run(ctx, 5, 2, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	if calls != 0 {
+		t.Errorf("expected no calls after cancellation, got %d", calls)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("index %d: expected context.Canceled, got %v", i, err)
+		}
+	}
+}
+
+func TestRun_Empty(t *testing.T) {
+	errs := Run(context.Background(), 0, 4, func(ctx context.Context, i int) error {
+		t.Fatal("fn should not be called")
+		return nil
+	})
+	if len(errs) != 0 {
+		t.Errorf("expected no results, got %d", len(errs))
+	}
+}
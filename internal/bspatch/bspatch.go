@@ -0,0 +1,85 @@
+// Package bspatch applies patches in the BSDIFF40 format produced by
+// Colin Percival's bsdiff, which upgrades use to download only the
+// difference between two releases.
+package bspatch
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const magic = "BSDIFF40"
+
+// ErrCorrupt is returned for patches that are malformed or don't fit the
+// input they're applied to.
+var ErrCorrupt = errors.New("corrupt patch")
+
+// Apply returns the result of applying patch to old. Patches producing more
+// than maxSize bytes are rejected before anything is allocated.
+func Apply(old, patch []byte, maxSize int64) ([]byte, error) {
+	if len(patch) < 32 || string(patch[:8]) != magic {
+		return nil, fmt.Errorf("%w: not a BSDIFF40 patch", ErrCorrupt)
+	}
+	ctrlLen := offtin(patch[8:16])
+	diffLen := offtin(patch[16:24])
+	newSize := offtin(patch[24:32])
+	if ctrlLen < 0 || diffLen < 0 || newSize < 0 || 32+ctrlLen+diffLen > int64(len(patch)) {
+		return nil, fmt.Errorf("%w: bad header", ErrCorrupt)
+	}
+	if newSize > maxSize {
+		return nil, fmt.Errorf("patched file would be %d bytes, over the %d byte limit", newSize, maxSize)
+	}
+
+	body := patch[32:]
+	ctrl := bzip2.NewReader(bytes.NewReader(body[:ctrlLen]))
+	diff := bzip2.NewReader(bytes.NewReader(body[ctrlLen : ctrlLen+diffLen]))
+	extra := bzip2.NewReader(bytes.NewReader(body[ctrlLen+diffLen:]))
+
+	out := make([]byte, newSize)
+	var oldPos, newPos int64
+	var buf [24]byte
+	for newPos < newSize {
+		// Each control triple copies x bytes of old plus diff, then y bytes
+		// of extra, then seeks old by z
+		if _, err := io.ReadFull(ctrl, buf[:]); err != nil {
+			return nil, fmt.Errorf("%w: control block: %v", ErrCorrupt, err)
+		}
+		x, y, z := offtin(buf[0:8]), offtin(buf[8:16]), offtin(buf[16:24])
+
+		if x < 0 || y < 0 || newPos+x > newSize || newPos+x+y > newSize {
+			return nil, fmt.Errorf("%w: control block out of range", ErrCorrupt)
+		}
+		if _, err := io.ReadFull(diff, out[newPos:newPos+x]); err != nil {
+			return nil, fmt.Errorf("%w: diff block: %v", ErrCorrupt, err)
+		}
+		for i := int64(0); i < x; i++ {
+			if p := oldPos + i; p >= 0 && p < int64(len(old)) {
+				out[newPos+i] += old[p]
+			}
+		}
+		newPos += x
+		oldPos += x
+
+		if _, err := io.ReadFull(extra, out[newPos:newPos+y]); err != nil {
+			return nil, fmt.Errorf("%w: extra block: %v", ErrCorrupt, err)
+		}
+		newPos += y
+		oldPos += z
+	}
+	return out, nil
+}
+
+// offtin decodes bsdiff's 8-byte sign-magnitude little-endian integer.
+func offtin(b []byte) int64 {
+	y := int64(b[7] & 0x7f)
+	for i := 6; i >= 0; i-- {
+		y = y<<8 | int64(b[i])
+	}
+	if b[7]&0x80 != 0 {
+		y = -y
+	}
+	return y
+}
@@ -0,0 +1,71 @@
+package bspatch
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// testPatch was made with bsdiff from "hello world, this is dub v1.0" to
+// "hello world, this is dub v1.1 (patched)".
+const testPatch = "42534449464634302b0000000000000029000000000000002700000000000000" +
+	"425a6839314159265359eb5289ae000005e0004818000220002186819a0c56c9b8bb9229c284875a944d70" +
+	"425a683931415926535960623767000000600060000040200030cc0cf505ce2ee48a70a120c0c46ece" +
+	"425a68393141592653597a499344000000118040602e404400200031003020036a4c621023c5dc914e14241e9264d100"
+
+func decodePatch(t *testing.T) []byte {
+	t.Helper()
+	patch, err := hex.DecodeString(testPatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return patch
+}
+
+func TestApply(t *testing.T) {
+	got, err := Apply([]byte("hello world, this is dub v1.0"), decodePatch(t), 1<<20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "hello world, this is dub v1.1 (patched)" {
+		t.Errorf("unexpected result %q", got)
+	}
+}
+
+func TestApply_RejectsCorruptPatches(t *testing.T) {
+	patch := decodePatch(t)
+
+	tests := map[string][]byte{
+		"empty":     nil,
+		"bad magic": append([]byte("BSDIFF41"), patch[8:]...),
+		"truncated": patch[:len(patch)-20],
+	}
+	for name, p := range tests {
+		if _, err := Apply([]byte("hello world, this is dub v1.0"), p, 1<<20); !errors.Is(err, ErrCorrupt) {
+			t.Errorf("%s: expected ErrCorrupt, got %v", name, err)
+		}
+	}
+}
+
+func TestApply_MaxSize(t *testing.T) {
+	if _, err := Apply([]byte("hello world, this is dub v1.0"), decodePatch(t), 10); err == nil {
+		t.Error("expected an error for a result over the size limit")
+	}
+}
+
+func TestOfftin(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want int64
+	}{
+		{[]byte{0, 0, 0, 0, 0, 0, 0, 0}, 0},
+		{[]byte{0x2b, 0, 0, 0, 0, 0, 0, 0}, 43},
+		{[]byte{0x00, 0x01, 0, 0, 0, 0, 0, 0}, 256},
+		{[]byte{5, 0, 0, 0, 0, 0, 0, 0x80}, -5},
+	}
+	for _, tt := range tests {
+		if got := offtin(tt.in); got != tt.want {
+			t.Errorf("offtin(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
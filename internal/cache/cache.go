@@ -0,0 +1,341 @@
+// Package cache stores resolved name→ID lookups (tags, folders) so scripts
+// that pass names repeatedly do not trigger a list call on every invocation.
+//
+// Entries are scoped per workspace and kept both in memory for the life of
+// the process and on disk under the cache directory until their TTL expires.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+// DefaultTTL is used when no TTL is configured.
+const DefaultTTL = 10 * time.Minute
+
+// Kind identifies the resource type whose names are cached.
+type Kind string
+
+const (
+	KindTag    Kind = "tags"
+	KindFolder Kind = "folders"
+)
+
+type entry struct {
+	ID       string    `json:"id"`
+	CachedAt time.Time `json:"cachedAt"`
+}
+
+// scopeData maps kind → lower-cased name → entry.
+type scopeData map[Kind]map[string]entry
+
+var (
+	mu     sync.Mutex
+	scopes = map[string]scopeData{}
+	now    = time.Now
+)
+
+// Scope derives a stable, non-reversible cache scope from a workspace API key.
+func Scope(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// TTL returns the effective cache TTL. DUB_CACHE_TTL overrides the
+// config file's cache_ttl; a zero TTL disables caching.
+func TTL() (time.Duration, error) {
+	value := os.Getenv("DUB_CACHE_TTL")
+	if value == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return 0, err
+		}
+		value = cfg.CacheTTL
+	}
+	if value == "" {
+		return DefaultTTL, nil
+	}
+	if value == "0" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid cache TTL %q: expected a duration like 10m or 0 to disable", value)
+	}
+	return ttl, nil
+}
+
+// Lookup returns the cached ID for name, if present and younger than ttl.
+// Names are matched case-insensitively.
+func Lookup(scope string, kind Kind, name string, ttl time.Duration) (string, bool) {
+	if ttl <= 0 {
+		return "", false
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	e, ok := load(scope)[kind][normalize(name)]
+	if !ok || now().Sub(e.CachedAt) > ttl {
+		return "", false
+	}
+	return e.ID, true
+}
+
+// Store records name→ID mappings for kind and persists them.
+func Store(scope string, kind Kind, ids map[string]string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	data := load(scope)
+	if data[kind] == nil {
+		data[kind] = map[string]entry{}
+	}
+	at := now()
+	for name, id := range ids {
+		data[kind][normalize(name)] = entry{ID: id, CachedAt: at}
+	}
+	return save(scope, data)
+}
+
+// Invalidate drops all cached entries of kind for scope, e.g. after a rename.
+func Invalidate(scope string, kind Kind) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	data := load(scope)
+	if _, ok := data[kind]; !ok {
+		return nil
+	}
+	delete(data, kind)
+	return save(scope, data)
+}
+
+// Clear removes every cached entry for all workspaces, including any other
+// caches stored under the cache directory.
+func Clear() error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	scopes = map[string]scopeData{}
+
+	base, err := config.CacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(base); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// ScopeStats summarizes the cached entries for one workspace.
+type ScopeStats struct {
+	Scope   string
+	Entries map[Kind]int
+	Expired int
+	Bytes   int64
+}
+
+// Usage returns the total bytes stored under the cache directory,
+// including caches other than name resolution.
+func Usage() (int64, error) {
+	base, err := config.CacheDir()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	err = filepath.WalkDir(base, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total, err
+}
+
+// Stats returns per-workspace entry counts. Name entries older than ttl,
+// and entries of other kinds older than their own TTL, are counted as
+// expired.
+func Stats(ttl time.Duration) ([]ScopeStats, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	files, err := scopeFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]ScopeStats, 0, len(files))
+	for _, f := range files {
+		st := ScopeStats{Scope: f.scope, Entries: map[Kind]int{}, Bytes: f.size}
+		for kind, entries := range load(f.scope) {
+			for _, e := range entries {
+				st.Entries[kind]++
+				if now().Sub(e.CachedAt) > kindTTL(kind, ttl) {
+					st.Expired++
+				}
+			}
+		}
+		stats = append(stats, st)
+	}
+	return stats, nil
+}
+
+// GC removes entries expired as described on Stats and deletes cache files
+// left empty. It returns the number of entries removed.
+func GC(ttl time.Duration) (int, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	files, err := scopeFiles()
+	if err != nil {
+		return 0, err
+	}
+	d, err := dir()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, f := range files {
+		data := load(f.scope)
+		for kind, entries := range data {
+			for name, e := range entries {
+				if now().Sub(e.CachedAt) > kindTTL(kind, ttl) {
+					delete(entries, name)
+					removed++
+				}
+			}
+			if len(entries) == 0 {
+				delete(data, kind)
+			}
+		}
+
+		if len(data) == 0 {
+			delete(scopes, f.scope)
+			if err := os.Remove(filepath.Join(d, f.scope+".json")); err != nil && !os.IsNotExist(err) {
+				return removed, err
+			}
+			continue
+		}
+		if err := save(f.scope, data); err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// kindTTL returns how long entries of kind are kept, given the name lookup
+// TTL. Permissions have their own, longer TTL.
+func kindTTL(kind Kind, ttl time.Duration) time.Duration {
+	if kind == KindPermission {
+		return PermissionTTL
+	}
+	return ttl
+}
+
+type scopeFile struct {
+	scope string
+	size  int64
+}
+
+// scopeFiles lists the per-workspace cache files on disk. Callers must hold mu.
+func scopeFiles() ([]scopeFile, error) {
+	d, err := dir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(d)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []scopeFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		var size int64
+		if info, err := e.Info(); err == nil {
+			size = info.Size()
+		}
+		files = append(files, scopeFile{scope: strings.TrimSuffix(name, ".json"), size: size})
+	}
+	return files, nil
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// dir returns the directory holding per-workspace resolution caches.
+func dir() (string, error) {
+	base, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "resolve"), nil
+}
+
+// load returns the in-memory data for scope, reading it from disk on first use.
+// A missing or corrupt cache file is treated as empty. Callers must hold mu.
+func load(scope string) scopeData {
+	if data, ok := scopes[scope]; ok {
+		return data
+	}
+
+	data := scopeData{}
+	if d, err := dir(); err == nil {
+		if raw, err := os.ReadFile(filepath.Join(d, scope+".json")); err == nil {
+			_ = json.Unmarshal(raw, &data)
+		}
+	}
+	scopes[scope] = data
+	return data
+}
+
+// save writes scope data to disk. Callers must hold mu.
+func save(scope string, data scopeData) error {
+	d, err := dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d, 0o700); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(d, scope+".json"), raw, 0o600)
+}
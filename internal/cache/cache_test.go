@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setup(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_CACHE_TTL", "")
+
+	mu.Lock()
+	scopes = map[string]scopeData{}
+	mu.Unlock()
+	t.Cleanup(func() { now = time.Now })
+	return dir
+}
+
+func TestStoreAndLookup(t *testing.T) {
+	setup(t)
+	scope := Scope("dub_test")
+
+	if err := Store(scope, KindTag, map[string]string{"Marketing": "tag_1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	id, ok := Lookup(scope, KindTag, "marketing", time.Minute)
+	if !ok || id != "tag_1" {
+		t.Errorf("expected case-insensitive hit tag_1, got %q %v", id, ok)
+	}
+	if _, ok := Lookup(scope, KindFolder, "marketing", time.Minute); ok {
+		t.Error("expected kinds to be cached separately")
+	}
+	if _, ok := Lookup(Scope("dub_other"), KindTag, "marketing", time.Minute); ok {
+		t.Error("expected workspaces to be cached separately")
+	}
+	if _, ok := Lookup(scope, KindTag, "marketing", 0); ok {
+		t.Error("expected zero TTL to disable lookups")
+	}
+}
+
+func TestLookup_Expired(t *testing.T) {
+	setup(t)
+	scope := Scope("dub_test")
+
+	base := time.Now()
+	now = func() time.Time { return base }
+	if err := Store(scope, KindTag, map[string]string{"a": "tag_a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now = func() time.Time { return base.Add(2 * time.Minute) }
+	if _, ok := Lookup(scope, KindTag, "a", time.Minute); ok {
+		t.Error("expected entry older than TTL to miss")
+	}
+}
+
+func TestPersistsAcrossProcesses(t *testing.T) {
+	dir := setup(t)
+	scope := Scope("dub_test")
+
+	if err := Store(scope, KindFolder, map[string]string{"Q1": "fold_1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dub-cli", "resolve", scope+".json")); err != nil {
+		t.Fatalf("expected cache file on disk: %v", err)
+	}
+
+	// Simulate a new process by dropping the in-memory copy
+	mu.Lock()
+	scopes = map[string]scopeData{}
+	mu.Unlock()
+
+	if id, ok := Lookup(scope, KindFolder, "q1", time.Minute); !ok || id != "fold_1" {
+		t.Errorf("expected persisted entry, got %q %v", id, ok)
+	}
+}
+
+func TestInvalidateAndClear(t *testing.T) {
+	setup(t)
+	scope := Scope("dub_test")
+
+	_ = Store(scope, KindTag, map[string]string{"a": "tag_a"})
+	_ = Store(scope, KindFolder, map[string]string{"b": "fold_b"})
+
+	if err := Invalidate(scope, KindTag); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := Lookup(scope, KindTag, "a", time.Minute); ok {
+		t.Error("expected tags to be invalidated")
+	}
+	if _, ok := Lookup(scope, KindFolder, "b", time.Minute); !ok {
+		t.Error("expected folders to survive tag invalidation")
+	}
+
+	if err := Clear(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := Lookup(scope, KindFolder, "b", time.Minute); ok {
+		t.Error("expected cache to be empty after Clear")
+	}
+}
+
+func TestTTL(t *testing.T) {
+	setup(t)
+
+	if ttl, err := TTL(); err != nil || ttl != DefaultTTL {
+		t.Errorf("expected default TTL, got %v %v", ttl, err)
+	}
+
+	t.Setenv("DUB_CACHE_TTL", "0")
+	if ttl, err := TTL(); err != nil || ttl != 0 {
+		t.Errorf("expected disabled TTL, got %v %v", ttl, err)
+	}
+
+	t.Setenv("DUB_CACHE_TTL", "1h")
+	if ttl, err := TTL(); err != nil || ttl != time.Hour {
+		t.Errorf("expected 1h TTL, got %v %v", ttl, err)
+	}
+
+	t.Setenv("DUB_CACHE_TTL", "soon")
+	if _, err := TTL(); err == nil {
+		t.Error("expected error for invalid TTL")
+	}
+}
+
+func TestStatsAndGC(t *testing.T) {
+	setup(t)
+	scope := Scope("dub_test")
+
+	base := time.Now()
+	now = func() time.Time { return base }
+	_ = Store(scope, KindTag, map[string]string{"old": "tag_old"})
+	// Permissions outlive the name TTL
+	_ = StorePermissions(scope, map[string]bool{"links.write": true})
+	now = func() time.Time { return base.Add(time.Hour) }
+	_ = Store(scope, KindTag, map[string]string{"new": "tag_new"})
+	_ = Store(Scope("dub_other"), KindFolder, map[string]string{"x": "fold_x"})
+	now = func() time.Time { return base.Add(2 * time.Hour) }
+
+	stats, err := Stats(90 * time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 scopes, got %d", len(stats))
+	}
+	for _, st := range stats {
+		if st.Scope == scope && (st.Entries[KindTag] != 2 || st.Expired != 1) {
+			t.Errorf("unexpected stats for %s: %+v", scope, st)
+		}
+		if st.Bytes == 0 {
+			t.Errorf("expected non-zero size for %s", st.Scope)
+		}
+	}
+
+	removed, err := GC(90 * time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("expected 1 expired entry removed, got %d", removed)
+	}
+	if _, ok := Lookup(scope, KindTag, "new", 90*time.Minute); !ok {
+		t.Error("expected fresh entry to survive GC")
+	}
+	if granted, known := LookupPermission(scope, "links.write"); !known || !granted {
+		t.Error("expected a permission within PermissionTTL to survive GC")
+	}
+
+	if usage, err := Usage(); err != nil || usage == 0 {
+		t.Errorf("expected non-zero usage, got %d %v", usage, err)
+	}
+}
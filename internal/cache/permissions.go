@@ -0,0 +1,38 @@
+package cache
+
+import "time"
+
+// KindPermission holds the API key scopes seen granted or denied, keyed by
+// scope name such as "links.write".
+const KindPermission Kind = "permissions"
+
+// PermissionTTL is how long a key's known scopes are trusted. Scopes rarely
+// change, so this is longer than the name lookup TTL.
+const PermissionTTL = 24 * time.Hour
+
+const (
+	permissionGranted = "granted"
+	permissionDenied  = "denied"
+)
+
+// LookupPermission reports whether perm is granted for scope, and whether
+// that is known.
+func LookupPermission(scope, perm string) (granted, known bool) {
+	value, ok := Lookup(scope, KindPermission, perm, PermissionTTL)
+	if !ok {
+		return false, false
+	}
+	return value == permissionGranted, true
+}
+
+// StorePermissions records which scopes are granted or denied.
+func StorePermissions(scope string, perms map[string]bool) error {
+	values := make(map[string]string, len(perms))
+	for perm, granted := range perms {
+		values[perm] = permissionDenied
+		if granted {
+			values[perm] = permissionGranted
+		}
+	}
+	return Store(scope, KindPermission, values)
+}
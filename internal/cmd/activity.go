@@ -0,0 +1,193 @@
+// internal/cmd/activity.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// activityEventTypes are the event types merged into a customer's activity.
+var activityEventTypes = []string{"clicks", "leads", "sales"}
+
+// customerActivity is a customer with every event attributed to them.
+type customerActivity struct {
+	Customer map[string]interface{}   `json:"customer"`
+	Events   []map[string]interface{} `json:"events"`
+	Summary  activitySummary          `json:"summary"`
+}
+
+// activitySummary counts a customer's events. LifetimeValue is the sum of
+// their sale amounts in dollars.
+type activitySummary struct {
+	Clicks        int     `json:"clicks"`
+	Leads         int     `json:"leads"`
+	Sales         int     `json:"sales"`
+	LifetimeValue float64 `json:"lifetimeValue"`
+}
+
+func newCustomersActivityCmd() *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:     "activity",
+		Aliases: []string{"activities"},
+		Short:   "Show a customer's clicks, leads, and sales",
+		Long: `Show a customer's details with every click, lead, and sale attributed to
+them, newest first, followed by their lifetime value.
+
+Examples:
+  dub customers activity --id cus_abc123
+  dub customers activity --id cus_abc123 -o json --query '.summary'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			activity, err := fetchCustomerActivity(cmd.Context(), client, id)
+			if err != nil {
+				return err
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), activity, outfmt.GetQuery(cmd.Context()))
+			}
+			return writeCustomerActivity(cmd, activity)
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Customer ID (required)")
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+// fetchCustomerActivity loads the customer and every page of their events of
+// each type concurrently, merging the events newest first.
+func fetchCustomerActivity(ctx context.Context, client *api.Client, id string) (*customerActivity, error) {
+	activity := &customerActivity{}
+	events := make([][]map[string]interface{}, len(activityEventTypes))
+
+	errs := batch.Run(ctx, len(activityEventTypes)+1, len(activityEventTypes)+1, func(ctx context.Context, i int) error {
+		if i == len(activityEventTypes) {
+			if err := getJSON(ctx, client, "/customers/"+url.PathEscape(id), &activity.Customer); err != nil {
+				return fmt.Errorf("failed to get customer: %w", err)
+			}
+			return nil
+		}
+
+		params := url.Values{"customerId": {id}, "event": {activityEventTypes[i]}, "interval": {"all"}}
+		body, err := fetchEventPages(ctx, client, params)
+		if err != nil {
+			return fmt.Errorf("%s: %w", activityEventTypes[i], err)
+		}
+		if err := json.Unmarshal(body, &events[i]); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", activityEventTypes[i], err)
+		}
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	for i, typed := range events {
+		for _, event := range typed {
+			if outfmt.SafeString(event["event"]) == "" {
+				// Events are listed per type, so the singular type is implied
+				event["event"] = activityEventTypes[i][:len(activityEventTypes[i])-1]
+			}
+			activity.Events = append(activity.Events, event)
+		}
+	}
+	sort.SliceStable(activity.Events, func(i, j int) bool {
+		return outfmt.SafeString(activity.Events[i]["timestamp"]) > outfmt.SafeString(activity.Events[j]["timestamp"])
+	})
+	if activity.Events == nil {
+		activity.Events = []map[string]interface{}{}
+	}
+
+	activity.Summary = activitySummary{Clicks: len(events[0]), Leads: len(events[1]), Sales: len(events[2])}
+	for _, sale := range events[2] {
+		activity.Summary.LifetimeValue += saleAmount(sale)
+	}
+	return activity, nil
+}
+
+// saleAmount returns a sale event's amount in dollars. The API reports
+// amounts in cents.
+func saleAmount(event map[string]interface{}) float64 {
+	if sale, ok := event["sale"].(map[string]interface{}); ok {
+		return outfmt.SafeFloat(sale["amount"]) / 100
+	}
+	return outfmt.SafeFloat(event["saleAmount"]) / 100
+}
+
+func writeCustomerActivity(cmd *cobra.Command, activity *customerActivity) error {
+	out := cmd.OutOrStdout()
+	c := activity.Customer
+
+	name := outfmt.SafeString(c["name"])
+	if email := outfmt.SafeString(c["email"]); email != "" {
+		name += " <" + email + ">"
+	}
+	_, _ = fmt.Fprintf(out, "Customer: %s (%s)\n", dashIfEmpty(name), outfmt.SafeString(c["id"]))
+	if ext := outfmt.SafeString(c["externalId"]); ext != "" {
+		_, _ = fmt.Fprintf(out, "External ID: %s\n", ext)
+	}
+	if created := outfmt.SafeString(c["createdAt"]); created != "" {
+		_, _ = fmt.Fprintf(out, "Customer since: %s\n", outfmt.FormatDate(created))
+	}
+	_, _ = fmt.Fprintln(out)
+
+	if len(activity.Events) == 0 {
+		_, _ = fmt.Fprintln(out, "No activity.")
+	} else {
+		columns := []outfmt.Column{
+			{Name: "Timestamp", Width: 0, Align: outfmt.AlignLeft},
+			{Name: "Event", Width: 0, Align: outfmt.AlignLeft},
+			{Name: "Link", Width: 20, Align: outfmt.AlignLeft},
+			{Name: "Details", Width: 30, Align: outfmt.AlignLeft},
+		}
+		rows := make([][]string, len(activity.Events))
+		for i, event := range activity.Events {
+			rows[i] = []string{
+				formatTimestamp(event["timestamp"]),
+				outfmt.SafeString(event["event"]),
+				formatEventLink(event),
+				activityDetails(event),
+			}
+		}
+		if err := outfmt.FormatTable(out, columns, rows); err != nil {
+			return err
+		}
+	}
+
+	s := activity.Summary
+	_, _ = fmt.Fprintf(out, "\nLifetime value: %s from %d sale(s), %d lead(s), %d click(s)\n",
+		formatAmount(s.LifetimeValue), s.Sales, s.Leads, s.Clicks)
+	return nil
+}
+
+// activityDetails summarizes what is specific to an event: the amount of a
+// sale, or the name of a lead event.
+func activityDetails(event map[string]interface{}) string {
+	switch outfmt.SafeString(event["event"]) {
+	case "sale":
+		return formatAmount(saleAmount(event))
+	case "lead":
+		return outfmt.Truncate(formatEventField(event["eventName"]), 30)
+	default:
+		return formatEventCountry(event["country"], false)
+	}
+}
@@ -0,0 +1,62 @@
+// internal/cmd/activity_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCustomersActivityCmd(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/customers/cus_1" {
+			_, _ = w.Write([]byte(`{"id": "cus_1", "name": "Ada", "email": "ada@example.com"}`))
+			return
+		}
+		if r.URL.Query().Get("customerId") != "cus_1" {
+			t.Errorf("expected customerId filter, got %s", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("event") {
+		case "clicks":
+			// a full page of clicks, then one more on the second page
+			n := eventsPageSize
+			if r.URL.Query().Get("page") == "2" {
+				n = 1
+			}
+			_, _ = w.Write([]byte("[" + strings.TrimSuffix(strings.Repeat(`{"event": "click", "timestamp": "2026-01-01T10:00:00Z", "country": "US"},`, n), ",") + "]"))
+		case "leads":
+			_, _ = w.Write([]byte(`[{"event": "lead", "timestamp": "2026-01-02T10:00:00Z", "eventName": "Sign up"}]`))
+		case "sales":
+			_, _ = w.Write([]byte(`[
+				{"event": "sale", "timestamp": "2026-01-03T10:00:00Z", "sale": {"amount": 4900}},
+				{"event": "sale", "timestamp": "2026-02-03T10:00:00Z", "sale": {"amount": 123456}}
+			]`))
+		}
+	})
+
+	cmd := newCustomersActivityCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--id", "cus_1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"Customer: Ada <ada@example.com> (cus_1)",
+		"$1,234.56",
+		"Sign up",
+		"Lifetime value: $1,283.56 from 2 sale(s), 1 lead(s), 101 click(s)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "$1,234.56") > strings.Index(got, "Sign up") {
+		t.Errorf("expected newest events first, got:\n%s", got)
+	}
+}
@@ -0,0 +1,191 @@
+// internal/cmd/allworkspaces.go
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// fanOutCommands are the read commands --all-workspaces runs, by name.
+var fanOutCommands = map[string]bool{
+	"list":      true,
+	"get":       true,
+	"count":     true,
+	"top":       true,
+	"find":      true,
+	"analytics": true,
+}
+
+// enableAllWorkspaces makes cmd run against every stored workspace instead
+// of one, once its flags are parsed.
+func enableAllWorkspaces(cmd *cobra.Command) error {
+	if !fanOutCommands[cmd.Name()] || cmd.RunE == nil {
+		return NewUsageErrorf("--all-workspaces only works with read commands such as list, get, and count")
+	}
+	if cmd.Flags().Changed("workspace") {
+		return NewUsageErrorf("--all-workspaces cannot be combined with --workspace")
+	}
+	if os.Getenv("DUB_API_KEY") != "" {
+		return NewUsageErrorf("--all-workspaces uses the keys stored with dub auth login; unset DUB_API_KEY")
+	}
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runAllWorkspaces(cmd, args, run)
+	}
+	return nil
+}
+
+// storedWorkspaces returns the names of the workspaces with a key for role.
+func storedWorkspaces(role string) ([]string, error) {
+	store, err := storeOpener()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open keyring: %w", err)
+	}
+	creds, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var names []string
+	for _, c := range creds {
+		if strings.EqualFold(c.Role, role) && !seen[c.Name] {
+			seen[c.Name] = true
+			names = append(names, c.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// runAllWorkspaces runs a command's RunE once per workspace, concurrently,
+// and writes the merged results with the workspace of each. Every run gets
+// its own copy of cmd, with the workspace in its context and its own output
+// buffers, so runs share nothing they write to. The runs emit JSON, which
+// is merged before --query and the requested format are applied.
+func runAllWorkspaces(cmd *cobra.Command, args []string, run func(*cobra.Command, []string) error) error {
+	ctx := cmd.Context()
+	names, err := storedWorkspaces(GetKeyRole(ctx))
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no workspaces configured. Run: dub auth login")
+	}
+
+	// Commands with their own --output read it instead of the global format
+	format := outfmt.GetFormat(ctx)
+	if f := cmd.Flags().Lookup("output"); f != nil {
+		format = f.Value.String()
+		if err := f.Value.Set("json"); err != nil {
+			return err
+		}
+	}
+	query := outfmt.GetQuery(ctx)
+
+	outputs := make([]bytes.Buffer, len(names))
+	stderrs := make([]bytes.Buffer, len(names))
+	errs := batch.Run(ctx, len(names), batch.DefaultConcurrency, func(ctx context.Context, i int) error {
+		wsCmd := *cmd
+		wsCtx := context.WithValue(ctx, workspaceKey, names[i])
+		wsCtx = outfmt.WithQuery(outfmt.WithFormat(wsCtx, "json"), "")
+		wsCmd.SetContext(wsCtx)
+		wsCmd.SetOut(&outputs[i])
+		wsCmd.SetErr(&stderrs[i])
+		return run(&wsCmd, args)
+	})
+
+	var merged []interface{}
+	var text []string
+	failed := 0
+	for i, name := range names {
+		writePrefixed(cmd, name, stderrs[i].String())
+		if errs[i] != nil {
+			failed++
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", name, errs[i])
+			continue
+		}
+		var data interface{}
+		if err := json.Unmarshal(outputs[i].Bytes(), &data); err != nil {
+			// Output that isn't JSON, such as --quiet IDs, is kept as lines
+			for _, line := range strings.Split(strings.TrimRight(outputs[i].String(), "\n"), "\n") {
+				if line != "" {
+					text = append(text, name+"\t"+line)
+				}
+			}
+			continue
+		}
+		merged = append(merged, withWorkspace(name, data)...)
+	}
+
+	if err := writeAllWorkspaces(cmd, merged, text, format, query); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d workspaces failed", failed, len(names))
+	}
+	return nil
+}
+
+// withWorkspace returns the items of a workspace's result, each an object
+// with a workspace field.
+func withWorkspace(name string, data interface{}) []interface{} {
+	items, ok := data.([]interface{})
+	if !ok {
+		items = []interface{}{data}
+	}
+	result := make([]interface{}, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			obj = map[string]interface{}{"value": item}
+		}
+		obj["workspace"] = name
+		result[i] = obj
+	}
+	return result
+}
+
+// writeAllWorkspaces writes the merged results as JSON, or as a table led
+// by a WORKSPACE column.
+func writeAllWorkspaces(cmd *cobra.Command, merged []interface{}, text []string, format, query string) error {
+	w := cmd.OutOrStdout()
+	for _, line := range text {
+		_, _ = fmt.Fprintln(w, line)
+	}
+	if len(text) > 0 && len(merged) == 0 {
+		return nil
+	}
+	if merged == nil {
+		merged = []interface{}{}
+	}
+	if format == "json" || query != "" {
+		return outfmt.FormatJSON(w, merged, query)
+	}
+	if len(merged) == 0 {
+		_, _ = fmt.Fprintln(w, "No results.")
+		return nil
+	}
+	if ok, err := outfmt.FormatAutoTable(w, merged); ok || err != nil {
+		return err
+	}
+	return outfmt.FormatJSON(w, merged, "")
+}
+
+// writePrefixed copies a workspace's stderr, naming the workspace on each line.
+func writePrefixed(cmd *cobra.Command, name, s string) {
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", name, scanner.Text())
+	}
+}
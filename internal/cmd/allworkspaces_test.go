@@ -0,0 +1,83 @@
+// internal/cmd/allworkspaces_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/secrets"
+)
+
+func TestAllWorkspaces(t *testing.T) {
+	store := newMockStore()
+	_ = store.Set("globex", secrets.Credentials{Name: "globex", APIKey: "dub_globex"})
+	_ = store.Set("acme", secrets.Credentials{Name: "acme", APIKey: "dub_acme"})
+	_ = store.Set("broken", secrets.Credentials{Name: "broken", APIKey: "dub_broken"})
+	origOpener := storeOpener
+	storeOpener = func() (secrets.Store, error) { return store, nil }
+	defer func() { storeOpener = origOpener }()
+
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer dub_acme":
+			_, _ = w.Write([]byte(`[{"id": "tag_a", "name": "launch"}]`))
+		case "Bearer dub_globex":
+			_, _ = w.Write([]byte(`[{"id": "tag_g1", "name": "promo"}, {"id": "tag_g2", "name": "docs"}]`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": "forbidden", "message": "Forbidden"}}`))
+		}
+	})
+	t.Setenv("DUB_API_KEY", "")
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"tags", "list", "--all-workspaces", "--api-url", server.URL, "-o", "json"}
+	err := execute(context.Background(), args, nil, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 workspaces failed") {
+		t.Fatalf("expected the broken workspace to fail, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "broken: forbidden: Forbidden") {
+		t.Errorf("expected the failure on stderr, got %q", stderr.String())
+	}
+	var tags []map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &tags); err != nil {
+		t.Fatalf("expected JSON, got %q: %v", stdout.String(), err)
+	}
+	var got []string
+	for _, tag := range tags {
+		got = append(got, tag["workspace"].(string)+"/"+tag["id"].(string))
+	}
+	if strings.Join(got, ",") != "acme/tag_a,globex/tag_g1,globex/tag_g2" {
+		t.Errorf("unexpected merged tags %v", got)
+	}
+
+	_ = store.Delete("broken")
+	stdout.Reset()
+	args = []string{"tags", "list", "--all-workspaces", "--api-url", server.URL}
+	if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "WORKSPACE") || !strings.HasPrefix(lines[1], "acme") {
+		t.Errorf("expected a table led by the workspace, got:\n%s", stdout.String())
+	}
+}
+
+func TestAllWorkspaces_Rejected(t *testing.T) {
+	setTestEnv(t)
+	t.Setenv("DUB_API_KEY", "")
+
+	for _, args := range [][]string{
+		{"tags", "create", "--name", "x", "--all-workspaces"},
+		{"tags", "list", "--all-workspaces", "--workspace", "acme"},
+	} {
+		err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err == nil || !IsUsageError(err) {
+			t.Errorf("%v: expected a usage error, got %v", args, err)
+		}
+	}
+}
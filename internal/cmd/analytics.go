@@ -34,12 +34,15 @@ func newAnalyticsCmd() *cobra.Command {
 		output   string
 		limit    int
 		all      bool
+		rawCodes bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "analytics",
 		Short: "Retrieve analytics",
-		Long:  "Retrieve analytics for links, including clicks, leads, and sales.",
+		Long: `Retrieve analytics for links, including clicks, leads, and sales.
+
+Use dub analytics anomalies to flag days with unusual click counts.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient(cmd.Context())
 			if err != nil {
@@ -62,11 +65,8 @@ func newAnalyticsCmd() *cobra.Command {
 			if interval != "" {
 				params.Set("interval", interval)
 			}
-			if start != "" {
-				params.Set("start", start)
-			}
-			if end != "" {
-				params.Set("end", end)
+			if err := setDateRange(params, "--start", start, "--end", end); err != nil {
+				return err
 			}
 			if country != "" {
 				params.Set("country", country)
@@ -100,17 +100,17 @@ func newAnalyticsCmd() *cobra.Command {
 				return err
 			}
 
-			return handleAnalyticsResponse(cmd, resp, groupBy, output, limit, all)
+			return handleAnalyticsResponse(cmd, resp, groupBy, output, limit, all, rawCodes)
 		},
 	}
 
 	cmd.Flags().StringVar(&event, "event", "", "Event type: clicks, leads, or sales")
-	cmd.Flags().StringVar(&groupBy, "group-by", "", "Property to group by: count, timeseries, countries, cities, devices, browsers, os, referers")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Property to group by: count, timeseries, countries, cities, regions, devices, browsers, os, referers, triggers")
 	cmd.Flags().StringVar(&domain, "domain", "", "Filter by domain")
 	cmd.Flags().StringVar(&linkID, "link-id", "", "Filter by link ID")
 	cmd.Flags().StringVar(&interval, "interval", "", "Time interval: 1h, 24h, 7d, 30d, 90d, all")
-	cmd.Flags().StringVar(&start, "start", "", "Start date (ISO 8601)")
-	cmd.Flags().StringVar(&end, "end", "", "End date (ISO 8601)")
+	cmd.Flags().StringVar(&start, "start", "", `Start date: ISO 8601, 2025-01, yesterday, or "7 days ago"`)
+	cmd.Flags().StringVar(&end, "end", "", "End date, in the same forms as --start (dates include the whole day)")
 	cmd.Flags().StringVar(&country, "country", "", "Filter by country code")
 	cmd.Flags().StringVar(&city, "city", "", "Filter by city")
 	cmd.Flags().StringVar(&device, "device", "", "Filter by device type")
@@ -120,14 +120,17 @@ func newAnalyticsCmd() *cobra.Command {
 	cmd.Flags().StringVar(&timezone, "timezone", "", "Timezone for results")
 	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of rows to show (for grouped results)")
+	cmd.Flags().BoolVar(&rawCodes, "raw-codes", false, "Show country codes instead of flags and names")
 	cmd.Flags().BoolVar(&all, "all", false, "Show all rows (ignore limit)")
 
+	cmd.AddCommand(newAnalyticsAnomaliesCmd())
+
 	return cmd
 }
 
 // handleAnalyticsResponse handles the response for analytics command,
 // formatting output as table or JSON based on the output flag and group-by value.
-func handleAnalyticsResponse(cmd *cobra.Command, resp *http.Response, groupBy, output string, limit int, all bool) error {
+func handleAnalyticsResponse(cmd *cobra.Command, resp *http.Response, groupBy, output string, limit int, all, rawCodes bool) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
@@ -157,8 +160,8 @@ func handleAnalyticsResponse(cmd *cobra.Command, resp *http.Response, groupBy, o
 		return formatAnalyticsCount(cmd, body)
 	case "timeseries":
 		return formatAnalyticsTimeseries(cmd, body, limit, all)
-	case "countries", "cities", "devices", "browsers", "os", "referers":
-		return formatAnalyticsGrouped(cmd, body, groupBy, limit, all)
+	case "countries", "cities", "regions", "devices", "browsers", "os", "referers", "triggers":
+		return formatAnalyticsGrouped(cmd, body, groupBy, limit, all, rawCodes)
 	default:
 		// Unknown group-by, fall back to JSON
 		var data interface{}
@@ -267,7 +270,8 @@ func formatAnalyticsTimeseries(cmd *cobra.Command, body []byte, limit int, all b
 }
 
 // formatAnalyticsGrouped formats grouped analytics data (countries, cities, etc.).
-func formatAnalyticsGrouped(cmd *cobra.Command, body []byte, groupBy string, limit int, all bool) error {
+// Country codes are expanded to flag and name unless rawCodes is set.
+func formatAnalyticsGrouped(cmd *cobra.Command, body []byte, groupBy string, limit int, all, rawCodes bool) error {
 	var data []map[string]interface{}
 	if err := json.Unmarshal(body, &data); err != nil {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(body))
@@ -301,8 +305,12 @@ func formatAnalyticsGrouped(cmd *cobra.Command, body []byte, groupBy string, lim
 	// Build rows
 	rows := make([][]string, len(displayData))
 	for i, item := range displayData {
+		value := outfmt.SafeString(item[dataKey])
+		if dataKey == "country" && !rawCodes {
+			value = outfmt.FormatCountry(value)
+		}
 		rows[i] = []string{
-			outfmt.SafeString(item[dataKey]),
+			value,
 			formatMetricValue(item["clicks"]),
 			formatMetricValue(item["leads"]),
 			formatMetricValue(item["sales"]),
@@ -330,6 +338,8 @@ func getGroupByColumn(groupBy string) (columnName, dataKey string) {
 		return "Country", "country"
 	case "cities":
 		return "City", "city"
+	case "regions":
+		return "Region", "region"
 	case "devices":
 		return "Device", "device"
 	case "browsers":
@@ -338,6 +348,8 @@ func getGroupByColumn(groupBy string) (columnName, dataKey string) {
 		return "OS", "os"
 	case "referers":
 		return "Referer", "referer"
+	case "triggers":
+		return "Trigger", "trigger"
 	default:
 		return "Value", groupBy
 	}
@@ -350,6 +362,8 @@ func getGroupByNoun(groupBy string) string {
 		return "countries"
 	case "cities":
 		return "cities"
+	case "regions":
+		return "regions"
 	case "devices":
 		return "devices"
 	case "browsers":
@@ -358,6 +372,8 @@ func getGroupByNoun(groupBy string) string {
 		return "operating systems"
 	case "referers":
 		return "referers"
+	case "triggers":
+		return "triggers"
 	default:
 		return "items"
 	}
@@ -96,10 +96,10 @@ func TestAnalyticsCmd_HasLongDescription(t *testing.T) {
 	}
 }
 
-func TestAnalyticsCmd_NoSubCommands(t *testing.T) {
+func TestAnalyticsCmd_SubCommands(t *testing.T) {
 	cmd := newAnalyticsCmd()
-	if len(cmd.Commands()) != 0 {
-		t.Errorf("expected analytics to have no subcommands, got %d", len(cmd.Commands()))
+	if len(cmd.Commands()) != 1 || cmd.Commands()[0].Name() != "anomalies" {
+		t.Errorf("expected analytics to have only the anomalies subcommand, got %d", len(cmd.Commands()))
 	}
 }
 
@@ -142,6 +142,8 @@ func TestGetGroupByColumn(t *testing.T) {
 		{"browsers", "Browser", "browser"},
 		{"os", "OS", "os"},
 		{"referers", "Referer", "referer"},
+		{"regions", "Region", "region"},
+		{"triggers", "Trigger", "trigger"},
 		{"unknown", "Value", "unknown"},
 	}
 
@@ -169,6 +171,8 @@ func TestGetGroupByNoun(t *testing.T) {
 		{"browsers", "browsers"},
 		{"os", "operating systems"},
 		{"referers", "referers"},
+		{"regions", "regions"},
+		{"triggers", "triggers"},
 		{"unknown", "items"},
 	}
 
@@ -202,7 +206,7 @@ func TestHandleAnalyticsResponse_CountFormat(t *testing.T) {
 		Body:       mockReadCloser{strings.NewReader(body)},
 	}
 
-	err := handleAnalyticsResponse(cmd, resp, "", "table", 25, false)
+	err := handleAnalyticsResponse(cmd, resp, "", "table", 25, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -233,7 +237,7 @@ func TestHandleAnalyticsResponse_TimeseriesFormat(t *testing.T) {
 		Body:       mockReadCloser{strings.NewReader(body)},
 	}
 
-	err := handleAnalyticsResponse(cmd, resp, "timeseries", "table", 25, false)
+	err := handleAnalyticsResponse(cmd, resp, "timeseries", "table", 25, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -265,7 +269,7 @@ func TestHandleAnalyticsResponse_CountriesFormat(t *testing.T) {
 		Body:       mockReadCloser{strings.NewReader(body)},
 	}
 
-	err := handleAnalyticsResponse(cmd, resp, "countries", "table", 25, false)
+	err := handleAnalyticsResponse(cmd, resp, "countries", "table", 25, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -274,14 +278,65 @@ func TestHandleAnalyticsResponse_CountriesFormat(t *testing.T) {
 	if !strings.Contains(output, "COUNTRY") {
 		t.Error("expected output to contain 'COUNTRY' header")
 	}
-	if !strings.Contains(output, "US") {
-		t.Error("expected output to contain 'US'")
+	if !strings.Contains(output, "🇺🇸 United States") {
+		t.Error("expected output to expand 'US' to flag and name")
+	}
+	if !strings.Contains(output, "UK") {
+		t.Error("expected unknown code 'UK' to be shown unchanged")
 	}
 	if !strings.Contains(output, "5,432") {
 		t.Error("expected output to contain formatted count '5,432'")
 	}
 }
 
+func TestHandleAnalyticsResponse_RawCodes(t *testing.T) {
+	cmd := newAnalyticsCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       mockReadCloser{strings.NewReader(`[{"country": "DE", "clicks": 1}]`)},
+	}
+
+	if err := handleAnalyticsResponse(cmd, resp, "countries", "table", 25, false, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "Germany") || !strings.Contains(output, "DE") {
+		t.Errorf("expected raw country code, got:\n%s", output)
+	}
+}
+
+func TestHandleAnalyticsResponse_TriggersFormat(t *testing.T) {
+	cmd := newAnalyticsCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	body := `[
+		{"trigger": "link", "clicks": 900, "leads": 10, "sales": 2},
+		{"trigger": "qr", "clicks": 1200, "leads": 5, "sales": 1}
+	]`
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       mockReadCloser{strings.NewReader(body)},
+	}
+
+	err := handleAnalyticsResponse(cmd, resp, "triggers", "table", 25, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "TRIGGER") {
+		t.Error("expected output to contain 'TRIGGER' header")
+	}
+	if !strings.Contains(output, "qr") || !strings.Contains(output, "1,200") {
+		t.Errorf("expected table rows for triggers, got:\n%s", output)
+	}
+}
+
 func TestHandleAnalyticsResponse_LimitApplied(t *testing.T) {
 	cmd := newAnalyticsCmd()
 	var buf bytes.Buffer
@@ -300,7 +355,7 @@ func TestHandleAnalyticsResponse_LimitApplied(t *testing.T) {
 		Body:       mockReadCloser{strings.NewReader(body)},
 	}
 
-	err := handleAnalyticsResponse(cmd, resp, "countries", "table", 2, false)
+	err := handleAnalyticsResponse(cmd, resp, "countries", "table", 2, false, true)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -336,7 +391,7 @@ func TestHandleAnalyticsResponse_AllFlag(t *testing.T) {
 		Body:       mockReadCloser{strings.NewReader(body)},
 	}
 
-	err := handleAnalyticsResponse(cmd, resp, "countries", "table", 2, true)
+	err := handleAnalyticsResponse(cmd, resp, "countries", "table", 2, true, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -362,7 +417,7 @@ func TestHandleAnalyticsResponse_JSONOutput(t *testing.T) {
 		Body:       mockReadCloser{strings.NewReader(body)},
 	}
 
-	err := handleAnalyticsResponse(cmd, resp, "", "json", 25, false)
+	err := handleAnalyticsResponse(cmd, resp, "", "json", 25, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -388,7 +443,7 @@ func TestHandleAnalyticsResponse_ErrorStatus(t *testing.T) {
 		Body:       mockReadCloser{strings.NewReader(body)},
 	}
 
-	err := handleAnalyticsResponse(cmd, resp, "", "table", 25, false)
+	err := handleAnalyticsResponse(cmd, resp, "", "table", 25, false, false)
 	if err == nil {
 		t.Error("expected error for 404 response")
 	}
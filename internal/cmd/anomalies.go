@@ -0,0 +1,264 @@
+// internal/cmd/anomalies.go
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/notify"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// clickAnomaly is a period whose clicks stray from the rolling baseline of
+// the periods before it.
+type clickAnomaly struct {
+	Start     time.Time `json:"start"`
+	Clicks    int       `json:"clicks"`
+	Baseline  float64   `json:"baseline"`
+	StdDev    float64   `json:"stdDev"`
+	ZScore    float64   `json:"zScore"`
+	Direction string    `json:"direction"`
+}
+
+func newAnalyticsAnomaliesCmd() *cobra.Command {
+	var (
+		interval    string
+		linkID      string
+		domain      string
+		timezone    string
+		window      int
+		threshold   float64
+		notifySpecs []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "anomalies",
+		Short: "Flag days with unusual click counts",
+		Long: `Fetch the click timeseries and flag the days whose clicks deviate from a
+rolling baseline by more than --threshold standard deviations.
+
+Each day is compared with the mean of the --window days before it. The
+standard deviation is taken as at least one click, so a quiet link going
+from 0 to 1 click isn't flagged. The first --window days only serve as
+baseline, and today is left out until it is over.
+
+With --notify, the most recent complete day is posted to a Slack or Discord
+webhook when it is flagged, so a daily scheduled run alerts a channel once
+per anomaly. Earlier flagged days are only listed.
+
+Examples:
+  dub analytics anomalies --interval 30d
+  dub analytics anomalies --interval 90d --window 14 --threshold 2.5 --link-id link_abc
+  dub analytics anomalies --notify slack:https://hooks.slack.com/services/T000/B000/XXXX`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if window < 2 {
+				return NewUsageErrorf("--window must be at least 2")
+			}
+			if threshold <= 0 {
+				return NewUsageErrorf("--threshold must be positive")
+			}
+			notifiers, err := parseNotifiers(notifySpecs)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			params := url.Values{}
+			params.Set("event", "clicks")
+			params.Set("groupBy", "timeseries")
+			params.Set("interval", interval)
+			if linkID != "" {
+				params.Set("linkId", linkID)
+			}
+			if domain != "" {
+				params.Set("domain", domain)
+			}
+			if timezone != "" {
+				params.Set("timezone", timezone)
+			}
+			var timeseries []map[string]interface{}
+			if err := getJSON(cmd.Context(), client, "/analytics?"+params.Encode(), &timeseries); err != nil {
+				return fmt.Errorf("failed to fetch timeseries analytics: %w", err)
+			}
+
+			var points []reportPoint
+			for _, p := range timeseries {
+				start, err := time.Parse(time.RFC3339, outfmt.SafeString(p["start"]))
+				if err != nil {
+					continue
+				}
+				points = append(points, reportPoint{Start: start, Clicks: outfmt.SafeInt(p["clicks"])})
+			}
+			points = completePoints(points, dateNow())
+			if len(points) <= window {
+				return fmt.Errorf("need more than %d days of data for a %d-day baseline, got %d; use a longer --interval or a shorter --window", window, window, len(points))
+			}
+
+			anomalies := detectAnomalies(points, window, threshold)
+			if err := writeAnomalies(cmd, anomalies, len(points)-window, threshold); err != nil {
+				return err
+			}
+			latest := latestAnomaly(anomalies, points)
+			if latest == nil || len(notifiers) == 0 {
+				return nil
+			}
+			return sendNotification(cmd, notifiers, anomaliesMessage([]clickAnomaly{*latest}, linkID, domain, window, threshold))
+		},
+	}
+
+	cmd.Flags().StringVar(&interval, "interval", "30d", "Time interval: 30d, 90d, 1y, all")
+	cmd.Flags().StringVar(&linkID, "link-id", "", "Only look at this link")
+	cmd.Flags().StringVar(&domain, "domain", "", "Only look at links on this domain")
+	cmd.Flags().StringVar(&timezone, "timezone", "", "Timezone for day boundaries")
+	cmd.Flags().IntVar(&window, "window", 7, "Number of preceding days in the rolling baseline")
+	cmd.Flags().Float64Var(&threshold, "threshold", 3, "Z-score beyond which a day is flagged")
+	addNotifyFlag(cmd, &notifySpecs)
+
+	return cmd
+}
+
+// writeAnomalies writes the flagged days as a table or JSON.
+func writeAnomalies(cmd *cobra.Command, anomalies []clickAnomaly, days int, threshold float64) error {
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		if anomalies == nil {
+			anomalies = []clickAnomaly{}
+		}
+		return outfmt.FormatJSON(cmd.OutOrStdout(), anomalies, outfmt.GetQuery(cmd.Context()))
+	}
+
+	w := cmd.OutOrStdout()
+	if len(anomalies) == 0 {
+		_, _ = fmt.Fprintf(w, "No anomalies in %d day(s) (threshold %s standard deviations).\n", days, strconv.FormatFloat(threshold, 'f', -1, 64))
+		return nil
+	}
+
+	columns := []outfmt.Column{
+		{Name: "Date", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Clicks", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Baseline", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Z-Score", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Direction", Width: 0, Align: outfmt.AlignLeft},
+	}
+	rows := make([][]string, len(anomalies))
+	for i, a := range anomalies {
+		rows[i] = []string{
+			a.Start.Format("2006-01-02"),
+			formatClicks(a.Clicks),
+			strconv.FormatFloat(a.Baseline, 'f', 1, 64),
+			formatZScore(a.ZScore),
+			a.Direction,
+		}
+	}
+	return outfmt.FormatTable(w, columns, rows)
+}
+
+// latestAnomaly returns the anomaly of the last point, or nil if that
+// period wasn't flagged. Only it is notified, so repeated runs don't post
+// the same day again.
+func latestAnomaly(anomalies []clickAnomaly, points []reportPoint) *clickAnomaly {
+	if len(anomalies) == 0 || len(points) == 0 {
+		return nil
+	}
+	if last := anomalies[len(anomalies)-1]; last.Start.Equal(points[len(points)-1].Start) {
+		return &last
+	}
+	return nil
+}
+
+// anomaliesMessage describes the flagged days for --notify.
+func anomaliesMessage(anomalies []clickAnomaly, linkID, domain string, window int, threshold float64) notify.Message {
+	scope := "the workspace"
+	switch {
+	case linkID != "":
+		scope = linkID
+	case domain != "":
+		scope = domain
+	}
+	msg := notify.Message{
+		Title: "Click anomalies for " + scope,
+		Text: fmt.Sprintf("%d day(s) strayed more than %s standard deviations from the %d-day baseline.",
+			len(anomalies), strconv.FormatFloat(threshold, 'f', -1, 64), window),
+		Level: notify.Alert,
+	}
+	for _, a := range anomalies {
+		msg.Fields = append(msg.Fields, notify.Field{
+			Name:  a.Start.Format("2006-01-02"),
+			Value: fmt.Sprintf("%s clicks vs %.1f baseline (%s, %s)", formatClicks(a.Clicks), a.Baseline, formatZScore(a.ZScore), a.Direction),
+		})
+	}
+	return msg
+}
+
+// completePoints drops the trailing points whose period hasn't ended by now,
+// since a day still in progress would read as a drop. A point's period runs
+// until the next one starts; the last is taken to be as long as the one
+// before it, or a month when the series is monthly.
+func completePoints(points []reportPoint, now time.Time) []reportPoint {
+	if len(points) < 2 {
+		return points
+	}
+	last, prev := points[len(points)-1].Start, points[len(points)-2].Start
+	end := last.Add(last.Sub(prev))
+	if last.Sub(prev) >= 28*24*time.Hour {
+		end = last.AddDate(0, 1, 0)
+	}
+	for len(points) > 0 && end.After(now) {
+		end = points[len(points)-1].Start
+		points = points[:len(points)-1]
+	}
+	return points
+}
+
+// detectAnomalies compares each point after the first window with the mean
+// and standard deviation of the window points before it, returning those
+// more than threshold deviations away.
+func detectAnomalies(points []reportPoint, window int, threshold float64) []clickAnomaly {
+	var anomalies []clickAnomaly
+	for i := window; i < len(points); i++ {
+		var sum float64
+		for _, p := range points[i-window : i] {
+			sum += float64(p.Clicks)
+		}
+		mean := sum / float64(window)
+
+		var variance float64
+		for _, p := range points[i-window : i] {
+			d := float64(p.Clicks) - mean
+			variance += d * d
+		}
+		stdDev := math.Max(math.Sqrt(variance/float64(window)), 1)
+
+		z := (float64(points[i].Clicks) - mean) / stdDev
+		if math.Abs(z) <= threshold {
+			continue
+		}
+		direction := "spike"
+		if z < 0 {
+			direction = "drop"
+		}
+		anomalies = append(anomalies, clickAnomaly{
+			Start:     points[i].Start,
+			Clicks:    points[i].Clicks,
+			Baseline:  mean,
+			StdDev:    stdDev,
+			ZScore:    z,
+			Direction: direction,
+		})
+	}
+	return anomalies
+}
+
+// formatZScore renders a z-score with one decimal and an explicit sign.
+func formatZScore(z float64) string {
+	return fmt.Sprintf("%+.1f", z)
+}
@@ -0,0 +1,151 @@
+// internal/cmd/anomalies_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clicks := []int{100, 104, 96, 102, 98, 100, 250, 101, 99, 103, 100, 97, 0}
+	points := make([]reportPoint, len(clicks))
+	for i, c := range clicks {
+		points[i] = reportPoint{Start: start.AddDate(0, 0, i), Clicks: c}
+	}
+
+	// The days after the spike have it in their baseline but stay unflagged
+	anomalies := detectAnomalies(points, 5, 3)
+	if len(anomalies) != 2 {
+		t.Fatalf("expected 2 anomalies, got %+v", anomalies)
+	}
+	if a := anomalies[0]; a.Clicks != 250 || a.Direction != "spike" || a.Baseline != 100 {
+		t.Errorf("unexpected spike %+v", a)
+	}
+	if a := anomalies[1]; a.Clicks != 0 || a.Direction != "drop" || a.ZScore >= -3 {
+		t.Errorf("unexpected drop %+v", a)
+	}
+}
+
+func TestDetectAnomalies_FlatBaseline(t *testing.T) {
+	points := []reportPoint{{Clicks: 0}, {Clicks: 0}, {Clicks: 0}, {Clicks: 1}, {Clicks: 9}}
+	anomalies := detectAnomalies(points, 3, 3)
+	if len(anomalies) != 1 || anomalies[0].Clicks != 9 {
+		t.Fatalf("expected only the jump to 9 to be flagged, got %+v", anomalies)
+	}
+	if math.IsInf(anomalies[0].ZScore, 0) {
+		t.Error("z-score should stay finite on a flat baseline")
+	}
+}
+
+func TestCompletePoints(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := make([]reportPoint, 5)
+	for i := range points {
+		points[i] = reportPoint{Start: start.AddDate(0, 0, i)}
+	}
+
+	// Midday on Jan 5 leaves Jan 5 itself unfinished
+	got := completePoints(points, time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC))
+	if len(got) != 4 || !got[3].Start.Equal(start.AddDate(0, 0, 3)) {
+		t.Errorf("expected Jan 1-4, got %+v", got)
+	}
+	if got := completePoints(points, time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)); len(got) != 5 {
+		t.Errorf("expected every day once Jan 5 is over, got %d", len(got))
+	}
+
+	monthly := []reportPoint{{Start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, {Start: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}}
+	if got := completePoints(monthly, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)); len(got) != 1 {
+		t.Errorf("expected February left out until it is over, got %+v", got)
+	}
+}
+
+func TestAnalyticsAnomaliesCmd(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("groupBy") != "timeseries" || q.Get("interval") != "30d" || q.Get("linkId") != "link_1" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		var series []map[string]interface{}
+		for i, c := range []int{10, 11, 9, 10, 10, 11, 9, 60} {
+			series = append(series, map[string]interface{}{
+				"start":  fmt.Sprintf("2026-01-%02dT00:00:00.000Z", i+1),
+				"clicks": c,
+			})
+		}
+		_ = json.NewEncoder(w).Encode(series)
+	})
+
+	var stdout bytes.Buffer
+	cmd := newAnalyticsAnomaliesCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--link-id", "link_1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "2026-01-08") || !strings.Contains(out, "spike") || strings.Contains(out, "2026-01-07") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestAnalyticsAnomaliesCmd_Notify(t *testing.T) {
+	var posted map[string]interface{}
+	clicks := []int{10, 11, 9, 10, 10, 11, 9, 60}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hooks/discord" {
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var series []map[string]interface{}
+		for i, c := range clicks {
+			series = append(series, map[string]interface{}{
+				"start":  fmt.Sprintf("2026-01-%02dT00:00:00.000Z", i+1),
+				"clicks": c,
+			})
+		}
+		_ = json.NewEncoder(w).Encode(series)
+	})
+
+	cmd := newAnalyticsAnomaliesCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--domain", "go.acme.com", "--notify", "discord:" + server.URL + "/hooks/discord"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	embeds, _ := posted["embeds"].([]interface{})
+	if len(embeds) != 1 {
+		t.Fatalf("expected one embed, got %v", posted)
+	}
+	embed := embeds[0].(map[string]interface{})
+	fields := embed["fields"].([]interface{})
+	if embed["title"] != "Click anomalies for go.acme.com" || len(fields) != 1 ||
+		fields[0].(map[string]interface{})["name"] != "2026-01-08" {
+		t.Errorf("unexpected notification %v", embed)
+	}
+
+	// The next day's run still lists the spike, but doesn't post it again
+	clicks = append(clicks, 10)
+	posted = nil
+	cmd = newAnalyticsAnomaliesCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--domain", "go.acme.com", "--notify", "discord:" + server.URL + "/hooks/discord"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "2026-01-08") || posted != nil {
+		t.Errorf("expected the spike listed but not notified again, got %v\n%s", posted, stdout.String())
+	}
+}
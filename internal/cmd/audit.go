@@ -0,0 +1,123 @@
+// internal/cmd/audit.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+// auditRecord is one line of the audit log.
+type auditRecord struct {
+	Timestamp     string `json:"timestamp"`
+	Workspace     string `json:"workspace,omitempty"`
+	Command       string `json:"command"`
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	ResourceID    string `json:"resourceId,omitempty"`
+	RequestDigest string `json:"requestDigest,omitempty"`
+	Status        int    `json:"status,omitempty"`
+	Result        string `json:"result"`
+	Error         string `json:"error,omitempty"`
+}
+
+// auditLog appends a record of every mutating request made by a command to
+// a JSON Lines file. Writing is best effort: a failure to log never fails
+// the command.
+type auditLog struct {
+	path    string
+	command string
+
+	mu sync.Mutex
+}
+
+// newAuditLog returns the audit log for cmd, or nil when audit_log isn't
+// enabled in the config.
+func newAuditLog(cmd *cobra.Command) *auditLog {
+	cfg, err := config.Load()
+	if err != nil || !cfg.AuditLog {
+		return nil
+	}
+	path, err := config.AuditLogPath()
+	if err != nil {
+		return nil
+	}
+	return &auditLog{path: path, command: cmd.CommandPath()}
+}
+
+// forWorkspace returns an api.Auditor recording requests against workspace.
+func (l *auditLog) forWorkspace(workspace string) api.Auditor {
+	return workspaceAuditor{log: l, workspace: workspace}
+}
+
+func (l *auditLog) write(rec auditRecord) {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	_, _ = f.Write(append(line, '\n'))
+	_ = f.Close()
+}
+
+type workspaceAuditor struct {
+	log       *auditLog
+	workspace string
+}
+
+func (a workspaceAuditor) Audit(e api.AuditEntry) {
+	rec := auditRecord{
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		Workspace:     a.workspace,
+		Command:       a.log.command,
+		Method:        e.Method,
+		Path:          e.Path,
+		ResourceID:    e.ResourceID,
+		RequestDigest: e.BodyDigest,
+		Status:        e.StatusCode,
+		Result:        "ok",
+	}
+	switch {
+	case e.Err != nil:
+		rec.Result, rec.Error = "error", e.Err.Error()
+	case e.StatusCode >= 400:
+		rec.Result, rec.Error = "error", http.StatusText(e.StatusCode)
+	}
+	a.log.write(rec)
+}
+
+// multiAuditor passes each request on to several auditors.
+type multiAuditor []api.Auditor
+
+func (m multiAuditor) Audit(e api.AuditEntry) {
+	for _, a := range m {
+		a.Audit(e)
+	}
+}
+
+// getAuditLog returns the command's audit log, or nil when disabled.
+func getAuditLog(ctx context.Context) *auditLog {
+	if v, ok := ctx.Value(auditLogKey).(*auditLog); ok {
+		return v
+	}
+	return nil
+}
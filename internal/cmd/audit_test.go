@@ -0,0 +1,74 @@
+// internal/cmd/audit_test.go
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+func TestAuditLog_RecordsMutations(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_WORKSPACE", "acme")
+
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "link_1"}`))
+	})
+
+	run := func() {
+		t.Helper()
+		root := NewRootCmd()
+		root.SetArgs([]string{"links", "delete", "--id", "link_1", "--api-url", server.URL})
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	path, err := config.AuditLogPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Disabled by default
+	run()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no audit log without audit_log, got %v", err)
+	}
+
+	if err := (&config.Config{AuditLog: true}).Save(); err != nil {
+		t.Fatal(err)
+	}
+	run()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var rec auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("got %d audit records, want 1", len(records))
+	}
+	rec := records[0]
+	if rec.Workspace != "acme" || rec.Command != "dub links delete" || rec.Method != "DELETE" ||
+		rec.Path != "/links/link_1" || rec.ResourceID != "link_1" || rec.Result != "ok" || rec.Timestamp == "" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+}
@@ -2,13 +2,23 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/dub-cli/internal/api"
 	"github.com/salmonumbrella/dub-cli/internal/auth"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
 	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 	"github.com/salmonumbrella/dub-cli/internal/secrets"
 )
 
@@ -24,15 +34,45 @@ func newAuthCmd() *cobra.Command {
 	cmd.AddCommand(newAuthListCmd())
 	cmd.AddCommand(newAuthSwitchCmd())
 	cmd.AddCommand(newAuthStatusCmd())
+	cmd.AddCommand(newAuthBackendCmd())
+	cmd.AddCommand(newAuthTestCmd())
 
 	return cmd
 }
 
 func newAuthLoginCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		role        string
+		printURL    bool
+		bind        string
+		templateDir string
+	)
+
+	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Authenticate with Dub",
-		Long:  "Opens a browser to enter your Dub API key.",
+		Long: `Opens a browser to enter your Dub API key.
+
+A workspace can hold several keys, such as a read-only key alongside an admin
+key. Use --as to store the key under a role, then select it on any command
+with --as <role>.
+
+On a remote or headless machine, --print-url prints the setup URL and a QR
+code instead of opening a browser. Combine it with --bind 0.0.0.0 to finish
+setup from a phone on the same network; the URL contains one-time tokens, so
+only share it with devices you trust.
+
+--template-dir replaces the setup and success pages with setup.html and
+success.html from a directory, to brand or translate them. Either may be left
+out to keep the built-in page. setup.html must render {{.CSRFToken}} and have
+csrf_token, workspace, and api_key fields; success.html must render
+{{.CSRFToken}} so it can post to complete?csrf=.
+
+Examples:
+  dub auth login
+  dub auth login --as read-only
+  dub auth login --print-url --bind 0.0.0.0
+  dub auth login --template-dir ./branding`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			store, err := secrets.OpenDefault()
 			if err != nil {
@@ -43,20 +83,45 @@ func newAuthLoginCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			server.SetRole(role)
+			server.SetBindAddress(bind)
+			if templateDir != "" {
+				if err := server.SetTemplateDir(templateDir); err != nil {
+					return fmt.Errorf("invalid --template-dir: %w", err)
+				}
+			}
+			if printURL {
+				server.SetPrintURL(cmd.OutOrStdout())
+			}
 
 			result, err := server.Start(cmd.Context())
 			if err != nil {
 				return err
 			}
 
+			// Learn the key's read scopes up front so commands it can't run
+			// fail with a clear message
+			probeKeyScopes(cmd.Context(), result.APIKey)
+
+			if result.Role != "" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Successfully authenticated workspace: %s (as %s)\n", result.WorkspaceName, result.Role)
+				return nil
+			}
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Successfully authenticated workspace: %s\n", result.WorkspaceName)
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&role, "as", "", "Store the key under this role, e.g. read-only")
+	cmd.Flags().BoolVar(&printURL, "print-url", false, "Print the setup URL and a QR code instead of opening a browser")
+	cmd.Flags().StringVar(&bind, "bind", "127.0.0.1", "Address the setup server listens on (e.g. 0.0.0.0 to allow other devices)")
+	cmd.Flags().StringVar(&templateDir, "template-dir", "", "Directory with setup.html and success.html to use instead of the built-in pages")
+
+	return cmd
 }
 
 func newAuthLogoutCmd() *cobra.Command {
-	var workspace string
+	var workspace, role string
 
 	cmd := &cobra.Command{
 		Use:   "logout [workspace]",
@@ -75,6 +140,14 @@ func newAuthLogoutCmd() *cobra.Command {
 				return fmt.Errorf("failed to open keyring: %w", err)
 			}
 
+			if role != "" {
+				if err := store.DeleteRole(workspace, role); err != nil {
+					return fmt.Errorf("failed to remove %q key: %w", role, err)
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Removed %s key from workspace: %s\n", role, workspace)
+				return nil
+			}
+
 			if err := store.Delete(workspace); err != nil {
 				return fmt.Errorf("failed to remove workspace: %w", err)
 			}
@@ -91,14 +164,21 @@ func newAuthLogoutCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&workspace, "workspace", "w", "", "Workspace to remove")
+	cmd.Flags().StringVar(&role, "as", "", "Only remove the key stored under this role")
 
 	return cmd
 }
 
 func newAuthListCmd() *cobra.Command {
-	return &cobra.Command{
+	var scopes bool
+
+	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List configured workspaces",
+		Short: "List configured workspaces and their keys",
+		Long: `List configured workspaces and every key stored for them.
+
+With --scopes, each key is checked against the API to show which resources it
+can read. Write access isn't probed.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			store, err := secrets.OpenDefault()
 			if err != nil {
@@ -109,18 +189,108 @@ func newAuthListCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to list workspaces: %w", err)
 			}
+			sort.Slice(creds, func(i, j int) bool {
+				if creds[i].Name != creds[j].Name {
+					return creds[i].Name < creds[j].Name
+				}
+				return creds[i].Role < creds[j].Role
+			})
+
+			keyScopes := make([][]string, len(creds))
+			if scopes {
+				batch.Run(cmd.Context(), len(creds), batch.DefaultConcurrency, func(ctx context.Context, i int) error {
+					keyScopes[i] = grantedScopes(probeKeyScopes(ctx, creds[i].APIKey))
+					return nil
+				})
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				out := make([]map[string]interface{}, len(creds))
+				for i, c := range creds {
+					out[i] = map[string]interface{}{"name": c.Name, "role": c.Role, "created_at": c.CreatedAt}
+					if scopes {
+						out[i]["scopes"] = keyScopes[i]
+					}
+				}
+				return outfmt.FormatJSON(cmd.OutOrStdout(), out, outfmt.GetQuery(cmd.Context()))
+			}
 
 			if len(creds) == 0 {
 				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No workspaces configured. Run: dub auth login")
 				return nil
 			}
 
-			for _, c := range creds {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %s (added %s)\n", c.Name, c.CreatedAt.Format("2006-01-02"))
+			for i, c := range creds {
+				role := "default"
+				if c.Role != "" {
+					role = c.Role
+				}
+				line := fmt.Sprintf("  %s [%s] (added %s)", c.Name, role, c.CreatedAt.Format("2006-01-02"))
+				if scopes {
+					line += " scopes: " + dashIfEmpty(strings.Join(keyScopes[i], ", "))
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), line)
 			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&scopes, "scopes", false, "Check which resources each key can read")
+
+	return cmd
+}
+
+// keyScopeProbes are read-only requests used to discover what a key may
+// access.
+var keyScopeProbes = []struct {
+	scope string
+	path  string
+}{
+	{"links.read", "/links?pageSize=1"},
+	{"domains.read", "/domains?pageSize=1"},
+	{"tags.read", "/tags?pageSize=1"},
+	{"folders.read", "/folders?pageSize=1"},
+	{"analytics.read", "/analytics?interval=24h"},
+	{"customers.read", "/customers?pageSize=1"},
+}
+
+// probeKeyScopes checks which of the probed scopes apiKey has, caching the
+// results so later commands can fail fast. Scopes whose probe fails for
+// reasons other than permissions are left out.
+func probeKeyScopes(ctx context.Context, apiKey string) map[string]bool {
+	client := api.NewClient(apiKey)
+	if apiURL := GetAPIURL(ctx); apiURL != "" {
+		client.SetBaseURL(apiURL)
+	}
+
+	results := map[string]bool{}
+	for _, probe := range keyScopeProbes {
+		resp, err := client.Get(ctx, probe.path)
+		if err != nil {
+			continue
+		}
+		_ = resp.Body.Close()
+		switch {
+		case resp.StatusCode < 300:
+			results[probe.scope] = true
+		case resp.StatusCode == http.StatusForbidden:
+			results[probe.scope] = false
+		}
+	}
+
+	_ = cache.StorePermissions(cache.Scope(apiKey), results)
+	return results
+}
+
+// grantedScopes lists the granted scopes in probe order.
+func grantedScopes(results map[string]bool) []string {
+	var granted []string
+	for _, probe := range keyScopeProbes {
+		if results[probe.scope] {
+			granted = append(granted, probe.scope)
+		}
+	}
+	return granted
 }
 
 func newAuthSwitchCmd() *cobra.Command {
@@ -180,7 +350,11 @@ func newAuthStatusCmd() *cobra.Command {
 				return nil
 			}
 
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Authenticated with %d workspace(s)\n", len(creds))
+			workspaces := map[string]bool{}
+			for _, c := range creds {
+				workspaces[c.Name] = true
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Authenticated with %d workspace(s)\n", len(workspaces))
 
 			// Show default workspace if configured
 			defaultWs, err := config.GetDefaultWorkspace()
@@ -192,3 +366,177 @@ func newAuthStatusCmd() *cobra.Command {
 		},
 	}
 }
+
+func newAuthBackendCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backend",
+		Short: "Show which credential storage backend is active",
+		Long: `Show which credential storage backend is active and why it was chosen.
+
+Windows uses the Credential Manager. Under WSL, where the Linux keyring is
+usually unavailable, credentials are kept in an encrypted file protected by
+DUB_KEYRING_PASSWORD (or a password prompt). Set DUB_KEYRING_BACKEND to force
+a backend: keychain, wincred, secret-service, kwallet, keyctl, pass, or file.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := secrets.OpenDefault()
+			if err != nil {
+				return fmt.Errorf("failed to open keyring: %w", err)
+			}
+			keyringStore, ok := store.(*secrets.KeyringStore)
+			if !ok {
+				return fmt.Errorf("credential store does not report its backend")
+			}
+			info := keyringStore.Backend()
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), info, outfmt.GetQuery(cmd.Context()))
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Backend: %s\n", info.Backend)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Reason: %s\n", info.Reason)
+			if info.WSL {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "WSL: yes")
+			}
+			return nil
+		},
+	}
+}
+
+// keyTestResult is the outcome of validating one stored key.
+type keyTestResult struct {
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+func newAuthTestCmd() *cobra.Command {
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Validate every stored API key",
+		Long: `Validate every stored API key against the API in parallel and report
+whether each is valid, invalid, or expired, along with the request latency.
+A key restricted to scopes other than links is valid, noted as lacking the
+links scope.
+
+Exits non-zero when any key fails, so stale keys can be caught before they
+break automation.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			store, err := secrets.OpenDefault()
+			if err != nil {
+				return fmt.Errorf("failed to open keyring: %w", err)
+			}
+			creds, err := store.List()
+			if err != nil {
+				return fmt.Errorf("failed to list workspaces: %w", err)
+			}
+			if len(creds) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No workspaces configured. Run: dub auth login")
+				return nil
+			}
+
+			results := testStoredKeys(cmd.Context(), creds, concurrency)
+			return writeKeyTestResults(cmd, results)
+		},
+	}
+
+	addConcurrencyFlag(cmd, &concurrency)
+
+	return cmd
+}
+
+// testStoredKeys validates each key concurrently, returning results sorted
+// by workspace and role.
+func testStoredKeys(ctx context.Context, creds []secrets.Credentials, concurrency int) []keyTestResult {
+	results := make([]keyTestResult, len(creds))
+	batch.Run(ctx, len(creds), concurrency, func(ctx context.Context, i int) error {
+		client := api.NewClient(creds[i].APIKey)
+		if apiURL := GetAPIURL(ctx); apiURL != "" {
+			client.SetBaseURL(apiURL)
+		}
+
+		start := time.Now()
+		err := auth.ValidateAPIKey(ctx, client)
+		results[i] = keyTestResult{
+			Name:      creds[i].Name,
+			Role:      creds[i].Role,
+			Status:    keyTestStatus(err),
+			LatencyMS: time.Since(start).Milliseconds(),
+		}
+		if errors.Is(err, auth.ErrNoLinksScope) {
+			results[i].Error = "lacks links scope"
+		} else if err != nil {
+			results[i].Error = err.Error()
+		}
+		return nil
+	})
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].Role < results[j].Role
+	})
+	return results
+}
+
+func keyTestStatus(err error) string {
+	switch {
+	case err == nil, errors.Is(err, auth.ErrNoLinksScope):
+		return "valid"
+	case errors.Is(err, auth.ErrExpiredAPIKey):
+		return "expired"
+	case errors.Is(err, auth.ErrInvalidAPIKey):
+		return "invalid"
+	default:
+		return "error"
+	}
+}
+
+func writeKeyTestResults(cmd *cobra.Command, results []keyTestResult) error {
+	failed := 0
+	for _, r := range results {
+		if r.Status != "valid" {
+			failed++
+		}
+	}
+
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		if err := outfmt.FormatJSON(cmd.OutOrStdout(), results, outfmt.GetQuery(cmd.Context())); err != nil {
+			return err
+		}
+	} else {
+		columns := []outfmt.Column{
+			{Name: "Workspace", Width: 0, Align: outfmt.AlignLeft},
+			{Name: "Role", Width: 0, Align: outfmt.AlignLeft},
+			{Name: "Status", Width: 0, Align: outfmt.AlignLeft},
+			{Name: "Latency", Width: 0, Align: outfmt.AlignRight},
+			{Name: "Detail", Width: 0, Align: outfmt.AlignLeft},
+		}
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			role := "default"
+			if r.Role != "" {
+				role = r.Role
+			}
+			rows[i] = []string{r.Name, role, r.Status, fmt.Sprintf("%dms", r.LatencyMS), dashIfEmpty(r.Error)}
+		}
+		if err := outfmt.FormatTable(cmd.OutOrStdout(), columns, rows); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d key(s) failed validation", failed, len(results))
+	}
+	return nil
+}
@@ -2,13 +2,19 @@
 package cmd
 
 import (
+	"context"
+	"net/http"
+	"reflect"
 	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/secrets"
 )
 
 func TestAuthCmd_SubCommands(t *testing.T) {
 	cmd := newAuthCmd()
 
-	subCmds := []string{"login", "logout", "list", "switch", "status"}
+	subCmds := []string{"login", "logout", "list", "switch", "status", "backend", "test"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range cmd.Commands() {
@@ -22,3 +28,68 @@ func TestAuthCmd_SubCommands(t *testing.T) {
 		}
 	}
 }
+
+func TestProbeKeyScopes(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer dub_readonly" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/links", "/tags":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": "forbidden", "message": "insufficient scope"}}`))
+		}
+	})
+
+	ctx := context.WithValue(context.Background(), apiURLKey, server.URL)
+	results := probeKeyScopes(ctx, "dub_readonly")
+	if got, want := grantedScopes(results), []string{"links.read", "tags.read"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("grantedScopes = %v, want %v", got, want)
+	}
+
+	if granted, known := cache.LookupPermission(cache.Scope("dub_readonly"), "domains.read"); !known || granted {
+		t.Errorf("expected domains.read to be cached as denied, got granted=%v known=%v", granted, known)
+	}
+}
+
+func TestTestStoredKeys(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer dub_good":
+			_, _ = w.Write([]byte(`[]`))
+		case "Bearer dub_analytics":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": "forbidden", "message": "insufficient scope"}}`))
+		case "Bearer dub_old":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": {"code": "unauthorized", "message": "API key expired."}}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": {"code": "unauthorized", "message": "Invalid API key."}}`))
+		}
+	})
+
+	creds := []secrets.Credentials{
+		{Name: "staging", APIKey: "dub_bad"},
+		{Name: "acme", Role: "read-only", APIKey: "dub_old"},
+		{Name: "acme", APIKey: "dub_good"},
+		{Name: "reports", APIKey: "dub_analytics"},
+	}
+	ctx := context.WithValue(context.Background(), apiURLKey, server.URL)
+	results := testStoredKeys(ctx, creds, 2)
+
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Name+"/"+r.Role+"="+r.Status)
+	}
+	want := []string{"acme/=valid", "acme/read-only=expired", "reports/=valid", "staging/=invalid"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+	if results[2].Error != "lacks links scope" {
+		t.Errorf("expected a scoped key to note the missing links scope, got %q", results[2].Error)
+	}
+}
@@ -0,0 +1,348 @@
+// internal/cmd/bench.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// benchPhases are the parts of a request's latency, in the order reported.
+var benchPhases = []string{"total", "cli", "connect", "server", "transfer"}
+
+// benchPhaseTitles name the phases in text output.
+var benchPhaseTitles = map[string]string{
+	"total":    "Total",
+	"cli":      "CLI",
+	"connect":  "Connect",
+	"server":   "Server",
+	"transfer": "Transfer",
+}
+
+// benchHints explain what it means when a phase dominates.
+var benchHints = map[string]string{
+	"cli":      "Most time is spent in the CLI before the request is sent, e.g. waiting on --rate or a rate-limit pause.",
+	"connect":  "Most time is spent connecting (DNS, TCP, TLS); check the network, VPN, or proxy.",
+	"server":   "Most time is spent waiting for the response: network round trips plus API processing.",
+	"transfer": "Most time is spent downloading responses; try a smaller page with limit=.",
+}
+
+// benchSample is the timing of one request.
+type benchSample struct {
+	phases  map[string]time.Duration
+	status  int
+	retries int
+	err     error
+}
+
+// benchStats summarizes one phase across requests.
+type benchStats struct {
+	P50MS float64 `json:"p50_ms"`
+	P95MS float64 `json:"p95_ms"`
+	P99MS float64 `json:"p99_ms"`
+	MaxMS float64 `json:"max_ms"`
+}
+
+// benchReport is the result of a benchmark run.
+type benchReport struct {
+	URL            string                `json:"url"`
+	Requests       int                   `json:"requests"`
+	Concurrency    int                   `json:"concurrency"`
+	DurationMS     float64               `json:"duration_ms"`
+	RequestsPerSec float64               `json:"requests_per_sec"`
+	Errors         int                   `json:"errors"`
+	ErrorRate      float64               `json:"error_rate"`
+	Retries        int                   `json:"retries"`
+	Statuses       map[string]int        `json:"statuses"`
+	Phases         map[string]benchStats `json:"phases"`
+	Slowest        string                `json:"slowest_phase,omitempty"`
+}
+
+func newBenchCmd() *cobra.Command {
+	var (
+		requests    int
+		concurrency int
+		path        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure API latency and error rates",
+		Long: `Send repeated GET requests to an API path and report latency percentiles and
+the error rate, to tell whether slowness comes from the network, the API, or
+the CLI.
+
+Each request's time is split into phases:
+  cli       before the request is sent (rate limiting, circuit breaker)
+  connect   DNS, TCP, and TLS for new connections
+  server    from sending the request to the first byte of the response
+  transfer  reading the response body
+
+Requests go through the same client as other commands, so --rate and the
+circuit breaker apply, and retried requests count toward their latency.
+
+Examples:
+  dub bench
+  dub bench --requests 50 --concurrency 5 --path "/links?limit=1"
+  dub bench --path /domains -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if requests < 1 {
+				return NewUsageErrorf("--requests must be at least 1")
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+			if !strings.HasPrefix(path, "/") {
+				return NewUsageErrorf("--path must start with /, e.g. /links?limit=1")
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			url := client.BaseURL() + path
+			start := time.Now()
+			samples := make([]benchSample, requests)
+			batch.Run(cmd.Context(), requests, concurrency, func(ctx context.Context, i int) error {
+				samples[i] = benchRequest(ctx, client, url)
+				return nil
+			})
+			if err := cmd.Context().Err(); err != nil {
+				return err
+			}
+
+			report := summarizeBench(samples, time.Since(start))
+			report.URL = url
+			report.Concurrency = min(concurrency, requests)
+			if report.Errors == len(samples) {
+				if err := writeBenchReport(cmd, report); err != nil {
+					return err
+				}
+				return fmt.Errorf("all %d requests failed: %s", len(samples), benchFailure(samples[0]))
+			}
+			return writeBenchReport(cmd, report)
+		},
+	}
+
+	cmd.Flags().IntVar(&requests, "requests", 20, "Number of requests to send")
+	addConcurrencyFlag(cmd, &concurrency)
+	cmd.Flags().StringVar(&path, "path", "/links?limit=1", "API path to GET")
+
+	return cmd
+}
+
+// benchRequest makes one GET request, timing its phases with an HTTP trace.
+func benchRequest(ctx context.Context, client *api.Client, url string) benchSample {
+	// The transport calls these from its own goroutines
+	var (
+		mu                                 sync.Mutex
+		getConn, gotConn, wrote, firstByte time.Time
+		attempts                           int
+	)
+	at := func(t *time.Time) {
+		mu.Lock()
+		*t = time.Now()
+		mu.Unlock()
+	}
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			mu.Lock()
+			if getConn.IsZero() {
+				getConn = time.Now()
+			}
+			mu.Unlock()
+		},
+		GotConn: func(httptrace.GotConnInfo) { at(&gotConn) },
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			mu.Lock()
+			wrote = time.Now()
+			attempts++
+			mu.Unlock()
+		},
+		GotFirstResponseByte: func() { at(&firstByte) },
+	}
+
+	sample := benchSample{}
+	start := time.Now()
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodGet, url, nil)
+	if err != nil {
+		sample.err = err
+		return sample
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		sample.err = err
+		return sample
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	end := time.Now()
+
+	mu.Lock()
+	defer mu.Unlock()
+	sample.status = resp.StatusCode
+	sample.err = err
+	if attempts > 1 {
+		sample.retries = attempts - 1
+	}
+	sample.phases = map[string]time.Duration{"total": end.Sub(start)}
+	if !getConn.IsZero() && !firstByte.IsZero() {
+		sample.phases["cli"] = getConn.Sub(start)
+		sample.phases["connect"] = gotConn.Sub(getConn)
+		sample.phases["server"] = firstByte.Sub(wrote)
+		sample.phases["transfer"] = end.Sub(firstByte)
+		if attempts > 1 {
+			// Earlier attempts and the waits between them count as CLI time
+			sample.phases["connect"] = 0
+			sample.phases["cli"] = gotConn.Sub(start)
+		}
+	}
+	return sample
+}
+
+// summarizeBench computes the report for samples taken over elapsed.
+func summarizeBench(samples []benchSample, elapsed time.Duration) benchReport {
+	report := benchReport{
+		Requests:   len(samples),
+		DurationMS: millis(elapsed),
+		Statuses:   map[string]int{},
+		Phases:     map[string]benchStats{},
+	}
+	if elapsed > 0 {
+		report.RequestsPerSec = float64(len(samples)) / elapsed.Seconds()
+	}
+
+	durations := map[string][]time.Duration{}
+	for _, s := range samples {
+		report.Retries += s.retries
+		if s.status == 0 {
+			report.Statuses["error"]++
+		} else {
+			report.Statuses[strconv.Itoa(s.status)]++
+		}
+		if s.err != nil || s.status >= 400 {
+			report.Errors++
+		}
+		for phase, d := range s.phases {
+			durations[phase] = append(durations[phase], d)
+		}
+	}
+	report.ErrorRate = float64(report.Errors) / float64(len(samples))
+
+	var slowest time.Duration
+	for _, phase := range benchPhases {
+		ds := durations[phase]
+		if len(ds) == 0 {
+			continue
+		}
+		sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+		report.Phases[phase] = benchStats{
+			P50MS: millis(percentile(ds, 50)),
+			P95MS: millis(percentile(ds, 95)),
+			P99MS: millis(percentile(ds, 99)),
+			MaxMS: millis(ds[len(ds)-1]),
+		}
+		if p50 := percentile(ds, 50); phase != "total" && p50 > slowest {
+			slowest, report.Slowest = p50, phase
+		}
+	}
+	return report
+}
+
+// percentile returns the nearest-rank percentile p of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// benchFailure describes why a request failed.
+func benchFailure(s benchSample) string {
+	if s.err != nil {
+		return s.err.Error()
+	}
+	return fmt.Sprintf("HTTP %d", s.status)
+}
+
+func writeBenchReport(cmd *cobra.Command, report benchReport) error {
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		return outfmt.FormatJSON(w, report, outfmt.GetQuery(cmd.Context()))
+	}
+
+	_, _ = fmt.Fprintf(w, "GET %s\n", report.URL)
+	_, _ = fmt.Fprintf(w, "%d requests, concurrency %d, %s (%.1f req/s)\n\n",
+		report.Requests, report.Concurrency, formatMillis(report.DurationMS), report.RequestsPerSec)
+
+	var rows [][]string
+	for _, phase := range benchPhases {
+		stats, ok := report.Phases[phase]
+		if !ok {
+			continue
+		}
+		rows = append(rows, []string{
+			benchPhaseTitles[phase],
+			formatMillis(stats.P50MS),
+			formatMillis(stats.P95MS),
+			formatMillis(stats.P99MS),
+			formatMillis(stats.MaxMS),
+		})
+	}
+	columns := []outfmt.Column{
+		{Name: "Phase"},
+		{Name: "P50", Align: outfmt.AlignRight},
+		{Name: "P95", Align: outfmt.AlignRight},
+		{Name: "P99", Align: outfmt.AlignRight},
+		{Name: "Max", Align: outfmt.AlignRight},
+	}
+	if err := outfmt.FormatTable(w, columns, rows); err != nil {
+		return err
+	}
+
+	statuses := make([]string, 0, len(report.Statuses))
+	for status := range report.Statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for i, status := range statuses {
+		statuses[i] = fmt.Sprintf("%s ×%d", status, report.Statuses[status])
+	}
+	_, _ = fmt.Fprintf(w, "\nErrors: %d of %d (%.1f%%)", report.Errors, report.Requests, report.ErrorRate*100)
+	_, _ = fmt.Fprintf(w, "; responses: %s", strings.Join(statuses, ", "))
+	if report.Retries > 0 {
+		_, _ = fmt.Fprintf(w, "; %d retried", report.Retries)
+	}
+	_, _ = fmt.Fprintln(w)
+	if hint := benchHints[report.Slowest]; hint != "" {
+		_, _ = fmt.Fprintln(w, hint)
+	}
+	return nil
+}
+
+func formatMillis(ms float64) string {
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.1fms", ms)
+}
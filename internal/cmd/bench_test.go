@@ -0,0 +1,119 @@
+// internal/cmd/bench_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	var ds []time.Duration
+	for i := 1; i <= 100; i++ {
+		ds = append(ds, time.Duration(i)*time.Millisecond)
+	}
+	for p, want := range map[int]time.Duration{50: 50 * time.Millisecond, 95: 95 * time.Millisecond, 99: 99 * time.Millisecond} {
+		if got := percentile(ds, p); got != want {
+			t.Errorf("p%d = %s, want %s", p, got, want)
+		}
+	}
+	if got := percentile([]time.Duration{7 * time.Millisecond}, 99); got != 7*time.Millisecond {
+		t.Errorf("single sample p99 = %s", got)
+	}
+}
+
+func TestSummarizeBench(t *testing.T) {
+	ms := time.Millisecond
+	samples := []benchSample{
+		{status: 200, phases: map[string]time.Duration{"total": 100 * ms, "cli": ms, "connect": 0, "server": 90 * ms, "transfer": 9 * ms}},
+		{status: 200, retries: 1, phases: map[string]time.Duration{"total": 300 * ms, "cli": ms, "connect": 20 * ms, "server": 270 * ms, "transfer": 9 * ms}},
+		{status: 429, phases: map[string]time.Duration{"total": 50 * ms, "cli": ms, "connect": 0, "server": 45 * ms, "transfer": 4 * ms}},
+		{err: context.DeadlineExceeded},
+	}
+
+	report := summarizeBench(samples, 2*time.Second)
+	if report.Errors != 2 || report.ErrorRate != 0.5 {
+		t.Errorf("expected 2 errors (50%%), got %d (%v)", report.Errors, report.ErrorRate)
+	}
+	if report.Statuses["200"] != 2 || report.Statuses["429"] != 1 || report.Statuses["error"] != 1 {
+		t.Errorf("unexpected statuses %v", report.Statuses)
+	}
+	if report.Retries != 1 || report.RequestsPerSec != 2 {
+		t.Errorf("unexpected retries %d or rate %v", report.Retries, report.RequestsPerSec)
+	}
+	if got := report.Phases["total"]; got.P50MS != 100 || got.MaxMS != 300 {
+		t.Errorf("unexpected total stats %+v", got)
+	}
+	if report.Slowest != "server" {
+		t.Errorf("expected server to be the slowest phase, got %q", report.Slowest)
+	}
+}
+
+func TestBenchCmd(t *testing.T) {
+	var hits atomic.Int32
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		if r.URL.Path != "/domains" || r.URL.Query().Get("limit") != "1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if n%5 == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"nope"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	var stdout bytes.Buffer
+	args := []string{"--api-url", server.URL, "bench", "--requests", "10", "--concurrency", "3", "--path", "/domains?limit=1", "-o", "json"}
+	if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if hits.Load() != 10 {
+		t.Errorf("expected 10 requests, got %d", hits.Load())
+	}
+
+	var report benchReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	if report.Requests != 10 || report.Errors != 2 || report.Statuses["200"] != 8 || report.Statuses["404"] != 2 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	for _, phase := range benchPhases {
+		if _, ok := report.Phases[phase]; !ok {
+			t.Errorf("missing phase %q", phase)
+		}
+	}
+
+	stdout.Reset()
+	args = []string{"--api-url", server.URL, "bench", "--requests", "2", "--path", "/domains?limit=1"}
+	if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"GET " + server.URL + "/domains?limit=1", "PHASE", "Server", "Errors: "} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestBenchCmd_Validation(t *testing.T) {
+	setTestEnv(t)
+
+	for _, args := range [][]string{
+		{"bench", "--requests", "0"},
+		{"bench", "--concurrency", "0"},
+		{"bench", "--path", "links"},
+	} {
+		err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err == nil || !IsUsageError(err) {
+			t.Errorf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
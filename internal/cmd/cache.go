@@ -0,0 +1,289 @@
+// internal/cmd/cache.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the local cache",
+		Long: `Inspect and manage data the CLI caches on disk.
+
+Flags such as --tag-name and --folder-name resolve names to IDs. Results are
+cached per workspace for 10 minutes by default. Set DUB_CACHE_TTL or
+"cache_ttl" in the config file (e.g. "1h", or "0" to disable) to change this.
+The API key scopes seen granted or denied are kept for 24 hours.`,
+	}
+
+	cmd.AddCommand(newCacheStatusCmd())
+	cmd.AddCommand(newCacheClearCmd())
+	cmd.AddCommand(newCacheGCCmd())
+
+	return cmd
+}
+
+func newCacheClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cache.Clear(); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Cache cleared.")
+			return nil
+		},
+	}
+}
+
+func newCacheStatusCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show cache location, size, and per-workspace entries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ttl, err := cache.TTL()
+			if err != nil {
+				return err
+			}
+			dir, err := config.CacheDir()
+			if err != nil {
+				return err
+			}
+			usage, err := cache.Usage()
+			if err != nil {
+				return err
+			}
+			stats, err := cache.Stats(ttl)
+			if err != nil {
+				return err
+			}
+
+			labels := workspaceScopeLabels()
+			label := func(scope string) string {
+				if name, ok := labels[scope]; ok {
+					return name
+				}
+				return "unknown (" + scope + ")"
+			}
+
+			if output == "json" {
+				workspaces := make([]map[string]interface{}, 0, len(stats))
+				for _, st := range stats {
+					workspaces = append(workspaces, map[string]interface{}{
+						"workspace": label(st.Scope),
+						"tags":      st.Entries[cache.KindTag],
+						"folders":   st.Entries[cache.KindFolder],
+						"expired":   st.Expired,
+						"bytes":     st.Bytes,
+					})
+				}
+				data := map[string]interface{}{
+					"path":       dir,
+					"bytes":      usage,
+					"ttl":        ttl.String(),
+					"workspaces": workspaces,
+				}
+				return outfmt.FormatJSON(cmd.OutOrStdout(), data, outfmt.GetQuery(cmd.Context()))
+			}
+
+			ttlText := ttl.String()
+			if ttl == 0 {
+				ttlText = "disabled"
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Location: %s\n", dir)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Size:     %s\n", formatBytes(usage))
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "TTL:      %s\n", ttlText)
+
+			if len(stats) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "\nNo cached lookups.")
+				return nil
+			}
+
+			columns := []outfmt.Column{
+				{Name: "Workspace", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Tags", Width: 0, Align: outfmt.AlignRight},
+				{Name: "Folders", Width: 0, Align: outfmt.AlignRight},
+				{Name: "Expired", Width: 0, Align: outfmt.AlignRight},
+				{Name: "Size", Width: 0, Align: outfmt.AlignRight},
+			}
+			rows := make([][]string, len(stats))
+			for i, st := range stats {
+				rows[i] = []string{
+					label(st.Scope),
+					strconv.Itoa(st.Entries[cache.KindTag]),
+					strconv.Itoa(st.Entries[cache.KindFolder]),
+					strconv.Itoa(st.Expired),
+					formatBytes(st.Bytes),
+				}
+			}
+
+			_, _ = fmt.Fprintln(cmd.OutOrStdout())
+			return outfmt.FormatTable(cmd.OutOrStdout(), columns, rows)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+
+	return cmd
+}
+
+func newCacheGCCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "gc",
+		Short: "Remove expired cache entries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ttl, err := cache.TTL()
+			if err != nil {
+				return err
+			}
+
+			removed, err := cache.GC(ttl)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Removed %d expired entries.\n", removed)
+			return nil
+		},
+	}
+}
+
+// workspaceScopeLabels maps cache scopes to workspace names using the stored
+// credentials. Workspaces that cannot be identified are simply omitted.
+func workspaceScopeLabels() map[string]string {
+	labels := map[string]string{}
+	if apiKey := os.Getenv("DUB_API_KEY"); apiKey != "" {
+		labels[cache.Scope(apiKey)] = "DUB_API_KEY"
+	}
+
+	store, err := storeOpener()
+	if err != nil {
+		return labels
+	}
+	creds, err := store.List()
+	if err != nil {
+		return labels
+	}
+	for _, c := range creds {
+		labels[cache.Scope(c.APIKey)] = c.Name
+	}
+	return labels
+}
+
+// formatBytes renders a byte count in human-readable units.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// resolveNamesToIDs resolves tag or folder names to IDs, consulting the
+// workspace-scoped cache before listing the resource from the API.
+func resolveNamesToIDs(ctx context.Context, client *api.Client, kind cache.Kind, names []string) ([]string, error) {
+	ttl, err := cache.TTL()
+	if err != nil {
+		return nil, err
+	}
+	scope := cache.Scope(client.APIKey())
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		if id, ok := cache.Lookup(scope, kind, name, ttl); ok {
+			ids = append(ids, id)
+			continue
+		}
+
+		found, err := fetchNameIDs(ctx, client, kind, name)
+		if err != nil {
+			return nil, err
+		}
+		if ttl > 0 {
+			_ = cache.Store(scope, kind, found)
+		}
+
+		id, ok := matchName(found, name)
+		if !ok {
+			return nil, fmt.Errorf("%s %q not found", strings.TrimSuffix(string(kind), "s"), name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// fetchNameIDs lists resources of kind matching search and returns their name→ID map.
+func fetchNameIDs(ctx context.Context, client *api.Client, kind cache.Kind, search string) (map[string]string, error) {
+	params := url.Values{}
+	params.Set("search", search)
+
+	resp, err := client.Get(ctx, "/"+string(kind)+"?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := api.ParseAPIError(body)
+		return nil, fmt.Errorf("failed to look up %s %q: %s", kind, search, apiErr.Error())
+	}
+
+	var items []map[string]interface{}
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", kind, err)
+	}
+
+	found := make(map[string]string, len(items))
+	for _, item := range items {
+		name := outfmt.SafeString(item["name"])
+		id := outfmt.SafeString(item["id"])
+		if name != "" && id != "" {
+			found[name] = id
+		}
+	}
+	return found, nil
+}
+
+// matchName returns the ID whose name equals name, ignoring case.
+func matchName(found map[string]string, name string) (string, bool) {
+	for n, id := range found {
+		if strings.EqualFold(n, strings.TrimSpace(name)) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// invalidateNameCache drops cached lookups of kind after names may have changed.
+func invalidateNameCache(client *api.Client, kind cache.Kind) {
+	_ = cache.Invalidate(cache.Scope(client.APIKey()), kind)
+}
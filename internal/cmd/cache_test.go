@@ -0,0 +1,116 @@
+// internal/cmd/cache_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/secrets"
+)
+
+func TestResolveNamesToIDs_UsesCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_CACHE_TTL", "")
+
+	var calls int32
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path != "/tags" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"id":"tag_1","name":"Marketing"},{"id":"tag_2","name":"Sales"}]`))
+	})
+
+	client := api.NewClient("dub_test")
+	client.SetBaseURL(server.URL)
+
+	ids, err := resolveNamesToIDs(context.Background(), client, cache.KindTag, []string{"marketing", "Sales"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(ids, ",") != "tag_1,tag_2" {
+		t.Errorf("expected tag_1,tag_2, got %v", ids)
+	}
+	// "Sales" was cached by the first lookup's list response
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 API call, got %d", got)
+	}
+
+	if _, err := resolveNamesToIDs(context.Background(), client, cache.KindTag, []string{"Marketing"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected cached lookup, got %d API calls", got)
+	}
+
+	_, err = resolveNamesToIDs(context.Background(), client, cache.KindTag, []string{"unknown"})
+	if err == nil || !strings.Contains(err.Error(), `tag "unknown" not found`) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestCacheClearCmd(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cmd := newCacheCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"clear"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Cache cleared") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestCacheStatusCmd_LabelsWorkspaces(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	setTestEnv(t)
+	t.Setenv("DUB_CACHE_TTL", "")
+	t.Setenv("DUB_API_KEY", "")
+
+	store := newMockStore()
+	_ = store.Set("acme", secrets.Credentials{Name: "acme", APIKey: "dub_acme"})
+	origOpener := storeOpener
+	storeOpener = func() (secrets.Store, error) { return store, nil }
+	defer func() { storeOpener = origOpener }()
+
+	_ = cache.Store(cache.Scope("dub_acme"), cache.KindTag, map[string]string{"a": "tag_a", "b": "tag_b"})
+
+	cmd := newCacheCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"status"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Location:", "TTL:      10m0s", "acme", "TAGS"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:     "512 B",
+		2048:    "2.0 KiB",
+		5 << 20: "5.0 MiB",
+	}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// internal/cmd/cancel.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const maxTimeCancelKey contextKey = "maxTimeCancel"
+
+// ErrInterrupted is wrapped around the error of a command stopped by SIGINT
+// or SIGTERM.
+var ErrInterrupted = errors.New("interrupted")
+
+// maxTimeError is the cause of a command's context ending at --max-time.
+type maxTimeError struct {
+	limit time.Duration
+}
+
+func (e *maxTimeError) Error() string {
+	return fmt.Sprintf("exceeded --max-time of %s", e.limit)
+}
+
+// NotifyContext returns a context that's cancelled by the first SIGINT or
+// SIGTERM, so API calls, page loops, and bulk jobs stop where they are and
+// save their progress. A second signal kills the process as usual.
+func NotifyContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// withMaxTime bounds ctx by --max-time, keeping the cancel func for
+// stopMaxTime.
+func withMaxTime(ctx context.Context, limit time.Duration) context.Context {
+	ctx, cancel := context.WithTimeoutCause(ctx, limit, &maxTimeError{limit: limit})
+	return context.WithValue(ctx, maxTimeCancelKey, cancel)
+}
+
+// stopMaxTime releases the --max-time timer of a finished command.
+func stopMaxTime(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+	if cancel, ok := ctx.Value(maxTimeCancelKey).(context.CancelFunc); ok {
+		cancel()
+	}
+}
+
+// cancellationError says why a command failed when it was cut short, rather
+// than leaving a bare "context canceled" from deep in the API client. parent
+// is the context the command was run with; ctx is the command's own.
+func cancellationError(parent, ctx context.Context, err error) error {
+	if ctx != nil {
+		var maxTime *maxTimeError
+		if errors.As(context.Cause(ctx), &maxTime) {
+			return fmt.Errorf("%w: %w", maxTime, err)
+		}
+	}
+	if parent.Err() != nil {
+		return fmt.Errorf("%w: %w", ErrInterrupted, err)
+	}
+	return err
+}
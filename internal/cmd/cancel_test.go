@@ -0,0 +1,72 @@
+// internal/cmd/cancel_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCancellationError(t *testing.T) {
+	base := errors.New("failed to list tags: context canceled")
+
+	if err := cancellationError(context.Background(), context.Background(), base); err != base {
+		t.Errorf("expected the error to be unchanged, got %v", err)
+	}
+
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := cancellationError(parent, parent, base)
+	if !errors.Is(err, ErrInterrupted) || !errors.Is(err, base) {
+		t.Errorf("expected an interrupted error wrapping the original, got %v", err)
+	}
+	if exitCode(err) != 130 {
+		t.Errorf("expected exit code 130, got %d", exitCode(err))
+	}
+
+	ctx := withMaxTime(context.Background(), time.Millisecond)
+	<-ctx.Done()
+	err = cancellationError(context.Background(), ctx, base)
+	if err == nil || !strings.HasPrefix(err.Error(), "exceeded --max-time of 1ms: ") {
+		t.Errorf("expected a --max-time error, got %v", err)
+	}
+	if errors.Is(err, ErrInterrupted) {
+		t.Error("running out of time isn't an interrupt")
+	}
+}
+
+func TestMaxTimeFlag(t *testing.T) {
+	release := make(chan struct{})
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	defer close(release)
+
+	var stderr bytes.Buffer
+	start := time.Now()
+	err := execute(context.Background(), []string{"--api-url", server.URL, "--max-time", "100ms", "tags", "list"}, nil, &bytes.Buffer{}, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "exceeded --max-time of 100ms") {
+		t.Fatalf("expected a --max-time error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("command took %s to give up", elapsed)
+	}
+	if !strings.Contains(stderr.String(), "Error: exceeded --max-time of 100ms") {
+		t.Errorf("expected the error on stderr, got %q", stderr.String())
+	}
+}
+
+func TestMaxTimeFlag_RejectsNegative(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+	err := execute(context.Background(), []string{"--max-time", "-1s", "version"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+// internal/cmd/ci.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// ciDefaultDomain is the domain preview links are looked up on when neither
+// --domain nor the project config names one.
+const ciDefaultDomain = "dub.sh"
+
+func newCICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ci",
+		Short: "Common tasks for CI pipelines",
+		Long: `Composite commands for CI pipelines, such as short links to preview
+deployments that expire on their own and are cleaned up later.
+
+They never prompt, and combine well with --output gha.`,
+	}
+
+	cmd.AddCommand(newCICreatePreviewLinkCmd())
+	cmd.AddCommand(newCICleanupPreviewLinksCmd())
+
+	return cmd
+}
+
+func newCICreatePreviewLinkCmd() *cobra.Command {
+	var (
+		linkURL  string
+		key      string
+		domain   string
+		ttl      string
+		tagNames []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-preview-link",
+		Short: "Create or update an expiring link to a preview deployment",
+		Long: `Point a short link at a preview deployment, expiring after --ttl. If the
+key is already taken, as when a pipeline re-runs for the same pull request,
+that link is updated to the new URL and its expiration pushed back, unless
+it is protected (see dub links lock). Updates are kept in dub links history.
+
+Prints the short link, so a pipeline can capture it.
+
+Examples:
+  dub ci create-preview-link --url "$PREVIEW_URL" --key "pr-$PR_NUMBER"
+  dub ci create-preview-link --url "$PREVIEW_URL" --key "pr-$PR_NUMBER" --ttl 7d --domain go.acme.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			age, err := parseAge(ttl)
+			if err != nil {
+				return NewUsageErrorf("--ttl: %v", err)
+			}
+			if domain == "" {
+				domain = GetDefaultDomain(cmd.Context())
+			}
+			if domain == "" {
+				domain = ciDefaultDomain
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			body := map[string]interface{}{
+				"url":       linkURL,
+				"expiresAt": dateNow().Add(age).UTC().Format(time.RFC3339),
+			}
+			if len(tagNames) > 0 {
+				body["tagNames"] = tagNames
+			}
+
+			existing, err := findLinkByKey(cmd.Context(), client, domain, key)
+			if err != nil {
+				return err
+			}
+			var link map[string]interface{}
+			if existing == "" {
+				body["domain"] = domain
+				body["key"] = key
+				if err := postJSON(cmd.Context(), client, "/links", body, &link); err != nil {
+					return fmt.Errorf("failed to save preview link: %w", err)
+				}
+			} else {
+				before, err := fetchLinkForUpdate(cmd, client, existing, &expectFlag{})
+				if err != nil {
+					return err
+				}
+				if err := patchJSON(cmd.Context(), client, "/links/"+url.PathEscape(existing), body, &link); err != nil {
+					return fmt.Errorf("failed to save preview link: %w", err)
+				}
+				recordLinkChange(cmd, existing, before, link)
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), link, outfmt.GetQuery(cmd.Context()))
+			}
+			shortLink := outfmt.SafeString(link["shortLink"])
+			if shortLink == "" {
+				shortLink = "https://" + buildShortLink(domain, key)
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), shortLink)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&linkURL, "url", "", "Preview deployment URL (required)")
+	cmd.Flags().StringVar(&key, "key", "", "Short key, e.g. pr-123 (required)")
+	cmd.Flags().StringVar(&domain, "domain", "", "Domain for the short link (default: the project's default domain, or dub.sh)")
+	cmd.Flags().StringVar(&ttl, "ttl", "14d", "How long the link lives, e.g. 14d, 2w, or 12h")
+	cmd.Flags().StringSliceVar(&tagNames, "tag-name", nil, "Tag name (repeatable)")
+
+	_ = cmd.MarkFlagRequired("url")
+	_ = cmd.MarkFlagRequired("key")
+
+	return cmd
+}
+
+func newCICleanupPreviewLinksCmd() *cobra.Command {
+	var (
+		prefix      string
+		olderThan   string
+		domain      string
+		dryRun      bool
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cleanup-preview-links",
+		Short: "Delete old and expired preview links",
+		Long: `Delete the links whose key starts with --prefix and that were created more
+than --older-than ago or have expired. Protected links are kept unless
+--force is given. Deleted links are recorded in the undo history.
+
+Examples:
+  dub ci cleanup-preview-links --prefix pr- --dry-run
+  dub ci cleanup-preview-links --prefix pr- --older-than 30d --domain go.acme.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(prefix) == "" {
+				return NewUsageErrorf("--prefix must not be empty")
+			}
+			age, err := parseAge(olderThan)
+			if err != nil {
+				return NewUsageErrorf("--older-than: %v", err)
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			params := url.Values{"search": {prefix}}
+			if domain != "" {
+				params.Set("domain", domain)
+			}
+			links, err := fetchAllLinks[map[string]interface{}](cmd.Context(), client, params)
+			if err != nil {
+				return err
+			}
+			stale := stalePreviewLinks(links, prefix, dateNow().Add(-age), dateNow())
+			stale, skipped := withoutProtected(cmd.Context(), stale, isProtected)
+			if skipped > 0 {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Skipping %d protected preview link(s); pass --force to delete them too.\n", skipped)
+			}
+			if len(stale) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No preview links to clean up.")
+				return nil
+			}
+			return deleteLinks(cmd, client, stale, concurrency, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Key prefix of preview links, e.g. pr- (required)")
+	cmd.Flags().StringVar(&olderThan, "older-than", "14d", "Delete links created longer ago than this, e.g. 14d or 2w")
+	cmd.Flags().StringVar(&domain, "domain", "", "Only clean up links on this domain")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be deleted without deleting")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	_ = cmd.MarkFlagRequired("prefix")
+
+	return cmd
+}
+
+// stalePreviewLinks returns the links with a key starting with prefix that
+// were created before cutoff or expired by now.
+func stalePreviewLinks(links []map[string]interface{}, prefix string, cutoff, now time.Time) []map[string]interface{} {
+	var stale []map[string]interface{}
+	for _, link := range links {
+		if !strings.HasPrefix(outfmt.SafeString(link["key"]), prefix) {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, outfmt.SafeString(link["createdAt"]))
+		old := err == nil && created.Before(cutoff)
+		expires, err := time.Parse(time.RFC3339, outfmt.SafeString(link["expiresAt"]))
+		expired := err == nil && !expires.After(now)
+		if old || expired {
+			stale = append(stale, link)
+		}
+	}
+	return stale
+}
+
+// findLinkByKey returns the ID of the link with domain and key, or "" if
+// there is none.
+func findLinkByKey(ctx context.Context, client *api.Client, domain, key string) (string, error) {
+	return findLinkInfo(ctx, client, url.Values{"domain": {domain}, "key": {key}})
+}
+
+// findLinkInfo returns the ID of the link /links/info finds for params
+// (domain and key, or externalId), or "" if there is none.
+func findLinkInfo(ctx context.Context, client *api.Client, params url.Values) (string, error) {
+	resp, err := client.Get(ctx, "/links/info?"+params.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode >= 400 {
+		label := params.Get("externalId")
+		if label == "" {
+			label = buildShortLink(params.Get("domain"), params.Get("key"))
+		}
+		return "", fmt.Errorf("failed to look up %s: %s", label, api.ParseAPIError(body).Error())
+	}
+
+	var link struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &link); err != nil {
+		return "", fmt.Errorf("failed to parse link info: %w", err)
+	}
+	return link.ID, nil
+}
@@ -0,0 +1,153 @@
+// internal/cmd/ci_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+func TestCICreatePreviewLink(t *testing.T) {
+	origNow := dateNow
+	dateNow = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { dateNow = origNow })
+
+	exists := false
+	tags := `[]`
+	var requests []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		switch {
+		case r.URL.Path == "/links/info":
+			if r.URL.Query().Get("domain") != "dub.sh" || r.URL.Query().Get("key") != "pr-42" {
+				t.Errorf("unexpected lookup %s", r.URL.RawQuery)
+			}
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error": {"code": "not_found", "message": "Link not found"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": "link_42"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/links/link_42":
+			_, _ = w.Write([]byte(`{"id": "link_42", "domain": "dub.sh", "key": "pr-42", "url": "https://pr-41.preview.example.com", "tags": ` + tags + `}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/links", r.Method == http.MethodPatch && r.URL.Path == "/links/link_42":
+			exists = true
+			_, _ = w.Write([]byte(`{"id": "link_42", "shortLink": "https://dub.sh/pr-42"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	args := []string{"ci", "create-preview-link", "--api-url", server.URL,
+		"--url", "https://pr-42.preview.example.com", "--key", "pr-42", "--ttl", "7d"}
+	for run := 0; run < 2; run++ {
+		var stdout bytes.Buffer
+		if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+			t.Fatal(err)
+		}
+		if stdout.String() != "https://dub.sh/pr-42\n" {
+			t.Errorf("expected the short link, got %q", stdout.String())
+		}
+	}
+
+	if len(requests) != 5 {
+		t.Fatalf("expected a lookup and a write per run, and a snapshot before the update, got %v", requests)
+	}
+	var created, updated map[string]interface{}
+	_ = json.Unmarshal([]byte(strings.TrimPrefix(requests[1], "POST /links ")), &created)
+	_ = json.Unmarshal([]byte(strings.TrimPrefix(requests[4], "PATCH /links/link_42 ")), &updated)
+	if created["key"] != "pr-42" || created["domain"] != "dub.sh" || created["expiresAt"] != "2025-06-08T12:00:00Z" {
+		t.Errorf("unexpected create body %v", created)
+	}
+	if updated["url"] != "https://pr-42.preview.example.com" || updated["expiresAt"] != "2025-06-08T12:00:00Z" || updated["key"] != nil {
+		t.Errorf("unexpected update body %v", updated)
+	}
+	history, err := config.LoadLinkHistory("link_42")
+	if err != nil || len(history) != 1 || history[0].Before["url"] != "https://pr-41.preview.example.com" {
+		t.Errorf("expected the update in the link's history, got %v, %v", history, err)
+	}
+
+	// a protected preview link is left alone
+	tags = `[{"id": "tag_prot", "name": "protected"}]`
+	requests = nil
+	err = execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "refusing to update") {
+		t.Errorf("expected a protection error, got %v", err)
+	}
+	for _, req := range requests {
+		if strings.HasPrefix(req, "PATCH") {
+			t.Errorf("expected no update, got %v", requests)
+		}
+	}
+}
+
+func TestStalePreviewLinks(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	links := []map[string]interface{}{
+		{"id": "old", "key": "pr-1", "createdAt": "2025-05-01T00:00:00.000Z"},
+		{"id": "recent", "key": "pr-2", "createdAt": "2025-05-30T00:00:00.000Z"},
+		{"id": "expired", "key": "pr-3", "createdAt": "2025-05-30T00:00:00.000Z", "expiresAt": "2025-05-31T00:00:00.000Z"},
+		{"id": "other", "key": "promo-pr-1", "createdAt": "2025-01-01T00:00:00.000Z"},
+	}
+	var ids []string
+	for _, link := range stalePreviewLinks(links, "pr-", now.AddDate(0, 0, -14), now) {
+		ids = append(ids, link["id"].(string))
+	}
+	if strings.Join(ids, ",") != "old,expired" {
+		t.Errorf("stalePreviewLinks() = %v", ids)
+	}
+}
+
+func TestCICleanupPreviewLinks(t *testing.T) {
+	origNow := dateNow
+	dateNow = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { dateNow = origNow })
+
+	var deleted []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/links":
+			if r.URL.Query().Get("search") != "pr-" {
+				t.Errorf("expected a search for the prefix, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[
+				{"id": "l1", "domain": "dub.sh", "key": "pr-1", "createdAt": "2025-05-01T00:00:00.000Z"},
+				{"id": "l2", "domain": "dub.sh", "key": "pr-2", "createdAt": "2025-05-30T00:00:00.000Z"},
+				{"id": "l3", "domain": "dub.sh", "key": "pr-3", "createdAt": "2025-04-01T00:00:00.000Z", "tags": [{"name": "protected"}]}
+			]`))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/links/"))
+			_, _ = w.Write([]byte(`{"id": "l1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"ci", "cleanup-preview-links", "--prefix", "pr-", "--api-url", server.URL}
+	if err := execute(context.Background(), append(args, "--dry-run"), nil, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "Would delete dub.sh/pr-1 (l1)") || len(deleted) != 0 {
+		t.Errorf("unexpected dry run %q, deleted %v", stdout.String(), deleted)
+	}
+	if !strings.Contains(stderr.String(), "Skipping 1 protected preview link(s)") {
+		t.Errorf("expected the protected link to be skipped, got %q", stderr.String())
+	}
+
+	stdout.Reset()
+	if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(deleted, ",") != "l1" || !strings.Contains(stdout.String(), "Deleted dub.sh/pr-1") {
+		t.Errorf("expected only l1 deleted, got %v: %q", deleted, stdout.String())
+	}
+}
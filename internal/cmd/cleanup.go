@@ -0,0 +1,396 @@
+// internal/cmd/cleanup.go
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// Cleanup categories, usable with --only.
+const (
+	cleanupStale   = "stale"
+	cleanupExpired = "expired"
+	cleanupTags    = "tags"
+	cleanupFolders = "folders"
+)
+
+var cleanupCategories = []string{cleanupStale, cleanupExpired, cleanupTags, cleanupFolders}
+
+// cleanupItem is a resource proposed for cleanup.
+type cleanupItem struct {
+	Kind   string // "link", "tag", or "folder"
+	ID     string
+	Label  string
+	Reason string
+	// Snapshot is the listed resource, kept so a delete can be undone
+	Snapshot map[string]interface{}
+}
+
+func newCleanupCmd() *cobra.Command {
+	var (
+		olderThan   int
+		only        []string
+		action      string
+		auto        bool
+		dryRun      bool
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Find and remove stale links, unused tags, and empty folders",
+		Long: `Find workspace clutter and archive or delete it.
+
+Cleanup looks for:
+  stale     links with zero clicks created more than --older-than days ago
+  expired   links whose expiration date has passed
+  tags      tags with no links
+  folders   folders with no links
+
+Candidates are shown as a numbered checklist to pick from. Use --auto --yes to
+select everything without prompting, and --dry-run to only show what would
+change. Links are archived by default (--action delete removes them); unused
+tags and empty folders are always deleted.
+
+Examples:
+  dub cleanup --older-than 180
+  dub cleanup --only tags,folders --dry-run
+  dub cleanup --auto --yes --action delete`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if olderThan < 0 {
+				return NewUsageErrorf("--older-than must not be negative")
+			}
+			if action != "archive" && action != "delete" {
+				return NewUsageErrorf("--action must be one of: archive, delete")
+			}
+			for _, c := range only {
+				if !slices.Contains(cleanupCategories, c) {
+					return NewUsageErrorf("--only must be one of: %s", strings.Join(cleanupCategories, ", "))
+				}
+			}
+			if len(only) == 0 {
+				only = cleanupCategories
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+			if auto && !dryRun && !outfmt.GetYes(cmd.Context()) {
+				return NewUsageErrorf("--auto requires --yes (or --dry-run)")
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			items, err := findCleanupItems(cmd.Context(), client, only, olderThan, time.Now())
+			if err != nil {
+				return err
+			}
+			if len(items) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Nothing to clean up.")
+				return nil
+			}
+
+			// The list to pick from goes with the prompt to stderr, so both
+			// stay on the terminal when --out redirects output
+			if auto {
+				printCleanupChecklist(cmd.OutOrStdout(), items)
+			} else {
+				printCleanupChecklist(cmd.ErrOrStderr(), items)
+			}
+
+			selected := items
+			if !auto {
+				_, _ = fmt.Fprint(cmd.ErrOrStderr(), "\nSelect items to clean up (e.g. 1,3-5, all, none) [none]: ")
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && !errors.Is(err, io.EOF) {
+					return err
+				}
+				indexes, err := parseSelection(line, len(items))
+				if err != nil {
+					return NewUsageErrorf("%v", err)
+				}
+				selected = make([]cleanupItem, len(indexes))
+				for i, idx := range indexes {
+					selected[i] = items[idx]
+				}
+			}
+			if len(selected) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Nothing selected.")
+				return nil
+			}
+
+			if dryRun {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout())
+				for _, item := range selected {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Would %s %s %s\n", cleanupVerb(item, action), item.Kind, item.Label)
+				}
+				return nil
+			}
+
+			return runCleanup(cmd, client, selected, action, concurrency)
+		},
+	}
+
+	cmd.Flags().IntVar(&olderThan, "older-than", 90, "Minimum age in days for zero-click links")
+	cmd.Flags().StringSliceVar(&only, "only", nil, "Categories to check: stale, expired, tags, folders (default all)")
+	cmd.Flags().StringVar(&action, "action", "archive", "What to do with links: archive, delete")
+	cmd.Flags().BoolVar(&auto, "auto", false, "Select every candidate without prompting (requires --yes)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be cleaned up without changing anything")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	return cmd
+}
+
+// findCleanupItems scans the workspace for cleanup candidates in the given categories.
+func findCleanupItems(ctx context.Context, client *api.Client, only []string, olderThanDays int, now time.Time) ([]cleanupItem, error) {
+	var items []cleanupItem
+
+	if slices.Contains(only, cleanupStale) || slices.Contains(only, cleanupExpired) {
+		links, err := fetchAllLinks[linkRecord](ctx, client, url.Values{})
+		if err != nil {
+			return nil, err
+		}
+		cutoff := now.AddDate(0, 0, -olderThanDays)
+
+		links, _ = withoutProtected(ctx, links, linkRecord.protected)
+
+		for _, link := range links {
+			if link.Archived {
+				continue
+			}
+			label := buildShortLink(link.Domain, link.Key)
+
+			if slices.Contains(only, cleanupExpired) && link.ExpiresAt != nil {
+				if expires, err := time.Parse(time.RFC3339, *link.ExpiresAt); err == nil && expires.Before(now) {
+					items = append(items, cleanupItem{Kind: "link", ID: link.ID, Label: label, Reason: "expired " + expires.Format("Jan 2, 2006")})
+					continue
+				}
+			}
+			if slices.Contains(only, cleanupStale) && link.Clicks == 0 {
+				if created, err := time.Parse(time.RFC3339, link.CreatedAt); err == nil && created.Before(cutoff) {
+					items = append(items, cleanupItem{Kind: "link", ID: link.ID, Label: label, Reason: "no clicks since " + created.Format("Jan 2, 2006")})
+				}
+			}
+		}
+	}
+
+	if slices.Contains(only, cleanupTags) {
+		var tags []map[string]interface{}
+		if err := getJSON(ctx, client, "/tags", &tags); err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+		for _, tag := range tags {
+			n, err := cleanupLinkCount(ctx, client, tag, "tagIds")
+			if err != nil {
+				return nil, fmt.Errorf("failed to count links tagged %s: %w", outfmt.SafeString(tag["name"]), err)
+			}
+			if n == 0 {
+				items = append(items, cleanupItem{Kind: "tag", ID: outfmt.SafeString(tag["id"]), Label: outfmt.SafeString(tag["name"]), Reason: "no links", Snapshot: tag})
+			}
+		}
+	}
+
+	if slices.Contains(only, cleanupFolders) {
+		var folders []map[string]interface{}
+		if err := getJSON(ctx, client, "/folders", &folders); err != nil {
+			return nil, fmt.Errorf("failed to list folders: %w", err)
+		}
+		for _, folder := range folders {
+			n, err := cleanupLinkCount(ctx, client, folder, "folderId")
+			if err != nil {
+				return nil, fmt.Errorf("failed to count links in folder %s: %w", outfmt.SafeString(folder["name"]), err)
+			}
+			if n == 0 {
+				items = append(items, cleanupItem{Kind: "folder", ID: outfmt.SafeString(folder["id"]), Label: outfmt.SafeString(folder["name"]), Reason: "empty", Snapshot: folder})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// cleanupLinkCount returns the number of links in a tag or folder: the count
+// the API listed it with or, when that's missing, the count of links filtered
+// by param. A missing count is never taken as zero, which would delete a tag
+// or folder still in use.
+func cleanupLinkCount(ctx context.Context, client *api.Client, obj map[string]interface{}, param string) (int, error) {
+	if countObj, ok := obj["_count"].(map[string]interface{}); ok {
+		if n, ok := countObj["links"].(float64); ok {
+			return int(n), nil
+		}
+	}
+	if n, ok := obj["links"].(float64); ok {
+		return int(n), nil
+	}
+
+	var count int
+	query := url.Values{param: {outfmt.SafeString(obj["id"])}}
+	if err := getJSON(ctx, client, "/links/count?"+query.Encode(), &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// printCleanupChecklist writes the numbered list of candidates.
+func printCleanupChecklist(w io.Writer, items []cleanupItem) {
+	columns := []outfmt.Column{
+		{Name: "#", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Type", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Name", Width: 40, Align: outfmt.AlignLeft},
+		{Name: "Reason", Width: 0, Align: outfmt.AlignLeft},
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = []string{strconv.Itoa(i + 1), item.Kind, item.Label, item.Reason}
+	}
+	_ = outfmt.FormatTable(w, columns, rows)
+}
+
+// parseSelection parses a checklist answer such as "1,3-5", "all", or "none"
+// into sorted zero-based indexes.
+func parseSelection(answer string, n int) ([]int, error) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	switch answer {
+	case "", "none", "n":
+		return nil, nil
+	case "all", "a":
+		indexes := make([]int, n)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+
+	seen := map[int]bool{}
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(hi)); err != nil {
+				return nil, fmt.Errorf("invalid selection %q", part)
+			}
+		}
+		if start < 1 || end > n || start > end {
+			return nil, fmt.Errorf("selection %q is out of range 1-%d", part, n)
+		}
+		for i := start; i <= end; i++ {
+			seen[i-1] = true
+		}
+	}
+
+	indexes := make([]int, 0, len(seen))
+	for i := range seen {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
+
+// cleanupVerb describes what happens to item: links follow --action, tags
+// and folders are always deleted.
+func cleanupVerb(item cleanupItem, action string) string {
+	if item.Kind == "link" {
+		return action
+	}
+	return "delete"
+}
+
+// runCleanup archives or deletes the selected items, up to concurrency at a
+// time, and reports the outcome. Links are snapshotted before being deleted
+// so that every change can be undone.
+func runCleanup(cmd *cobra.Command, client *api.Client, items []cleanupItem, action string, concurrency int) error {
+	ctx := cmd.Context()
+	errs := batch.Run(ctx, len(items), concurrency, func(ctx context.Context, i int) error {
+		item := &items[i]
+		path := "/" + item.Kind + "s/" + url.PathEscape(item.ID)
+
+		var err error
+		if cleanupVerb(*item, action) == "archive" {
+			err = discardResponse(client.Patch(ctx, path, map[string]interface{}{"archived": true}))
+		} else {
+			if item.Kind == "link" {
+				item.Snapshot, _ = snapshotLink(ctx, client, item.ID)
+			}
+			err = discardResponse(client.Delete(ctx, path))
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", item.Kind, item.Label, err)
+		}
+		return nil
+	})
+
+	var failed []error
+	var history []config.HistoryEntry
+	done := map[string]int{}
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		verb := cleanupVerb(items[i], action)
+		done[verb+"d "+items[i].Kind]++
+		history = append(history, newHistoryEntry(verb, items[i].Kind, items[i].ID, items[i].Label, items[i].Snapshot))
+	}
+	recordHistory(cmd, history...)
+
+	if done["deleted tag"] > 0 {
+		invalidateNameCache(client, cache.KindTag)
+	}
+	if done["deleted folder"] > 0 {
+		invalidateNameCache(client, cache.KindFolder)
+	}
+
+	_, _ = fmt.Fprintln(cmd.OutOrStdout())
+	for _, key := range []string{"archived link", "deleted link", "deleted tag", "deleted folder"} {
+		if n := done[key]; n > 0 {
+			verb, noun, _ := strings.Cut(key, " ")
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s %d %s(s).\n", strings.ToUpper(verb[:1])+verb[1:], n, noun)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to clean up %d of %d item(s):\n%w", len(failed), len(items), errors.Join(failed...))
+	}
+	return nil
+}
+
+// discardResponse closes a response, converting API error statuses to errors.
+func discardResponse(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return api.ReadAPIError(resp)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		answer  string
+		want    []int
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"none", nil, false},
+		{"all\n", []int{0, 1, 2, 3, 4}, false},
+		{"1,3-4", []int{0, 2, 3}, false},
+		{" 2 , 2 ", []int{1}, false},
+		{"6", nil, true},
+		{"3-1", nil, true},
+		{"x", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			got, err := parseSelection(tt.answer, 5)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error state: %v", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSelection(%q) = %v, want %v", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
+
+// newCleanupServer serves a small workspace and records mutating requests.
+func newCleanupServer(t *testing.T) (string, *[]string) {
+	t.Helper()
+	var mutating []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mutating = append(mutating, r.Method+" "+r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/links":
+			_, _ = w.Write([]byte(`[
+				{"id": "link_stale", "domain": "dub.sh", "key": "old", "clicks": 0, "createdAt": "2025-01-01T00:00:00Z"},
+				{"id": "link_new", "domain": "dub.sh", "key": "new", "clicks": 0, "createdAt": "2026-10-01T00:00:00Z"},
+				{"id": "link_busy", "domain": "dub.sh", "key": "busy", "clicks": 9, "createdAt": "2025-01-01T00:00:00Z"},
+				{"id": "link_expired", "domain": "dub.sh", "key": "promo", "clicks": 3, "createdAt": "2025-01-01T00:00:00Z", "expiresAt": "2026-01-01T00:00:00Z"},
+				{"id": "link_archived", "domain": "dub.sh", "key": "gone", "clicks": 0, "archived": true, "createdAt": "2025-01-01T00:00:00Z"}
+			]`))
+		case "/tags":
+			_, _ = w.Write([]byte(`[{"id": "tag_used", "name": "Used", "_count": {"links": 2}}, {"id": "tag_unused", "name": "Unused", "_count": {"links": 0}}, {"id": "tag_uncounted", "name": "Uncounted"}]`))
+		case "/folders":
+			_, _ = w.Write([]byte(`[{"id": "fold_empty", "name": "Empty", "_count": {"links": 0}}, {"id": "fold_uncounted", "name": "Uncounted"}]`))
+		case "/links/count":
+			// Listed without a count, so counted by query; both are in use
+			if q := r.URL.Query(); q.Get("tagIds") != "tag_uncounted" && q.Get("folderId") != "fold_uncounted" {
+				t.Errorf("unexpected count query %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`4`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	return server.URL, &mutating
+}
+
+func TestFindCleanupItems(t *testing.T) {
+	serverURL, _ := newCleanupServer(t)
+	client := api.NewClient("dub_test")
+	client.SetBaseURL(serverURL)
+
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	items, err := findCleanupItems(context.Background(), client, cleanupCategories, 90, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	want := []string{"link_stale", "link_expired", "tag_unused", "fold_empty"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected candidates %v, got %v", want, ids)
+	}
+}
+
+func runCleanupCmd(t *testing.T, stdin string, yes bool, args ...string) (string, []string, error) {
+	t.Helper()
+	serverURL, mutating := newCleanupServer(t)
+
+	ctx := context.WithValue(context.Background(), apiURLKey, serverURL)
+	ctx = outfmt.WithYes(ctx, yes)
+
+	cmd := newCleanupCmd()
+	cmd.SetContext(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"--only", "tags,folders"}, args...))
+	err := cmd.Execute()
+	return out.String(), *mutating, err
+}
+
+func TestCleanupCmd_InteractiveSelection(t *testing.T) {
+	out, mutating, err := runCleanupCmd(t, "2\n", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(mutating, []string{"DELETE /folders/fold_empty"}) {
+		t.Errorf("expected only the selected folder to be deleted, got %v", mutating)
+	}
+	if !strings.Contains(out, "Deleted 1 folder(s).") {
+		t.Errorf("expected summary, got:\n%s", out)
+	}
+	if strings.Contains(out, "Select items") {
+		t.Errorf("expected the prompt on stderr, not in output:\n%s", out)
+	}
+}
+
+func TestCleanupCmd_DryRun(t *testing.T) {
+	out, mutating, err := runCleanupCmd(t, "", false, "--auto", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mutating) != 0 {
+		t.Errorf("expected no changes in dry run, got %v", mutating)
+	}
+	for _, want := range []string{"Would delete tag Unused", "Would delete folder Empty"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestCleanupCmd_AutoRequiresYes(t *testing.T) {
+	if _, _, err := runCleanupCmd(t, "", false, "--auto"); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("expected --yes error, got %v", err)
+	}
+
+	_, mutating, err := runCleanupCmd(t, "", true, "--auto")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mutating) != 2 {
+		t.Errorf("expected both candidates to be deleted, got %v", mutating)
+	}
+}
+
+func TestCleanupCmd_ConcurrencyBounds(t *testing.T) {
+	for _, value := range []string{"0", "17"} {
+		if _, _, err := runCleanupCmd(t, "", false, "--concurrency", value); err == nil || !strings.Contains(err.Error(), "--concurrency") {
+			t.Errorf("expected --concurrency %s to be rejected, got %v", value, err)
+		}
+	}
+}
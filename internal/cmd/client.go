@@ -3,13 +3,21 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
 	"github.com/salmonumbrella/dub-cli/internal/config"
 	"github.com/salmonumbrella/dub-cli/internal/secrets"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
 )
 
 // storeOpener allows injecting a mock store for testing
@@ -25,10 +33,172 @@ var defaultWorkspaceGetter = config.GetDefaultWorkspace
 // 1. DUB_API_KEY environment variable (for CI/testing)
 // 2. --workspace / -w flag (via context)
 // 3. DUB_WORKSPACE environment variable (already folded into flag default)
-// 4. Default workspace from config (set via `dub auth switch`)
-// 5. If only one workspace configured, use it automatically
-// 6. If multiple workspaces configured, return error asking user to specify
+// 4. Workspace of the active context (set via `dub context use`)
+// 5. Default workspace from config (set via `dub auth switch`)
+// 6. If only one workspace configured, use it automatically
+// 7. If multiple workspaces configured, return error asking user to specify
+//
+// The API base URL is overridden by --api-url or the active context, and
+// requests are throttled by --rate when set.
 func getClient(ctx context.Context) (*api.Client, error) {
+	client, err := newAuthenticatedClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiURL := GetAPIURL(ctx); apiURL != "" {
+		client.SetBaseURL(apiURL)
+	}
+	if limiter := GetRateLimiter(ctx); limiter != nil {
+		client.SetRateLimiter(limiter)
+	}
+
+	breaker := getBreakerSettings(ctx)
+	client.SetCircuitBreaker(breaker.Threshold, breaker.Cooldown)
+	var notice sync.Once
+	client.OnCircuitOpen(func(host string, cooldown time.Duration) {
+		notice.Do(func() {
+			_, _ = fmt.Fprintln(os.Stderr, circuitOpenNotice(host, cooldown))
+		})
+	})
+	client.SetPermissions(keyPermissions{scope: cache.Scope(client.APIKey())})
+	if headers := getHeaderPrinter(ctx); headers != nil {
+		client.OnResponse(headers.print)
+	}
+	var auditors multiAuditor
+	if audit := getAuditLog(ctx); audit != nil {
+		workspace, _ := activeWorkspaceName(ctx)
+		auditors = append(auditors, audit.forWorkspace(workspace))
+	}
+	if gha := getGHAReporter(ctx); gha != nil {
+		auditors = append(auditors, gha)
+	}
+	if len(auditors) > 0 {
+		client.SetAuditor(auditors)
+	}
+	if v := os.Getenv("DUB_MAX_RESPONSE_SIZE"); v != "" {
+		size, err := parseByteSize(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DUB_MAX_RESPONSE_SIZE: %w", err)
+		}
+		client.SetMaxResponseSize(size)
+	}
+	return client, nil
+}
+
+// keyPermissions caches an API key's known scopes on disk so that commands
+// it lacks permission for fail before any request is sent.
+type keyPermissions struct {
+	scope string
+}
+
+func (p keyPermissions) Lookup(perm string) (granted, known bool) {
+	return cache.LookupPermission(p.scope, perm)
+}
+
+func (p keyPermissions) Record(perm string, granted bool) {
+	_ = cache.StorePermissions(p.scope, map[string]bool{perm: granted})
+}
+
+// circuitOpenNotice explains why requests to host are paused after repeated
+// server errors.
+func circuitOpenNotice(host string, cooldown time.Duration) string {
+	return ui.Warning(fmt.Sprintf(
+		"%s is returning repeated server errors, so requests to it are paused for %s. "+
+			"Try again shortly, or pass --no-circuit-breaker to keep trying.", host, cooldown))
+}
+
+// parseByteSize parses sizes such as "1048576", "512KB", "64MB", or "1GiB".
+// Decimal and binary suffixes are both treated as powers of 1024.
+func parseByteSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	multipliers := []struct {
+		suffix string
+		n      int64
+	}{
+		{"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+	}
+
+	mult := int64(1)
+	for _, m := range multipliers {
+		if strings.HasSuffix(s, m.suffix) {
+			s, mult = strings.TrimSpace(strings.TrimSuffix(s, m.suffix)), m.n
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("expected a size such as 64MB, got %q", s)
+	}
+	return n * mult, nil
+}
+
+// getJSON GETs path and decodes the JSON response into dest.
+func getJSON(ctx context.Context, client *api.Client, path string, dest interface{}) error {
+	_, err := getJSONHeader(ctx, client, path, dest)
+	return err
+}
+
+// getJSONHeader is getJSON that also returns the response headers.
+func getJSONHeader(ctx context.Context, client *api.Client, path string, dest interface{}) (http.Header, error) {
+	resp, err := client.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := api.ParseAPIError(body)
+		return nil, fmt.Errorf("%s", apiErr.Error())
+	}
+
+	return resp.Header, json.Unmarshal(body, dest)
+}
+
+// postJSON POSTs body to path and decodes the JSON response into dest.
+func postJSON(ctx context.Context, client *api.Client, path string, body, dest interface{}) error {
+	resp, err := client.Post(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return api.ReadAPIError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
+
+// patchJSON PATCHes body to path and decodes the JSON response into dest.
+func patchJSON(ctx context.Context, client *api.Client, path string, body, dest interface{}) error {
+	resp, err := client.Patch(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return api.ReadAPIError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	return nil
+}
+
+// newAuthenticatedClient resolves credentials as described on getClient.
+func newAuthenticatedClient(ctx context.Context) (*api.Client, error) {
 	// Check for API key environment variable first (useful for CI/testing)
 	if apiKey := os.Getenv("DUB_API_KEY"); apiKey != "" {
 		return api.NewClient(apiKey), nil
@@ -44,11 +214,17 @@ func getClient(ctx context.Context) (*api.Client, error) {
 
 // getClientWithStore is the core logic, separated for testing
 func getClientWithStore(ctx context.Context, store secrets.Store) (*api.Client, error) {
+	// --as selects one of a workspace's role keys instead of its default key
+	role := GetKeyRole(ctx)
+
 	// Check for workspace flag (includes DUB_WORKSPACE via flag default)
 	workspace := GetWorkspace(ctx)
 	if workspace != "" {
-		creds, err := store.Get(workspace)
+		creds, err := store.GetRole(workspace, role)
 		if err != nil {
+			if role != "" {
+				return nil, fmt.Errorf("workspace %q has no %q key. Run: dub auth list", workspace, role)
+			}
 			return nil, fmt.Errorf("workspace %q not found. Run: dub auth list", workspace)
 		}
 		return api.NewClient(creds.APIKey), nil
@@ -57,7 +233,7 @@ func getClientWithStore(ctx context.Context, store secrets.Store) (*api.Client,
 	// Check for default workspace from config
 	defaultWs, err := defaultWorkspaceGetter()
 	if err == nil && defaultWs != "" {
-		creds, err := store.Get(defaultWs)
+		creds, err := store.GetRole(defaultWs, role)
 		if err == nil {
 			return api.NewClient(creds.APIKey), nil
 		}
@@ -68,13 +244,22 @@ func getClientWithStore(ctx context.Context, store secrets.Store) (*api.Client,
 	// (don't fail the command just because config is unreadable)
 
 	// No workspace specified - use first available or error if multiple
-	creds, err := store.List()
+	all, err := store.List()
 	if err != nil {
 		return nil, err
 	}
+	var creds []secrets.Credentials
+	for _, c := range all {
+		if strings.EqualFold(c.Role, role) {
+			creds = append(creds, c)
+		}
+	}
 
 	switch len(creds) {
 	case 0:
+		if role != "" && len(all) > 0 {
+			return nil, fmt.Errorf("no %q key configured. Run: dub auth login --as %s", role, role)
+		}
 		return nil, fmt.Errorf("not authenticated. Run: dub auth login")
 	case 1:
 		return api.NewClient(creds[0].APIKey), nil
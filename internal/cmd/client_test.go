@@ -29,22 +29,42 @@ func (m *mockStore) Keys() ([]string, error) {
 }
 
 func (m *mockStore) Set(name string, creds secrets.Credentials) error {
-	m.creds[name] = creds
+	m.creds[mockStoreKey(name, creds.Role)] = creds
 	return nil
 }
 
 func (m *mockStore) Get(name string) (secrets.Credentials, error) {
-	if creds, ok := m.creds[name]; ok {
+	return m.GetRole(name, "")
+}
+
+func (m *mockStore) GetRole(name, role string) (secrets.Credentials, error) {
+	if creds, ok := m.creds[mockStoreKey(name, role)]; ok {
 		return creds, nil
 	}
 	return secrets.Credentials{}, errors.New("not found")
 }
 
 func (m *mockStore) Delete(name string) error {
-	delete(m.creds, name)
+	for key := range m.creds {
+		if ws, _ := secrets.SplitRole(key); ws == name {
+			delete(m.creds, key)
+		}
+	}
 	return nil
 }
 
+func (m *mockStore) DeleteRole(name, role string) error {
+	delete(m.creds, mockStoreKey(name, role))
+	return nil
+}
+
+func mockStoreKey(name, role string) string {
+	if role != "" {
+		return name + "#" + role
+	}
+	return name
+}
+
 func (m *mockStore) List() ([]secrets.Credentials, error) {
 	var out []secrets.Credentials
 	for _, c := range m.creds {
@@ -294,3 +314,65 @@ func containsAll(s string, substrs ...string) bool {
 	}
 	return true
 }
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"1024", 1024, false},
+		{"512KB", 512 << 10, false},
+		{"64mb", 64 << 20, false},
+		{"1 GiB", 1 << 30, false},
+		{"10B", 10, false},
+		{"big", 0, true},
+		{"-1MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseByteSize(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseByteSize(%q) = %d, %v; want %d, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestGetClient_InvalidMaxResponseSize(t *testing.T) {
+	t.Setenv("DUB_API_KEY", "dub_test")
+	t.Setenv("DUB_MAX_RESPONSE_SIZE", "lots")
+
+	if _, err := getClient(context.Background()); err == nil {
+		t.Error("expected error for invalid DUB_MAX_RESPONSE_SIZE")
+	}
+}
+
+func TestGetClientWithStore_KeyRole(t *testing.T) {
+	store := newMockStore()
+	_ = store.Set("production", secrets.Credentials{Name: "production", APIKey: "dub_admin123"})
+	_ = store.Set("production", secrets.Credentials{Name: "production", Role: "read-only", APIKey: "dub_read456"})
+
+	// Role keys don't make the single workspace ambiguous
+	client, err := getClientWithStore(context.Background(), store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.APIKey(); got != "dub_admin123" {
+		t.Errorf("expected default key, got: %s", got)
+	}
+
+	ctx := context.WithValue(context.Background(), keyRoleKey, "read-only")
+	client, err = getClientWithStore(ctx, store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.APIKey(); got != "dub_read456" {
+		t.Errorf("expected read-only key, got: %s", got)
+	}
+
+	ctx = context.WithValue(ctx, workspaceKey, "production")
+	ctx = context.WithValue(ctx, keyRoleKey, "billing")
+	if _, err := getClientWithStore(ctx, store); err == nil || !strings.Contains(err.Error(), `no "billing" key`) {
+		t.Errorf("expected missing role error, got %v", err)
+	}
+}
@@ -2,11 +2,15 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"encoding/csv"
 	"fmt"
 	"io"
-	"net/http"
+	"math"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -18,11 +22,12 @@ func newCommissionsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "commissions",
 		Short: "Manage commissions",
-		Long:  "List and update partner commissions.",
+		Long:  "List, update, and export partner commissions.",
 	}
 
 	cmd.AddCommand(newCommissionsListCmd())
 	cmd.AddCommand(newCommissionsUpdateCmd())
+	cmd.AddCommand(newCommissionsExportCmd())
 
 	return cmd
 }
@@ -32,6 +37,10 @@ func newCommissionsListCmd() *cobra.Command {
 		programID string
 		partnerID string
 		status    string
+		since     string
+		until     string
+		minAmount float64
+		maxAmount float64
 		output    string
 		limit     int
 		all       bool
@@ -40,17 +49,21 @@ func newCommissionsListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List commissions",
-		Long:  "List all commissions for a program.",
+		Long: `List all commissions for a program, with the total amount of every
+matching commission below the table.
+
+Amounts are in dollars. --since and --until take a date (2025-01-01), a
+month (2025-01), an RFC 3339 timestamp, yesterday, or "30 days ago"; --until
+dates include the whole day or month.
+
+Examples:
+  dub commissions list --program-id prog_abc --status pending --since 2025-01-01 --until 2025-03-31
+  dub commissions list --program-id prog_abc --min-amount 100 --all`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if programID == "" {
 				return fmt.Errorf("--program-id is required")
 			}
 
-			client, err := getClient(cmd.Context())
-			if err != nil {
-				return err
-			}
-
 			params := url.Values{}
 			params.Set("programId", programID)
 			if partnerID != "" {
@@ -59,19 +72,40 @@ func newCommissionsListCmd() *cobra.Command {
 			if status != "" {
 				params.Set("status", status)
 			}
+			if err := setDateRange(params, "--since", since, "--until", until); err != nil {
+				return err
+			}
+			filter := amountFilter{}
+			if flagHasValue(cmd.Flags(), "min-amount") {
+				filter.min = &minAmount
+			}
+			if flagHasValue(cmd.Flags(), "max-amount") {
+				filter.max = &maxAmount
+			}
 
-			resp, err := client.Get(cmd.Context(), "/commissions?"+params.Encode())
+			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			return handleCommissionsListResponse(cmd, resp, output, limit, all)
+			// Every page is fetched so that totals cover all matches
+			commissions, err := fetchAllCommissions[map[string]interface{}](cmd.Context(), client, params)
+			if err != nil {
+				return err
+			}
+			commissions = filter.apply(commissions)
+
+			return writeCommissionsList(cmd, commissions, output, limit, all)
 		},
 	}
 
 	cmd.Flags().StringVar(&programID, "program-id", "", "Program ID (required)")
 	cmd.Flags().StringVar(&partnerID, "partner-id", "", "Filter by partner ID")
 	cmd.Flags().StringVar(&status, "status", "", "Filter by status (pending, approved, paid)")
+	cmd.Flags().StringVar(&since, "since", "", "Only commissions created on or after this date")
+	cmd.Flags().StringVar(&until, "until", "", "Only commissions created on or before this date")
+	cmd.Flags().Float64Var(&minAmount, "min-amount", 0, "Only commissions of at least this amount")
+	cmd.Flags().Float64Var(&maxAmount, "max-amount", 0, "Only commissions of at most this amount")
 	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of commissions to show")
 	cmd.Flags().BoolVar(&all, "all", false, "Show all commissions (ignore limit)")
@@ -81,36 +115,55 @@ func newCommissionsListCmd() *cobra.Command {
 	return cmd
 }
 
-// handleCommissionsListResponse handles the response for commissions list command,
-// formatting output as table or JSON based on the output flag.
-func handleCommissionsListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all bool) error {
-	defer func() { _ = resp.Body.Close() }()
+// amountFilter keeps commissions within optional dollar bounds. The API
+// can't filter by amount, so this happens client-side.
+type amountFilter struct {
+	min, max *float64
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
+func (f amountFilter) apply(commissions []map[string]interface{}) []map[string]interface{} {
+	if f.min == nil && f.max == nil {
+		return commissions
 	}
+	var kept []map[string]interface{}
+	for _, c := range commissions {
+		amount := commissionAmount(c)
+		if (f.min == nil || amount >= *f.min) && (f.max == nil || amount <= *f.max) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
 
-	if resp.StatusCode >= 400 {
-		apiErr := api.ParseAPIError(body)
-		return fmt.Errorf("%s", apiErr.Error())
+// commissionAmount returns a commission's amount in dollars. The API
+// reports amounts in cents.
+func commissionAmount(commission map[string]interface{}) float64 {
+	return outfmt.SafeFloat(commission["amount"]) / 100
+}
+
+// writeCommissionsList prints commissions as JSON or as a table followed by
+// their total.
+func writeCommissionsList(cmd *cobra.Command, commissions []map[string]interface{}, output string, limit int, all bool) error {
+	if commissions == nil {
+		commissions = []map[string]interface{}{}
 	}
 
-	// For JSON output, use the existing handler
-	if output == "json" {
-		var data interface{}
-		if err := json.Unmarshal(body, &data); err != nil {
-			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(body))
-			return nil
+	if sortBy := outfmt.GetSortBy(cmd.Context()); sortBy != "" {
+		items := make([]interface{}, len(commissions))
+		for i, c := range commissions {
+			items[i] = c
+		}
+		if err := outfmt.SortItems(items, sortBy, outfmt.GetDesc(cmd.Context())); err != nil {
+			return err
+		}
+		for i, item := range items {
+			commissions[i] = item.(map[string]interface{})
 		}
-		query := outfmt.GetQuery(cmd.Context())
-		return outfmt.FormatJSON(cmd.OutOrStdout(), data, query)
 	}
 
-	// Parse commissions for table output
-	var commissions []map[string]interface{}
-	if err := json.Unmarshal(body, &commissions); err != nil {
-		return fmt.Errorf("failed to parse commissions: %w", err)
+	if output == "json" {
+		query := outfmt.GetQuery(cmd.Context())
+		return outfmt.FormatJSON(cmd.OutOrStdout(), commissions, query)
 	}
 
 	totalCount := len(commissions)
@@ -141,7 +194,7 @@ func handleCommissionsListResponse(cmd *cobra.Command, resp *http.Response, outp
 		rows[i] = []string{
 			outfmt.Truncate(outfmt.SafeString(commission["id"]), 20),
 			formatPartner(commission),
-			formatAmount(outfmt.SafeFloat(commission["amount"])),
+			formatAmount(commissionAmount(commission)),
 			outfmt.SafeString(commission["status"]),
 			outfmt.FormatDate(commission["createdAt"]),
 		}
@@ -152,9 +205,15 @@ func handleCommissionsListResponse(cmd *cobra.Command, resp *http.Response, outp
 		return err
 	}
 
+	var total float64
+	for _, commission := range commissions {
+		total += commissionAmount(commission)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nTotal: %s across %d commission(s)\n", formatAmount(total), totalCount)
+
 	// Show pagination message if limited
 	if displayLimit < totalCount {
-		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d of %d commissions. Use --limit or --all for more.\n", displayLimit, totalCount)
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Showing %d of %d commissions. Use --limit or --all for more.\n", displayLimit, totalCount)
 	}
 
 	return nil
@@ -241,6 +300,7 @@ func newCommissionsUpdateCmd() *cobra.Command {
 		id     string
 		status string
 		amount float64
+		patch  patchFlag
 	)
 
 	cmd := &cobra.Command{
@@ -265,8 +325,11 @@ func newCommissionsUpdateCmd() *cobra.Command {
 				body["amount"] = amount
 			}
 
+			if err := patch.apply(body); err != nil {
+				return err
+			}
 			if len(body) == 0 {
-				return fmt.Errorf("at least one of --status or --amount must be specified")
+				return fmt.Errorf("at least one of --status, --amount, or --patch must be specified")
 			}
 
 			resp, err := client.Patch(cmd.Context(), "/commissions/"+url.PathEscape(id), body)
@@ -281,8 +344,188 @@ func newCommissionsUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&id, "id", "", "Commission ID (required)")
 	cmd.Flags().StringVar(&status, "status", "", "New status (pending, approved, paid)")
 	cmd.Flags().Float64Var(&amount, "amount", 0, "Commission amount")
+	patch.register(cmd)
 
 	_ = cmd.MarkFlagRequired("id")
 
 	return cmd
 }
+
+// commissionsPageSize is the page size used when paging through every
+// commission.
+const commissionsPageSize = 100
+
+// commissionRecord is the subset of a commission exported to CSV. Amounts
+// are in cents.
+type commissionRecord struct {
+	ID          string  `json:"id"`
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Earnings    float64 `json:"earnings"`
+	Currency    string  `json:"currency"`
+	Status      string  `json:"status"`
+	InvoiceID   string  `json:"invoiceId"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+	Partner     struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"partner"`
+}
+
+// commissionCSVHeader names the export columns, in an order accounting
+// tools can map directly.
+var commissionCSVHeader = []string{
+	"Date", "Commission ID", "Partner", "Partner Email", "Partner ID", "Description",
+	"Sale Amount", "Commission", "Currency", "Status", "Invoice ID", "Last Updated",
+}
+
+func newCommissionsExportCmd() *cobra.Command {
+	var (
+		programID string
+		partnerID string
+		status    string
+		month     string
+		since     string
+		until     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export commissions as CSV for accounting",
+		Long: `Export every matching commission as CSV, one row per commission, shaped for
+import into accounting tools: ISO dates, plain decimal amounts in major
+currency units, and a currency code column.
+
+--since and --until take the same dates as commissions list, for ranges
+other than a calendar month.
+
+Examples:
+  dub commissions export --program-id prog_abc --status paid --month 2025-01 --out payouts.csv
+  dub commissions export --program-id prog_abc --since "90 days ago" --until yesterday
+  dub commissions export --program-id prog_abc --partner-id pn_123 > partner.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := url.Values{"programId": {programID}}
+			if partnerID != "" {
+				params.Set("partnerId", partnerID)
+			}
+			if status != "" {
+				params.Set("status", status)
+			}
+			if month != "" {
+				start, err := time.Parse("2006-01", month)
+				if err != nil {
+					return NewUsageErrorf("--month must be formatted as YYYY-MM, got %q", month)
+				}
+				params.Set("start", start.Format(time.RFC3339))
+				params.Set("end", start.AddDate(0, 1, 0).Add(-time.Second).Format(time.RFC3339))
+			}
+			if err := setDateRange(params, "--since", since, "--until", until); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			commissions, err := fetchAllCommissions[commissionRecord](cmd.Context(), client, params)
+			if err != nil {
+				return err
+			}
+
+			if err := writeCommissionsCSV(cmd.OutOrStdout(), commissions); err != nil {
+				return err
+			}
+			if path := outFilePath(cmd.Context()); path != "" {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d commission(s) to %s\n", len(commissions), path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&programID, "program-id", "", "Program ID (required)")
+	cmd.Flags().StringVar(&partnerID, "partner-id", "", "Filter by partner ID")
+	cmd.Flags().StringVar(&status, "status", "", "Filter by status (pending, approved, paid)")
+	cmd.Flags().StringVar(&month, "month", "", "Only commissions created in this month (YYYY-MM, UTC)")
+	cmd.Flags().StringVar(&since, "since", "", "Only commissions created on or after this date")
+	cmd.Flags().StringVar(&until, "until", "", "Only commissions created on or before this date")
+
+	_ = cmd.MarkFlagRequired("program-id")
+	cmd.MarkFlagsMutuallyExclusive("month", "since")
+	cmd.MarkFlagsMutuallyExclusive("month", "until")
+
+	return cmd
+}
+
+// fetchAllCommissions pages through every commission matching params,
+// decoding each into T.
+func fetchAllCommissions[T any](ctx context.Context, client *api.Client, params url.Values) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		params.Set("pageSize", strconv.Itoa(commissionsPageSize))
+
+		var commissions []T
+		if err := getJSON(ctx, client, "/commissions?"+params.Encode(), &commissions); err != nil {
+			return nil, fmt.Errorf("failed to list commissions: %w", err)
+		}
+		all = append(all, commissions...)
+		if len(commissions) < commissionsPageSize {
+			return all, nil
+		}
+	}
+}
+
+func writeCommissionsCSV(w io.Writer, commissions []commissionRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(commissionCSVHeader); err != nil {
+		return err
+	}
+	for _, c := range commissions {
+		currency := strings.ToUpper(c.Currency)
+		if currency == "" {
+			currency = "USD"
+		}
+		description := c.Description
+		if description == "" {
+			description = c.Type
+		}
+		if err := cw.Write([]string{
+			csvDate(c.CreatedAt),
+			c.ID,
+			c.Partner.Name,
+			c.Partner.Email,
+			c.Partner.ID,
+			description,
+			centsToDecimal(c.Amount),
+			centsToDecimal(c.Earnings),
+			currency,
+			c.Status,
+			c.InvoiceID,
+			csvDate(c.UpdatedAt),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// centsToDecimal formats an amount in cents as a plain decimal, e.g.
+// 123456 -> "1234.56".
+func centsToDecimal(cents float64) string {
+	return strconv.FormatFloat(math.Round(cents)/100, 'f', 2, 64)
+}
+
+// csvDate reduces a timestamp to its UTC date, leaving unparseable values
+// as they are.
+func csvDate(ts string) string {
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return ts
+	}
+	return t.UTC().Format("2006-01-02")
+}
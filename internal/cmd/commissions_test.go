@@ -2,7 +2,18 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCommissionsCmd_Name(t *testing.T) {
@@ -14,7 +25,7 @@ func TestCommissionsCmd_Name(t *testing.T) {
 
 func TestCommissionsCmd_SubCommands(t *testing.T) {
 	cmd := newCommissionsCmd()
-	subCmds := []string{"list", "update"}
+	subCmds := []string{"list", "update", "export"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range cmd.Commands() {
@@ -40,7 +51,7 @@ func TestCommissionsListCmd_RequiresProgramID(t *testing.T) {
 
 func TestCommissionsListCmd_Flags(t *testing.T) {
 	cmd := newCommissionsListCmd()
-	flags := []string{"program-id", "partner-id", "status", "output", "limit", "all"}
+	flags := []string{"program-id", "partner-id", "status", "since", "until", "min-amount", "max-amount", "output", "limit", "all"}
 	for _, name := range flags {
 		if cmd.Flags().Lookup(name) == nil {
 			t.Errorf("expected flag %q to exist", name)
@@ -116,6 +127,64 @@ func TestFormatAmount(t *testing.T) {
 	}
 }
 
+func TestCommissionsListCmd_FiltersAndTotals(t *testing.T) {
+	var query url.Values
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`[
+			{"id": "cm_small", "amount": 500, "status": "pending", "partner": {"name": "Acme"}},
+			{"id": "cm_mid", "amount": 25000, "status": "pending", "partner": {"name": "Acme"}},
+			{"id": "cm_big", "amount": 150050, "status": "pending", "partner": {"name": "Globex"}}
+		]`))
+	})
+
+	cmd := newCommissionsListCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--program-id", "prog_1", "--status", "pending", "--since", "2025-01-01", "--until", "2025-03-31",
+		"--min-amount", "100", "--limit", "1"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if query.Get("start") != "2025-01-01T00:00:00Z" || query.Get("end") != "2025-03-31T23:59:59Z" {
+		t.Errorf("unexpected date range: start=%q end=%q", query.Get("start"), query.Get("end"))
+	}
+	out := stdout.String()
+	if strings.Contains(out, "cm_small") {
+		t.Errorf("expected commission below --min-amount to be filtered out:\n%s", out)
+	}
+	if !strings.Contains(out, "$250.00") || strings.Contains(out, "cm_big") {
+		t.Errorf("expected only the first matching commission in the table:\n%s", out)
+	}
+	if !strings.Contains(out, "Total: $1,750.50 across 2 commission(s)") {
+		t.Errorf("expected totals over every match:\n%s", out)
+	}
+}
+
+func TestParseDateFlag(t *testing.T) {
+	start, err := parseDateFlag("2025-03-31", false)
+	if err != nil || !start.Equal(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start %v (%v)", start, err)
+	}
+	end, err := parseDateFlag("2025-03-31", true)
+	if err != nil || !end.Equal(time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("unexpected end %v (%v)", end, err)
+	}
+	exact, err := parseDateFlag("2025-03-31T12:00:00Z", true)
+	if err != nil || exact.Hour() != 12 {
+		t.Errorf("expected RFC 3339 timestamps to be kept as-is, got %v (%v)", exact, err)
+	}
+	if _, err := parseDateFlag("March", false); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
 func TestCommissionsUpdateCmd_RequiresID(t *testing.T) {
 	cmd := newCommissionsUpdateCmd()
 	cmd.SetArgs([]string{"--status", "approved"})
@@ -143,3 +212,65 @@ func TestCommissionsUpdateCmd_Flags(t *testing.T) {
 		}
 	}
 }
+
+func TestCommissionsExportCmd(t *testing.T) {
+	var queries []url.Values
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		// A full page, so the export asks for the next one
+		items := make([]string, commissionsPageSize)
+		for i := range items {
+			items[i] = `{"id": "cm_` + strconv.Itoa(i) + `", "type": "sale", "amount": 123456, "earnings": 12345, "currency": "usd",
+				"status": "paid", "createdAt": "2025-01-15T18:30:00.000Z", "partner": {"id": "pn_1", "name": "Acme, Inc.", "email": "pay@acme.com"}}`
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	})
+
+	out := filepath.Join(t.TempDir(), "payouts.csv")
+	var stderr bytes.Buffer
+	args := []string{"--api-url", server.URL, "commissions", "export", "--program-id", "prog_1", "--status", "paid", "--month", "2025-01", "--out", out}
+	if err := execute(context.Background(), args, nil, &bytes.Buffer{}, &stderr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("expected 2 pages requested, got %d", len(queries))
+	}
+	q := queries[0]
+	if q.Get("programId") != "prog_1" || q.Get("status") != "paid" || q.Get("start") != "2025-01-01T00:00:00Z" || q.Get("end") != "2025-01-31T23:59:59Z" {
+		t.Errorf("unexpected query: %v", q)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != commissionsPageSize+1 {
+		t.Fatalf("expected header and %d rows, got %d", commissionsPageSize, len(records))
+	}
+	want := []string{"2025-01-15", "cm_0", "Acme, Inc.", "pay@acme.com", "pn_1", "sale", "1234.56", "123.45", "USD", "paid", "", ""}
+	if !reflect.DeepEqual(records[1], want) {
+		t.Errorf("expected row %v, got %v", want, records[1])
+	}
+	if !strings.Contains(stderr.String(), "Exported 100 commission(s)") {
+		t.Errorf("unexpected output: %q", stderr.String())
+	}
+}
+
+func TestCommissionsExportCmd_InvalidMonth(t *testing.T) {
+	cmd := newCommissionsExportCmd()
+	cmd.SetArgs([]string{"--program-id", "prog_1", "--month", "January"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "YYYY-MM") {
+		t.Errorf("expected month format error, got %v", err)
+	}
+}
@@ -2,7 +2,13 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -46,6 +52,9 @@ PowerShell:
   # To load completions for every new session, run:
   PS> dub completion powershell > dub.ps1
   # and source this file from your PowerShell profile.
+
+Automatic install (detects your shell, use --dry-run to preview):
+  $ dub completion install
 `,
 		DisableFlagsInUseLine: true,
 		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
@@ -53,17 +62,286 @@ PowerShell:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			switch args[0] {
 			case "bash":
-				return cmd.Root().GenBashCompletion(os.Stdout)
+				return cmd.Root().GenBashCompletion(cmd.OutOrStdout())
 			case "zsh":
-				return cmd.Root().GenZshCompletion(os.Stdout)
+				return cmd.Root().GenZshCompletion(cmd.OutOrStdout())
 			case "fish":
-				return cmd.Root().GenFishCompletion(os.Stdout, true)
+				return cmd.Root().GenFishCompletion(cmd.OutOrStdout(), true)
 			case "powershell":
-				return cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
+				return cmd.Root().GenPowerShellCompletionWithDesc(cmd.OutOrStdout())
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newCompletionInstallCmd())
+
+	return cmd
+}
+
+// completionPlan describes where a completion script is installed and which
+// line (if any) must be added to the shell startup file to load it.
+type completionPlan struct {
+	Shell      string
+	ScriptPath string
+	RCFile     string
+	RCLines    []string
+}
+
+func newCompletionInstallCmd() *cobra.Command {
+	var (
+		shell  string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install shell completions automatically",
+		Long: `Detect the current shell, write the completion script to the standard
+location for that shell, and add a sourcing line to the shell startup file
+when one is needed.
+
+Examples:
+  dub completion install              # Install for the current shell
+  dub completion install --shell zsh  # Install for a specific shell
+  dub completion install --dry-run    # Show planned changes without writing`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if shell == "" {
+				shell = detectShell()
+			}
+			if shell == "" {
+				return fmt.Errorf("could not detect your shell; specify one with --shell")
 			}
+
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return err
+			}
+
+			plan, err := planCompletionInstall(shell, home, brewPrefix())
+			if err != nil {
+				return err
+			}
+
+			script, err := generateCompletion(cmd.Root(), plan.Shell)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if dryRun {
+				_, _ = fmt.Fprintf(out, "Would write %s completion script to %s\n", plan.Shell, plan.ScriptPath)
+				if plan.RCFile != "" && !rcFileContains(plan.RCFile, plan.RCLines) {
+					_, _ = fmt.Fprintf(out, "Would append to %s:\n", plan.RCFile)
+					for _, line := range plan.RCLines {
+						_, _ = fmt.Fprintf(out, "  %s\n", line)
+					}
+				}
+				return nil
+			}
+
+			if err := os.MkdirAll(filepath.Dir(plan.ScriptPath), 0o755); err != nil {
+				return fmt.Errorf("failed to create completion directory: %w", err)
+			}
+			if err := os.WriteFile(plan.ScriptPath, script, 0o644); err != nil {
+				return fmt.Errorf("failed to write completion script: %w", err)
+			}
+			_, _ = fmt.Fprintf(out, "Wrote %s completion script to %s\n", plan.Shell, plan.ScriptPath)
+
+			if plan.RCFile != "" {
+				appended, err := appendRCLines(plan.RCFile, plan.RCLines)
+				if err != nil {
+					return fmt.Errorf("failed to update %s: %w", plan.RCFile, err)
+				}
+				if appended {
+					_, _ = fmt.Fprintf(out, "Updated %s\n", plan.RCFile)
+				}
+			}
+
+			_, _ = fmt.Fprintln(out, "Start a new shell for completions to take effect.")
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&shell, "shell", "", "Shell to install for: bash|zsh|fish|powershell (default: detected)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show planned changes without writing anything")
+
 	return cmd
 }
+
+// detectShell returns the user's shell name based on $SHELL, falling back to
+// PowerShell on Windows. Returns an empty string if it cannot be determined.
+func detectShell() string {
+	if sh := os.Getenv("SHELL"); sh != "" {
+		return filepath.Base(sh)
+	}
+	if runtime.GOOS == "windows" {
+		return "powershell"
+	}
+	return ""
+}
+
+// brewPrefix returns the Homebrew prefix if Homebrew is installed.
+func brewPrefix() string {
+	if prefix := os.Getenv("HOMEBREW_PREFIX"); prefix != "" {
+		return prefix
+	}
+	if _, err := exec.LookPath("brew"); err != nil {
+		return ""
+	}
+	out, err := exec.Command("brew", "--prefix").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// planCompletionInstall decides where the completion script for shell goes.
+func planCompletionInstall(shell, home, brew string) (*completionPlan, error) {
+	switch shell {
+	case "bash":
+		if brew != "" {
+			// Homebrew's bash-completion loads everything in this directory
+			return &completionPlan{
+				Shell:      "bash",
+				ScriptPath: filepath.Join(brew, "etc", "bash_completion.d", "dub"),
+			}, nil
+		}
+		script := filepath.Join(home, ".local", "share", "bash-completion", "completions", "dub")
+		return &completionPlan{
+			Shell:      "bash",
+			ScriptPath: script,
+			RCFile:     filepath.Join(home, ".bashrc"),
+			RCLines:    []string{fmt.Sprintf("[ -f %q ] && source %q", script, script)},
+		}, nil
+	case "zsh":
+		dir := filepath.Join(home, ".zsh", "completions")
+		return &completionPlan{
+			Shell:      "zsh",
+			ScriptPath: filepath.Join(dir, "_dub"),
+			RCFile:     filepath.Join(home, ".zshrc"),
+			RCLines: []string{
+				fmt.Sprintf("fpath=(%s $fpath)", dir),
+				"autoload -U compinit && compinit",
+			},
+		}, nil
+	case "fish":
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		// fish autoloads completions from this directory
+		return &completionPlan{
+			Shell:      "fish",
+			ScriptPath: filepath.Join(configHome, "fish", "completions", "dub.fish"),
+		}, nil
+	case "powershell", "pwsh":
+		profile := filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1")
+		if runtime.GOOS == "windows" {
+			profile = filepath.Join(home, "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1")
+		}
+		script := filepath.Join(filepath.Dir(profile), "dub-completion.ps1")
+		return &completionPlan{
+			Shell:      "powershell",
+			ScriptPath: script,
+			RCFile:     profile,
+			RCLines:    []string{fmt.Sprintf(". %q", script)},
+		}, nil
+	default:
+		return nil, NewUsageErrorf("unsupported shell %q: expected bash, zsh, fish, or powershell", shell)
+	}
+}
+
+// generateCompletion renders the completion script for shell.
+func generateCompletion(root *cobra.Command, shell string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch shell {
+	case "bash":
+		err = root.GenBashCompletion(&buf)
+	case "zsh":
+		err = root.GenZshCompletion(&buf)
+	case "fish":
+		err = root.GenFishCompletion(&buf, true)
+	case "powershell":
+		err = root.GenPowerShellCompletionWithDesc(&buf)
+	default:
+		err = fmt.Errorf("unsupported shell %q", shell)
+	}
+	return buf.Bytes(), err
+}
+
+// rcFileContains reports whether every line is already present in the rc file.
+func rcFileContains(path string, lines []string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	content := string(data)
+	for _, line := range lines {
+		if !strings.Contains(content, line) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendRCLines appends lines to the rc file unless they are already present.
+// Returns true if the file was modified.
+func appendRCLines(path string, lines []string) (bool, error) {
+	if rcFileContains(path, lines) {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+
+	block := "\n# dub shell completion\n" + strings.Join(lines, "\n") + "\n"
+	if _, err := f.WriteString(block); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeRCLines removes the block appendRCLines added to the rc file, leaving
+// the rest of the file alone. Returns true if the file was modified.
+func removeRCLines(path string, lines []string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	ours := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		ours[line] = true
+	}
+	in := strings.Split(string(data), "\n")
+	out := make([]string, 0, len(in))
+	for i := 0; i < len(in); i++ {
+		if in[i] != "# dub shell completion" {
+			out = append(out, in[i])
+			continue
+		}
+		// Drop the marker, the lines after it, and the blank line before it
+		for i+1 < len(in) && ours[in[i+1]] {
+			i++
+		}
+		if n := len(out); n > 0 && out[n-1] == "" {
+			out = out[:n-1]
+		}
+	}
+	if len(out) == len(in) {
+		return false, nil
+	}
+	return true, os.WriteFile(path, []byte(strings.Join(out, "\n")), 0o644)
+}
@@ -0,0 +1,127 @@
+// internal/cmd/completion_test.go
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanCompletionInstall(t *testing.T) {
+	home := "/home/test"
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	tests := []struct {
+		name       string
+		shell      string
+		brew       string
+		wantScript string
+		wantRC     string
+	}{
+		{"bash with brew", "bash", "/opt/homebrew", "/opt/homebrew/etc/bash_completion.d/dub", ""},
+		{"bash without brew", "bash", "", "/home/test/.local/share/bash-completion/completions/dub", "/home/test/.bashrc"},
+		{"zsh", "zsh", "", "/home/test/.zsh/completions/_dub", "/home/test/.zshrc"},
+		{"fish", "fish", "", "/home/test/.config/fish/completions/dub.fish", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := planCompletionInstall(tt.shell, home, tt.brew)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if plan.ScriptPath != filepath.FromSlash(tt.wantScript) {
+				t.Errorf("ScriptPath = %q, want %q", plan.ScriptPath, tt.wantScript)
+			}
+			if plan.RCFile != filepath.FromSlash(tt.wantRC) {
+				t.Errorf("RCFile = %q, want %q", plan.RCFile, tt.wantRC)
+			}
+		})
+	}
+}
+
+func TestPlanCompletionInstall_UnsupportedShell(t *testing.T) {
+	_, err := planCompletionInstall("tcsh", "/home/test", "")
+	if err == nil {
+		t.Fatal("expected error for unsupported shell")
+	}
+	if !IsUsageError(err) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
+
+func TestAppendRCLines_Idempotent(t *testing.T) {
+	rc := filepath.Join(t.TempDir(), ".zshrc")
+	if err := os.WriteFile(rc, []byte("export EDITOR=vim\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []string{"fpath=(~/.zsh/completions $fpath)", "autoload -U compinit && compinit"}
+
+	appended, err := appendRCLines(rc, lines)
+	if err != nil || !appended {
+		t.Fatalf("expected first append to modify file, got appended=%v err=%v", appended, err)
+	}
+
+	appended, err = appendRCLines(rc, lines)
+	if err != nil || appended {
+		t.Fatalf("expected second append to be a no-op, got appended=%v err=%v", appended, err)
+	}
+
+	data, _ := os.ReadFile(rc)
+	if strings.Count(string(data), "autoload -U compinit") != 1 {
+		t.Errorf("expected sourcing line exactly once, got:\n%s", data)
+	}
+	if !strings.HasPrefix(string(data), "export EDITOR=vim\n") {
+		t.Error("expected existing rc content to be preserved")
+	}
+}
+
+func TestCompletionInstall_DryRun(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("HOMEBREW_PREFIX", "")
+	t.Setenv("PATH", "")
+
+	root := NewRootCmd()
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetArgs([]string{"completion", "install", "--shell", "zsh", "--dry-run"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Would write zsh completion script") || !strings.Contains(out, "Would append to") {
+		t.Errorf("unexpected dry-run output: %q", out)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".zsh")); !os.IsNotExist(err) {
+		t.Error("dry run must not create files")
+	}
+}
+
+func TestCompletionInstall_WritesScript(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	root := NewRootCmd()
+	root.SetOut(new(bytes.Buffer))
+	root.SetArgs([]string{"completion", "install", "--shell", "fish"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	script := filepath.Join(home, ".config", "fish", "completions", "dub.fish")
+	data, err := os.ReadFile(script)
+	if err != nil {
+		t.Fatalf("expected completion script: %v", err)
+	}
+	if !strings.Contains(string(data), "dub") {
+		t.Error("expected completion script content")
+	}
+}
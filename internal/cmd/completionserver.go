@@ -0,0 +1,304 @@
+// internal/cmd/completionserver.go
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+)
+
+// rpcMaxLine is the longest request line the completion server accepts.
+const rpcMaxLine = 1 << 20
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// rpcLink is a link as returned to integrations: just what's needed to show
+// and copy it.
+type rpcLink struct {
+	ID        string `json:"id"`
+	ShortLink string `json:"shortLink"`
+	URL       string `json:"url"`
+	Title     string `json:"title,omitempty"`
+	Clicks    int    `json:"clicks"`
+}
+
+func newCompletionServerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion-server",
+		Short: "Serve link search and creation to editors over stdin/stdout",
+		Long: `Serve JSON-RPC 2.0 on stdin and stdout, one message per line, so editor
+and launcher integrations (VS Code, Raycast, Alfred) can search and create
+links with the CLI's stored credentials instead of handling API keys
+themselves. The server runs until stdin closes or "shutdown" is called.
+
+Methods:
+  initialize                               -> {name, version, methods}
+  links.search  {query, domain?, limit?}   -> [{id, shortLink, url, title, clicks}]
+  links.create  {url, key?, domain?, tagNames?, folderName?}
+                                           -> {id, shortLink, url, title, clicks}
+  shutdown                                 -> null
+
+links.search uses the local mirror (see dub mirror) when it was synced in
+the last 24 hours, and the API otherwise. links.create applies the project's
+default domain and tags like dub links create.
+
+Example:
+  echo '{"jsonrpc":"2.0","id":1,"method":"links.search","params":{"query":"launch"}}' | dub completion-server`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &completionServer{cmd: cmd}
+			return s.serve(cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
+
+// completionServer answers JSON-RPC requests one at a time.
+type completionServer struct {
+	cmd    *cobra.Command
+	client *api.Client
+}
+
+// serve reads requests from r until EOF or shutdown, writing responses to w.
+func (s *completionServer) serve(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), rpcMaxLine)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var req rpcRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			if err := enc.Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+				Error: &rpcError{Code: rpcParseError, Message: "parse error: " + err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := s.handle(s.cmd.Context(), req)
+		// Requests without an ID are notifications and get no response
+		if len(req.ID) == 0 {
+			if req.Method == "shutdown" {
+				return nil
+			}
+			continue
+		}
+
+		resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+		if err == nil {
+			resp.Result, err = json.Marshal(result)
+		}
+		if err != nil {
+			var rerr *rpcError
+			if !errors.As(err, &rerr) {
+				rerr = &rpcError{Code: rpcServerError, Message: err.Error()}
+			}
+			resp.Result = nil
+			resp.Error = rerr
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+		if req.Method == "shutdown" {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *completionServer) handle(ctx context.Context, req rpcRequest) (interface{}, error) {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return nil, &rpcError{Code: rpcInvalidRequest, Message: `invalid request: expected "jsonrpc": "2.0" and a method`}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"name":    "dub",
+			"version": Version,
+			"methods": []string{"initialize", "links.search", "links.create", "shutdown"},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "links.search":
+		var p struct {
+			Query  string `json:"query"`
+			Domain string `json:"domain"`
+			Limit  int    `json:"limit"`
+		}
+		if err := decodeRPCParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.Query == "" {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "query is required"}
+		}
+		if p.Limit <= 0 {
+			p.Limit = 20
+		}
+		return s.searchLinks(ctx, p.Query, p.Domain, p.Limit)
+	case "links.create":
+		var p struct {
+			URL        string   `json:"url"`
+			Key        string   `json:"key"`
+			Domain     string   `json:"domain"`
+			TagNames   []string `json:"tagNames"`
+			FolderName string   `json:"folderName"`
+		}
+		if err := decodeRPCParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.URL == "" {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "url is required"}
+		}
+		return s.createLink(ctx, p.URL, p.Key, p.Domain, p.TagNames, p.FolderName)
+	}
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+}
+
+func decodeRPCParams(params json.RawMessage, dest interface{}) error {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, dest); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// getClient returns the API client, created on first use so that requests
+// that don't need one work without credentials.
+func (s *completionServer) getClient(ctx context.Context) (*api.Client, error) {
+	if s.client == nil {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.client = client
+	}
+	return s.client, nil
+}
+
+func (s *completionServer) searchLinks(ctx context.Context, query, domain string, limit int) ([]rpcLink, error) {
+	links, err := findInMirror(s.cmd, "", query, domain, true, 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	if links == nil {
+		client, err := s.getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		params := url.Values{"search": {query}, "pageSize": {strconv.Itoa(limit)}}
+		if domain != "" {
+			params.Set("domain", domain)
+		}
+		if err := getJSON(ctx, client, "/links?"+params.Encode(), &links); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(links) > limit {
+		links = links[:limit]
+	}
+	results := make([]rpcLink, len(links))
+	for i, link := range links {
+		results[i] = newRPCLink(link)
+	}
+	return results, nil
+}
+
+func (s *completionServer) createLink(ctx context.Context, linkURL, key, domain string, tagNames []string, folderName string) (rpcLink, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return rpcLink{}, err
+	}
+
+	if domain == "" {
+		domain = GetDefaultDomain(ctx)
+	}
+	if len(tagNames) == 0 {
+		tagNames = GetDefaultTags(ctx)
+	}
+	body := map[string]interface{}{"url": linkURL}
+	if key != "" {
+		body["key"] = key
+	}
+	if domain != "" {
+		body["domain"] = domain
+	}
+	if err := applyLinkOrganization(ctx, client, body, tagNames, folderName); err != nil {
+		return rpcLink{}, err
+	}
+
+	resp, err := client.Post(ctx, "/links", body)
+	if err != nil {
+		return rpcLink{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 400 {
+		return rpcLink{}, api.ReadAPIError(resp)
+	}
+	var link map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&link); err != nil {
+		return rpcLink{}, fmt.Errorf("failed to parse link: %w", err)
+	}
+	return newRPCLink(link), nil
+}
+
+func newRPCLink(link map[string]interface{}) rpcLink {
+	shortLink := outfmt.SafeString(link["shortLink"])
+	if shortLink == "" {
+		shortLink = "https://" + linkLabel(link)
+	}
+	return rpcLink{
+		ID:        outfmt.SafeString(link["id"]),
+		ShortLink: shortLink,
+		URL:       outfmt.SafeString(link["url"]),
+		Title:     outfmt.SafeString(link["title"]),
+		Clicks:    outfmt.SafeInt(link["clicks"]),
+	}
+}
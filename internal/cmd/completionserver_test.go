@@ -0,0 +1,96 @@
+// internal/cmd/completionserver_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCompletionServer(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/links":
+			if r.URL.Query().Get("search") != "launch" {
+				t.Errorf("unexpected search: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[
+				{"id": "l1", "domain": "dub.sh", "key": "launch", "url": "https://example.com/launch", "clicks": 12},
+				{"id": "l2", "shortLink": "https://go.acme.com/launch-2", "url": "https://example.com/2", "title": "Launch 2"}
+			]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/links":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"url":"https://example.com/new"`) {
+				t.Errorf("unexpected create body: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"id": "l3", "shortLink": "https://dub.sh/new", "url": "https://example.com/new"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	input := strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize"}`,
+		`{"jsonrpc": "2.0", "id": "s", "method": "links.search", "params": {"query": "launch", "limit": 1}}`,
+		`{"jsonrpc": "2.0", "method": "links.search", "params": {"query": "launch"}}`,
+		`not json`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "links.create", "params": {"url": "https://example.com/new"}}`,
+		`{"jsonrpc": "2.0", "id": 4, "method": "links.create", "params": {}}`,
+		`{"jsonrpc": "2.0", "id": 5, "method": "links.delete"}`,
+		`{"jsonrpc": "2.0", "id": 6, "method": "shutdown"}`,
+		`{"jsonrpc": "2.0", "id": 7, "method": "initialize"}`,
+	}, "\n")
+
+	cmd := newCompletionServerCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetIn(strings.NewReader(input))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var responses []map[string]interface{}
+	dec := json.NewDecoder(&stdout)
+	for dec.More() {
+		var resp map[string]interface{}
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+
+	// The notification gets no response and nothing is read after shutdown
+	if len(responses) != 7 {
+		t.Fatalf("expected 7 responses, got %d: %v", len(responses), responses)
+	}
+	errCode := func(resp map[string]interface{}) float64 {
+		e, _ := resp["error"].(map[string]interface{})
+		code, _ := e["code"].(float64)
+		return code
+	}
+
+	if methods := responses[0]["result"].(map[string]interface{})["methods"]; len(methods.([]interface{})) != 4 {
+		t.Errorf("unexpected initialize result: %v", responses[0])
+	}
+	results := responses[1]["result"].([]interface{})
+	if responses[1]["id"] != "s" || len(results) != 1 || results[0].(map[string]interface{})["shortLink"] != "https://dub.sh/launch" {
+		t.Errorf("unexpected search response: %v", responses[1])
+	}
+	if errCode(responses[2]) != rpcParseError {
+		t.Errorf("expected a parse error, got %v", responses[2])
+	}
+	if created := responses[3]["result"].(map[string]interface{}); created["shortLink"] != "https://dub.sh/new" {
+		t.Errorf("unexpected create response: %v", responses[3])
+	}
+	if errCode(responses[4]) != rpcInvalidParams || errCode(responses[5]) != rpcMethodNotFound {
+		t.Errorf("expected invalid params then method not found, got %v, %v", responses[4], responses[5])
+	}
+	if _, ok := responses[6]["result"]; !ok || responses[6]["result"] != nil {
+		t.Errorf("expected a null shutdown result, got %v", responses[6])
+	}
+}
@@ -0,0 +1,23 @@
+// internal/cmd/concurrency.go
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+)
+
+// addConcurrencyFlag registers --concurrency on commands that fan out many
+// API requests through the batch worker pool.
+func addConcurrencyFlag(cmd *cobra.Command, concurrency *int) {
+	cmd.Flags().IntVar(concurrency, "concurrency", batch.DefaultConcurrency,
+		"Number of parallel API requests (1-16); lower it if you hit rate limits")
+}
+
+// validateConcurrency checks a --concurrency value against the pool's limits.
+func validateConcurrency(concurrency int) error {
+	if concurrency < 1 || concurrency > batch.MaxConcurrency {
+		return NewUsageErrorf("--concurrency must be between 1 and %d", batch.MaxConcurrency)
+	}
+	return nil
+}
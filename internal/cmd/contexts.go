@@ -0,0 +1,242 @@
+// internal/cmd/contexts.go
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+func newContextCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "context",
+		Aliases: []string{"ctx"},
+		Short:   "Manage named contexts",
+		Long: `Manage named contexts bundling a workspace, API URL, and defaults.
+
+The current context supplies defaults for every command. Flags and
+environment variables always take precedence, and --context (or
+DUB_CONTEXT) selects a different context for a single command.
+
+Examples:
+  dub context create prod --workspace acme --default-domain go.acme.com
+  dub context use prod
+  dub links create --url https://example.com   # uses acme and go.acme.com`,
+	}
+
+	cmd.AddCommand(newContextCreateCmd())
+	cmd.AddCommand(newContextListCmd())
+	cmd.AddCommand(newContextUseCmd())
+	cmd.AddCommand(newContextCurrentCmd())
+	cmd.AddCommand(newContextDeleteCmd())
+
+	return cmd
+}
+
+func newContextCreateCmd() *cobra.Command {
+	var c config.Context
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create or replace a context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if c.APIURL != "" {
+				if err := validateAPIURL(c.APIURL); err != nil {
+					return err
+				}
+			}
+
+			if err := config.SetContext(args[0], c); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Context %q saved. Activate it with: dub context use %s\n", args[0], args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&c.Workspace, "workspace", "", "Workspace name")
+	cmd.Flags().StringVar(&c.APIURL, "api-url", "", "API base URL")
+	cmd.Flags().StringVar(&c.DefaultDomain, "default-domain", "", "Default domain for new links")
+
+	return cmd
+}
+
+func newContextListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List contexts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			if len(cfg.Contexts) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No contexts configured. Create one with: dub context create <name>")
+				return nil
+			}
+
+			names := make([]string, 0, len(cfg.Contexts))
+			for name := range cfg.Contexts {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			columns := []outfmt.Column{
+				{Name: "Current", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Name", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Workspace", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "API URL", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Default Domain", Width: 0, Align: outfmt.AlignLeft},
+			}
+			rows := make([][]string, len(names))
+			for i, name := range names {
+				c := cfg.Contexts[name]
+				current := ""
+				if name == cfg.CurrentContext {
+					current = "*"
+				}
+				rows[i] = []string{current, name, dashIfEmpty(c.Workspace), dashIfEmpty(c.APIURL), dashIfEmpty(c.DefaultDomain)}
+			}
+
+			return outfmt.FormatTable(cmd.OutOrStdout(), columns, rows)
+		},
+	}
+}
+
+func newContextUseCmd() *cobra.Command {
+	var unset bool
+
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the current context",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if unset {
+				if err := config.UseContext(""); err != nil {
+					return fmt.Errorf("failed to save config: %w", err)
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Current context cleared.")
+				return nil
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("context name required")
+			}
+
+			if err := config.UseContext(args[0]); err != nil {
+				if errors.Is(err, config.ErrContextNotFound) {
+					return fmt.Errorf("context %q not found. Run: dub context list", args[0])
+				}
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %q\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&unset, "unset", false, "Clear the current context")
+
+	return cmd
+}
+
+func newContextCurrentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Show the current context",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			if cfg.CurrentContext == "" {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No current context")
+				return nil
+			}
+
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), cfg.CurrentContext)
+			return nil
+		},
+	}
+}
+
+func newContextDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.DeleteContext(args[0]); err != nil {
+				if errors.Is(err, config.ErrContextNotFound) {
+					return fmt.Errorf("context %q not found. Run: dub context list", args[0])
+				}
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Deleted context %q\n", args[0])
+			return nil
+		},
+	}
+}
+
+// applyActiveContext fills root flags from the selected context (--context or
+// the current context) unless they were set by flag or environment variable.
+// It returns the context's default domain.
+func applyActiveContext(cmd *cobra.Command, flags *rootFlags) (string, error) {
+	name := flags.Context
+	if name == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			// An unreadable config should not block commands that don't need it
+			return "", nil
+		}
+		name = cfg.CurrentContext
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	c, err := config.GetContext(name)
+	if err != nil {
+		if errors.Is(err, config.ErrContextNotFound) {
+			return "", fmt.Errorf("context %q not found. Run: dub context list", name)
+		}
+		return "", err
+	}
+
+	persistent := cmd.Root().PersistentFlags()
+	if c.Workspace != "" && !flagHasValue(persistent, "workspace") {
+		flags.Workspace = c.Workspace
+	}
+	if c.APIURL != "" && !flagHasValue(persistent, "api-url") {
+		flags.APIURL = c.APIURL
+	}
+
+	return c.DefaultDomain, nil
+}
+
+// validateAPIURL checks that u is an absolute http(s) URL.
+func validateAPIURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return NewUsageErrorf("invalid API URL %q: must be an http(s) URL", u)
+	}
+	return nil
+}
+
+// dashIfEmpty returns "-" for empty table cells.
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,178 @@
+// internal/cmd/contexts_test.go
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+// runContextProbe executes a throwaway subcommand under a fresh root and
+// returns the context values seen by it.
+func runContextProbe(t *testing.T, args ...string) (workspace, apiURL, domain string, err error) {
+	t.Helper()
+
+	root := NewRootCmd()
+	root.AddCommand(&cobra.Command{
+		Use: "probe",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workspace = GetWorkspace(cmd.Context())
+			apiURL = GetAPIURL(cmd.Context())
+			domain = GetDefaultDomain(cmd.Context())
+			return nil
+		},
+	})
+	root.SetArgs(append([]string{"probe"}, args...))
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	err = root.Execute()
+	return
+}
+
+func TestActiveContext_AppliesDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_WORKSPACE", "")
+	t.Setenv("DUB_CONTEXT", "")
+	t.Setenv("DUB_API_URL", "")
+
+	_ = config.SetContext("prod", config.Context{Workspace: "acme", APIURL: "https://api.acme.test", DefaultDomain: "go.acme.com"})
+	_ = config.SetContext("staging", config.Context{Workspace: "acme-staging"})
+	if err := config.UseContext("prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, apiURL, domain, err := runContextProbe(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ws != "acme" || apiURL != "https://api.acme.test" || domain != "go.acme.com" {
+		t.Errorf("expected prod context values, got %q %q %q", ws, apiURL, domain)
+	}
+
+	// Explicit flags win over the context
+	ws, _, _, _ = runContextProbe(t, "--workspace", "other")
+	if ws != "other" {
+		t.Errorf("expected --workspace to override context, got %q", ws)
+	}
+
+	// --context selects a different context for one command
+	ws, apiURL, _, _ = runContextProbe(t, "--context", "staging")
+	if ws != "acme-staging" || apiURL != "" {
+		t.Errorf("expected staging context values, got %q %q", ws, apiURL)
+	}
+
+	if _, _, _, err := runContextProbe(t, "--context", "missing"); err == nil || !strings.Contains(err.Error(), `context "missing" not found`) {
+		t.Errorf("expected missing context error, got %v", err)
+	}
+}
+
+func TestProjectConfig_ExplicitContextWins(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_WORKSPACE", "")
+	t.Setenv("DUB_CONTEXT", "")
+	t.Setenv("DUB_API_URL", "")
+
+	_ = config.SetContext("staging", config.Context{Workspace: "acme-staging", DefaultDomain: "stg.acme.com"})
+	dir := t.TempDir()
+	project := "workspace = \"acme\"\ndefault_domain = \"go.acme.com\"\n"
+	if err := os.WriteFile(filepath.Join(dir, config.ProjectFileName), []byte(project), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	ws, _, domain, err := runContextProbe(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ws != "acme" || domain != "go.acme.com" {
+		t.Errorf("expected the project file to apply, got %q %q", ws, domain)
+	}
+
+	ws, _, domain, _ = runContextProbe(t, "--context", "staging")
+	if ws != "acme-staging" || domain != "stg.acme.com" {
+		t.Errorf("expected --context to win over the project file, got %q %q", ws, domain)
+	}
+
+	t.Setenv("DUB_CONTEXT", "staging")
+	ws, _, domain, _ = runContextProbe(t)
+	if ws != "acme-staging" || domain != "stg.acme.com" {
+		t.Errorf("expected DUB_CONTEXT to win over the project file, got %q %q", ws, domain)
+	}
+}
+
+func TestContextCmd_CreateUseList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	run := func(args ...string) (string, error) {
+		cmd := newContextCmd()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("create", "prod", "--api-url", "not-a-url"); err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error for invalid --api-url, got %v", err)
+	}
+	if _, err := run("create", "prod", "--workspace", "acme", "--default-domain", "go.acme.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := run("use", "prod"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err := run("list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "prod") || !strings.Contains(out, "go.acme.com") || !strings.Contains(out, "*") {
+		t.Errorf("unexpected list output:\n%s", out)
+	}
+
+	if out, _ := run("current"); strings.TrimSpace(out) != "prod" {
+		t.Errorf("expected current context prod, got %q", out)
+	}
+	if _, err := run("use", "missing"); err == nil {
+		t.Error("expected error using missing context")
+	}
+}
+
+func TestProjectConfig_OverridesContext(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_WORKSPACE", "")
+	t.Setenv("DUB_CONTEXT", "")
+
+	_ = config.SetContext("prod", config.Context{Workspace: "acme", DefaultDomain: "go.acme.com"})
+	if err := config.UseContext("prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	content := "workspace = \"acme-docs\"\ndefault_domain = \"docs.acme.com\"\ndefault_tags = [\"docs\"]\n"
+	if err := os.WriteFile(filepath.Join(dir, config.ProjectFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	ws, _, domain, err := runContextProbe(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ws != "acme-docs" || domain != "docs.acme.com" {
+		t.Errorf("expected project values, got %q %q", ws, domain)
+	}
+
+	// Explicit flags still win
+	ws, _, _, _ = runContextProbe(t, "--workspace", "other")
+	if ws != "other" {
+		t.Errorf("expected --workspace to override project, got %q", ws)
+	}
+}
@@ -0,0 +1,298 @@
+// internal/cmd/cron.go
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// errCronLocked is returned by lockFile when another process holds the lock.
+var errCronLocked = errors.New("lock is held by another process")
+
+// cronLockPoll is how often a held lock is retried while waiting for it.
+const cronLockPoll = time.Second
+
+// cronKillDelay is how long a job that outlived --timeout has to exit after
+// being interrupted before it is killed.
+const cronKillDelay = 10 * time.Second
+
+var cronNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// cronCommand builds the process that runs a job. Tests replace it to avoid
+// executing the test binary as dub.
+var cronCommand = func(ctx context.Context, args []string) (*exec.Cmd, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate dub: %w", err)
+	}
+	return exec.CommandContext(ctx, exe, args...), nil
+}
+
+// cronSummary describes one run of a job.
+type cronSummary struct {
+	Job        string    `json:"job"`
+	Command    []string  `json:"command"`
+	Status     string    `json:"status"`   // ok, failed, timeout, or skipped
+	ExitCode   int       `json:"exitCode"` // -1 if the command didn't exit on its own
+	StartedAt  time.Time `json:"startedAt"`
+	JitterMs   int64     `json:"jitterMs"`
+	DurationMs int64     `json:"durationMs"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func newCronCmd() *cobra.Command {
+	var (
+		name     string
+		jitter   time.Duration
+		timeout  time.Duration
+		lockWait time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cron [flags] [--] <command> [args...]",
+		Short: "Run a dub command safely from a scheduler",
+		Long: `Run a dub command from crontab, systemd timers, or CI schedules without
+overlapping runs or thundering herds.
+
+Before running, dub cron waits a random delay of up to --jitter, then takes
+a lock named by --name (by default derived from the command). If the previous
+run still holds the lock, the run is skipped, or after --lock-wait if set.
+A job running longer than --timeout is interrupted, then killed.
+
+The command's output passes through unchanged. When it finishes, a summary is
+written to stderr: one line of text, or a JSON object with --output json
+(job, command, status, exitCode, startedAt, jitterMs, durationMs, error).
+Status is ok, failed, timeout, or skipped. Skipped runs exit 0; failed and
+timed out runs exit 1.
+
+Global flags for the command go after the command name.
+
+Examples:
+  # crontab: ship new events every 15 minutes
+  */15 * * * * dub cron --jitter 2m --timeout 10m -- events list --since-last -o json >> events.jsonl
+  dub -o json cron --name nightly-report -- report --interval 24h --out report.md`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if jitter < 0 || timeout < 0 || lockWait < 0 {
+				return NewUsageErrorf("--jitter, --timeout, and --lock-wait must not be negative")
+			}
+			if name == "" {
+				name = cronJobName(args)
+			} else if !cronNamePattern.MatchString(name) {
+				return NewUsageErrorf("--name may only contain letters, digits, '.', '_', and '-'")
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			summary := &cronSummary{Job: name, Command: args, ExitCode: -1, StartedAt: time.Now().UTC()}
+			err := runCronJob(ctx, cmd, summary, jitter, timeout, lockWait)
+			if err != nil && summary.Error == "" {
+				summary.Error = err.Error()
+			}
+			if werr := writeCronSummary(cmd, summary); werr != nil && err == nil {
+				err = werr
+			}
+			return err
+		},
+	}
+
+	// Flags stop at the command so its own flags are passed through as is
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().StringVar(&name, "name", "", "Job name used for the lock (default: derived from the command)")
+	cmd.Flags().DurationVar(&jitter, "jitter", 0, "Wait a random delay of up to this long before starting")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Interrupt the command if it runs longer than this (0 for no limit)")
+	cmd.Flags().DurationVar(&lockWait, "lock-wait", 0, "Wait up to this long for a previous run to finish instead of skipping")
+
+	return cmd
+}
+
+// runCronJob waits out the jitter, takes the job's lock, and runs the
+// command, filling in summary as it goes.
+func runCronJob(ctx context.Context, cmd *cobra.Command, summary *cronSummary, jitter, timeout, lockWait time.Duration) error {
+	if jitter > 0 {
+		delay := rand.N(jitter)
+		summary.JitterMs = delay.Milliseconds()
+		if err := sleepContext(ctx, delay); err != nil {
+			summary.Status = "failed"
+			return err
+		}
+	}
+
+	lock, err := acquireCronLock(ctx, summary.Job, lockWait)
+	if errors.Is(err, errCronLocked) {
+		summary.Status = "skipped"
+		summary.Error = err.Error()
+		return nil
+	}
+	if err != nil {
+		summary.Status = "failed"
+		return err
+	}
+	defer func() { _ = lock.Close() }()
+
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	c, err := cronCommand(runCtx, summary.Command)
+	if err != nil {
+		summary.Status = "failed"
+		return err
+	}
+	c.Stdin = cmd.InOrStdin()
+	c.Stdout = cmd.OutOrStdout()
+	c.Stderr = cmd.ErrOrStderr()
+	// The job runs here, not on a daemon, so that --timeout stops it and
+	// the lock is held until it has really ended
+	if c.Env == nil {
+		c.Env = os.Environ()
+	}
+	c.Env = append(c.Env, "DUB_NO_DAEMON=1")
+	c.Cancel = func() error { return interruptProcess(c.Process) }
+	c.WaitDelay = cronKillDelay
+
+	start := time.Now()
+	err = c.Run()
+	summary.DurationMs = time.Since(start).Milliseconds()
+
+	switch {
+	case timeout > 0 && errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		summary.Status = "timeout"
+		return fmt.Errorf("job %s timed out after %s", summary.Job, timeout)
+	case err != nil:
+		summary.Status = "failed"
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			summary.ExitCode = exitErr.ExitCode()
+			return fmt.Errorf("job %s failed with exit code %d", summary.Job, summary.ExitCode)
+		}
+		return fmt.Errorf("job %s failed: %w", summary.Job, err)
+	}
+	summary.Status = "ok"
+	summary.ExitCode = 0
+	return nil
+}
+
+// acquireCronLock takes the lock for job, retrying for up to wait. The lock
+// is held until the returned file is closed or the process exits.
+func acquireCronLock(ctx context.Context, job string, wait time.Duration) (*os.File, error) {
+	path, err := config.CronLockPath(job)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		err := lockFile(f)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errCronLocked) || !time.Now().Before(deadline) {
+			_ = f.Close()
+			if errors.Is(err, errCronLocked) {
+				if pid := readCronLockPID(path); pid != 0 {
+					return nil, fmt.Errorf("%w (pid %d)", errCronLocked, pid)
+				}
+			}
+			return nil, err
+		}
+		if err := sleepContext(ctx, min(cronLockPoll, time.Until(deadline))); err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+	}
+
+	// Record the holder to explain skipped runs
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+	return f, nil
+}
+
+func readCronLockPID(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid
+}
+
+// cronJobName derives a job name from the command's words plus a hash of
+// all its arguments, so differently flagged runs lock separately.
+func cronJobName(args []string) string {
+	var words []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		if cronNamePattern.MatchString(arg) {
+			words = append(words, arg)
+		}
+	}
+	sum := sha256.Sum256([]byte(strings.Join(args, "\x00")))
+	return strings.Join(append(words, hex.EncodeToString(sum[:4])), "-")
+}
+
+func writeCronSummary(cmd *cobra.Command, s *cronSummary) error {
+	w := cmd.ErrOrStderr()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		// One compact line, so summaries can be appended to a log
+		return json.NewEncoder(w).Encode(s)
+	}
+
+	line := fmt.Sprintf("dub cron: %s %s", s.Job, s.Status)
+	if s.ExitCode >= 0 && s.Status != "skipped" {
+		line += fmt.Sprintf(" (exit %d)", s.ExitCode)
+	}
+	if s.DurationMs > 0 {
+		line += fmt.Sprintf(" in %s", time.Duration(s.DurationMs)*time.Millisecond)
+	}
+	if s.Error != "" {
+		line += ": " + s.Error
+	}
+	_, err := io.WriteString(w, line+"\n")
+	return err
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
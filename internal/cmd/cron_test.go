@@ -0,0 +1,149 @@
+// internal/cmd/cron_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// TestCronHelperProcess stands in for dub when cron tests run a job.
+func TestCronHelperProcess(t *testing.T) {
+	if os.Getenv("DUB_CRON_HELPER") != "1" {
+		return
+	}
+	args := os.Args
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	switch args[0] {
+	case "ok":
+		fmt.Println("ran", strings.Join(args[1:], " "))
+		os.Exit(0)
+	case "fail":
+		os.Exit(3)
+	case "sleep":
+		time.Sleep(time.Minute)
+	case "env":
+		fmt.Println("DUB_NO_DAEMON=" + os.Getenv("DUB_NO_DAEMON"))
+	}
+	os.Exit(0)
+}
+
+func useCronHelper(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	orig := cronCommand
+	cronCommand = func(ctx context.Context, args []string) (*exec.Cmd, error) {
+		c := exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=TestCronHelperProcess", "--"}, args...)...)
+		c.Env = append(os.Environ(), "DUB_CRON_HELPER=1")
+		return c, nil
+	}
+	t.Cleanup(func() { cronCommand = orig })
+}
+
+func runCron(t *testing.T, args ...string) (string, cronSummary, error) {
+	t.Helper()
+	cmd := newCronCmd()
+	cmd.SetArgs(args)
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	err := cmd.ExecuteContext(outfmt.WithFormat(context.Background(), "json"))
+
+	var summary cronSummary
+	if jerr := json.NewDecoder(bytes.NewReader(stderr.Bytes())).Decode(&summary); jerr != nil {
+		t.Fatalf("expected JSON summary, got %q: %v", stderr.String(), jerr)
+	}
+	return stdout.String(), summary, err
+}
+
+func TestCronCmd(t *testing.T) {
+	useCronHelper(t)
+
+	t.Run("ok", func(t *testing.T) {
+		out, summary, err := runCron(t, "--jitter", "10ms", "ok", "--since-last")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(out, "ran --since-last") {
+			t.Errorf("expected command output to pass through, got %q", out)
+		}
+		if summary.Status != "ok" || summary.ExitCode != 0 || summary.JitterMs >= 10 {
+			t.Errorf("unexpected summary: %+v", summary)
+		}
+		if !strings.HasPrefix(summary.Job, "ok-") {
+			t.Errorf("expected job named after the command, got %q", summary.Job)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		_, summary, err := runCron(t, "fail")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if summary.Status != "failed" || summary.ExitCode != 3 {
+			t.Errorf("unexpected summary: %+v", summary)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, summary, err := runCron(t, "--timeout", "200ms", "sleep")
+		if err == nil || !strings.Contains(err.Error(), "timed out") {
+			t.Fatalf("expected timeout error, got %v", err)
+		}
+		if summary.Status != "timeout" || summary.ExitCode != -1 {
+			t.Errorf("unexpected summary: %+v", summary)
+		}
+	})
+
+	t.Run("local", func(t *testing.T) {
+		t.Setenv("DUB_NO_DAEMON", "")
+		out, _, err := runCron(t, "env")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// On a daemon, --timeout and the lock would end only the client
+		if strings.TrimSpace(out) != "DUB_NO_DAEMON=1" {
+			t.Errorf("expected the job to run without the daemon, got %q", out)
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		lock, err := acquireCronLock(context.Background(), "held", 0)
+		if err != nil {
+			t.Fatalf("failed to take lock: %v", err)
+		}
+		defer func() { _ = lock.Close() }()
+
+		_, summary, err := runCron(t, "--name", "held", "ok")
+		if err != nil {
+			t.Fatalf("expected skipped run to succeed, got %v", err)
+		}
+		if summary.Status != "skipped" || !strings.Contains(summary.Error, fmt.Sprintf("pid %d", os.Getpid())) {
+			t.Errorf("unexpected summary: %+v", summary)
+		}
+	})
+}
+
+func TestCronJobName(t *testing.T) {
+	a := cronJobName([]string{"events", "list", "--since-last"})
+	b := cronJobName([]string{"events", "list"})
+	if !strings.HasPrefix(a, "events-list-") || a == b {
+		t.Errorf("expected distinct names per command, got %q and %q", a, b)
+	}
+	if a != cronJobName([]string{"events", "list", "--since-last"}) {
+		t.Error("expected stable names")
+	}
+}
@@ -0,0 +1,25 @@
+// internal/cmd/cron_unix.go
+//go:build !windows
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on f without blocking, returning
+// errCronLocked if another process holds it.
+func lockFile(f *os.File) error {
+	err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return errCronLocked
+	}
+	return err
+}
+
+// interruptProcess asks p to stop, giving it a chance to clean up.
+func interruptProcess(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
@@ -0,0 +1,28 @@
+// internal/cmd/cron_windows.go
+//go:build windows
+
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile takes an exclusive lock on f without blocking, returning
+// errCronLocked if another process holds it.
+func lockFile(f *os.File) error {
+	err := windows.LockFileEx(windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &windows.Overlapped{})
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return errCronLocked
+	}
+	return err
+}
+
+// interruptProcess stops p. Windows can't deliver an interrupt to another
+// process, so it is killed outright.
+func interruptProcess(p *os.Process) error {
+	return p.Kill()
+}
@@ -2,11 +2,11 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -23,6 +23,7 @@ func newCustomersCmd() *cobra.Command {
 
 	cmd.AddCommand(newCustomersListCmd())
 	cmd.AddCommand(newCustomersGetCmd())
+	cmd.AddCommand(newCustomersActivityCmd())
 	cmd.AddCommand(newCustomersUpdateCmd())
 	cmd.AddCommand(newCustomersDeleteCmd())
 
@@ -31,17 +32,36 @@ func newCustomersCmd() *cobra.Command {
 
 func newCustomersListCmd() *cobra.Command {
 	var (
-		search string
-		output string
-		limit  int
-		all    bool
+		search     string
+		email      string
+		externalID string
+		page       int
+		perPage    int
+		output     string
+		limit      int
+		all        bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List customers",
-		Long:  "List all customers in your workspace.",
+		Long: `List all customers in your workspace.
+
+Pages are fetched until --limit customers are found, or every page with
+--all. To fetch one page as the API returns it, pass --page and --per-page.
+
+Examples:
+  dub customers list --email jane@example.com
+  dub customers list --all -o json
+  dub customers list --page 3 --per-page 50`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if page < 1 {
+				return NewUsageErrorf("--page must be at least 1")
+			}
+			if perPage < 1 || perPage > customersPageSize {
+				return NewUsageErrorf("--per-page must be between 1 and %d", customersPageSize)
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
@@ -51,29 +71,92 @@ func newCustomersListCmd() *cobra.Command {
 			if search != "" {
 				params.Set("search", search)
 			}
+			if email != "" {
+				params.Set("email", email)
+			}
+			if externalID != "" {
+				params.Set("externalId", externalID)
+			}
 
-			path := "/customers"
-			if len(params) > 0 {
-				path += "?" + params.Encode()
+			var customers []json.RawMessage
+			more := ""
+			if flagHasValue(cmd.Flags(), "page") || flagHasValue(cmd.Flags(), "per-page") {
+				// A single page, shown in full
+				params.Set("page", strconv.Itoa(page))
+				params.Set("pageSize", strconv.Itoa(perPage))
+				if err := getJSON(cmd.Context(), client, "/customers?"+params.Encode(), &customers); err != nil {
+					return err
+				}
+				if len(customers) == perPage {
+					more = fmt.Sprintf("Use --page %d for the next page.", page+1)
+				}
+				limit, all = len(customers), true
+			} else {
+				max := limit
+				if all {
+					max = 0
+				}
+				var hasMore bool
+				if customers, hasMore, err = fetchCustomers(cmd.Context(), client, params, max); err != nil {
+					return err
+				}
+				if hasMore {
+					more = "More customers may exist. Use --limit or --all for more."
+				}
 			}
 
-			resp, err := client.Get(cmd.Context(), path)
+			body, err := json.Marshal(customers)
 			if err != nil {
 				return err
 			}
-
-			return handleCustomersListResponse(cmd, resp, output, limit, all)
+			if customers == nil {
+				body = []byte("[]")
+			}
+			return writeCustomersList(cmd, body, output, limit, all, more)
 		},
 	}
 
 	cmd.Flags().StringVar(&search, "search", "", "Search query")
+	cmd.Flags().StringVar(&email, "email", "", "Only the customer with this exact email")
+	cmd.Flags().StringVar(&externalID, "external-id", "", "Only the customer with this external ID")
+	cmd.Flags().IntVar(&page, "page", 1, "Fetch only this page of results")
+	cmd.Flags().IntVar(&perPage, "per-page", customersPageSize, fmt.Sprintf("Customers per page, up to %d (fetches a single page)", customersPageSize))
 	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of customers to show")
-	cmd.Flags().BoolVar(&all, "all", false, "Show all customers (ignore limit)")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch and show every customer (ignore limit)")
+
+	cmd.MarkFlagsMutuallyExclusive("page", "all")
+	cmd.MarkFlagsMutuallyExclusive("per-page", "all")
 
 	return cmd
 }
 
+// customersPageSize is the most customers the API returns per page.
+const customersPageSize = 100
+
+// fetchCustomers pages through the customers matching params until it has
+// at least max of them, or all of them when max is 0. It reports whether
+// there may be more.
+func fetchCustomers(ctx context.Context, client *api.Client, params url.Values, max int) ([]json.RawMessage, bool, error) {
+	var all []json.RawMessage
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		params.Set("pageSize", strconv.Itoa(customersPageSize))
+
+		var customers []json.RawMessage
+		if err := getJSON(ctx, client, "/customers?"+params.Encode(), &customers); err != nil {
+			return nil, false, fmt.Errorf("failed to list customers: %w", err)
+		}
+		all = append(all, customers...)
+		if len(customers) < customersPageSize {
+			return all, false, nil
+		}
+		if max > 0 && len(all) >= max {
+			return all, true, nil
+		}
+	}
+}
+
 func newCustomersGetCmd() *cobra.Command {
 	var id string
 
@@ -113,6 +196,7 @@ func newCustomersUpdateCmd() *cobra.Command {
 		name       string
 		email      string
 		externalID string
+		patch      patchFlag
 	)
 
 	cmd := &cobra.Command{
@@ -140,6 +224,9 @@ func newCustomersUpdateCmd() *cobra.Command {
 				body["externalId"] = externalID
 			}
 
+			if err := patch.apply(body); err != nil {
+				return err
+			}
 			if len(body) == 0 {
 				return fmt.Errorf("at least one field must be specified for update")
 			}
@@ -157,6 +244,7 @@ func newCustomersUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Customer name")
 	cmd.Flags().StringVar(&email, "email", "", "Customer email")
 	cmd.Flags().StringVar(&externalID, "external-id", "", "External customer ID")
+	patch.register(cmd)
 
 	_ = cmd.MarkFlagRequired("id")
 
@@ -165,15 +253,35 @@ func newCustomersUpdateCmd() *cobra.Command {
 
 func newCustomersDeleteCmd() *cobra.Command {
 	var (
-		id     string
-		dryRun bool
+		id        string
+		dryRun    bool
+		fromStdin stdinIDs
 	)
 
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a customer",
-		Long:  "Delete a customer from your workspace.",
+		Long:  "Delete a customer from your workspace, or the customers whose IDs are piped in with --ids-from-stdin.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromStdin.enabled {
+				ids, err := fromStdin.read(cmd, dryRun)
+				if err != nil {
+					return err
+				}
+				if dryRun {
+					printWouldDelete(cmd, "customer", ids)
+					return nil
+				}
+				client, err := getClient(cmd.Context())
+				if err != nil {
+					return err
+				}
+				_, err = deleteByIDs(cmd, client, "customer", ids, func(id string) string {
+					return "/customers/" + url.PathEscape(id)
+				})
+				return err
+			}
+
 			if id == "" {
 				return fmt.Errorf("--id is required")
 			}
@@ -197,29 +305,21 @@ func newCustomersDeleteCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&id, "id", "", "Customer ID (required)")
+	cmd.Flags().StringVar(&id, "id", "", "Customer ID (required unless --ids-from-stdin)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be deleted without actually deleting")
-
-	_ = cmd.MarkFlagRequired("id")
+	fromStdin.register(cmd, "id", "id")
 
 	return cmd
 }
 
-// handleCustomersListResponse handles the response for customers list command,
-// formatting output as table or JSON based on the output flag.
-func handleCustomersListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all bool) error {
-	defer func() { _ = resp.Body.Close() }()
-
-	body, err := io.ReadAll(resp.Body)
+// writeCustomersList writes a JSON array of customers as a table or JSON
+// based on the output flag, followed by more, a hint about further pages.
+func writeCustomersList(cmd *cobra.Command, body []byte, output string, limit int, all bool, more string) error {
+	body, err := sortListBody(cmd, body)
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode >= 400 {
-		apiErr := api.ParseAPIError(body)
-		return fmt.Errorf("%s", apiErr.Error())
-	}
-
 	// For JSON output, use the existing handler
 	if output == "json" {
 		var data interface{}
@@ -277,6 +377,8 @@ func handleCustomersListResponse(cmd *cobra.Command, resp *http.Response, output
 	// Show pagination message if limited
 	if displayLimit < totalCount {
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d of %d customers. Use --limit or --all for more.\n", displayLimit, totalCount)
+	} else if more != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d customers. %s\n", displayLimit, more)
 	}
 
 	return nil
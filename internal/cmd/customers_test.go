@@ -2,6 +2,11 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -15,7 +20,7 @@ func TestCustomersCmd_Name(t *testing.T) {
 
 func TestCustomersCmd_SubCommands(t *testing.T) {
 	cmd := newCustomersCmd()
-	subCmds := []string{"list", "get", "update", "delete"}
+	subCmds := []string{"list", "get", "activity", "update", "delete"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range cmd.Commands() {
@@ -105,7 +110,7 @@ func TestCustomersDeleteCmd_RequiresID(t *testing.T) {
 
 func TestCustomersListCmd_Flags(t *testing.T) {
 	cmd := newCustomersListCmd()
-	flags := []string{"search", "output", "limit", "all"}
+	flags := []string{"search", "email", "external-id", "page", "per-page", "output", "limit", "all"}
 	for _, name := range flags {
 		if cmd.Flags().Lookup(name) == nil {
 			t.Errorf("expected flag %q to exist", name)
@@ -220,3 +225,51 @@ func TestFormatCustomerField(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomersListCmd_Paging(t *testing.T) {
+	// 150 customers, served in pages
+	var queries []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, r.URL.RawQuery)
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("pageSize"))
+		customers := []map[string]string{}
+		for i := (page - 1) * size; i < page*size && i < 150; i++ {
+			customers = append(customers, map[string]string{"id": fmt.Sprintf("cus_%d", i), "email": fmt.Sprintf("c%d@example.com", i)})
+		}
+		_ = json.NewEncoder(w).Encode(customers)
+	})
+
+	list := func(args ...string) []map[string]interface{} {
+		t.Helper()
+		var stdout bytes.Buffer
+		args = append([]string{"customers", "list", "--api-url", server.URL, "-o", "json"}, args...)
+		if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+			t.Fatal(err)
+		}
+		var customers []map[string]interface{}
+		if err := json.Unmarshal(stdout.Bytes(), &customers); err != nil {
+			t.Fatalf("expected JSON, got %q", stdout.String())
+		}
+		return customers
+	}
+
+	if got := list("--all"); len(got) != 150 {
+		t.Errorf("expected --all to walk every page, got %d customers", len(got))
+	}
+	if got := list("--page", "3", "--per-page", "50"); len(got) != 50 || got[0]["id"] != "cus_100" {
+		t.Errorf("expected the third page of 50, got %d starting at %v", len(got), got[0]["id"])
+	}
+
+	queries = nil
+	list("--email", "c7@example.com", "--external-id", "ext_7")
+	if len(queries) != 1 || !strings.Contains(queries[0], "email=c7%40example.com") || !strings.Contains(queries[0], "externalId=ext_7") {
+		t.Errorf("expected the filters to be sent, got %v", queries)
+	}
+
+	err := execute(context.Background(), []string{"customers", "list", "--api-url", server.URL, "--per-page", "500"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected a usage error for --per-page 500, got %v", err)
+	}
+}
@@ -0,0 +1,661 @@
+// internal/cmd/daemon.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/debug"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/secrets"
+)
+
+const (
+	daemonDialTimeout  = 200 * time.Millisecond
+	daemonStartTimeout = 5 * time.Second
+	daemonIdleTimeout  = 15 * time.Minute
+)
+
+// daemonSkipCommands are never delegated: they manage the daemon itself,
+// change local credentials, replace or remove the binary, read secrets from
+// the terminal, serve long-lived protocols, or supervise other processes,
+// like events tail running --exec scripts.
+var daemonSkipCommands = []string{"daemon", "auth", "upgrade", "password", "completion-server", "metrics", "cron", "tail", "uninstall"}
+
+// daemonSkipFlags are flags whose commands are never delegated because they
+// need the terminal, like --interactive pickers, or this process, like
+// --profile.
+var daemonSkipFlags = []string{"--no-daemon", "--no-daemon=true", "--interactive", "--interactive=true", "-i", "--profile"}
+
+// daemonRequest is the first message a client sends on a connection.
+type daemonRequest struct {
+	Op      string   `json:"op"` // "run", "status", or "stop"
+	Version string   `json:"version"`
+	Args    []string `json:"args,omitempty"`
+	Env     []string `json:"env,omitempty"`
+	Dir     string   `json:"dir,omitempty"`
+}
+
+// daemonFrame carries output, stdin, and results in both directions after
+// the request.
+type daemonFrame struct {
+	Stream string        `json:"stream,omitempty"` // "stdout", "stderr", or "stdin"
+	Data   []byte        `json:"data,omitempty"`
+	Size   int           `json:"size,omitempty"` // bytes wanted, on stdin requests
+	EOF    bool          `json:"eof,omitempty"`
+	Exit   *int          `json:"exit,omitempty"`
+	Status *daemonStatus `json:"status,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type daemonStatus struct {
+	PID     int       `json:"pid"`
+	Version string    `json:"version"`
+	Started time.Time `json:"started"`
+	Served  int       `json:"served"`
+}
+
+func newDaemonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run a background process that keeps connections warm",
+		Long: `Run a background process that keeps HTTP connections, credentials, and
+caches warm for scripts that invoke dub many times.
+
+While the daemon is running, other dub commands are sent to it over a local
+unix socket instead of starting from scratch. Pass --no-daemon (or set
+DUB_NO_DAEMON=1) to run a command locally. Commands are run one at a time
+with the caller's working directory and DUB_* environment variables.
+
+Examples:
+  dub daemon start
+  for id in $(cat ids.txt); do dub links get "$id"; done
+  dub daemon stop`,
+	}
+
+	cmd.AddCommand(newDaemonStartCmd())
+	cmd.AddCommand(newDaemonRunCmd())
+	cmd.AddCommand(newDaemonStopCmd())
+	cmd.AddCommand(newDaemonStatusCmd())
+
+	return cmd
+}
+
+func newDaemonStartCmd() *cobra.Command {
+	var idleTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start the daemon in the background",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			socket, err := daemonSocketPath()
+			if err != nil {
+				return err
+			}
+			if status, err := queryDaemonStatus(socket); err == nil {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Daemon already running (pid %d).\n", status.PID)
+				return nil
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to locate dub executable: %w", err)
+			}
+			logPath := filepath.Join(filepath.Dir(socket), "daemon.log")
+			if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+				return err
+			}
+			logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to open daemon log: %w", err)
+			}
+			defer func() { _ = logFile.Close() }()
+
+			child := exec.Command(exe, "daemon", "run", "--idle-timeout", idleTimeout.String())
+			child.Stdout = logFile
+			child.Stderr = logFile
+			if err := child.Start(); err != nil {
+				return fmt.Errorf("failed to start daemon: %w", err)
+			}
+			pid := child.Process.Pid
+			_ = child.Process.Release()
+
+			deadline := time.Now().Add(daemonStartTimeout)
+			for time.Now().Before(deadline) {
+				if _, err := queryDaemonStatus(socket); err == nil {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Daemon started (pid %d).\n", pid)
+					return nil
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+			return fmt.Errorf("daemon did not start within %s; see %s", daemonStartTimeout, logPath)
+		},
+	}
+
+	cmd.Flags().DurationVar(&idleTimeout, "idle-timeout", daemonIdleTimeout, "Exit after this long without requests (0 = never)")
+
+	return cmd
+}
+
+func newDaemonRunCmd() *cobra.Command {
+	var idleTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run the daemon in the foreground",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if idleTimeout < 0 {
+				return NewUsageErrorf("--idle-timeout must not be negative")
+			}
+
+			socket, err := daemonSocketPath()
+			if err != nil {
+				return err
+			}
+			ln, err := listenDaemon(socket)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = os.Remove(socket) }()
+
+			// Survive the terminal that started us closing
+			signal.Ignore(syscall.SIGHUP)
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			cacheCredentialStore()
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "dub daemon listening on %s\n", socket)
+			return newDaemonServer(idleTimeout).serve(ctx, ln)
+		},
+	}
+
+	cmd.Flags().DurationVar(&idleTimeout, "idle-timeout", daemonIdleTimeout, "Exit after this long without requests (0 = never)")
+
+	return cmd
+}
+
+func newDaemonStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the running daemon",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			socket, err := daemonSocketPath()
+			if err != nil {
+				return err
+			}
+			conn, err := net.DialTimeout("unix", socket, daemonDialTimeout)
+			if err != nil {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Daemon is not running.")
+				return nil
+			}
+			defer func() { _ = conn.Close() }()
+
+			if err := json.NewEncoder(conn).Encode(daemonRequest{Op: "stop", Version: Version}); err != nil {
+				return err
+			}
+			var frame daemonFrame
+			if err := json.NewDecoder(conn).Decode(&frame); err != nil {
+				return fmt.Errorf("failed to stop daemon: %w", err)
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Daemon stopped.")
+			return nil
+		},
+	}
+}
+
+func newDaemonStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the daemon is running",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			socket, err := daemonSocketPath()
+			if err != nil {
+				return err
+			}
+			status, _ := queryDaemonStatus(socket)
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				result := map[string]interface{}{"running": status != nil, "daemon": status}
+				return outfmt.FormatJSON(cmd.OutOrStdout(), result, outfmt.GetQuery(cmd.Context()))
+			}
+			if status == nil {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Daemon is not running.")
+				return nil
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Daemon running (pid %d, version %s, up %s, %d command(s) served).\n",
+				status.PID, status.Version, time.Since(status.Started).Round(time.Second), status.Served)
+			return nil
+		},
+	}
+}
+
+// daemonSocketPath returns the unix socket the daemon listens on.
+func daemonSocketPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "daemon.sock"), nil
+}
+
+// listenDaemon listens on socket, replacing a stale socket left by a daemon
+// that exited uncleanly.
+func listenDaemon(socket string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socket), 0o700); err != nil {
+		return nil, err
+	}
+	if conn, err := net.DialTimeout("unix", socket, daemonDialTimeout); err == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("daemon already running on %s", socket)
+	}
+	_ = os.Remove(socket)
+
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socket, err)
+	}
+	if err := os.Chmod(socket, 0o600); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// cacheCredentialStore opens the keyring once for the daemon's lifetime
+// instead of once per command.
+func cacheCredentialStore() {
+	open := storeOpener
+	var (
+		once  sync.Once
+		store secrets.Store
+		err   error
+	)
+	storeOpener = func() (secrets.Store, error) {
+		once.Do(func() { store, err = open() })
+		return store, err
+	}
+}
+
+// queryDaemonStatus asks the daemon on socket for its status.
+func queryDaemonStatus(socket string) (*daemonStatus, error) {
+	conn, err := net.DialTimeout("unix", socket, daemonDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := json.NewEncoder(conn).Encode(daemonRequest{Op: "status", Version: Version}); err != nil {
+		return nil, err
+	}
+	var frame daemonFrame
+	if err := json.NewDecoder(conn).Decode(&frame); err != nil {
+		return nil, err
+	}
+	if frame.Status == nil {
+		return nil, errors.New("unexpected daemon response")
+	}
+	return frame.Status, nil
+}
+
+// daemonServer runs delegated commands one at a time.
+type daemonServer struct {
+	idleTimeout time.Duration
+	started     time.Time
+
+	mu     sync.Mutex // serializes commands, which share env and cwd
+	served int
+	reset  chan struct{}
+	stop   chan struct{}
+	once   sync.Once
+}
+
+func newDaemonServer(idleTimeout time.Duration) *daemonServer {
+	return &daemonServer{
+		idleTimeout: idleTimeout,
+		started:     time.Now(),
+		reset:       make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+	}
+}
+
+// serve accepts connections until ctx is done, a stop request arrives, or
+// the idle timeout passes without requests.
+func (s *daemonServer) serve(ctx context.Context, ln net.Listener) error {
+	go func() {
+		var idle <-chan time.Time
+		timer := time.NewTimer(s.idleTimeout)
+		defer timer.Stop()
+		if s.idleTimeout > 0 {
+			idle = timer.C
+		}
+		for {
+			select {
+			case <-ctx.Done():
+			case <-s.stop:
+			case <-idle:
+			case <-s.reset:
+				if s.idleTimeout > 0 {
+					timer.Reset(s.idleTimeout)
+				}
+				continue
+			}
+			_ = ln.Close()
+			return
+		}
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handle(ctx, conn)
+	}
+}
+
+func (s *daemonServer) handle(ctx context.Context, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	select {
+	case s.reset <- struct{}{}:
+	default:
+	}
+
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+	var req daemonRequest
+	if err := dec.Decode(&req); err != nil {
+		return
+	}
+
+	switch req.Op {
+	case "status":
+		s.mu.Lock()
+		served := s.served
+		s.mu.Unlock()
+		_ = enc.Encode(daemonFrame{Status: &daemonStatus{PID: os.Getpid(), Version: Version, Started: s.started, Served: served}})
+	case "stop":
+		_ = enc.Encode(daemonFrame{Exit: new(int)})
+		s.once.Do(func() { close(s.stop) })
+	case "run":
+		if req.Version != Version {
+			_ = enc.Encode(daemonFrame{Error: fmt.Sprintf("daemon is running version %s", Version)})
+			return
+		}
+		s.run(ctx, req, dec, enc)
+	default:
+		_ = enc.Encode(daemonFrame{Error: fmt.Sprintf("unknown op %q", req.Op)})
+	}
+}
+
+// run executes a delegated command with the caller's environment and
+// working directory, streaming output back as it is written.
+func (s *daemonServer) run(ctx context.Context, req daemonRequest, dec *json.Decoder, enc *json.Encoder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.served++
+
+	restoreEnv := applyDaemonEnv(req.Env)
+	defer restoreEnv()
+	if req.Dir != "" {
+		if wd, err := os.Getwd(); err == nil && os.Chdir(req.Dir) == nil {
+			defer func() { _ = os.Chdir(wd) }()
+		}
+	}
+
+	// The command is canceled when the client hangs up, e.g. on Ctrl-C or
+	// its --max-time, so it doesn't keep running and holding s.mu
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	conn := &daemonConn{enc: enc, frames: make(chan daemonFrame), cancel: cancel}
+	go conn.readFrames(ctx, dec)
+	// Later debug logs of the daemon itself go to its own stderr again
+	defer debug.Configure(false, "", os.Stderr)
+
+	err := execute(ctx, req.Args, conn.stdin(), conn.writer("stdout"), conn.writer("stderr"))
+	code := exitCode(err)
+	_ = conn.send(daemonFrame{Exit: &code})
+}
+
+// daemonEnvVar reports whether an environment variable is forwarded from
+// clients to the daemon.
+func daemonEnvVar(kv string) bool {
+	name, _, _ := strings.Cut(kv, "=")
+	return strings.HasPrefix(name, "DUB_") || name == "NO_COLOR" || strings.HasPrefix(name, "XDG_") ||
+		name == "LANG" || name == "LC_ALL" || name == "LC_MESSAGES" || name == "GITHUB_STEP_SUMMARY"
+}
+
+// applyDaemonEnv replaces the forwarded variables with env and returns a
+// function restoring the daemon's own values.
+func applyDaemonEnv(env []string) func() {
+	var saved []string
+	for _, kv := range os.Environ() {
+		if daemonEnvVar(kv) {
+			saved = append(saved, kv)
+			name, _, _ := strings.Cut(kv, "=")
+			_ = os.Unsetenv(name)
+		}
+	}
+	for _, kv := range env {
+		if name, value, ok := strings.Cut(kv, "="); ok && daemonEnvVar(kv) {
+			_ = os.Setenv(name, value)
+		}
+	}
+
+	return func() {
+		for _, kv := range os.Environ() {
+			if daemonEnvVar(kv) {
+				name, _, _ := strings.Cut(kv, "=")
+				_ = os.Unsetenv(name)
+			}
+		}
+		for _, kv := range saved {
+			name, value, _ := strings.Cut(kv, "=")
+			_ = os.Setenv(name, value)
+		}
+	}
+}
+
+// daemonConn multiplexes a command's stdio over one connection.
+type daemonConn struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	// frames carries the client's stdin replies; it is closed when the
+	// client hangs up
+	frames chan daemonFrame
+	cancel context.CancelFunc
+}
+
+func (c *daemonConn) send(frame daemonFrame) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.enc.Encode(frame); err != nil {
+		c.cancel()
+		return err
+	}
+	return nil
+}
+
+// readFrames reads the client's frames until it hangs up, then cancels the
+// command.
+func (c *daemonConn) readFrames(ctx context.Context, dec *json.Decoder) {
+	defer close(c.frames)
+	defer c.cancel()
+	for {
+		var frame daemonFrame
+		if err := dec.Decode(&frame); err != nil {
+			return
+		}
+		select {
+		case c.frames <- frame:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type daemonWriter struct {
+	conn   *daemonConn
+	stream string
+}
+
+func (c *daemonConn) writer(stream string) io.Writer {
+	return &daemonWriter{conn: c, stream: stream}
+}
+
+func (w *daemonWriter) Write(p []byte) (int, error) {
+	if err := w.conn.send(daemonFrame{Stream: w.stream, Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// daemonStdin asks the client for input only when the command reads it, so
+// callers whose stdin is unrelated (e.g. a shell loop) aren't drained.
+type daemonStdin struct {
+	conn *daemonConn
+	eof  bool
+}
+
+func (c *daemonConn) stdin() io.Reader {
+	return &daemonStdin{conn: c}
+}
+
+func (r *daemonStdin) Read(p []byte) (int, error) {
+	if r.eof {
+		return 0, io.EOF
+	}
+	if err := r.conn.send(daemonFrame{Stream: "stdin", Size: len(p)}); err != nil {
+		return 0, err
+	}
+	frame, ok := <-r.conn.frames
+	if !ok {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n := copy(p, frame.Data)
+	if frame.EOF {
+		r.eof = true
+		if n == 0 {
+			return 0, io.EOF
+		}
+	}
+	return n, nil
+}
+
+// exitCode maps a command error to the process exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case IsUsageError(err):
+		return 2
+	case errors.Is(err, ErrInterrupted):
+		return 130
+	default:
+		return 1
+	}
+}
+
+// shouldDelegate reports whether args may be sent to a running daemon.
+func shouldDelegate(args []string) bool {
+	if os.Getenv("DUB_NO_DAEMON") != "" {
+		return false
+	}
+	for _, arg := range args {
+		if slices.Contains(daemonSkipFlags, arg) || slices.Contains(daemonSkipCommands, arg) || strings.HasPrefix(arg, "--profile=") {
+			return false
+		}
+	}
+	return true
+}
+
+// delegateToDaemon runs args on the daemon listening on socket. It returns
+// false when no compatible daemon is available and the command should run
+// locally. Once the daemon has the request, the command may be running
+// there, so a lost connection is an error rather than a local rerun.
+func delegateToDaemon(ctx context.Context, socket string, args []string, stdin io.Reader, stdout, stderr io.Writer) (bool, error) {
+	conn, err := net.DialTimeout("unix", socket, daemonDialTimeout)
+	if err != nil {
+		return false, nil
+	}
+	defer func() { _ = conn.Close() }()
+	// Hanging up on interrupt ends the wait for the daemon's output
+	defer context.AfterFunc(ctx, func() { _ = conn.Close() })()
+
+	dir, _ := os.Getwd()
+	var env []string
+	for _, kv := range os.Environ() {
+		if daemonEnvVar(kv) {
+			env = append(env, kv)
+		}
+	}
+
+	enc := json.NewEncoder(conn)
+	dec := json.NewDecoder(conn)
+	if err := enc.Encode(daemonRequest{Op: "run", Version: Version, Args: args, Env: env, Dir: dir}); err != nil {
+		return false, nil
+	}
+
+	for {
+		var frame daemonFrame
+		if err := dec.Decode(&frame); err != nil {
+			if ctx.Err() != nil {
+				return true, ErrInterrupted
+			}
+			return true, fmt.Errorf("lost connection to dub daemon: %w", err)
+		}
+		if frame.Error != "" {
+			// e.g. a daemon left running across an upgrade, which refuses
+			// the request before running anything
+			return false, nil
+		}
+
+		switch {
+		case frame.Exit != nil:
+			switch *frame.Exit {
+			case 0:
+				return true, nil
+			case 2:
+				return true, NewUsageErrorf("exit status 2")
+			case 130:
+				return true, ErrInterrupted
+			default:
+				return true, fmt.Errorf("exit status %d", *frame.Exit)
+			}
+		case frame.Stream == "stdout":
+			_, _ = stdout.Write(frame.Data)
+		case frame.Stream == "stderr":
+			_, _ = stderr.Write(frame.Data)
+		case frame.Stream == "stdin":
+			buf := make([]byte, frame.Size)
+			n, err := stdin.Read(buf)
+			reply := daemonFrame{Stream: "stdin", Data: buf[:n], EOF: err != nil}
+			if err := enc.Encode(reply); err != nil {
+				return true, fmt.Errorf("lost connection to dub daemon: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startTestDaemon serves delegated commands on a temporary socket.
+func startTestDaemon(t *testing.T) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	ln, err := listenDaemon(socket)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = newDaemonServer(0).serve(ctx, ln)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return socket
+}
+
+func TestDaemon_DelegatesCommandWithStdin(t *testing.T) {
+	var gotBody string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		_, _ = w.Write([]byte(`{"id": "tag_1", "name": "Launch"}`))
+	})
+
+	socket := startTestDaemon(t)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"tags", "create", "--name", "@-", "--api-url", server.URL, "-o", "json"}
+	ok, err := delegateToDaemon(context.Background(), socket, args, strings.NewReader("Launch\n"), &stdout, &stderr)
+	if !ok {
+		t.Fatal("expected command to be delegated")
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v (stderr: %s)", err, stderr.String())
+	}
+
+	if !strings.Contains(gotBody, `"name":"Launch"`) {
+		t.Errorf("expected stdin to reach the daemon, got body %s", gotBody)
+	}
+	if !strings.Contains(stdout.String(), "tag_1") {
+		t.Errorf("expected output to be streamed back, got %q", stdout.String())
+	}
+}
+
+func TestDaemon_UsageErrorExitCode(t *testing.T) {
+	socket := startTestDaemon(t)
+
+	var stdout, stderr bytes.Buffer
+	ok, err := delegateToDaemon(context.Background(), socket, []string{"links", "--bogus"}, strings.NewReader(""), &stdout, &stderr)
+	if !ok {
+		t.Fatal("expected command to be delegated")
+	}
+	if !IsUsageError(err) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "unknown flag") {
+		t.Errorf("expected cobra error on stderr, got %q", stderr.String())
+	}
+}
+
+func TestDaemon_ClientHangUpCancelsCommand(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// Reading the body lets the server notice the client hanging up
+			_, _ = io.ReadAll(r.Body)
+			arrived <- struct{}{}
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	socket := startTestDaemon(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+	args := []string{"tags", "create", "--name", "Launch", "--api-url", server.URL}
+	if ok, err := delegateToDaemon(ctx, socket, args, strings.NewReader(""), io.Discard, io.Discard); !ok || !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("expected an interrupted delegated command, got ok=%v err=%v", ok, err)
+	}
+
+	// The canceled command no longer holds the daemon
+	done := make(chan error, 1)
+	go func() {
+		_, err := delegateToDaemon(context.Background(), socket, []string{"tags", "list", "--api-url", server.URL}, strings.NewReader(""), io.Discard, io.Discard)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the daemon kept running the command after the client hung up")
+	}
+}
+
+// startFakeDaemon answers each request on a temporary socket with frames.
+func startFakeDaemon(t *testing.T, frames ...daemonFrame) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	ln, err := listenDaemon(socket)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			var req daemonRequest
+			_ = json.NewDecoder(conn).Decode(&req)
+			enc := json.NewEncoder(conn)
+			for _, f := range frames {
+				_ = enc.Encode(f)
+			}
+			_ = conn.Close()
+		}
+	}()
+	return socket
+}
+
+func TestDaemon_LostConnectionIsNotRerunLocally(t *testing.T) {
+	socket := startFakeDaemon(t)
+	ok, err := delegateToDaemon(context.Background(), socket, []string{"links", "delete", "--id", "link_1"}, strings.NewReader(""), io.Discard, io.Discard)
+	if !ok || err == nil || !strings.Contains(err.Error(), "lost connection") {
+		t.Errorf("expected a lost connection error, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestDaemon_InterruptExitCode(t *testing.T) {
+	code := 130
+	socket := startFakeDaemon(t, daemonFrame{Exit: &code})
+	ok, err := delegateToDaemon(context.Background(), socket, []string{"links", "list"}, strings.NewReader(""), io.Discard, io.Discard)
+	if !ok || !errors.Is(err, ErrInterrupted) {
+		t.Errorf("expected ErrInterrupted, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestDaemon_FallsBackWithoutDaemon(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "missing.sock")
+	ok, err := delegateToDaemon(context.Background(), socket, []string{"version"}, strings.NewReader(""), io.Discard, io.Discard)
+	if ok || err != nil {
+		t.Errorf("expected fallback to local execution, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestDaemon_Status(t *testing.T) {
+	socket := startTestDaemon(t)
+
+	status, err := queryDaemonStatus(socket)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Version != Version || time.Since(status.Started) > time.Minute {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
+
+func TestShouldDelegate(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"links", "list"}, true},
+		{[]string{"links", "list", "--no-daemon"}, false},
+		{[]string{"daemon", "status"}, false},
+		{[]string{"-w", "acme", "auth", "list"}, false},
+		{[]string{"links", "delete", "--interactive"}, false},
+		{[]string{"links", "move", "-i", "--folder-name", "x"}, false},
+		{[]string{"events", "tail", "--exec", "./hook.sh"}, false},
+		{[]string{"uninstall", "--yes"}, false},
+	}
+	for _, tt := range tests {
+		if got := shouldDelegate(tt.args); got != tt.want {
+			t.Errorf("shouldDelegate(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+
+	t.Setenv("DUB_NO_DAEMON", "1")
+	if shouldDelegate([]string{"links", "list"}) {
+		t.Error("expected DUB_NO_DAEMON to disable delegation")
+	}
+}
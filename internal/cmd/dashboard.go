@@ -0,0 +1,154 @@
+// internal/cmd/dashboard.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/browser"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// dashboardURL is the base URL of the Dub web app.
+const dashboardURL = "https://app.dub.co"
+
+// dashboardSections are the pages `dub dashboard` opens, relative to the
+// workspace.
+var dashboardSections = []struct {
+	name  string
+	path  string
+	short string
+}{
+	{"links", "/links", "Open the workspace's links"},
+	{"analytics", "/analytics", "Open the workspace's analytics"},
+	{"domains", "/settings/domains", "Open the workspace's domain settings"},
+	{"settings", "/settings", "Open the workspace settings"},
+}
+
+func newDashboardCmd() *cobra.Command {
+	var noOpen bool
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Open the Dub dashboard in the browser",
+		Long: `Open a page of the Dub dashboard for the active workspace.
+
+The workspace is the one selected with --workspace, the default set with
+dub auth switch, or the only one configured. Its name is used as the slug in
+the dashboard URL.
+
+Examples:
+  dub dashboard links
+  dub dashboard analytics -w acme
+  dub dashboard settings --no-open   # print the URL instead`,
+	}
+
+	for _, section := range dashboardSections {
+		cmd.AddCommand(&cobra.Command{
+			Use:   section.name,
+			Short: section.short,
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				slug, err := activeWorkspaceName(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				pageURL := dashboardURL + "/" + url.PathEscape(slug) + section.path
+				if !noOpen {
+					if err := openBrowser(pageURL); err == nil {
+						return nil
+					}
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), pageURL)
+				return nil
+			},
+		})
+	}
+
+	cmd.PersistentFlags().BoolVar(&noOpen, "no-open", false, "Print the URL instead of opening the browser")
+
+	return cmd
+}
+
+// openBrowser opens a URL in the default browser. Tests replace it.
+var openBrowser = browser.Open
+
+// dashboardPage returns the path of a resource's page below the workspace,
+// e.g. "/links/dub.sh/launch".
+type dashboardPage func(resource map[string]interface{}) string
+
+// linkDashboardPage is a link's page in the dashboard.
+func linkDashboardPage(link map[string]interface{}) string {
+	return "/links/" + url.PathEscape(outfmt.SafeString(link["domain"])) + "/" + url.PathEscape(outfmt.SafeString(link["key"]))
+}
+
+// domainDashboardPage is the workspace's domain settings, where new domains
+// are configured and verified.
+func domainDashboardPage(map[string]interface{}) string {
+	return "/settings/domains"
+}
+
+// openDashboard opens the dashboard page of a resource returned by a create
+// command, printing the URL instead if no browser can be started.
+func openDashboard(cmd *cobra.Command, client *api.Client, data interface{}, page dashboardPage) error {
+	resource, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("cannot open the dashboard: unexpected response")
+	}
+
+	slug, err := dashboardWorkspaceSlug(cmd.Context(), client, outfmt.SafeString(resource["workspaceId"]))
+	if err != nil {
+		return err
+	}
+
+	pageURL := dashboardURL + "/" + url.PathEscape(slug) + page(resource)
+	if err := openBrowser(pageURL); err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Open %s in your browser\n", pageURL)
+	}
+	return nil
+}
+
+// dashboardWorkspaceSlug looks up the slug of workspaceID, falling back to
+// the active workspace name when the response didn't include an ID.
+func dashboardWorkspaceSlug(ctx context.Context, client *api.Client, workspaceID string) (string, error) {
+	if workspaceID != "" {
+		var workspace struct {
+			Slug string `json:"slug"`
+		}
+		if err := getJSON(ctx, client, "/workspaces/"+url.PathEscape(workspaceID), &workspace); err == nil && workspace.Slug != "" {
+			return workspace.Slug, nil
+		}
+	}
+	return activeWorkspaceName(ctx)
+}
+
+// activeWorkspaceName returns the workspace commands run against: the
+// selected one, the default, or the only one stored. Stored names are
+// assumed to match the workspace slug.
+func activeWorkspaceName(ctx context.Context) (string, error) {
+	if ws := GetWorkspace(ctx); ws != "" {
+		return ws, nil
+	}
+	if ws, err := defaultWorkspaceGetter(); err == nil && ws != "" {
+		return ws, nil
+	}
+	// Keyring names say nothing about a key given in DUB_API_KEY
+	if store, err := storeOpener(); err == nil && os.Getenv("DUB_API_KEY") == "" {
+		if creds, err := store.List(); err == nil {
+			names := map[string]bool{}
+			for _, c := range creds {
+				names[c.Name] = true
+			}
+			if len(names) == 1 {
+				return creds[0].Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("cannot determine the workspace slug for the dashboard; pass --workspace <slug>")
+}
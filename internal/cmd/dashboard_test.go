@@ -0,0 +1,56 @@
+// internal/cmd/dashboard_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/secrets"
+)
+
+func TestDashboardCmd_OpensSection(t *testing.T) {
+	var opened string
+	origOpen := openBrowser
+	openBrowser = func(url string) error {
+		opened = url
+		return nil
+	}
+	defer func() { openBrowser = origOpen }()
+
+	cmd := newDashboardCmd()
+	cmd.SetContext(context.WithValue(context.Background(), workspaceKey, "acme"))
+	cmd.SetArgs([]string{"domains"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "https://app.dub.co/acme/settings/domains"; opened != want {
+		t.Errorf("opened %q, want %q", opened, want)
+	}
+}
+
+func TestDashboardCmd_SingleStoredWorkspace(t *testing.T) {
+	t.Setenv("DUB_API_KEY", "")
+
+	store := newMockStore()
+	_ = store.Set("acme", secrets.Credentials{Name: "acme", APIKey: "dub_admin"})
+	_ = store.Set("acme", secrets.Credentials{Name: "acme", Role: "read-only", APIKey: "dub_read"})
+
+	origOpener, origGetter := storeOpener, defaultWorkspaceGetter
+	storeOpener = func() (secrets.Store, error) { return store, nil }
+	defaultWorkspaceGetter = func() (string, error) { return "", errors.New("no default workspace configured") }
+	defer func() { storeOpener, defaultWorkspaceGetter = origOpener, origGetter }()
+
+	var out bytes.Buffer
+	cmd := newDashboardCmd()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"analytics", "--no-open"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "https://app.dub.co/acme/analytics" {
+		t.Errorf("unexpected URL %q", got)
+	}
+}
@@ -0,0 +1,125 @@
+// internal/cmd/dates.go
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateNow is the clock relative dates are resolved against.
+var dateNow = time.Now
+
+// relativeDate matches "7 days ago", "2w ago", and the like.
+var relativeDate = regexp.MustCompile(`^(\d+)\s*([a-z]+)\s+ago$`)
+
+// dateUnits maps the unit names accepted in relative dates to a function
+// stepping a time back by n of them.
+var dateUnits = map[string]func(t time.Time, n int) time.Time{
+	"minute": func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Minute) },
+	"hour":   func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Hour) },
+	"day":    func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -n) },
+	"week":   func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -7*n) },
+	"month":  func(t time.Time, n int) time.Time { return t.AddDate(0, -n, 0) },
+	"year":   func(t time.Time, n int) time.Time { return t.AddDate(-n, 0, 0) },
+}
+
+var dateUnitAliases = map[string]string{
+	"m":   "minute",
+	"min": "minute",
+	"h":   "hour",
+	"hr":  "hour",
+	"d":   "day",
+	"w":   "week",
+	"wk":  "week",
+	"mo":  "month",
+	"y":   "year",
+	"yr":  "year",
+}
+
+// parseDateFlag parses the dates accepted by --start, --end, --since, and
+// --until: an RFC 3339 timestamp, a day (2025-01-31), month (2025-01), or
+// year (2025), "now", "today", "yesterday", or a relative time such as
+// "7 days ago". Days, months, and years are in UTC and stand for their first
+// second, or with endOfDay their last.
+func parseDateFlag(value string, endOfDay bool) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	now := dateNow().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	span := func(start, next time.Time) time.Time {
+		if endOfDay {
+			return next.Add(-time.Second)
+		}
+		return start
+	}
+
+	switch s {
+	case "now":
+		return now, nil
+	case "today":
+		return span(today, today.AddDate(0, 0, 1)), nil
+	case "yesterday":
+		return span(today.AddDate(0, 0, -1), today), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return span(t, t.AddDate(0, 0, 1)), nil
+	}
+	if t, err := time.Parse("2006-01", s); err == nil {
+		return span(t, t.AddDate(0, 1, 0)), nil
+	}
+	if t, err := time.Parse("2006", s); err == nil {
+		return span(t, t.AddDate(1, 0, 0)), nil
+	}
+
+	if m := relativeDate.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		unit := m[2]
+		if alias, ok := dateUnitAliases[unit]; ok {
+			unit = alias
+		} else if _, ok := dateUnits[unit]; !ok {
+			unit = strings.TrimSuffix(unit, "s")
+			if alias, ok := dateUnitAliases[unit]; ok {
+				unit = alias
+			}
+		}
+		if back, ok := dateUnits[unit]; ok {
+			return back(now, n), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf(`expected a date such as 2025-01-31, 2025-01, yesterday, or "7 days ago", got %q`, value)
+}
+
+// setDateRange parses the values of a start and end flag into the API's
+// start and end parameters, leaving out flags that weren't set. The end
+// covers the whole day, month, or year it names.
+func setDateRange(params url.Values, startFlag, start, endFlag, end string) error {
+	if start != "" {
+		t, err := parseDateFlag(start, false)
+		if err != nil {
+			return NewUsageErrorf("%s: %v", startFlag, err)
+		}
+		params.Set("start", t.UTC().Format(time.RFC3339))
+	}
+	if end != "" {
+		t, err := parseDateFlag(end, true)
+		if err != nil {
+			return NewUsageErrorf("%s: %v", endFlag, err)
+		}
+		params.Set("end", t.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
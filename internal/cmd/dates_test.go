@@ -0,0 +1,70 @@
+// internal/cmd/dates_test.go
+package cmd
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseDateFlag_Forms(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 30, 0, 0, time.UTC)
+	dateNow = func() time.Time { return now }
+	t.Cleanup(func() { dateNow = time.Now })
+
+	tests := []struct {
+		value    string
+		endOfDay bool
+		want     string
+	}{
+		{"now", false, "2026-03-15T10:30:00Z"},
+		{"today", false, "2026-03-15T00:00:00Z"},
+		{"Yesterday", false, "2026-03-14T00:00:00Z"},
+		{"yesterday", true, "2026-03-14T23:59:59Z"},
+		{"2025-01", false, "2025-01-01T00:00:00Z"},
+		{"2025-02", true, "2025-02-28T23:59:59Z"},
+		{"2025", true, "2025-12-31T23:59:59Z"},
+		{"2025-01-31 09:15", true, "2025-01-31T09:15:00Z"},
+		{"7 days ago", false, "2026-03-08T10:30:00Z"},
+		{"7 days ago", true, "2026-03-08T10:30:00Z"},
+		{"1 day ago", false, "2026-03-14T10:30:00Z"},
+		{"2w ago", false, "2026-03-01T10:30:00Z"},
+		{"3 hours ago", false, "2026-03-15T07:30:00Z"},
+		{"1 month ago", false, "2026-02-15T10:30:00Z"},
+		{"2 yrs ago", false, "2024-03-15T10:30:00Z"},
+	}
+	for _, tt := range tests {
+		got, err := parseDateFlag(tt.value, tt.endOfDay)
+		if err != nil {
+			t.Errorf("parseDateFlag(%q) error: %v", tt.value, err)
+			continue
+		}
+		if s := got.UTC().Format(time.RFC3339); s != tt.want {
+			t.Errorf("parseDateFlag(%q, %v) = %s, want %s", tt.value, tt.endOfDay, s, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"last tuesday", "7 fortnights ago", "ago", "2025-13"} {
+		if _, err := parseDateFlag(bad, false); err == nil {
+			t.Errorf("parseDateFlag(%q): expected error", bad)
+		}
+	}
+}
+
+func TestSetDateRange(t *testing.T) {
+	params := url.Values{}
+	if err := setDateRange(params, "--start", "2025-01", "--end", "2025-01"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.Get("start") != "2025-01-01T00:00:00Z" || params.Get("end") != "2025-01-31T23:59:59Z" {
+		t.Errorf("unexpected range %v", params)
+	}
+
+	err := setDateRange(url.Values{}, "--start", "", "--end", "soon")
+	if err == nil || !IsUsageError(err) || err.Error()[:6] != "--end:" {
+		t.Errorf("expected a usage error naming --end, got %v", err)
+	}
+	if params := (url.Values{}); setDateRange(params, "--start", "", "--end", "") != nil || len(params) != 0 {
+		t.Errorf("expected unset flags to be left out, got %v", params)
+	}
+}
@@ -0,0 +1,244 @@
+// internal/cmd/dedupe.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// duplicateGroup is a set of links pointing at the same destination. Links
+// are ordered by clicks, so Links[0] is the one kept when consolidating.
+type duplicateGroup struct {
+	Destination string       `json:"destination"`
+	Links       []linkRecord `json:"links"`
+}
+
+func newLinksDedupeCmd() *cobra.Command {
+	var (
+		domain      string
+		archive     bool
+		dryRun      bool
+		output      string
+		ignoreUTM   bool
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Find links that share a destination URL",
+		Long: `Group links by normalized destination URL and report destinations with
+more than one short link.
+
+URLs are compared ignoring the scheme, host case, a leading "www.", default
+ports, trailing slashes, fragments, and query parameter order. Links whose
+UTM parameters differ are kept apart, since they attribute different
+campaigns; --ignore-utm groups them too.
+
+With --archive, every duplicate except the most-clicked link in each group is
+archived.
+
+Examples:
+  dub links dedupe --domain dub.sh
+  dub links dedupe --domain dub.sh --archive --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			params := url.Values{}
+			if domain != "" {
+				params.Set("domain", domain)
+			}
+			links, err := fetchAllLinks[linkRecord](cmd.Context(), client, params)
+			if err != nil {
+				return err
+			}
+
+			groups := findDuplicateLinks(links, ignoreUTM)
+
+			if output == "json" {
+				if err := outfmt.FormatJSON(cmd.OutOrStdout(), groups, outfmt.GetQuery(cmd.Context())); err != nil {
+					return err
+				}
+			} else if err := printDuplicateGroups(cmd, groups); err != nil {
+				return err
+			}
+
+			if !archive || len(groups) == 0 {
+				return nil
+			}
+
+			var extras []linkRecord
+			for _, g := range groups {
+				extras = append(extras, g.Links[1:]...)
+			}
+			extras, skipped := withoutProtected(cmd.Context(), extras, linkRecord.protected)
+			if skipped > 0 {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Skipping %d protected duplicate(s); pass --force to archive them too.\n", skipped)
+			}
+
+			if dryRun {
+				for _, link := range extras {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Would archive %s\n", buildShortLink(link.Domain, link.Key))
+				}
+				return nil
+			}
+
+			if err := archiveLinks(cmd, client, extras, concurrency); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Archived %d duplicate link(s).\n", len(extras))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&domain, "domain", "", "Only check links on this domain")
+	cmd.Flags().BoolVar(&archive, "archive", false, "Archive duplicates, keeping the most-clicked link in each group")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show which links would be archived without archiving them")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+	cmd.Flags().BoolVar(&ignoreUTM, "ignore-utm", false, "Treat links that differ only in utm_* parameters as duplicates")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	return cmd
+}
+
+// findDuplicateLinks groups unarchived links by normalized destination and
+// returns groups with more than one link, largest first.
+func findDuplicateLinks(links []linkRecord, ignoreUTM bool) []duplicateGroup {
+	byDest := map[string][]linkRecord{}
+	var order []string
+	for _, link := range links {
+		if link.Archived || link.URL == "" {
+			continue
+		}
+		dest := normalizeDestination(link.URL, ignoreUTM)
+		if _, ok := byDest[dest]; !ok {
+			order = append(order, dest)
+		}
+		byDest[dest] = append(byDest[dest], link)
+	}
+
+	var groups []duplicateGroup
+	for _, dest := range order {
+		group := byDest[dest]
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Clicks > group[j].Clicks })
+		groups = append(groups, duplicateGroup{Destination: dest, Links: group})
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return len(groups[i].Links) > len(groups[j].Links) })
+
+	return groups
+}
+
+// normalizeDestination canonicalizes a destination URL for comparison,
+// dropping utm_* parameters if ignoreUTM is set.
+func normalizeDestination(raw string, ignoreUTM bool) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(raw)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if port != "" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for key := range query {
+		if ignoreUTM && strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+
+	normalized := host + strings.TrimSuffix(u.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		normalized += "?" + encoded
+	}
+	return normalized
+}
+
+// printDuplicateGroups writes one table row per link, marking the link kept
+// in each group.
+func printDuplicateGroups(cmd *cobra.Command, groups []duplicateGroup) error {
+	if len(groups) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No duplicate destinations found.")
+		return nil
+	}
+
+	columns := []outfmt.Column{
+		{Name: "Destination", Width: 40, Align: outfmt.AlignLeft},
+		{Name: "Short Link", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Clicks", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Keep", Width: 0, Align: outfmt.AlignLeft},
+	}
+
+	var rows [][]string
+	duplicates := 0
+	for _, g := range groups {
+		for i, link := range g.Links {
+			dest, keep := "", ""
+			if i == 0 {
+				dest, keep = g.Destination, "✓"
+			} else {
+				duplicates++
+			}
+			rows = append(rows, []string{dest, buildShortLink(link.Domain, link.Key), formatClicks(link.Clicks), keep})
+		}
+	}
+
+	if err := outfmt.FormatTable(cmd.OutOrStdout(), columns, rows); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\n%d destination(s) with %d duplicate link(s).\n", len(groups), duplicates)
+	return nil
+}
+
+// archiveLinks archives links, up to concurrency at a time, reporting every
+// failure. Archived links are recorded in the undo history.
+func archiveLinks(cmd *cobra.Command, client *api.Client, links []linkRecord, concurrency int) error {
+	errs := batch.Run(cmd.Context(), len(links), concurrency, func(ctx context.Context, i int) error {
+		path := "/links/" + url.PathEscape(links[i].ID)
+		if err := discardResponse(client.Patch(ctx, path, map[string]interface{}{"archived": true})); err != nil {
+			return fmt.Errorf("%s: %w", buildShortLink(links[i].Domain, links[i].Key), err)
+		}
+		return nil
+	})
+
+	var failed []error
+	var archived []config.HistoryEntry
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		archived = append(archived, newHistoryEntry("archive", "link", links[i].ID, buildShortLink(links[i].Domain, links[i].Key), nil))
+	}
+	recordHistory(cmd, archived...)
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to archive %d of %d link(s):\n%w", len(failed), len(links), errors.Join(failed...))
+	}
+	return nil
+}
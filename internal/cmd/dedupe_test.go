@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDestination(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"https://Example.com/path/", "https://www.example.com/path"},
+		{"https://example.com:443/a?y=2&x=1", "https://example.com/a?x=1&y=2"},
+		{"https://example.com/a?utm_source=x#top", "https://example.com/a?utm_source=x"},
+		{"http://example.com/", "https://example.com"},
+	}
+	for _, tt := range tests {
+		if normalizeDestination(tt.a, false) != normalizeDestination(tt.b, false) {
+			t.Errorf("expected %q and %q to normalize equally, got %q and %q", tt.a, tt.b, normalizeDestination(tt.a, false), normalizeDestination(tt.b, false))
+		}
+	}
+
+	if normalizeDestination("https://example.com/a", false) == normalizeDestination("https://example.com/b", false) {
+		t.Error("expected different paths to stay distinct")
+	}
+
+	spring, summer := "https://example.com/a?utm_campaign=spring", "https://example.com/a?utm_campaign=summer"
+	if normalizeDestination(spring, false) == normalizeDestination(summer, false) {
+		t.Error("expected different campaigns to stay distinct")
+	}
+	if normalizeDestination(spring, true) != normalizeDestination("https://example.com/a", true) {
+		t.Error("expected --ignore-utm to drop UTM parameters")
+	}
+}
+
+func TestFindDuplicateLinks(t *testing.T) {
+	links := []linkRecord{
+		{ID: "1", URL: "https://example.com/a", Clicks: 5},
+		{ID: "2", URL: "https://www.example.com/a/", Clicks: 50},
+		{ID: "3", URL: "https://example.com/b", Clicks: 1},
+		{ID: "4", URL: "https://example.com/a?utm_source=x", Clicks: 0, Archived: true},
+		{ID: "5", URL: "https://other.com", Clicks: 1},
+		{ID: "6", URL: "https://other.com/", Clicks: 2},
+		{ID: "7", URL: "https://example.com/a", Clicks: 9},
+	}
+
+	groups := findDuplicateLinks(links, false)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+
+	var ids []string
+	for _, l := range groups[0].Links {
+		ids = append(ids, l.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"2", "7", "1"}) {
+		t.Errorf("expected largest group ordered by clicks, got %v", ids)
+	}
+	if groups[1].Links[0].ID != "6" {
+		t.Errorf("expected most-clicked link kept, got %s", groups[1].Links[0].ID)
+	}
+}
+
+func TestLinksDedupeCmd_Archive(t *testing.T) {
+	var (
+		archived []string
+		domain   string
+	)
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			archived = append(archived, r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		domain = r.URL.Query().Get("domain")
+		_, _ = w.Write([]byte(`[
+			{"id": "keep", "domain": "dub.sh", "key": "a", "url": "https://example.com", "clicks": 10},
+			{"id": "dup", "domain": "dub.sh", "key": "b", "url": "https://example.com/", "clicks": 1}
+		]`))
+	})
+
+	cmd := newLinksDedupeCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--domain", "dub.sh", "--archive"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if domain != "dub.sh" {
+		t.Errorf("expected domain filter to be sent, got %q", domain)
+	}
+	if !reflect.DeepEqual(archived, []string{"/links/dup"}) {
+		t.Errorf("expected only the duplicate to be archived, got %v", archived)
+	}
+	if !strings.Contains(out.String(), "1 destination(s) with 1 duplicate link(s).") {
+		t.Errorf("expected summary, got:\n%s", out.String())
+	}
+}
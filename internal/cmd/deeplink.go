@@ -0,0 +1,174 @@
+// internal/cmd/deeplink.go
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// deepLinkFields are the destinations of a link and the flags setting them.
+var deepLinkFields = []struct{ field, flag string }{
+	{"url", "--url"},
+	{"ios", "--ios"},
+	{"android", "--android"},
+}
+
+// uriScheme is the syntax of a URI scheme (RFC 3986, section 3.1).
+var uriScheme = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*$`)
+
+var (
+	iosUserAgent     = regexp.MustCompile(`(?i)iphone|ipad|ipod`)
+	androidUserAgent = regexp.MustCompile(`(?i)android`)
+)
+
+// previewUserAgents are the devices shown by --preview without --user-agent.
+var previewUserAgents = []string{
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+}
+
+// isWebURL reports whether dest is an http(s) URL rather than an app link.
+func isWebURL(dest string) bool {
+	scheme, _, _ := strings.Cut(dest, ":")
+	return strings.EqualFold(scheme, "http") || strings.EqualFold(scheme, "https")
+}
+
+// validateDestination checks the syntax of a web URL or app link.
+func validateDestination(dest string) error {
+	scheme, rest, ok := strings.Cut(dest, ":")
+	if !ok || scheme == "" {
+		return fmt.Errorf("missing scheme, e.g. https:// or myapp://")
+	}
+	if !uriScheme.MatchString(scheme) {
+		return fmt.Errorf("invalid scheme %q: it must start with a letter and contain only letters, digits, +, -, and .", scheme)
+	}
+	if strings.ContainsAny(dest, " \t\r\n") {
+		return fmt.Errorf("contains whitespace; encode it as %%20")
+	}
+	if isWebURL(dest) {
+		if u, err := url.Parse(dest); err != nil || u.Host == "" {
+			return fmt.Errorf("not a valid web URL")
+		}
+		return nil
+	}
+	if strings.Trim(rest, "/") == "" {
+		return fmt.Errorf("app link has nothing after %s:", scheme)
+	}
+	if _, err := url.Parse(dest); err != nil {
+		return fmt.Errorf("not a valid app link: %v", err)
+	}
+	return nil
+}
+
+// checkDeepLinks validates the destinations in body and returns warnings
+// about app links that leave some visitors without a working fallback.
+func checkDeepLinks(body map[string]interface{}) ([]string, error) {
+	var warnings []string
+	for _, f := range deepLinkFields {
+		dest, _ := body[f.field].(string)
+		if dest == "" {
+			continue
+		}
+		if err := validateDestination(dest); err != nil {
+			return nil, NewUsageErrorf("%s %q: %v", f.flag, dest, err)
+		}
+		if isWebURL(dest) {
+			continue
+		}
+		switch {
+		case f.field == "url":
+			warnings = append(warnings, fmt.Sprintf("--url %q is an app link, so desktop browsers and devices without the app have no web fallback; "+
+				"use a web page for --url and put the app link in --ios or --android", dest))
+		case f.field == "android" && strings.HasPrefix(strings.ToLower(dest), "intent:"):
+			if !strings.Contains(dest, "S.browser_fallback_url=") {
+				warnings = append(warnings, fmt.Sprintf("--android %q has no S.browser_fallback_url, so Android devices without the app show an error", dest))
+			}
+		default:
+			warnings = append(warnings, fmt.Sprintf("%s %q uses a custom scheme, so devices without the app show an error; "+
+				"a universal link or app link (https) falls back to the web", f.flag, dest))
+		}
+	}
+	return warnings, nil
+}
+
+// warnDeepLinks validates body's destinations, printing any warnings.
+func warnDeepLinks(cmd *cobra.Command, body map[string]interface{}) error {
+	warnings, err := checkDeepLinks(body)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning("warning: "+w))
+	}
+	return nil
+}
+
+// deepLinkResolution is where a link sends one user agent.
+type deepLinkResolution struct {
+	UserAgent   string `json:"userAgent"`
+	Device      string `json:"device"`
+	Field       string `json:"field"`
+	Destination string `json:"destination"`
+}
+
+// resolveDeepLink returns where a link with body's destinations sends ua,
+// following the link's iOS and Android targeting.
+func resolveDeepLink(body map[string]interface{}, ua string) deepLinkResolution {
+	r := deepLinkResolution{UserAgent: ua, Device: "Other", Field: "url"}
+	switch {
+	case iosUserAgent.MatchString(ua):
+		r.Device = "iOS"
+		if outfmt.SafeString(body["ios"]) != "" {
+			r.Field = "ios"
+		}
+	case androidUserAgent.MatchString(ua):
+		r.Device = "Android"
+		if outfmt.SafeString(body["android"]) != "" {
+			r.Field = "android"
+		}
+	}
+	r.Destination = outfmt.SafeString(body[r.Field])
+	return r
+}
+
+// writeDeepLinkPreview shows where the link would send each user agent.
+func writeDeepLinkPreview(cmd *cobra.Command, body map[string]interface{}, userAgents []string) error {
+	if len(userAgents) == 0 {
+		userAgents = previewUserAgents
+	}
+	resolutions := make([]deepLinkResolution, len(userAgents))
+	for i, ua := range userAgents {
+		resolutions[i] = resolveDeepLink(body, ua)
+	}
+
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		return outfmt.FormatJSON(w, resolutions, outfmt.GetQuery(cmd.Context()))
+	}
+	columns := []outfmt.Column{
+		{Name: "Device"},
+		{Name: "User Agent", Width: 40},
+		{Name: "Destination", Width: 50},
+		{Name: "From"},
+	}
+	rows := make([][]string, len(resolutions))
+	for i, r := range resolutions {
+		rows[i] = []string{r.Device, r.UserAgent, r.Destination, r.Field}
+	}
+	if err := outfmt.FormatTable(w, columns, rows); err != nil {
+		return err
+	}
+	if geo, _ := body["geo"].(map[string]string); len(geo) > 0 {
+		_, _ = fmt.Fprintln(w, "\nCountry targeting (--geo) also applies, depending on where the visitor is.")
+	}
+	_, _ = fmt.Fprintln(w, "\nNothing was created; run again without --preview to create the link.")
+	return nil
+}
@@ -0,0 +1,80 @@
+// internal/cmd/deeplink_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckDeepLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     map[string]interface{}
+		wantErr  string
+		warnings int
+	}{
+		{"web only", map[string]interface{}{"url": "https://example.com"}, "", 0},
+		{"universal links", map[string]interface{}{"url": "https://example.com", "ios": "https://app.example.com/x"}, "", 0},
+		{"custom scheme", map[string]interface{}{"url": "https://example.com", "ios": "myapp://open/42"}, "", 1},
+		{"app link as url", map[string]interface{}{"url": "myapp://open"}, "", 1},
+		{"intent with fallback", map[string]interface{}{"url": "https://example.com", "android": "intent://open#Intent;scheme=myapp;S.browser_fallback_url=https%3A%2F%2Fexample.com;end"}, "", 0},
+		{"intent without fallback", map[string]interface{}{"url": "https://example.com", "android": "intent://open#Intent;scheme=myapp;end"}, "", 1},
+		{"missing scheme", map[string]interface{}{"url": "https://example.com", "ios": "open/42"}, "--ios", 0},
+		{"invalid scheme", map[string]interface{}{"url": "https://example.com", "android": "my_app://open"}, "invalid scheme", 0},
+		{"empty app link", map[string]interface{}{"url": "https://example.com", "ios": "myapp://"}, "nothing after", 0},
+		{"web url without host", map[string]interface{}{"url": "https:///path"}, "--url", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := checkDeepLinks(tt.body)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !IsUsageError(err) {
+					t.Fatalf("expected a usage error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(warnings) != tt.warnings {
+				t.Errorf("expected %d warning(s), got %v", tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestLinksCreatePreview(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"links", "create", "--api-url", server.URL, "--preview", "-o", "json",
+		"--url", "https://example.com/app", "--ios", "myapp://open",
+		"--user-agent", "Mozilla/5.0 (iPad; CPU OS 17_5 like Mac OS X)",
+		"--user-agent", "Mozilla/5.0 (Linux; Android 14; Pixel 8)"}
+	if err := execute(context.Background(), args, nil, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	var got []deepLinkResolution
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("expected JSON, got %q: %v", stdout.String(), err)
+	}
+	if len(got) != 2 || got[0].Device != "iOS" || got[0].Destination != "myapp://open" ||
+		got[1].Device != "Android" || got[1].Field != "url" || got[1].Destination != "https://example.com/app" {
+		t.Errorf("unexpected resolutions %+v", got)
+	}
+	if !strings.Contains(stderr.String(), "--ios \"myapp://open\" uses a custom scheme") {
+		t.Errorf("expected a fallback warning, got %q", stderr.String())
+	}
+
+	err := execute(context.Background(), []string{"links", "create", "--api-url", server.URL,
+		"--url", "https://example.com", "--android", "my app://x"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected a usage error for an invalid app link, got %v", err)
+	}
+}
@@ -0,0 +1,419 @@
+// internal/cmd/dns.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/dnsprovider"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// Records Dub expects custom domains to point at.
+const (
+	dubCNAMETarget = "cname.dub.co"
+	dubApexIP      = "76.76.21.21"
+)
+
+// dnsRecord is a record to add at the DNS provider. Name is relative to the
+// zone, with "@" for the zone apex.
+type dnsRecord struct {
+	Domain string `json:"domain"`
+	Zone   string `json:"zone"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+}
+
+// dnsProvider prints setup instructions or Terraform for one DNS host.
+type dnsProvider struct {
+	name         string
+	instructions func(w io.Writer, rec dnsRecord, zoneID string)
+	terraform    func(w io.Writer, rec dnsRecord, zoneID string)
+}
+
+var dnsProviders = []dnsProvider{
+	{"cloudflare", cloudflareInstructions, cloudflareTerraform},
+	{"route53", route53Instructions, route53Terraform},
+	{"namecheap", namecheapInstructions, namecheapTerraform},
+}
+
+// twoLevelSuffixes are public suffixes with two labels, so that the zone of
+// go.acme.co.uk is acme.co.uk rather than co.uk.
+var twoLevelSuffixes = []string{
+	"co.uk", "org.uk", "ac.uk", "com.au", "net.au", "org.au", "co.nz", "co.jp",
+	"co.in", "co.za", "com.br", "com.mx", "com.sg", "com.tr", "com.cn", "com.hk",
+}
+
+func newDomainsDNSCmd() *cobra.Command {
+	var (
+		provider  string
+		zone      string
+		zoneID    string
+		terraform bool
+	)
+
+	names := make([]string, len(dnsProviders))
+	for i, p := range dnsProviders {
+		names[i] = p.name
+	}
+
+	cmd := &cobra.Command{
+		Use:   "dns <domain>",
+		Short: "Show the DNS records to add for a custom domain",
+		Long: `Print the exact DNS record a custom domain needs, in the terms of your DNS
+provider. Subdomains get a CNAME to ` + dubCNAMETarget + ` and apex domains an A record
+to ` + dubApexIP + `.
+
+With --terraform, a ready-to-apply Terraform resource is printed instead.
+For Route 53, the instructions include an aws route53 command.
+
+The zone is guessed from the domain (go.acme.com is in acme.com); set
+--zone when it differs.
+
+Examples:
+  dub domains dns go.acme.com --provider cloudflare
+  dub domains dns acme.com --provider namecheap
+  dub domains dns go.acme.com --provider route53 --zone-id Z0123456789 --terraform`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			idx := slices.IndexFunc(dnsProviders, func(p dnsProvider) bool { return p.name == provider })
+			if idx < 0 {
+				return NewUsageErrorf("--provider must be one of: %s", strings.Join(names, ", "))
+			}
+
+			rec, err := dubDNSRecord(args[0], zone)
+			if err != nil {
+				return NewUsageErrorf("%v", err)
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), rec, outfmt.GetQuery(cmd.Context()))
+			}
+			if terraform {
+				dnsProviders[idx].terraform(cmd.OutOrStdout(), rec, zoneID)
+			} else {
+				dnsProviders[idx].instructions(cmd.OutOrStdout(), rec, zoneID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&provider, "provider", "", "DNS provider: "+strings.Join(names, "|")+" (required)")
+	cmd.Flags().StringVar(&zone, "zone", "", "DNS zone the domain belongs to (default: guessed from the domain)")
+	cmd.Flags().StringVar(&zoneID, "zone-id", "", "Zone ID to fill in for Cloudflare and Route 53")
+	cmd.Flags().BoolVar(&terraform, "terraform", false, "Print a Terraform resource instead of instructions")
+	_ = cmd.MarkFlagRequired("provider")
+
+	return cmd
+}
+
+// dubDNSRecord returns the record domain needs to point at Dub. zone is
+// guessed when empty.
+func dubDNSRecord(domain, zone string) (dnsRecord, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if !strings.Contains(domain, ".") || strings.ContainsAny(domain, "/: ") {
+		return dnsRecord{}, fmt.Errorf("%q is not a domain name", domain)
+	}
+
+	zone = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(zone)), ".")
+	if zone == "" {
+		zone = guessDNSZone(domain)
+	}
+	if domain != zone && !strings.HasSuffix(domain, "."+zone) {
+		return dnsRecord{}, fmt.Errorf("%s is not in zone %s", domain, zone)
+	}
+
+	if domain == zone {
+		return dnsRecord{Domain: domain, Zone: zone, Type: "A", Name: "@", Value: dubApexIP}, nil
+	}
+	return dnsRecord{Domain: domain, Zone: zone, Type: "CNAME", Name: strings.TrimSuffix(domain, "."+zone), Value: dubCNAMETarget}, nil
+}
+
+// guessDNSZone returns the registrable domain of domain, e.g. acme.com for
+// go.acme.com.
+func guessDNSZone(domain string) string {
+	labels := strings.Split(domain, ".")
+	n := 2
+	if len(labels) > 2 && slices.Contains(twoLevelSuffixes, strings.Join(labels[len(labels)-2:], ".")) {
+		n = 3
+	}
+	if len(labels) <= n {
+		return domain
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
+
+// terraformName turns a domain into a Terraform resource name.
+func terraformName(domain string) string {
+	return "dub_" + strings.NewReplacer(".", "_", "-", "_").Replace(domain)
+}
+
+// zoneIDOr returns zoneID quoted, or the Terraform variable to declare.
+func zoneIDOr(zoneID, variable string) string {
+	if zoneID != "" {
+		return strconv.Quote(zoneID)
+	}
+	return "var." + variable
+}
+
+func cloudflareInstructions(w io.Writer, rec dnsRecord, _ string) {
+	_, _ = fmt.Fprintf(w, "In the Cloudflare dashboard, open %s > DNS > Records and add:\n\n", rec.Zone)
+	_, _ = fmt.Fprintf(w, "  Type:          %s\n", rec.Type)
+	_, _ = fmt.Fprintf(w, "  Name:          %s\n", rec.Name)
+	if rec.Type == "A" {
+		_, _ = fmt.Fprintf(w, "  IPv4 address:  %s\n", rec.Value)
+	} else {
+		_, _ = fmt.Fprintf(w, "  Target:        %s\n", rec.Value)
+	}
+	_, _ = fmt.Fprintln(w, "  Proxy status:  DNS only (grey cloud)")
+	_, _ = fmt.Fprintln(w, "  TTL:           Auto")
+	_, _ = fmt.Fprintln(w, "\nThe record must not be proxied, or Dub cannot issue a certificate for it.")
+}
+
+func cloudflareTerraform(w io.Writer, rec dnsRecord, zoneID string) {
+	_, _ = fmt.Fprintf(w, `resource "cloudflare_record" %q {
+  zone_id = %s
+  name    = %q
+  type    = %q
+  content = %q
+  proxied = false
+  ttl     = 1
+}
+`, terraformName(rec.Domain), zoneIDOr(zoneID, "cloudflare_zone_id"), rec.Name, rec.Type, rec.Value)
+}
+
+func route53Instructions(w io.Writer, rec dnsRecord, zoneID string) {
+	_, _ = fmt.Fprintf(w, "In the Route 53 console, open the hosted zone for %s and create a record:\n\n", rec.Zone)
+	_, _ = fmt.Fprintf(w, "  Record name:   %s\n", strings.TrimPrefix(rec.Name, "@"))
+	_, _ = fmt.Fprintf(w, "  Record type:   %s\n", rec.Type)
+	_, _ = fmt.Fprintf(w, "  Value:         %s\n", rec.Value)
+	_, _ = fmt.Fprintf(w, "  TTL (seconds): %d\n", dnsprovider.Route53TTL)
+	_, _ = fmt.Fprintln(w, "\nOr with the AWS CLI:")
+	_, _ = fmt.Fprintln(w)
+
+	if zoneID == "" {
+		zoneID = "<hosted-zone-id>"
+	}
+	batch, _ := json.Marshal(map[string]interface{}{
+		"Changes": []interface{}{map[string]interface{}{
+			"Action": "UPSERT",
+			"ResourceRecordSet": map[string]interface{}{
+				"Name":            rec.Domain,
+				"Type":            rec.Type,
+				"TTL":             dnsprovider.Route53TTL,
+				"ResourceRecords": []interface{}{map[string]string{"Value": rec.Value}},
+			},
+		}},
+	})
+	_, _ = fmt.Fprintf(w, "  aws route53 change-resource-record-sets --hosted-zone-id %s \\\n    --change-batch '%s'\n", zoneID, batch)
+}
+
+func route53Terraform(w io.Writer, rec dnsRecord, zoneID string) {
+	_, _ = fmt.Fprintf(w, `resource "aws_route53_record" %q {
+  zone_id = %s
+  name    = %q
+  type    = %q
+  ttl     = %d
+  records = [%q]
+}
+`, terraformName(rec.Domain), zoneIDOr(zoneID, "route53_zone_id"), rec.Domain, rec.Type, dnsprovider.Route53TTL, rec.Value)
+}
+
+func namecheapInstructions(w io.Writer, rec dnsRecord, _ string) {
+	_, _ = fmt.Fprintf(w, "In Namecheap, open Domain List > %s > Advanced DNS and add a new record:\n\n", rec.Zone)
+	_, _ = fmt.Fprintf(w, "  Type:   %s Record\n", rec.Type)
+	_, _ = fmt.Fprintf(w, "  Host:   %s\n", rec.Name)
+	_, _ = fmt.Fprintf(w, "  Value:  %s\n", namecheapValue(rec))
+	_, _ = fmt.Fprintln(w, "  TTL:    Automatic")
+	_, _ = fmt.Fprintln(w, "\nRemove any parking-page URL Redirect record for the same host first.")
+}
+
+func namecheapTerraform(w io.Writer, rec dnsRecord, _ string) {
+	_, _ = fmt.Fprintf(w, `resource "namecheap_domain_records" %q {
+  domain = %q
+  mode   = "MERGE"
+
+  record {
+    hostname = %q
+    type     = %q
+    address  = %q
+  }
+}
+`, terraformName(rec.Domain), rec.Zone, rec.Name, rec.Type, namecheapValue(rec))
+}
+
+// namecheapValue is the record value as Namecheap expects it, with CNAME
+// targets fully qualified.
+func namecheapValue(rec dnsRecord) string {
+	if rec.Type == "CNAME" {
+		return rec.Value + "."
+	}
+	return rec.Value
+}
+
+// dnsProviderFromEnv configures a DNS provider API client. Tests replace it.
+var dnsProviderFromEnv = dnsprovider.FromEnv
+
+// domainPollInterval is how often domains setup and domains check --wait
+// check verification. Tests shorten it.
+var domainPollInterval = 10 * time.Second
+
+// domainSetupResult is the outcome of domains setup.
+type domainSetupResult struct {
+	Record   dnsRecord `json:"record"`
+	Provider string    `json:"provider"`
+	Result   string    `json:"result"`
+	Verified bool      `json:"verified"`
+	Status   string    `json:"status,omitempty"`
+}
+
+func newDomainsSetupCmd() *cobra.Command {
+	var (
+		provider  string
+		zone      string
+		zoneID    string
+		apply     bool
+		overwrite bool
+		noWait    bool
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "setup <domain>",
+		Short: "Create a domain's DNS record at your provider and wait for verification",
+		Long: `Create the DNS record a custom domain needs through your DNS provider's API,
+then poll Dub until the domain is verified.
+
+Without --apply, the record is only shown. Credentials are read from the
+environment:
+  cloudflare  CLOUDFLARE_API_TOKEN (Zone:DNS:Edit)
+  route53     AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN
+
+An existing record of the same name and type that points elsewhere is left
+alone, and setup fails, unless --overwrite is given.
+Add the domain to Dub first with dub domains create.
+
+Examples:
+  dub domains setup go.acme.com --provider cloudflare
+  dub domains setup go.acme.com --provider cloudflare --apply
+  dub domains setup go.acme.com --provider route53 --apply --timeout 30m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(dnsprovider.Supported, provider) {
+				return NewUsageErrorf("--provider must be one of: %s", strings.Join(dnsprovider.Supported, ", "))
+			}
+			rec, err := dubDNSRecord(args[0], zone)
+			if err != nil {
+				return NewUsageErrorf("%v", err)
+			}
+
+			if !apply {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Would create %s %s -> %s at %s.\nRun again with --apply to create it.\n", rec.Type, rec.Domain, rec.Value, provider)
+				return nil
+			}
+
+			p, err := dnsProviderFromEnv(provider, zoneID)
+			if err != nil {
+				return err
+			}
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			result, err := p.Apply(cmd.Context(), dnsprovider.Record{Zone: rec.Zone, Name: rec.Domain, Type: rec.Type, Value: rec.Value, Overwrite: overwrite})
+			var conflict *dnsprovider.ConflictError
+			if errors.As(err, &conflict) {
+				return fmt.Errorf("%w; pass --overwrite to point it at %s instead", err, rec.Value)
+			}
+			if err != nil {
+				return err
+			}
+			out := domainSetupResult{Record: rec, Provider: provider, Result: string(result)}
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s %s %s -> %s in %s.\n", setupVerb(result), rec.Type, rec.Domain, rec.Value, p.Name())
+
+			if !noWait {
+				out.Verified, out.Status, err = waitForDomainVerification(cmd, client, rec.Domain, timeout)
+				if err != nil {
+					return err
+				}
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), out, outfmt.GetQuery(cmd.Context()))
+			}
+			if out.Verified {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s is verified.\n", rec.Domain)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&provider, "provider", "", "DNS provider: "+strings.Join(dnsprovider.Supported, "|")+" (required)")
+	cmd.Flags().StringVar(&zone, "zone", "", "DNS zone the domain belongs to (default: guessed from the domain)")
+	cmd.Flags().StringVar(&zoneID, "zone-id", "", "Provider zone ID (default: looked up from the zone name)")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Create the record (otherwise it is only shown)")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Replace a record of the same name and type that points elsewhere")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false, "Don't wait for Dub to verify the domain")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for verification")
+	_ = cmd.MarkFlagRequired("provider")
+
+	return cmd
+}
+
+func setupVerb(r dnsprovider.Result) string {
+	switch r {
+	case dnsprovider.Created:
+		return "Created"
+	case dnsprovider.Updated:
+		return "Updated"
+	default:
+		return "Already have"
+	}
+}
+
+// waitForDomainVerification polls Dub's status endpoint for slug until it
+// reports a valid configuration or timeout passes.
+func waitForDomainVerification(cmd *cobra.Command, client *api.Client, slug string, timeout time.Duration) (bool, string, error) {
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+
+	lastStatus := ""
+	for {
+		var status struct {
+			Status   string `json:"status"`
+			Verified bool   `json:"verified"`
+		}
+		if err := getJSON(ctx, client, "/domains/"+url.PathEscape(slug)+"/status", &status); err != nil && ctx.Err() == nil {
+			return false, "", fmt.Errorf("failed to check domain status: %w", err)
+		}
+		if status.Verified || strings.EqualFold(status.Status, "Valid Configuration") {
+			return true, status.Status, nil
+		}
+		if status.Status != "" && status.Status != lastStatus {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Waiting for Dub to verify %s (%s)...\n", slug, status.Status)
+			lastStatus = status.Status
+		}
+
+		select {
+		case <-ctx.Done():
+			if cmd.Context().Err() != nil {
+				return false, lastStatus, cmd.Context().Err()
+			}
+			return false, lastStatus, fmt.Errorf("%s was not verified within %s; DNS changes can take longer to propagate. Check later with: dub domains check --slug %s", slug, timeout, slug)
+		case <-time.After(domainPollInterval):
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// internal/cmd/dns_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/dnsprovider"
+)
+
+func TestDubDNSRecord(t *testing.T) {
+	tests := []struct {
+		domain, zone string
+		want         dnsRecord
+	}{
+		{"go.acme.com", "", dnsRecord{Domain: "go.acme.com", Zone: "acme.com", Type: "CNAME", Name: "go", Value: "cname.dub.co"}},
+		{"Acme.com.", "", dnsRecord{Domain: "acme.com", Zone: "acme.com", Type: "A", Name: "@", Value: "76.76.21.21"}},
+		{"go.acme.co.uk", "", dnsRecord{Domain: "go.acme.co.uk", Zone: "acme.co.uk", Type: "CNAME", Name: "go", Value: "cname.dub.co"}},
+		{"a.b.acme.com", "b.acme.com", dnsRecord{Domain: "a.b.acme.com", Zone: "b.acme.com", Type: "CNAME", Name: "a", Value: "cname.dub.co"}},
+	}
+	for _, tt := range tests {
+		got, err := dubDNSRecord(tt.domain, tt.zone)
+		if err != nil {
+			t.Errorf("dubDNSRecord(%q, %q): unexpected error: %v", tt.domain, tt.zone, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("dubDNSRecord(%q, %q) = %+v, want %+v", tt.domain, tt.zone, got, tt.want)
+		}
+	}
+
+	for _, bad := range [][2]string{{"localhost", ""}, {"https://go.acme.com", ""}, {"go.acme.com", "other.com"}} {
+		if _, err := dubDNSRecord(bad[0], bad[1]); err == nil {
+			t.Errorf("dubDNSRecord(%q, %q): expected error", bad[0], bad[1])
+		}
+	}
+}
+
+func TestDomainsDNSCmd(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"go.acme.com", "--provider", "cloudflare"}, []string{"Target:        cname.dub.co", "DNS only"}},
+		{[]string{"go.acme.com", "--provider", "cloudflare", "--terraform"}, []string{`resource "cloudflare_record" "dub_go_acme_com"`, "zone_id = var.cloudflare_zone_id", "proxied = false"}},
+		{[]string{"go.acme.com", "--provider", "route53", "--zone-id", "Z123"}, []string{"--hosted-zone-id Z123", `"Name":"go.acme.com"`}},
+		{[]string{"go.acme.com", "--provider", "route53", "--zone-id", "Z123", "--terraform"}, []string{`zone_id = "Z123"`, `records = ["cname.dub.co"]`}},
+		{[]string{"acme.com", "--provider", "namecheap"}, []string{"A Record", "Host:   @", "76.76.21.21"}},
+		{[]string{"go.acme.com", "--provider", "namecheap", "--terraform"}, []string{`domain = "acme.com"`, `address  = "cname.dub.co."`}},
+	}
+	for _, tt := range tests {
+		cmd := newDomainsDNSCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetArgs(tt.args)
+		if err := cmd.Execute(); err != nil {
+			t.Errorf("%v: unexpected error: %v", tt.args, err)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("%v: expected output to contain %q, got:\n%s", tt.args, want, out.String())
+			}
+		}
+	}
+
+	cmd := newDomainsDNSCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"go.acme.com", "--provider", "godaddy"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--provider must be one of") {
+		t.Errorf("expected unknown provider error, got %v", err)
+	}
+}
+
+type fakeDNSProvider struct {
+	applied []dnsprovider.Record
+}
+
+func (p *fakeDNSProvider) Name() string { return "Fake DNS" }
+
+func (p *fakeDNSProvider) Apply(_ context.Context, rec dnsprovider.Record) (dnsprovider.Result, error) {
+	p.applied = append(p.applied, rec)
+	return dnsprovider.Created, nil
+}
+
+func TestDomainsSetupCmd_AppliesAndWaits(t *testing.T) {
+	fake := &fakeDNSProvider{}
+	origProvider, origInterval := dnsProviderFromEnv, domainPollInterval
+	dnsProviderFromEnv = func(name, zoneID string) (dnsprovider.Provider, error) { return fake, nil }
+	domainPollInterval = time.Millisecond
+	t.Cleanup(func() { dnsProviderFromEnv, domainPollInterval = origProvider, origInterval })
+
+	var checks atomic.Int32
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domains/go.acme.com/status" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		if checks.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"status": "Pending Verification"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "Valid Configuration"}`))
+	})
+
+	cmd := newDomainsSetupCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	var out, stderr bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"go.acme.com", "--provider", "cloudflare", "--apply"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []dnsprovider.Record{{Zone: "acme.com", Name: "go.acme.com", Type: "CNAME", Value: "cname.dub.co"}}
+	if !reflect.DeepEqual(fake.applied, want) {
+		t.Errorf("expected %v applied, got %v", want, fake.applied)
+	}
+	if checks.Load() != 3 {
+		t.Errorf("expected polling until verified, got %d checks", checks.Load())
+	}
+	if !strings.Contains(stderr.String(), "Created CNAME go.acme.com -> cname.dub.co in Fake DNS") ||
+		!strings.Contains(stderr.String(), "Pending Verification") {
+		t.Errorf("unexpected progress output: %s", stderr.String())
+	}
+	if out.String() != "go.acme.com is verified.\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestDomainsSetupCmd_RequiresApply(t *testing.T) {
+	cmd := newDomainsSetupCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"acme.com", "--provider", "route53"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Would create A acme.com -> 76.76.21.21") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
@@ -3,14 +3,17 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/notify"
 	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 )
 
@@ -27,6 +30,10 @@ func newDomainsCmd() *cobra.Command {
 	cmd.AddCommand(newDomainsDeleteCmd())
 	cmd.AddCommand(newDomainsRegisterCmd())
 	cmd.AddCommand(newDomainsCheckCmd())
+	cmd.AddCommand(newDomainsDefaultsCmd())
+	cmd.AddCommand(newDomainsRenewCmd())
+	cmd.AddCommand(newDomainsDNSCmd())
+	cmd.AddCommand(newDomainsSetupCmd())
 
 	return cmd
 }
@@ -37,6 +44,7 @@ func newDomainsCreateCmd() *cobra.Command {
 		placeholder string
 		expiredURL  string
 		archived    bool
+		open        bool
 	)
 
 	cmd := &cobra.Command{
@@ -71,7 +79,11 @@ func newDomainsCreateCmd() *cobra.Command {
 				return err
 			}
 
-			return handleResponse(cmd, resp)
+			domain, err := writeResponse(cmd, resp)
+			if err != nil || !open {
+				return err
+			}
+			return openDashboard(cmd, client, domain, domainDashboardPage)
 		},
 	}
 
@@ -79,6 +91,7 @@ func newDomainsCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&placeholder, "placeholder", "", "Placeholder URL for root domain")
 	cmd.Flags().StringVar(&expiredURL, "expired-url", "", "URL for expired links")
 	cmd.Flags().BoolVar(&archived, "archived", false, "Archive the domain")
+	cmd.Flags().BoolVar(&open, "open", false, "Open the domain settings in the Dub dashboard")
 
 	_ = cmd.MarkFlagRequired("slug")
 
@@ -159,6 +172,10 @@ func handleDomainsListResponse(cmd *cobra.Command, resp *http.Response, output s
 		return fmt.Errorf("%s", apiErr.Error())
 	}
 
+	if body, err = sortListBody(cmd, body); err != nil {
+		return err
+	}
+
 	// For JSON output, use the existing handler
 	if output == "json" {
 		var data interface{}
@@ -254,6 +271,7 @@ func newDomainsUpdateCmd() *cobra.Command {
 		placeholder string
 		expiredURL  string
 		archived    bool
+		patch       patchFlag
 	)
 
 	cmd := &cobra.Command{
@@ -281,6 +299,9 @@ func newDomainsUpdateCmd() *cobra.Command {
 				body["archived"] = archived
 			}
 
+			if err := patch.apply(body); err != nil {
+				return err
+			}
 			if len(body) == 0 {
 				return fmt.Errorf("at least one field must be specified for update")
 			}
@@ -298,6 +319,7 @@ func newDomainsUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&placeholder, "placeholder", "", "Placeholder URL for root domain")
 	cmd.Flags().StringVar(&expiredURL, "expired-url", "", "URL for expired links")
 	cmd.Flags().BoolVar(&archived, "archived", false, "Archive the domain")
+	patch.register(cmd)
 
 	_ = cmd.MarkFlagRequired("slug")
 
@@ -306,15 +328,35 @@ func newDomainsUpdateCmd() *cobra.Command {
 
 func newDomainsDeleteCmd() *cobra.Command {
 	var (
-		slug   string
-		dryRun bool
+		slug      string
+		dryRun    bool
+		fromStdin stdinIDs
 	)
 
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a domain",
-		Long:  "Delete a domain from your workspace.",
+		Long:  "Delete a domain from your workspace, or the domains whose slugs are piped in with --ids-from-stdin.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromStdin.enabled {
+				slugs, err := fromStdin.read(cmd, dryRun)
+				if err != nil {
+					return err
+				}
+				if dryRun {
+					printWouldDelete(cmd, "domain", slugs)
+					return nil
+				}
+				client, err := getClient(cmd.Context())
+				if err != nil {
+					return err
+				}
+				_, err = deleteByIDs(cmd, client, "domain", slugs, func(slug string) string {
+					return "/domains/" + url.PathEscape(slug)
+				})
+				return err
+			}
+
 			if slug == "" {
 				return fmt.Errorf("--slug is required")
 			}
@@ -338,10 +380,9 @@ func newDomainsDeleteCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&slug, "slug", "", "Domain name (required)")
+	cmd.Flags().StringVar(&slug, "slug", "", "Domain name (required unless --ids-from-stdin)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be deleted without actually deleting")
-
-	_ = cmd.MarkFlagRequired("slug")
+	fromStdin.register(cmd, "slug", "slug")
 
 	return cmd
 }
@@ -384,34 +425,99 @@ func newDomainsRegisterCmd() *cobra.Command {
 }
 
 func newDomainsCheckCmd() *cobra.Command {
-	var slug string
+	var (
+		slug        string
+		wait        bool
+		timeout     time.Duration
+		notifySpecs []string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "check",
 		Short: "Check domain status",
-		Long:  "Check the configuration status of a domain.",
+		Long: `Check the configuration status of a domain.
+
+With --wait, keep checking until Dub verifies the domain or --timeout
+passes. --notify posts the outcome to a Slack or Discord webhook.
+
+Examples:
+  dub domains check --slug go.acme.com
+  dub domains check --slug go.acme.com --wait --timeout 1h --notify slack:https://hooks.slack.com/services/T000/B000/XXXX`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if slug == "" {
 				return fmt.Errorf("--slug is required")
 			}
-
-			client, err := getClient(cmd.Context())
+			if !wait && len(notifySpecs) > 0 {
+				return NewUsageErrorf("--notify requires --wait")
+			}
+			if wait && timeout <= 0 {
+				return NewUsageErrorf("--timeout must be positive")
+			}
+			notifiers, err := parseNotifiers(notifySpecs)
 			if err != nil {
 				return err
 			}
 
-			resp, err := client.Get(cmd.Context(), "/domains/"+url.PathEscape(slug)+"/status")
+			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			return handleResponse(cmd, resp)
+			if !wait {
+				resp, err := client.Get(cmd.Context(), "/domains/"+url.PathEscape(slug)+"/status")
+				if err != nil {
+					return err
+				}
+				return handleResponse(cmd, resp)
+			}
+
+			verified, status, waitErr := waitForDomainVerification(cmd, client, slug, timeout)
+			if waitErr != nil && cmd.Context().Err() != nil {
+				return waitErr
+			}
+			if len(notifiers) > 0 {
+				if err := sendNotification(cmd, notifiers, domainCheckMessage(slug, status, verified, waitErr)); err != nil {
+					return errors.Join(waitErr, err)
+				}
+			}
+			if waitErr != nil {
+				return waitErr
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), map[string]interface{}{
+					"domain":   slug,
+					"verified": verified,
+					"status":   status,
+				}, outfmt.GetQuery(cmd.Context()))
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s is verified.\n", slug)
+			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&slug, "slug", "", "Domain name (required)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until Dub verifies the domain")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "With --wait, how long to wait for verification")
+	addNotifyFlag(cmd, &notifySpecs)
 
 	_ = cmd.MarkFlagRequired("slug")
 
 	return cmd
 }
+
+// domainCheckMessage describes the outcome of domains check --wait for
+// --notify.
+func domainCheckMessage(slug, status string, verified bool, err error) notify.Message {
+	if verified {
+		return notify.Message{Title: slug + " is verified", Level: notify.OK}
+	}
+	msg := notify.Message{Title: slug + " is not verified", Level: notify.Alert}
+	if err != nil {
+		msg.Text = err.Error()
+	}
+	if status != "" {
+		msg.Fields = []notify.Field{{Name: "Status", Value: status}}
+	}
+	return msg
+}
@@ -3,13 +3,19 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestDomainsCmd_SubCommands(t *testing.T) {
 	cmd := newDomainsCmd()
 
-	subCmds := []string{"create", "list", "update", "delete", "register", "check"}
+	subCmds := []string{"create", "list", "update", "delete", "register", "check", "renew", "dns", "setup"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range cmd.Commands() {
@@ -234,3 +240,69 @@ func TestDomainsDeleteCmd_DryRunFlag(t *testing.T) {
 		t.Error("expected flag 'dry-run' to exist")
 	}
 }
+
+func TestDomainsCheckCmd_WaitNotifies(t *testing.T) {
+	origInterval := domainPollInterval
+	domainPollInterval = time.Millisecond
+	t.Cleanup(func() { domainPollInterval = origInterval })
+
+	var checks atomic.Int32
+	verifyAfter := int32(2)
+	var posted []map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hooks/slack":
+			var payload map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			posted = append(posted, payload)
+		case "/domains/go.acme.com/status":
+			if checks.Add(1) <= verifyAfter {
+				_, _ = w.Write([]byte(`{"status": "Pending Verification"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status": "Valid Configuration"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := newDomainsCheckCmd()
+		cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"--slug", "go.acme.com", "--wait", "--notify", "slack:" + server.URL + "/hooks/slack"}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "go.acme.com is verified.\n" || checks.Load() != 3 {
+		t.Errorf("expected polling until verified, got %q after %d checks", out, checks.Load())
+	}
+	if len(posted) != 1 || posted[0]["text"] != "*go.acme.com is verified*" {
+		t.Errorf("expected a verified notification, got %v", posted)
+	}
+
+	verifyAfter = 1 << 30
+	if _, err := run("--timeout", "20ms"); err == nil || !strings.Contains(err.Error(), "was not verified within 20ms") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	if len(posted) != 2 || posted[1]["text"] != "*go.acme.com is not verified*" {
+		t.Errorf("expected a failure notification, got %v", posted)
+	}
+}
+
+func TestDomainsCheckCmd_NotifyRequiresWait(t *testing.T) {
+	cmd := newDomainsCheckCmd()
+	cmd.SetArgs([]string{"--slug", "go.acme.com", "--notify", "slack:https://hooks.slack.com/services/x"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !IsUsageError(err) {
+		t.Errorf("expected a usage error, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+// internal/cmd/domainsdefaults.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// domainDefaultsResult reports what dub domains defaults changed.
+type domainDefaultsResult struct {
+	Domain     string                 `json:"domain"`
+	Defaults   map[string]interface{} `json:"defaults"`
+	Backfilled int                    `json:"backfilled"`
+	Skipped    int                    `json:"skippedProtected"`
+	DryRun     bool                   `json:"dryRun,omitempty"`
+}
+
+func newDomainsDefaultsCmd() *cobra.Command {
+	var (
+		expiredURL      string
+		placeholder     string
+		applyToExisting bool
+		dryRun          bool
+		concurrency     int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "defaults <domain>",
+		Short: "Set a domain's default expired URL and placeholder",
+		Long: `Set the defaults of a domain: the URL visitors of its expired links are
+sent to, and the placeholder URL suggested when creating links on it.
+
+With --apply-to-existing, links on the domain that have no expired URL of
+their own get --expired-url too, in bulk updates of up to 100 links. The
+placeholder only applies to the domain. Protected links are skipped unless
+--force is given. Back-filling requires --yes (or --dry-run).
+
+Examples:
+  dub domains defaults go.acme.com --expired-url https://acme.com/410 --placeholder https://acme.com
+  dub domains defaults go.acme.com --expired-url https://acme.com/410 --apply-to-existing --dry-run
+  dub domains defaults go.acme.com --expired-url https://acme.com/410 --apply-to-existing --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slug := args[0]
+			defaults := map[string]interface{}{}
+			if cmd.Flags().Changed("expired-url") {
+				defaults["expiredUrl"] = expiredURL
+			}
+			if cmd.Flags().Changed("placeholder") {
+				defaults["placeholder"] = placeholder
+			}
+			if len(defaults) == 0 {
+				return NewUsageErrorf("set --expired-url, --placeholder, or both")
+			}
+			if applyToExisting {
+				if expiredURL == "" {
+					return NewUsageErrorf("--apply-to-existing requires --expired-url; the placeholder only applies to the domain")
+				}
+				if !dryRun && !outfmt.GetYes(cmd.Context()) {
+					return NewUsageErrorf("--apply-to-existing updates existing links and requires --yes (or --dry-run)")
+				}
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			result := domainDefaultsResult{Domain: slug, Defaults: defaults, DryRun: dryRun}
+			if !dryRun {
+				if err := discardResponse(client.Patch(cmd.Context(), "/domains/"+url.PathEscape(slug), defaults)); err != nil {
+					return fmt.Errorf("failed to update %s: %w", slug, err)
+				}
+			}
+
+			var backfillErr error
+			if applyToExisting {
+				links, err := fetchAllLinks[map[string]interface{}](cmd.Context(), client, url.Values{"domain": {slug}})
+				if err != nil {
+					return err
+				}
+				var ids []string
+				missing, skipped := withoutProtected(cmd.Context(), linksWithoutExpiredURL(links), isProtected)
+				for _, link := range missing {
+					ids = append(ids, outfmt.SafeString(link["id"]))
+				}
+				result.Backfilled, result.Skipped = len(ids), skipped
+				if !dryRun && len(ids) > 0 {
+					backfillErr = backfillExpiredURL(cmd.Context(), client, ids, expiredURL, concurrency)
+				}
+			}
+
+			if err := writeDomainDefaults(cmd, result, applyToExisting); err != nil {
+				return err
+			}
+			return backfillErr
+		},
+	}
+
+	cmd.Flags().StringVar(&expiredURL, "expired-url", "", "URL to send visitors of expired links to")
+	cmd.Flags().StringVar(&placeholder, "placeholder", "", "Placeholder URL for root domain")
+	cmd.Flags().BoolVar(&applyToExisting, "apply-to-existing", false, "Also set --expired-url on existing links that have none")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without changing anything")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	return cmd
+}
+
+// linksWithoutExpiredURL returns the links with no expired URL of their own.
+func linksWithoutExpiredURL(links []map[string]interface{}) []map[string]interface{} {
+	var missing []map[string]interface{}
+	for _, link := range links {
+		if outfmt.SafeString(link["expiredUrl"]) == "" {
+			missing = append(missing, link)
+		}
+	}
+	return missing
+}
+
+// backfillExpiredURL sets the expired URL of the links with ids, in bulk
+// updates of up to maxBulkLinks links.
+func backfillExpiredURL(ctx context.Context, client *api.Client, ids []string, expiredURL string, concurrency int) error {
+	chunks := (len(ids) + maxBulkLinks - 1) / maxBulkLinks
+	errs := batch.Run(ctx, chunks, concurrency, func(ctx context.Context, i int) error {
+		chunk := ids[i*maxBulkLinks : min((i+1)*maxBulkLinks, len(ids))]
+		body := map[string]interface{}{
+			"linkIds": chunk,
+			"data":    map[string]interface{}{"expiredUrl": expiredURL},
+		}
+		if err := discardResponse(client.Patch(ctx, "/links/bulk", body)); err != nil {
+			return fmt.Errorf("links %d-%d: %w", i*maxBulkLinks+1, i*maxBulkLinks+len(chunk), err)
+		}
+		return nil
+	})
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update %d of %d batch(es) of links:\n%w", len(failed), chunks, errors.Join(failed...))
+	}
+	return nil
+}
+
+func writeDomainDefaults(cmd *cobra.Command, result domainDefaultsResult, applyToExisting bool) error {
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		return outfmt.FormatJSON(w, result, outfmt.GetQuery(cmd.Context()))
+	}
+
+	verb, backfill := "Updated", "Set the expired URL of"
+	if result.DryRun {
+		verb, backfill = "Would update", "Would set the expired URL of"
+	}
+	_, _ = fmt.Fprintf(w, "%s %s:\n", verb, result.Domain)
+	if v, ok := result.Defaults["expiredUrl"]; ok {
+		_, _ = fmt.Fprintf(w, "  Expired URL:  %s\n", dashIfEmpty(outfmt.SafeString(v)))
+	}
+	if v, ok := result.Defaults["placeholder"]; ok {
+		_, _ = fmt.Fprintf(w, "  Placeholder:  %s\n", dashIfEmpty(outfmt.SafeString(v)))
+	}
+	if applyToExisting {
+		_, _ = fmt.Fprintf(w, "%s %d existing link(s) without one.\n", backfill, result.Backfilled)
+		if result.Skipped > 0 {
+			_, _ = fmt.Fprintf(w, "Skipped %d protected link(s); pass --force to update them too.\n", result.Skipped)
+		}
+	}
+	return nil
+}
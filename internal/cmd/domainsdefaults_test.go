@@ -0,0 +1,116 @@
+// internal/cmd/domainsdefaults_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDomainsDefaultsCmd(t *testing.T) {
+	var (
+		requests []string
+		updated  []string
+	)
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/links":
+			if r.URL.Query().Get("domain") != "go.acme.com" {
+				t.Errorf("expected links on go.acme.com, got %s", r.URL.RawQuery)
+			}
+			// 150 links: the first has its own expired URL, the second is protected
+			var links []map[string]interface{}
+			start := 0
+			if r.URL.Query().Get("page") == "2" {
+				start = linksPageSize
+			}
+			for i := start; i < min(start+linksPageSize, 150); i++ {
+				link := map[string]interface{}{"id": fmt.Sprintf("link_%d", i), "domain": "go.acme.com", "key": fmt.Sprint(i)}
+				switch i {
+				case 0:
+					link["expiredUrl"] = "https://acme.com/custom"
+				case 1:
+					link["tags"] = []map[string]string{{"name": protectedTag}}
+				}
+				links = append(links, link)
+			}
+			_ = json.NewEncoder(w).Encode(links)
+		case r.Method == http.MethodPatch && r.URL.Path == "/links/bulk":
+			var req struct {
+				LinkIDs []string          `json:"linkIds"`
+				Data    map[string]string `json:"data"`
+			}
+			_ = json.Unmarshal(body, &req)
+			if req.Data["expiredUrl"] != "https://acme.com/410" {
+				t.Errorf("unexpected bulk update %s", body)
+			}
+			updated = append(updated, req.LinkIDs...)
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/domains/go.acme.com":
+			if string(body) != `{"expiredUrl":"https://acme.com/410","placeholder":"https://acme.com"}` {
+				t.Errorf("unexpected domain update %s", body)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	args := []string{"domains", "defaults", "go.acme.com", "--api-url", server.URL,
+		"--expired-url", "https://acme.com/410", "--placeholder", "https://acme.com", "--apply-to-existing"}
+
+	var stdout bytes.Buffer
+	if err := execute(context.Background(), append(args, "--dry-run"), nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "Would set the expired URL of 148 existing link(s)") ||
+		!strings.Contains(stdout.String(), "Skipped 1 protected link(s)") {
+		t.Errorf("unexpected dry run output %q", stdout.String())
+	}
+	for _, req := range requests {
+		if strings.HasPrefix(req, "PATCH") {
+			t.Errorf("dry run sent %s", req)
+		}
+	}
+
+	err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("expected back-filling without --yes to be refused, got %v", err)
+	}
+
+	stdout.Reset()
+	if err := execute(context.Background(), append(args, "--yes"), nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != 148 {
+		t.Errorf("expected 148 links back-filled, got %d", len(updated))
+	}
+	for _, id := range updated {
+		if id == "link_0" || id == "link_1" {
+			t.Errorf("%s should not have been updated", id)
+		}
+	}
+	if !strings.Contains(stdout.String(), "Updated go.acme.com:") || !strings.Contains(stdout.String(), "Set the expired URL of 148") {
+		t.Errorf("unexpected output %q", stdout.String())
+	}
+}
+
+func TestDomainsDefaultsCmd_Usage(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+	for _, args := range [][]string{
+		{"domains", "defaults", "go.acme.com"},
+		{"domains", "defaults", "go.acme.com", "--placeholder", "https://acme.com", "--apply-to-existing", "--dry-run"},
+	} {
+		err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err == nil || !IsUsageError(err) {
+			t.Errorf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
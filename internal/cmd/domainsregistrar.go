@@ -0,0 +1,138 @@
+// internal/cmd/domainsregistrar.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// domainRegistration is the registrar status of a domain registered through
+// Dub.
+type domainRegistration struct {
+	Slug      string     `json:"slug"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	DaysLeft  int        `json:"daysLeft"`
+	AutoRenew bool       `json:"autoRenew"`
+}
+
+func newDomainsRenewCmd() *cobra.Command {
+	var (
+		slug      string
+		autoRenew bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "renew",
+		Short: "Show or change renewal of a Dub-registered domain",
+		Long: `Show when a domain registered through Dub (see dub domains register)
+expires and whether it renews automatically, or turn auto-renewal on or off
+with --auto-renew.
+
+Transferring a domain out of Dub is not available through the API; start a
+transfer from the domain's settings in the Dub dashboard.
+
+Examples:
+  dub domains renew --slug acme.link
+  dub domains renew --slug acme.link --auto-renew=false`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if _, err := fetchRegisteredDomain(cmd.Context(), client, slug); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("auto-renew") {
+				body := map[string]interface{}{"autoRenew": autoRenew}
+				if err := discardResponse(client.Patch(cmd.Context(), "/domains/"+url.PathEscape(slug), body)); err != nil {
+					return fmt.Errorf("failed to update auto-renewal: %w", err)
+				}
+			}
+
+			domain, err := fetchRegisteredDomain(cmd.Context(), client, slug)
+			if err != nil {
+				return err
+			}
+			status := registrationStatus(domain, time.Now())
+			if cmd.Flags().Changed("auto-renew") && status.AutoRenew != autoRenew {
+				return fmt.Errorf("auto-renewal of %s is still %s after the update; the API did not apply it", status.Slug, onOff(status.AutoRenew))
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), status, outfmt.GetQuery(cmd.Context()))
+			}
+			w := cmd.OutOrStdout()
+			_, _ = fmt.Fprintf(w, "Domain:      %s\n", status.Slug)
+			if status.ExpiresAt != nil {
+				_, _ = fmt.Fprintf(w, "Expires:     %s (%d days)\n", status.ExpiresAt.Format("Jan 2, 2006"), status.DaysLeft)
+			}
+			_, _ = fmt.Fprintf(w, "Auto-renew:  %s\n", onOff(status.AutoRenew))
+			if !status.AutoRenew {
+				_, _ = fmt.Fprintln(w, "\nThe domain will lapse when it expires. Turn auto-renewal on with --auto-renew.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&slug, "slug", "", "Domain name (required)")
+	cmd.Flags().BoolVar(&autoRenew, "auto-renew", true, "Turn automatic renewal on or off")
+
+	_ = cmd.MarkFlagRequired("slug")
+
+	return cmd
+}
+
+// fetchRegisteredDomain returns the workspace domain slug, failing unless it
+// was registered through Dub.
+func fetchRegisteredDomain(ctx context.Context, client *api.Client, slug string) (map[string]interface{}, error) {
+	var domains []map[string]interface{}
+	if err := getJSON(ctx, client, "/domains?"+url.Values{"search": {slug}}.Encode(), &domains); err != nil {
+		return nil, fmt.Errorf("failed to look up domain: %w", err)
+	}
+	for _, domain := range domains {
+		if !strings.EqualFold(outfmt.SafeString(domain["slug"]), slug) {
+			continue
+		}
+		if reg, _ := domain["registeredDomain"].(map[string]interface{}); reg == nil {
+			return nil, fmt.Errorf("%s was not registered through Dub; only domains from dub domains register have registrar settings", slug)
+		}
+		return domain, nil
+	}
+	return nil, fmt.Errorf("domain %s not found in this workspace", slug)
+}
+
+// registrationStatus summarizes the registeredDomain of a domain.
+func registrationStatus(domain map[string]interface{}, now time.Time) domainRegistration {
+	reg, _ := domain["registeredDomain"].(map[string]interface{})
+	status := domainRegistration{Slug: outfmt.SafeString(domain["slug"])}
+
+	if t, err := time.Parse(time.RFC3339, outfmt.SafeString(reg["expiresAt"])); err == nil {
+		status.ExpiresAt = &t
+		status.DaysLeft = int(t.Sub(now).Hours() / 24)
+	}
+	// Newer responses report autoRenew directly, older ones only when
+	// auto-renewal was disabled
+	if v, ok := reg["autoRenew"].(bool); ok {
+		status.AutoRenew = v
+	} else {
+		status.AutoRenew = reg["autoRenewalDisabledAt"] == nil
+	}
+	return status
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRegistrarServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	autoRenew := "true"
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, r.Method+" "+r.URL.Path+" "+string(body))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/domains":
+			_, _ = w.Write([]byte(`[
+				{"slug": "acme.link", "registeredDomain": {"expiresAt": "2030-01-01T00:00:00Z", "autoRenew": ` + autoRenew + `}},
+				{"slug": "go.acme.com", "registeredDomain": null}
+			]`))
+		case r.Method == http.MethodPatch:
+			autoRenew = "false"
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	return server
+}
+
+func TestRegistrationStatus(t *testing.T) {
+	now := time.Date(2029, 12, 1, 0, 0, 0, 0, time.UTC)
+	status := registrationStatus(map[string]interface{}{
+		"slug":             "acme.link",
+		"registeredDomain": map[string]interface{}{"expiresAt": "2030-01-01T00:00:00Z", "autoRenewalDisabledAt": "2029-06-01T00:00:00Z"},
+	}, now)
+	if status.DaysLeft != 31 || status.AutoRenew || status.ExpiresAt == nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestDomainsRenewCmd_AutoRenew(t *testing.T) {
+	var requests []string
+	server := newRegistrarServer(t, &requests)
+
+	cmd := newDomainsRenewCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--slug", "acme.link", "--auto-renew=false"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(strings.Join(requests, "\n"), `PATCH /domains/acme.link {"autoRenew":false}`) {
+		t.Errorf("expected auto-renew update, got %v", requests)
+	}
+	if !strings.Contains(out.String(), "Auto-renew:  off") {
+		t.Errorf("expected refreshed status, got:\n%s", out.String())
+	}
+}
+
+func TestDomainsRenewCmd_NotRegistered(t *testing.T) {
+	var requests []string
+	server := newRegistrarServer(t, &requests)
+
+	cmd := newDomainsRenewCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--slug", "go.acme.com"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not registered through Dub") {
+		t.Fatalf("expected not registered error, got %v", err)
+	}
+}
+
+func TestDomainsRenewCmd_UpdateNotApplied(t *testing.T) {
+	var requests []string
+	// The server turns auto-renewal off whatever the request asks
+	server := newRegistrarServer(t, &requests)
+
+	cmd := newDomainsRenewCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--slug", "acme.link", "--auto-renew=true"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "still off") {
+		t.Fatalf("expected an error for an update the API ignored, got %v", err)
+	}
+}
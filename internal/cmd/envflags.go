@@ -0,0 +1,133 @@
+// internal/cmd/envflags.go
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+// envPrefix is prepended to every flag environment variable.
+const envPrefix = "DUB"
+
+// flagEnvName returns the environment variable that overrides a flag of cmd,
+// e.g. DUB_LINKS_LIST_LIMIT for `dub links list --limit`. Global flags use
+// DUB_<FLAG>, e.g. DUB_OUTPUT.
+func flagEnvName(cmd *cobra.Command, flag string, global bool) string {
+	parts := []string{envPrefix}
+	if !global {
+		// Skip the root command name
+		path := strings.Fields(cmd.CommandPath())
+		if len(path) > 1 {
+			parts = append(parts, path[1:]...)
+		}
+	}
+	parts = append(parts, flag)
+
+	name := strings.ToUpper(strings.Join(parts, "_"))
+	return strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
+
+// defaultAnnotation marks a flag set from an environment variable or config
+// default rather than on the command line.
+const defaultAnnotation = "dub_default"
+
+// setFlagDefault sets f to value without marking it Changed, so that
+// commands acting only on the flags a user passed, like links update, and
+// cobra's flag group checks don't mistake a default for explicit input.
+// Required flags are marked Changed, since cobra checks those by Changed.
+func setFlagDefault(flags *pflag.FlagSet, f *pflag.Flag, value string) error {
+	if required := f.Annotations[cobra.BashCompOneRequiredFlag]; len(required) > 0 && required[0] == "true" {
+		return flags.Set(f.Name, value)
+	}
+	if err := f.Value.Set(value); err != nil {
+		return err
+	}
+	return flags.SetAnnotation(f.Name, defaultAnnotation, []string{"true"})
+}
+
+// flagHasValue reports whether the flag name was given on the command line
+// or set from an environment variable or config default.
+func flagHasValue(flags *pflag.FlagSet, name string) bool {
+	f := flags.Lookup(name)
+	return f != nil && (f.Changed || len(f.Annotations[defaultAnnotation]) > 0)
+}
+
+// applyEnvFlags sets flags that were not given on the command line from
+// environment variables. Command-specific variables take precedence over
+// global ones, and explicit flags always win.
+func applyEnvFlags(cmd *cobra.Command) error {
+	inherited := cmd.InheritedFlags()
+
+	var firstErr error
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if firstErr != nil || flagHasValue(cmd.Flags(), f.Name) || f.Name == "help" || f.Name == "version" {
+			return
+		}
+
+		names := []string{flagEnvName(cmd, f.Name, false)}
+		if inherited.Lookup(f.Name) != nil {
+			names = append(names, flagEnvName(cmd, f.Name, true))
+		}
+
+		for _, name := range names {
+			value, ok := os.LookupEnv(name)
+			if !ok || value == "" {
+				continue
+			}
+			if err := setFlagDefault(cmd.Flags(), f, value); err != nil {
+				firstErr = NewUsageErrorf("invalid value %q for %s: %v", value, name, err)
+			}
+			return
+		}
+	})
+
+	return firstErr
+}
+
+// applyConfigDefaults sets flags that are still unset from the defaults
+// section of the user config and project file. Project values win.
+func applyConfigDefaults(cmd *cobra.Command, project *config.Project) error {
+	// Skip the root command name
+	path := strings.Fields(cmd.CommandPath())[1:]
+
+	values := map[string]string{}
+	sources := map[string]string{}
+	if cfg, err := config.Load(); err == nil {
+		userDefaults, err := config.FlagDefaults(cfg.Defaults, path)
+		if err != nil {
+			return NewUsageErrorf("config: %v", err)
+		}
+		for name, value := range userDefaults {
+			values[name], sources[name] = value, "config"
+		}
+	}
+	if project != nil {
+		projectDefaults, err := config.FlagDefaults(project.Defaults, path)
+		if err != nil {
+			return NewUsageErrorf("%s: %v", project.Path, err)
+		}
+		for name, value := range projectDefaults {
+			values[name], sources[name] = value, project.Path
+		}
+	}
+
+	for name, value := range values {
+		f := cmd.Flags().Lookup(name)
+		if f == nil {
+			// Shared tables may name flags other commands don't have
+			continue
+		}
+		if flagHasValue(cmd.Flags(), name) {
+			continue
+		}
+		if err := setFlagDefault(cmd.Flags(), f, value); err != nil {
+			return NewUsageErrorf("invalid default %q for --%s in %s: %v", value, name, sources[name], err)
+		}
+	}
+	return nil
+}
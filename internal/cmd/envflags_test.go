@@ -0,0 +1,156 @@
+// internal/cmd/envflags_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+func TestFlagEnvName(t *testing.T) {
+	root := NewRootCmd()
+	list, _, err := root.Find([]string{"links", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := flagEnvName(list, "limit", false); got != "DUB_LINKS_LIST_LIMIT" {
+		t.Errorf("expected DUB_LINKS_LIST_LIMIT, got %q", got)
+	}
+	if got := flagEnvName(list, "sort-by", true); got != "DUB_SORT_BY" {
+		t.Errorf("expected DUB_SORT_BY, got %q", got)
+	}
+}
+
+func TestApplyEnvFlags(t *testing.T) {
+	var limit int
+	var search, query string
+
+	root := &cobra.Command{Use: "dub"}
+	root.PersistentFlags().StringVar(&query, "query", "", "")
+	list := &cobra.Command{Use: "list", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	list.Flags().IntVar(&limit, "limit", 25, "")
+	list.Flags().StringVar(&search, "search", "", "")
+	root.AddCommand(list)
+
+	t.Setenv("DUB_LIST_LIMIT", "100")
+	t.Setenv("DUB_LIST_SEARCH", "from-env")
+	t.Setenv("DUB_QUERY", ".[0]")
+
+	// Explicit flags win over the environment
+	if err := list.ParseFlags([]string{"--search", "explicit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyEnvFlags(list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if limit != 100 {
+		t.Errorf("expected limit 100 from env, got %d", limit)
+	}
+	if search != "explicit" {
+		t.Errorf("expected explicit --search to win, got %q", search)
+	}
+	if query != ".[0]" {
+		t.Errorf("expected global DUB_QUERY to apply, got %q", query)
+	}
+	// A default is not explicit input, e.g. for links update
+	if list.Flags().Changed("limit") || !flagHasValue(list.Flags(), "limit") {
+		t.Error("expected env-provided flag to have a value without being marked as changed")
+	}
+}
+
+func TestApplyEnvFlags_RequiredFlag(t *testing.T) {
+	var id string
+	cmd := &cobra.Command{Use: "get", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	cmd.Flags().StringVar(&id, "id", "", "")
+	_ = cmd.MarkFlagRequired("id")
+
+	t.Setenv("DUB_ID", "link_1")
+	if err := applyEnvFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cmd.ValidateRequiredFlags(); err != nil || id != "link_1" {
+		t.Errorf("expected the env value to satisfy the required flag, got %q, %v", id, err)
+	}
+}
+
+func TestApplyEnvFlags_InvalidValue(t *testing.T) {
+	var limit int
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().IntVar(&limit, "limit", 25, "")
+
+	t.Setenv("DUB_LIMIT", "lots")
+
+	err := applyEnvFlags(cmd)
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error for invalid env value, got %v", err)
+	}
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := &config.Config{Defaults: map[string]interface{}{
+		"output": "json",
+		"list":   map[string]interface{}{"limit": float64(100), "search": "from-config"},
+	}}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	project := &config.Project{Path: ".dub.toml", Defaults: map[string]interface{}{
+		"list": map[string]interface{}{"limit": int64(50)},
+	}}
+
+	var limit int
+	var search, output string
+	root := &cobra.Command{Use: "dub"}
+	root.PersistentFlags().StringVar(&output, "output", "text", "")
+	list := &cobra.Command{Use: "list", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	list.Flags().IntVar(&limit, "limit", 25, "")
+	list.Flags().StringVar(&search, "search", "", "")
+	root.AddCommand(list)
+
+	// Explicit flags win over config defaults
+	if err := list.ParseFlags([]string{"--search", "explicit"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyConfigDefaults(list, project); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if limit != 50 {
+		t.Errorf("expected project default limit 50, got %d", limit)
+	}
+	if search != "explicit" {
+		t.Errorf("expected explicit --search to win, got %q", search)
+	}
+	if output != "json" {
+		t.Errorf("expected global output default to apply, got %q", output)
+	}
+}
+
+func TestLinksUpdate_IgnoresFieldDefaults(t *testing.T) {
+	t.Setenv("DUB_LINKS_UPDATE_COMMENTS", "from env")
+
+	var body map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		_, _ = w.Write([]byte(`{"id":"link_1"}`))
+	})
+
+	args := []string{"links", "update", "--api-url", server.URL, "--id", "link_1", "--utm-source", "newsletter"}
+	if err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := body["comments"]; ok || body["utm_source"] != "newsletter" {
+		t.Errorf("expected only the explicit field to be updated, got %v", body)
+	}
+}
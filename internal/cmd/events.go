@@ -2,16 +2,21 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/config"
 	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 )
 
@@ -23,40 +28,69 @@ func newEventsCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newEventsListCmd())
+	cmd.AddCommand(newEventsReplayCmd())
+	cmd.AddCommand(newEventsTailCmd())
 
 	return cmd
 }
 
 func newEventsListCmd() *cobra.Command {
 	var (
-		event    string
-		domain   string
-		linkID   string
-		interval string
-		start    string
-		end      string
-		country  string
-		city     string
-		device   string
-		browser  string
-		os       string
-		referer  string
-		output   string
-		limit    int
-		all      bool
+		event     string
+		domain    string
+		linkID    string
+		interval  string
+		start     string
+		end       string
+		country   string
+		city      string
+		device    string
+		browser   string
+		os        string
+		referer   string
+		output    string
+		limit     int
+		all       bool
+		rawCodes  bool
+		sinceLast bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List events",
-		Long:  "List click, lead, and sale events.",
+		Long: `List click, lead, and sale events.
+
+With --since-last, only events newer than the newest event seen on the
+previous --since-last run for the workspace are listed, and all of them are
+shown regardless of --limit. Useful for cron jobs shipping events elsewhere
+without duplicates.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if sinceLast && start != "" {
+				return NewUsageErrorf("--since-last cannot be used with --start")
+			}
+			params := url.Values{}
+			if err := setDateRange(params, "--start", start, "--end", end); err != nil {
+				return err
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			params := url.Values{}
+			var cursorKey, cursor string
+			if sinceLast {
+				cursorKey = eventsCursorKey(cmd.Context(), client)
+				cursor, err = config.GetCursor(cursorKey)
+				if err != nil {
+					return fmt.Errorf("failed to read events cursor: %w", err)
+				}
+				if cursor != "" {
+					params.Set("start", cursor)
+				}
+				all = true
+			}
+
 			if event != "" {
 				params.Set("event", event)
 			}
@@ -69,12 +103,6 @@ func newEventsListCmd() *cobra.Command {
 			if interval != "" {
 				params.Set("interval", interval)
 			}
-			if start != "" {
-				params.Set("start", start)
-			}
-			if end != "" {
-				params.Set("end", end)
-			}
 			if country != "" {
 				params.Set("country", country)
 			}
@@ -94,17 +122,41 @@ func newEventsListCmd() *cobra.Command {
 				params.Set("referer", referer)
 			}
 
-			path := "/events"
-			if len(params) > 0 {
-				path += "?" + params.Encode()
+			if !sinceLast {
+				path := "/events"
+				if len(params) > 0 {
+					path += "?" + params.Encode()
+				}
+				resp, err := client.Get(cmd.Context(), path)
+				if err != nil {
+					return err
+				}
+				return handleEventsListResponse(cmd, resp, output, limit, all, rawCodes)
 			}
 
-			resp, err := client.Get(cmd.Context(), path)
+			// Every page is read before the cursor moves, so that events
+			// beyond the first page aren't skipped on the next run
+			body, err := fetchEventPages(cmd.Context(), client, params)
+			if err != nil {
+				return err
+			}
+			filtered, newest, err := filterEventsAfter(body, cursor)
 			if err != nil {
 				return err
 			}
+			resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(filtered))}
 
-			return handleEventsListResponse(cmd, resp, output, limit, all)
+			if err := handleEventsListResponse(cmd, resp, output, limit, all, rawCodes); err != nil {
+				return err
+			}
+
+			// Only advance the cursor once the events have been written out
+			if newest != cursor {
+				if err := config.SetCursor(cursorKey, newest); err != nil {
+					return fmt.Errorf("failed to save events cursor: %w", err)
+				}
+			}
+			return nil
 		},
 	}
 
@@ -112,8 +164,8 @@ func newEventsListCmd() *cobra.Command {
 	cmd.Flags().StringVar(&domain, "domain", "", "Filter by domain")
 	cmd.Flags().StringVar(&linkID, "link-id", "", "Filter by link ID")
 	cmd.Flags().StringVar(&interval, "interval", "", "Time interval: 1h, 24h, 7d, 30d, 90d, all")
-	cmd.Flags().StringVar(&start, "start", "", "Start date (ISO 8601)")
-	cmd.Flags().StringVar(&end, "end", "", "End date (ISO 8601)")
+	cmd.Flags().StringVar(&start, "start", "", `Start date: ISO 8601, 2025-01, yesterday, or "7 days ago"`)
+	cmd.Flags().StringVar(&end, "end", "", "End date, in the same forms as --start (dates include the whole day)")
 	cmd.Flags().StringVar(&country, "country", "", "Filter by country code")
 	cmd.Flags().StringVar(&city, "city", "", "Filter by city")
 	cmd.Flags().StringVar(&device, "device", "", "Filter by device type")
@@ -122,14 +174,95 @@ func newEventsListCmd() *cobra.Command {
 	cmd.Flags().StringVar(&referer, "referer", "", "Filter by referer")
 	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of events to show")
+	cmd.Flags().BoolVar(&rawCodes, "raw-codes", false, "Show country codes instead of flags and names")
 	cmd.Flags().BoolVar(&all, "all", false, "Show all events (ignore limit)")
+	cmd.Flags().BoolVar(&sinceLast, "since-last", false, "Only list events newer than the last --since-last run")
 
 	return cmd
 }
 
+// eventsCursorKey identifies the --since-last cursor for the active workspace.
+func eventsCursorKey(ctx context.Context, client *api.Client) string {
+	if ws := GetWorkspace(ctx); ws != "" {
+		return "events:" + ws
+	}
+	return "events:" + cache.Scope(client.APIKey())
+}
+
+// fetchEventPages requests every page of the events matching params, until
+// a page comes back short, and returns them all as one JSON array.
+func fetchEventPages(ctx context.Context, client *api.Client, params url.Values) ([]byte, error) {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	events := []json.RawMessage{}
+	for page := 1; ; page++ {
+		q.Set("page", strconv.Itoa(page))
+		q.Set("limit", strconv.Itoa(eventsPageSize))
+
+		var batch []json.RawMessage
+		if err := getJSON(ctx, client, "/events?"+q.Encode(), &batch); err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		events = append(events, batch...)
+		if len(batch) < eventsPageSize {
+			break
+		}
+	}
+	return json.Marshal(events)
+}
+
+// filterEventsAfter drops events at or before cursor (an RFC 3339 timestamp)
+// and returns the remaining events with the newest timestamp seen, which is
+// cursor itself if no newer events were found.
+func filterEventsAfter(body []byte, cursor string) ([]byte, string, error) {
+	var events []map[string]interface{}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, "", fmt.Errorf("failed to parse events: %w", err)
+	}
+
+	var after time.Time
+	if cursor != "" {
+		t, err := time.Parse(time.RFC3339Nano, cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid events cursor %q: %w", cursor, err)
+		}
+		after = t
+	}
+
+	newest := after
+	kept := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		ts, err := time.Parse(time.RFC3339Nano, outfmt.SafeString(event["timestamp"]))
+		if err != nil {
+			// Keep events we cannot place in time rather than silently dropping them
+			kept = append(kept, event)
+			continue
+		}
+		if !ts.After(after) {
+			continue
+		}
+		kept = append(kept, event)
+		if ts.After(newest) {
+			newest = ts
+		}
+	}
+
+	data, err := json.Marshal(kept)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if newest.IsZero() || newest.Equal(after) {
+		return data, cursor, nil
+	}
+	return data, newest.UTC().Format(time.RFC3339Nano), nil
+}
+
 // handleEventsListResponse handles the response for events list command,
 // formatting output as table or JSON based on the output flag.
-func handleEventsListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all bool) error {
+func handleEventsListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all, rawCodes bool) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
@@ -142,6 +275,10 @@ func handleEventsListResponse(cmd *cobra.Command, resp *http.Response, output st
 		return fmt.Errorf("%s", apiErr.Error())
 	}
 
+	if body, err = sortListBody(cmd, body); err != nil {
+		return err
+	}
+
 	// For JSON output, use the existing handler
 	if output == "json" {
 		var data interface{}
@@ -189,7 +326,7 @@ func handleEventsListResponse(cmd *cobra.Command, resp *http.Response, output st
 			formatTimestamp(event["timestamp"]),
 			outfmt.SafeString(event["event"]),
 			formatEventLink(event),
-			formatEventField(event["country"]),
+			formatEventCountry(event["country"], rawCodes),
 			formatEventField(event["device"]),
 			formatEventField(event["browser"]),
 		}
@@ -258,6 +395,16 @@ func formatEventLink(event map[string]interface{}) string {
 	return "-"
 }
 
+// formatEventCountry formats a country code, expanding it to flag and name
+// unless raw is set.
+func formatEventCountry(v interface{}, raw bool) string {
+	code := formatEventField(v)
+	if raw || code == "-" {
+		return code
+	}
+	return outfmt.FormatCountry(code)
+}
+
 // formatEventField formats an event field value, returning "-" for empty/nil values.
 func formatEventField(v interface{}) string {
 	s := outfmt.SafeString(v)
@@ -3,9 +3,13 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestEventsCmd_Name(t *testing.T) {
@@ -260,7 +264,7 @@ func TestHandleEventsListResponse_TableOutput(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	err := handleEventsListResponse(cmd, resp, "table", 25, false)
+	err := handleEventsListResponse(cmd, resp, "table", 25, false, false)
 	if err != nil {
 		t.Fatalf("handleEventsListResponse() error = %v", err)
 	}
@@ -309,7 +313,7 @@ func TestHandleEventsListResponse_JSONOutput(t *testing.T) {
 	cmd.SetOut(&buf)
 	cmd.SetContext(context.Background())
 
-	err := handleEventsListResponse(cmd, resp, "json", 25, false)
+	err := handleEventsListResponse(cmd, resp, "json", 25, false, false)
 	if err != nil {
 		t.Fatalf("handleEventsListResponse() error = %v", err)
 	}
@@ -340,7 +344,7 @@ func TestHandleEventsListResponse_Pagination(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	err := handleEventsListResponse(cmd, resp, "table", 25, false)
+	err := handleEventsListResponse(cmd, resp, "table", 25, false, false)
 	if err != nil {
 		t.Fatalf("handleEventsListResponse() error = %v", err)
 	}
@@ -371,7 +375,7 @@ func TestHandleEventsListResponse_AllFlag(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	err := handleEventsListResponse(cmd, resp, "table", 25, true) // all=true
+	err := handleEventsListResponse(cmd, resp, "table", 25, true, false) // all=true
 	if err != nil {
 		t.Fatalf("handleEventsListResponse() error = %v", err)
 	}
@@ -395,7 +399,7 @@ func TestHandleEventsListResponse_APIError(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	err := handleEventsListResponse(cmd, resp, "table", 25, false)
+	err := handleEventsListResponse(cmd, resp, "table", 25, false, false)
 	if err == nil {
 		t.Fatal("expected error for 401 response")
 	}
@@ -403,3 +407,103 @@ func TestHandleEventsListResponse_APIError(t *testing.T) {
 		t.Errorf("expected error to contain 'unauthorized', got: %v", err)
 	}
 }
+
+func TestFormatEventCountry(t *testing.T) {
+	if got := formatEventCountry("FR", false); got != "🇫🇷 France" {
+		t.Errorf("expected expanded country, got %q", got)
+	}
+	if got := formatEventCountry("FR", true); got != "FR" {
+		t.Errorf("expected raw code, got %q", got)
+	}
+	if got := formatEventCountry(nil, false); got != "-" {
+		t.Errorf("expected '-' for missing country, got %q", got)
+	}
+}
+
+func TestFilterEventsAfter(t *testing.T) {
+	body := []byte(`[
+		{"timestamp": "2024-01-15T12:00:00Z", "event": "click"},
+		{"timestamp": "2024-01-15T11:00:00Z", "event": "click"},
+		{"timestamp": "2024-01-15T10:00:00Z", "event": "click"}
+	]`)
+
+	filtered, newest, err := filterEventsAfter(body, "2024-01-15T10:00:00Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if newest != "2024-01-15T12:00:00Z" {
+		t.Errorf("expected newest 2024-01-15T12:00:00Z, got %q", newest)
+	}
+	if strings.Contains(string(filtered), "10:00:00") {
+		t.Errorf("expected event at cursor to be dropped, got %s", filtered)
+	}
+
+	// No cursor keeps everything
+	_, newest, err = filterEventsAfter(body, "")
+	if err != nil || newest != "2024-01-15T12:00:00Z" {
+		t.Errorf("expected newest from all events, got %q (err %v)", newest, err)
+	}
+
+	// No newer events leaves the cursor unchanged
+	filtered, newest, err = filterEventsAfter(body, "2024-01-15T12:00:00Z")
+	if err != nil || newest != "2024-01-15T12:00:00Z" || string(filtered) != "[]" {
+		t.Errorf("expected empty result and unchanged cursor, got %s %q (err %v)", filtered, newest, err)
+	}
+}
+
+func TestEventsListCmd_SinceLastRejectsStart(t *testing.T) {
+	cmd := newEventsListCmd()
+	cmd.SetArgs([]string{"--since-last", "--start", "2024-01-01"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	err := cmd.Execute()
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
+
+func TestEventsListCmd_SinceLastPages(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// 150 events, newest first, across two pages
+	newest := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	var starts []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		starts = append(starts, q.Get("start"))
+		page, _ := strconv.Atoi(q.Get("page"))
+		var events []map[string]interface{}
+		for i := (page - 1) * eventsPageSize; i < min(page*eventsPageSize, 150); i++ {
+			events = append(events, map[string]interface{}{
+				"event":     "click",
+				"timestamp": newest.Add(-time.Duration(i) * time.Minute).Format(time.RFC3339),
+			})
+		}
+		if events == nil {
+			events = []map[string]interface{}{}
+		}
+		_ = json.NewEncoder(w).Encode(events)
+	})
+
+	var stdout bytes.Buffer
+	args := []string{"events", "list", "--since-last", "--api-url", server.URL, "-o", "json"}
+	if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("expected JSON, got %q: %v", stdout.String(), err)
+	}
+	if len(got) != 150 || len(starts) != 2 {
+		t.Fatalf("expected all 150 events from 2 pages, got %d from %d request(s)", len(got), len(starts))
+	}
+
+	starts = nil
+	if err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := newest.Format(time.RFC3339Nano); len(starts) == 0 || starts[0] != want {
+		t.Errorf("expected the next run to start at %s, got %v", want, starts)
+	}
+}
@@ -0,0 +1,154 @@
+// internal/cmd/eventsreplay_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventsReplayCmd(t *testing.T) {
+	var slept []time.Duration
+	orig := replaySleep
+	replaySleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	defer func() { replaySleep = orig }()
+
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("start") != "2025-01-01T00:00:00Z" || q.Get("end") != "2025-01-01T23:59:59Z" {
+			t.Errorf("unexpected range: %s", r.URL.RawQuery)
+		}
+		switch q.Get("event") {
+		case "clicks":
+			_, _ = w.Write([]byte(`[
+				{"event": "click", "timestamp": "2025-01-01T12:00:00.5Z", "click": {"id": "c2"}},
+				{"event": "click", "timestamp": "2025-01-01T12:00:00Z", "click": {"id": "c1"}}
+			]`))
+		case "sales":
+			_, _ = w.Write([]byte(`[{"event": "sale", "timestamp": "2025-01-01T09:00:00Z", "sale": {"amount": 4900}}]`))
+		default:
+			t.Errorf("unexpected event type %q", q.Get("event"))
+		}
+	})
+
+	var received []webhookPayload
+	attempts := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		if got, want := r.Header.Get("Dub-Signature"), signWebhook("whsec_test", body); got != want {
+			t.Errorf("Dub-Signature = %q, want %q", got, want)
+		}
+		// The first delivery fails once and is retried
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var p webhookPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		received = append(received, p)
+	}))
+	defer target.Close()
+
+	cmd := newEventsReplayCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, api.URL))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--start", "2025-01-01", "--end", "2025-01-01", "--event", "clicks,sales",
+		"--target", target.URL, "--sign-secret", "whsec_test", "--rate", "4"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("expected 3 deliveries, got %d", len(received))
+	}
+	wantOrder := []string{"sale.created", "link.clicked", "link.clicked"}
+	for i, p := range received {
+		if p.Event != wantOrder[i] {
+			t.Errorf("delivery %d = %s, want %s", i, p.Event, wantOrder[i])
+		}
+		if _, ok := p.Data["event"]; ok {
+			t.Errorf("delivery %d data should not repeat the event type: %v", i, p.Data)
+		}
+	}
+	if c := received[1].Data["click"].(map[string]interface{}); c["id"] != "c1" {
+		t.Errorf("expected clicks oldest first, got %v", received[1].Data)
+	}
+	if !strings.HasPrefix(received[0].ID, "evt_") || received[0].CreatedAt != "2025-01-01T09:00:00Z" {
+		t.Errorf("unexpected payload envelope: %+v", received[0])
+	}
+
+	// One backoff for the retry, then 250ms between each of the 3 deliveries
+	want := []time.Duration{time.Second, 250 * time.Millisecond, 250 * time.Millisecond}
+	if len(slept) != len(want) {
+		t.Fatalf("slept %v, want %v", slept, want)
+	}
+	for i := range want {
+		if slept[i] != want[i] {
+			t.Errorf("slept %v, want %v", slept, want)
+			break
+		}
+	}
+	if !strings.Contains(stdout.String(), "Delivered 3 of 3 event(s)") {
+		t.Errorf("unexpected output: %s", stdout.String())
+	}
+}
+
+func TestEventsReplayCmd_ReportsFailures(t *testing.T) {
+	api := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"event": "lead", "timestamp": "2025-01-01T09:00:00Z", "customer": {"id": "cus_1"}}]`))
+	})
+
+	attempts := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer target.Close()
+
+	cmd := newEventsReplayCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, api.URL))
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"--start", "2025-01-01", "--event", "leads", "--target", target.URL, "--sign-secret", "s", "--rate", "0"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "failed to deliver 1 of 1 event(s)") {
+		t.Fatalf("expected a delivery failure, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("a 400 should not be retried, got %d attempts", attempts)
+	}
+	if !strings.Contains(stderr.String(), "lead.created") || !strings.Contains(stderr.String(), "400") {
+		t.Errorf("expected the failure on stderr, got %q", stderr.String())
+	}
+}
+
+func TestNewWebhookPayload_StableID(t *testing.T) {
+	event := map[string]interface{}{"event": "click", "timestamp": "2025-01-01T12:00:00Z", "click": map[string]interface{}{"id": "c1"}}
+	a, err := newWebhookPayload(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := newWebhookPayload(event)
+	if a.ID != b.ID {
+		t.Errorf("IDs differ across replays: %s, %s", a.ID, b.ID)
+	}
+
+	event["event"] = "bounce"
+	if _, err := newWebhookPayload(event); err == nil {
+		t.Error("expected an error for an unknown event type")
+	}
+}
@@ -0,0 +1,285 @@
+// internal/cmd/eventstail.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// tailMinPoll is the shortest --poll events tail accepts, to stay well
+// within the API's rate limits.
+const tailMinPoll = 5 * time.Second
+
+// tailSleep waits between polls. Replaced in tests.
+var tailSleep = replaySleep
+
+func newEventsTailCmd() *cobra.Command {
+	var (
+		event       string
+		domain      string
+		linkID      string
+		start       string
+		poll        time.Duration
+		script      string
+		execTimeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Follow new events as they happen",
+		Long: `Poll for new click, lead, and sale events and print each one as it arrives,
+oldest first, until interrupted. Events are followed from now, or from
+--start to catch up first.
+
+With --exec, the script is run once per event instead, with the event as
+JSON on stdin and its key fields in the environment:
+
+  DUB_EVENT            click, lead, or sale
+  DUB_EVENT_NAME       the lead or sale event name
+  DUB_EVENT_TIMESTAMP  when the event happened (RFC 3339)
+  DUB_LINK_ID          the link's ID
+  DUB_SHORT_LINK       the short link
+  DUB_LINK_URL         the link's destination URL
+  DUB_CUSTOMER_ID      the customer's ID (leads and sales)
+  DUB_CUSTOMER_NAME    the customer's name
+  DUB_CUSTOMER_EMAIL   the customer's email
+  DUB_SALE_AMOUNT      the sale amount in cents
+  DUB_SALE_CURRENCY    the sale currency
+  DUB_COUNTRY          the visitor's country code
+
+Scripts run one at a time, in event order. A script that fails or outlives
+--exec-timeout is reported on stderr and tailing carries on.
+
+Examples:
+  dub events tail --event sales
+  dub events tail --event sales --exec ./notify-slack.sh
+  dub events tail --start "1 hour ago" -o json >> events.jsonl`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if poll < tailMinPoll {
+				return NewUsageErrorf("--poll must be at least %s", tailMinPoll)
+			}
+			if execTimeout < 0 {
+				return NewUsageErrorf("--exec-timeout must not be negative")
+			}
+			cursor := time.Now().UTC().Format(time.RFC3339Nano)
+			if start != "" {
+				t, err := parseDateFlag(start, false)
+				if err != nil {
+					return NewUsageErrorf("--start: %v", err)
+				}
+				cursor = t.UTC().Format(time.RFC3339Nano)
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			params := url.Values{}
+			if event != "" {
+				params.Set("event", event)
+			}
+			if domain != "" {
+				params.Set("domain", domain)
+			}
+			if linkID != "" {
+				params.Set("linkId", linkID)
+			}
+
+			ctx := cmd.Context()
+			handle := func(ctx context.Context, event map[string]interface{}) error {
+				return writeTailEvent(cmd, event)
+			}
+			if script != "" {
+				handle = func(ctx context.Context, event map[string]interface{}) error {
+					if err := runEventScript(ctx, cmd, script, execTimeout, event); err != nil && ctx.Err() == nil {
+						_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s %s\n", ui.Warning("warning:"), err)
+					}
+					return nil
+				}
+			}
+
+			for {
+				cursor, err = pollEvents(ctx, client, params, cursor, handle)
+				if err != nil && ctx.Err() == nil {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s failed to poll events: %v\n", time.Now().Format(time.RFC3339), err)
+				}
+				if tailSleep(ctx, poll) != nil {
+					return nil
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&event, "event", "", "Event type: clicks, leads, or sales (default: clicks)")
+	cmd.Flags().StringVar(&domain, "domain", "", "Filter by domain")
+	cmd.Flags().StringVar(&linkID, "link-id", "", "Filter by link ID")
+	cmd.Flags().StringVar(&start, "start", "", `Also show events since this date: ISO 8601 or "1 hour ago" (default: now)`)
+	cmd.Flags().DurationVar(&poll, "poll", 30*time.Second, "How often to check for new events")
+	cmd.Flags().StringVar(&script, "exec", "", "Run this script for each event, with the event JSON on stdin")
+	cmd.Flags().DurationVar(&execTimeout, "exec-timeout", 30*time.Second, "Stop a script that runs longer than this (0 for no limit)")
+
+	return cmd
+}
+
+// pollEvents fetches every page of the events after cursor and hands them
+// to handle oldest first. It returns the cursor to poll from next, which
+// only moves past events that were handled.
+func pollEvents(ctx context.Context, client *api.Client, params url.Values, cursor string, handle func(context.Context, map[string]interface{}) error) (string, error) {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	q.Set("start", cursor)
+
+	body, err := fetchEventPages(ctx, client, q)
+	if err != nil {
+		return cursor, err
+	}
+	filtered, _, err := filterEventsAfter(body, cursor)
+	if err != nil {
+		return cursor, err
+	}
+	after, _ := time.Parse(time.RFC3339Nano, cursor)
+	var events []map[string]interface{}
+	if err := json.Unmarshal(filtered, &events); err != nil {
+		return cursor, fmt.Errorf("failed to parse events: %w", err)
+	}
+	// The API lists the newest events first
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, _ := time.Parse(time.RFC3339Nano, outfmt.SafeString(events[i]["timestamp"]))
+		tj, _ := time.Parse(time.RFC3339Nano, outfmt.SafeString(events[j]["timestamp"]))
+		return ti.Before(tj)
+	})
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return cursor, ctx.Err()
+		}
+		if err := handle(ctx, event); err != nil {
+			return cursor, err
+		}
+		ts, err := time.Parse(time.RFC3339Nano, outfmt.SafeString(event["timestamp"]))
+		if err == nil && ts.After(after) {
+			after = ts
+			cursor = ts.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return cursor, nil
+}
+
+// writeTailEvent prints an event as one line, or as one line of JSON.
+func writeTailEvent(cmd *cobra.Command, event map[string]interface{}) error {
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	fields := []string{
+		formatTimestamp(event["timestamp"]),
+		outfmt.SafeString(event["event"]),
+		formatEventLink(event),
+		formatEventCountry(event["country"], false),
+	}
+	if email := eventField(event, "customer", "email"); email != "" {
+		fields = append(fields, email)
+	}
+	if amount, ok := eventAmount(event); ok {
+		fields = append(fields, formatCurrency(amount))
+	}
+	_, _ = fmt.Fprintln(w, strings.Join(fields, "  "))
+	return nil
+}
+
+// runEventScript runs script with event as JSON on stdin and its key fields
+// in the environment, passing the script's output through.
+func runEventScript(ctx context.Context, cmd *cobra.Command, script string, timeout time.Duration, event map[string]interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	c := exec.CommandContext(ctx, script)
+	c.Stdin = bytes.NewReader(append(data, '\n'))
+	c.Stdout = cmd.OutOrStdout()
+	c.Stderr = cmd.ErrOrStderr()
+	c.Env = append(os.Environ(), eventEnv(event)...)
+	if err := c.Run(); err != nil {
+		label := outfmt.SafeString(event["event"]) + " at " + outfmt.SafeString(event["timestamp"])
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%s for %s timed out after %s", script, label, timeout)
+		}
+		return fmt.Errorf("%s for %s: %w", script, label, err)
+	}
+	return nil
+}
+
+// eventEnv returns the environment variables describing event, leaving out
+// fields the event doesn't have.
+func eventEnv(event map[string]interface{}) []string {
+	vars := []struct{ name, value string }{
+		{"DUB_EVENT", outfmt.SafeString(event["event"])},
+		{"DUB_EVENT_NAME", outfmt.SafeString(event["eventName"])},
+		{"DUB_EVENT_TIMESTAMP", outfmt.SafeString(event["timestamp"])},
+		{"DUB_LINK_ID", eventField(event, "link", "id")},
+		{"DUB_SHORT_LINK", eventField(event, "link", "shortLink")},
+		{"DUB_LINK_URL", eventField(event, "link", "url")},
+		{"DUB_CUSTOMER_ID", eventField(event, "customer", "id")},
+		{"DUB_CUSTOMER_NAME", eventField(event, "customer", "name")},
+		{"DUB_CUSTOMER_EMAIL", eventField(event, "customer", "email")},
+		{"DUB_SALE_CURRENCY", eventField(event, "sale", "currency")},
+		{"DUB_COUNTRY", outfmt.SafeString(event["country"])},
+	}
+	if vars[3].value == "" {
+		vars[3].value = outfmt.SafeString(event["linkId"])
+	}
+	if amount, ok := eventAmount(event); ok {
+		vars = append(vars, struct{ name, value string }{"DUB_SALE_AMOUNT", fmt.Sprintf("%.0f", amount)})
+	}
+
+	env := make([]string, 0, len(vars))
+	for _, v := range vars {
+		if v.value != "" {
+			env = append(env, v.name+"="+v.value)
+		}
+	}
+	return env
+}
+
+// eventField returns a field of one of an event's nested objects.
+func eventField(event map[string]interface{}, object, field string) string {
+	obj, _ := event[object].(map[string]interface{})
+	return outfmt.SafeString(obj[field])
+}
+
+// eventAmount returns a sale event's amount in cents.
+func eventAmount(event map[string]interface{}) (float64, bool) {
+	sale, _ := event["sale"].(map[string]interface{})
+	amount, ok := sale["amount"].(float64)
+	return amount, ok
+}
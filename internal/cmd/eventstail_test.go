@@ -0,0 +1,117 @@
+// internal/cmd/eventstail_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventsTailExec(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	origSleep := tailSleep
+	defer func() { tailSleep = origSleep }()
+	polls := 0
+	tailSleep = func(ctx context.Context, d time.Duration) error {
+		polls++
+		if polls == 2 {
+			cancel()
+		}
+		return ctx.Err()
+	}
+
+	var starts []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		starts = append(starts, r.URL.Query().Get("start"))
+		if r.URL.Query().Get("event") != "sales" {
+			t.Errorf("expected the event filter, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[
+			{"event": "sale", "timestamp": "2025-01-01T10:05:00Z", "link": {"id": "link_1", "shortLink": "https://acme.link/b"}, "customer": {"email": "bo@example.com"}, "sale": {"amount": 25000, "currency": "usd"}},
+			{"event": "sale", "timestamp": "2025-01-01T10:01:00Z", "link": {"id": "link_1", "shortLink": "https://acme.link/b"}, "customer": {"email": "al@example.com"}, "sale": {"amount": 900, "currency": "usd"}}
+		]`))
+	})
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "on-event.sh")
+	logFile := filepath.Join(dir, "events.log")
+	content := "#!/bin/sh\necho \"$DUB_EVENT $DUB_CUSTOMER_EMAIL $DUB_SALE_AMOUNT $DUB_SHORT_LINK $(cat)\" >> " + logFile + "\n"
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"events", "tail", "--api-url", server.URL, "--event", "sales",
+		"--start", "2025-01-01T10:00:00Z", "--exec", script}
+	if err := execute(ctx, args, nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected each event to run the script once, got:\n%s", data)
+	}
+	if !strings.HasPrefix(lines[0], "sale al@example.com 900 https://acme.link/b {") ||
+		!strings.HasPrefix(lines[1], "sale bo@example.com 25000 https://acme.link/b {") {
+		t.Errorf("expected the oldest event first with its env and JSON, got:\n%s", data)
+	}
+	if len(starts) != 2 || starts[0] != "2025-01-01T10:00:00Z" || starts[1] != "2025-01-01T10:05:00Z" {
+		t.Errorf("expected the second poll to start after the newest event, got %v", starts)
+	}
+}
+
+func TestEventsTail_CatchesUpAcrossPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	origSleep := tailSleep
+	defer func() { tailSleep = origSleep }()
+	tailSleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	// 120 events, newest first, across two pages
+	newest := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		var events []map[string]interface{}
+		for i := (page - 1) * eventsPageSize; i < min(page*eventsPageSize, 120); i++ {
+			events = append(events, map[string]interface{}{
+				"event":     "click",
+				"timestamp": newest.Add(-time.Duration(i) * time.Second).Format(time.RFC3339),
+			})
+		}
+		_ = json.NewEncoder(w).Encode(events)
+	})
+
+	var stdout bytes.Buffer
+	args := []string{"events", "tail", "--api-url", server.URL, "--start", "2025-01-01T10:00:00Z", "-o", "json"}
+	if err := execute(ctx, args, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 120 || !strings.Contains(lines[0], "11:58:01Z") || !strings.Contains(lines[119], "12:00:00Z") {
+		t.Errorf("expected all 120 events oldest first, got %d line(s)", len(lines))
+	}
+}
+
+func TestEventsTail_RejectsFastPoll(t *testing.T) {
+	setTestEnv(t)
+
+	err := execute(context.Background(), []string{"events", "tail", "--poll", "1s"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected a usage error, got %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+// internal/cmd/examples.go
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/clipboard"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// exampleFiles holds one file per command group. Each example is a
+// "# description" line followed by the command, separated by blank lines.
+//
+//go:embed examples/*.txt
+var exampleFiles embed.FS
+
+// copyToClipboard copies text to the clipboard. Tests replace it.
+var copyToClipboard = clipboard.Copy
+
+type example struct {
+	Group       string `json:"group"`
+	Description string `json:"description"`
+	Command     string `json:"command"`
+}
+
+func newExamplesCmd() *cobra.Command {
+	var copyN int
+
+	cmd := &cobra.Command{
+		Use:   "examples [group]",
+		Short: "Show runnable examples for a command group",
+		Long: `Print curated example invocations for a command group, such as links or
+analytics. Without a group, list the groups that have examples.
+
+Use --copy with an example's number to put it on the clipboard, ready to
+paste and edit.
+
+Examples:
+  dub examples
+  dub examples links
+  dub examples links --copy 3`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: exampleGroups(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := cmd.OutOrStdout()
+			if len(args) == 0 {
+				if copyN != 0 {
+					return NewUsageErrorf("--copy requires a group")
+				}
+				return printExampleGroups(cmd)
+			}
+
+			examples, err := loadExamples(args[0])
+			if err != nil {
+				return err
+			}
+
+			if copyN != 0 {
+				if copyN < 1 || copyN > len(examples) {
+					return NewUsageErrorf("--copy must be between 1 and %d", len(examples))
+				}
+				ex := examples[copyN-1]
+				if err := copyToClipboard(ex.Command); err != nil {
+					return fmt.Errorf("failed to copy to the clipboard: %w", err)
+				}
+				_, _ = fmt.Fprintf(w, "Copied to the clipboard: %s\n", ex.Command)
+				return nil
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(w, examples, outfmt.GetQuery(cmd.Context()))
+			}
+			width := len(fmt.Sprint(len(examples)))
+			indent := strings.Repeat(" ", width+2)
+			for i, ex := range examples {
+				if i > 0 {
+					_, _ = fmt.Fprintln(w)
+				}
+				_, _ = fmt.Fprintf(w, "%*d. %s\n", width, i+1, ex.Description)
+				for _, line := range strings.Split(ex.Command, "\n") {
+					_, _ = fmt.Fprintf(w, "%s%s\n", indent, ui.Cyan(line))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&copyN, "copy", 0, "Copy example number N to the clipboard instead of printing")
+
+	return cmd
+}
+
+func printExampleGroups(cmd *cobra.Command) error {
+	groups := exampleGroups()
+	counts := make(map[string]int, len(groups))
+	for _, g := range groups {
+		examples, err := loadExamples(g)
+		if err != nil {
+			return err
+		}
+		counts[g] = len(examples)
+	}
+
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		return outfmt.FormatJSON(w, counts, outfmt.GetQuery(cmd.Context()))
+	}
+	rows := make([][]string, len(groups))
+	for i, g := range groups {
+		rows[i] = []string{g, fmt.Sprint(counts[g])}
+	}
+	if err := outfmt.FormatTable(w, []outfmt.Column{{Name: "Group"}, {Name: "Examples", Align: outfmt.AlignRight}}, rows); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w, "\nRun: dub examples <group>")
+	return nil
+}
+
+// exampleGroups returns the groups with an examples file, sorted.
+func exampleGroups() []string {
+	entries, _ := exampleFiles.ReadDir("examples")
+	groups := make([]string, 0, len(entries))
+	for _, e := range entries {
+		groups = append(groups, strings.TrimSuffix(e.Name(), ".txt"))
+	}
+	return groups
+}
+
+// loadExamples returns the examples of group.
+func loadExamples(group string) ([]example, error) {
+	data, err := exampleFiles.ReadFile(path.Join("examples", group+".txt"))
+	if err != nil {
+		return nil, NewUsageErrorf("no examples for %q (available: %s)", group, strings.Join(exampleGroups(), ", "))
+	}
+	return parseExamples(group, string(data)), nil
+}
+
+// parseExamples splits an examples file into its examples. A command may
+// span several lines, e.g. continued with backslashes.
+func parseExamples(group, text string) []example {
+	var examples []example
+	var cur *example
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		switch {
+		case line == "":
+			cur = nil
+		case strings.HasPrefix(line, "#"):
+			examples = append(examples, example{Group: group, Description: strings.TrimSpace(strings.TrimPrefix(line, "#"))})
+			cur = &examples[len(examples)-1]
+		case cur != nil && cur.Command == "":
+			cur.Command = line
+		case cur != nil:
+			cur.Command += "\n" + line
+		}
+	}
+
+	// Drop descriptions without a command
+	kept := examples[:0]
+	for _, ex := range examples {
+		if ex.Command != "" {
+			kept = append(kept, ex)
+		}
+	}
+	return kept
+}
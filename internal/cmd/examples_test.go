@@ -0,0 +1,166 @@
+// internal/cmd/examples_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseExamples(t *testing.T) {
+	text := `# First
+dub links list
+
+# Second, over two lines
+dub links create \
+  --url https://example.com
+
+# Dangling description
+`
+	got := parseExamples("links", text)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 examples, got %+v", got)
+	}
+	if got[0].Description != "First" || got[0].Command != "dub links list" {
+		t.Errorf("unexpected first example %+v", got[0])
+	}
+	if got[1].Command != "dub links create \\\n  --url https://example.com" {
+		t.Errorf("unexpected second example %q", got[1].Command)
+	}
+}
+
+// Every embedded example must name real commands and flags and pass the
+// command's argument and required flag checks.
+func TestEmbeddedExamplesAreRunnable(t *testing.T) {
+	for _, group := range exampleGroups() {
+		examples, err := loadExamples(group)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(examples) == 0 {
+			t.Errorf("%s: no examples", group)
+		}
+		for _, ex := range examples {
+			args := splitExampleArgs(strings.ReplaceAll(ex.Command, "\\\n", " "))
+			if len(args) == 0 || args[0] != "dub" {
+				t.Errorf("%s: %q doesn't run dub", group, ex.Command)
+				continue
+			}
+			// A fresh tree, so flags set by an earlier example don't count
+			cmd, rest, err := NewRootCmd().Find(args[1:])
+			if err != nil {
+				t.Errorf("%s: %q: %v", group, ex.Command, err)
+				continue
+			}
+			if err := cmd.ParseFlags(rest); err != nil {
+				t.Errorf("%s: %q: %v", group, ex.Command, err)
+				continue
+			}
+			if err := cmd.ValidateArgs(cmd.Flags().Args()); err != nil {
+				t.Errorf("%s: %q: %v", group, ex.Command, err)
+			}
+			if err := cmd.ValidateRequiredFlags(); err != nil {
+				t.Errorf("%s: %q: %v", group, ex.Command, err)
+			}
+			if err := cmd.ValidateFlagGroups(); err != nil {
+				t.Errorf("%s: %q: %v", group, ex.Command, err)
+			}
+		}
+	}
+}
+
+// splitExampleArgs splits a command line on spaces outside double quotes.
+func splitExampleArgs(line string) []string {
+	var args []string
+	var cur strings.Builder
+	inQuotes, inArg := false, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inArg = true
+		case r == ' ' && !inQuotes:
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args
+}
+
+func TestExamplesCmd(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+
+	var stdout bytes.Buffer
+	if err := execute(context.Background(), []string{"examples"}, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "links") || !strings.Contains(stdout.String(), "Run: dub examples <group>") {
+		t.Errorf("expected the list of groups, got %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := execute(context.Background(), []string{"examples", "tags"}, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "1. Create a tag\n   dub tags create") {
+		t.Errorf("unexpected examples output %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := execute(context.Background(), []string{"examples", "tags", "-o", "json"}, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	var examples []example
+	if err := json.Unmarshal(stdout.Bytes(), &examples); err != nil || len(examples) == 0 || examples[0].Group != "tags" {
+		t.Errorf("unexpected JSON output %q (%v)", stdout.String(), err)
+	}
+
+	err := execute(context.Background(), []string{"examples", "nope"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) || !strings.Contains(err.Error(), "available: analytics") {
+		t.Errorf("expected usage error listing groups, got %v", err)
+	}
+}
+
+func TestExamplesCmd_Copy(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+
+	var copied string
+	orig := copyToClipboard
+	copyToClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	t.Cleanup(func() { copyToClipboard = orig })
+
+	var stdout bytes.Buffer
+	if err := execute(context.Background(), []string{"examples", "tags", "--copy", "2"}, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if copied != "dub tags list --sort links" {
+		t.Errorf("copied %q", copied)
+	}
+	if !strings.Contains(stdout.String(), "Copied to the clipboard: dub tags list --sort links") {
+		t.Errorf("unexpected output %q", stdout.String())
+	}
+
+	for _, args := range [][]string{
+		{"examples", "tags", "--copy", "99"},
+		{"examples", "--copy", "1"},
+	} {
+		err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err == nil || !IsUsageError(err) {
+			t.Errorf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// internal/cmd/expect.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// expectFlag lets update commands refuse to write when the resource changed
+// since the caller last read it, so two scripts don't overwrite each other's
+// edits.
+type expectFlag struct {
+	raw     []string
+	ifMatch string
+}
+
+func (e *expectFlag) register(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&e.raw, "expect", nil, "Only update if field=value holds for the current resource (repeatable)")
+	cmd.Flags().StringVar(&e.ifMatch, "if-match", "", "Only update if the resource's ETag, or updatedAt when the API sends none, equals this")
+}
+
+// active reports whether any expectation was given.
+func (e *expectFlag) active() bool {
+	return len(e.raw) > 0 || e.ifMatch != ""
+}
+
+// validate checks the --expect syntax before anything is fetched.
+func (e *expectFlag) validate() error {
+	for _, raw := range e.raw {
+		if field, _, ok := strings.Cut(raw, "="); !ok || strings.TrimSpace(field) == "" {
+			return NewUsageErrorf("--expect %q: expected field=value", raw)
+		}
+	}
+	return nil
+}
+
+// check compares the expectations with resource as just fetched, whose
+// response carried header. label names the resource in the error.
+func (e *expectFlag) check(label string, resource map[string]interface{}, header http.Header) error {
+	var mismatches []string
+	for _, raw := range e.raw {
+		field, want, _ := strings.Cut(raw, "=")
+		field = strings.TrimSpace(field)
+		got, ok := resource[field]
+		if !ok && want != "" {
+			mismatches = append(mismatches, fmt.Sprintf("%s is not set (expected %q)", field, want))
+			continue
+		}
+		if have := expectString(got); have != want && !(got == nil && want == "null") {
+			mismatches = append(mismatches, fmt.Sprintf("%s is %q (expected %q)", field, have, want))
+		}
+	}
+
+	if e.ifMatch != "" {
+		version, source := header.Get("ETag"), "ETag"
+		if version == "" {
+			version, source = outfmt.SafeString(resource["updatedAt"]), "updatedAt"
+		}
+		if version == "" {
+			return fmt.Errorf("--if-match: %s has neither an ETag nor updatedAt to compare", label)
+		}
+		if strings.Trim(version, `"`) != strings.Trim(e.ifMatch, `"`) {
+			mismatches = append(mismatches, fmt.Sprintf("%s is %s (expected %s)", source, version, e.ifMatch))
+		}
+	}
+
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s changed since it was read, not updating: %s", label, strings.Join(mismatches, "; "))
+}
+
+// expectString renders a field the way it would be written in --expect.
+func expectString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// checkListed finds the resource with id in the listing at path and checks
+// it, for resources that can't be fetched individually. Listings carry no
+// per-resource ETag, so --if-match compares updatedAt.
+func (e *expectFlag) checkListed(ctx context.Context, client *api.Client, kind, path, id string) error {
+	if !e.active() {
+		return nil
+	}
+	item, err := snapshotListed(ctx, client, path, id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s %s to check --expect/--if-match: %w", kind, id, err)
+	}
+	return e.check(kind+" "+id, item, nil)
+}
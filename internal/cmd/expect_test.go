@@ -0,0 +1,82 @@
+// internal/cmd/expect_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExpectFlag_Check(t *testing.T) {
+	resource := map[string]interface{}{
+		"url":       "https://old.com",
+		"archived":  false,
+		"clicks":    12.0,
+		"title":     nil,
+		"updatedAt": "2026-01-01T00:00:00Z",
+	}
+
+	tests := []struct {
+		name    string
+		expect  expectFlag
+		header  http.Header
+		wantErr string
+	}{
+		{name: "match", expect: expectFlag{raw: []string{"url=https://old.com", "archived=false", "clicks=12", "title="}}},
+		{name: "null", expect: expectFlag{raw: []string{"title=null"}}},
+		{name: "mismatch", expect: expectFlag{raw: []string{"url=https://older.com"}}, wantErr: `url is "https://old.com" (expected "https://older.com")`},
+		{name: "missing field", expect: expectFlag{raw: []string{"comments=x"}}, wantErr: "comments is not set"},
+		{name: "etag", expect: expectFlag{ifMatch: `"v2"`}, header: http.Header{"Etag": {`"v2"`}}},
+		{name: "etag mismatch", expect: expectFlag{ifMatch: "v1"}, header: http.Header{"Etag": {`"v2"`}}, wantErr: "ETag is"},
+		{name: "updatedAt", expect: expectFlag{ifMatch: "2026-01-01T00:00:00Z"}},
+		{name: "updatedAt mismatch", expect: expectFlag{ifMatch: "2025-12-31T00:00:00Z"}, wantErr: "updatedAt is"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.expect.check("link link_1", resource, tt.header)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExpectFlag_Validate(t *testing.T) {
+	for _, raw := range []string{"url", "=x"} {
+		e := expectFlag{raw: []string{raw}}
+		if err := e.validate(); err == nil || !IsUsageError(err) {
+			t.Errorf("--expect %q: expected usage error, got %v", raw, err)
+		}
+	}
+}
+
+func TestLinksUpdate_ExpectRefusesChangedLink(t *testing.T) {
+	patched := false
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			patched = true
+		}
+		_, _ = w.Write([]byte(`{"id":"link_1","url":"https://someone-else.com"}`))
+	})
+
+	cmd := newLinksUpdateCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--id", "link_1", "--url", "https://new.com", "--expect", "url=https://old.com"})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "changed since it was read") {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if patched {
+		t.Error("link was updated despite the failed expectation")
+	}
+}
@@ -0,0 +1,252 @@
+// internal/cmd/feedback.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+const (
+	// issuesNewURL is the GitHub page for opening a new issue
+	issuesNewURL = "https://github.com/" + repoOwner + "/" + repoName + "/issues/new"
+	// maxIssueURLLength keeps prefilled issue URLs below common browser/server limits
+	maxIssueURLLength = 8000
+	// debugLogExcerptLines is the number of trailing debug log lines included in a bundle
+	debugLogExcerptLines = 50
+)
+
+// apiKeyPattern matches Dub API keys so they can be redacted from diagnostics.
+var apiKeyPattern = regexp.MustCompile(`dub_[A-Za-z0-9_]+`)
+
+// lastError is the most recent command failure, persisted for bug reports.
+type lastError struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	Error   string    `json:"error"`
+}
+
+// lastErrorPath returns the path of the file recording the most recent failure.
+func lastErrorPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "last_error.json"), nil
+}
+
+// recordLastError persists a failed command for later inclusion in `dub feedback`.
+// Errors are ignored: failing to record diagnostics must never mask the real error.
+func recordLastError(cmd *cobra.Command, err error) {
+	path, pathErr := lastErrorPath()
+	if pathErr != nil {
+		return
+	}
+
+	command := "dub"
+	if cmd != nil {
+		command = cmd.CommandPath()
+	}
+
+	data, marshalErr := json.MarshalIndent(lastError{
+		Time:    time.Now().UTC(),
+		Command: command,
+		Error:   redactSecrets(err.Error()),
+	}, "", "  ")
+	if marshalErr != nil {
+		return
+	}
+
+	if mkErr := os.MkdirAll(filepath.Dir(path), 0o700); mkErr != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// loadLastError reads the most recently recorded failure.
+// Returns nil if no failure has been recorded.
+func loadLastError() *lastError {
+	path, err := lastErrorPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var le lastError
+	if err := json.Unmarshal(data, &le); err != nil {
+		return nil
+	}
+	return &le
+}
+
+func newFeedbackCmd() *cobra.Command {
+	var (
+		title  string
+		noOpen bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "feedback",
+		Aliases: []string{"bug"},
+		Short:   "Report a bug with a diagnostic bundle",
+		Long: `Assemble a diagnostic bundle and open a prefilled GitHub issue.
+
+The bundle contains the CLI version, OS, redacted configuration, the most
+recent command error, and an excerpt of the last --debug log. API keys are
+redacted before anything leaves your machine.
+
+Examples:
+  dub feedback                       # Open a prefilled issue in the browser
+  dub bug --title "links list hangs"  # Same, with an issue title
+  dub feedback --out bundle.md       # Write the bundle to a file instead`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bundle := buildDiagnosticBundle()
+
+			if w, ok := cmd.Context().Value(outFileKey).(*outfmt.FileWriter); ok {
+				// The bundle may quote debug output, so keep the file private
+				w.SetPerm(0o600)
+				_, _ = fmt.Fprint(cmd.OutOrStdout(), bundle)
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Diagnostic bundle written to %s\n", w.Path())
+				return nil
+			}
+
+			issueURL := buildIssueURL(title, bundle)
+			if !noOpen {
+				if err := openBrowser(issueURL); err == nil {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Opened a prefilled issue in your browser.")
+					return nil
+				}
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Open this URL to file the issue:\n  %s\n", issueURL)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", "Issue title")
+	cmd.Flags().BoolVar(&noOpen, "no-open", false, "Print the issue URL instead of opening the browser")
+
+	return cmd
+}
+
+// buildDiagnosticBundle assembles a markdown report describing the local environment.
+func buildDiagnosticBundle() string {
+	var sb strings.Builder
+
+	sb.WriteString("## Description\n\n<!-- What happened, and what did you expect? -->\n\n")
+
+	sb.WriteString("## Environment\n\n")
+	fmt.Fprintf(&sb, "- Version: %s\n", Version)
+	fmt.Fprintf(&sb, "- Commit: %s\n", Commit)
+	fmt.Fprintf(&sb, "- Built: %s\n", Date)
+	fmt.Fprintf(&sb, "- OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&sb, "- Go: %s\n", runtime.Version())
+
+	sb.WriteString("\n## Configuration\n\n")
+	if cfg, err := config.Load(); err != nil {
+		fmt.Fprintf(&sb, "- Config: unreadable (%s)\n", redactSecrets(err.Error()))
+	} else {
+		fmt.Fprintf(&sb, "- Default workspace: %s\n", valueOrUnset(cfg.DefaultWorkspace))
+	}
+	fmt.Fprintf(&sb, "- DUB_API_KEY: %s\n", envPresence("DUB_API_KEY"))
+	fmt.Fprintf(&sb, "- DUB_WORKSPACE: %s\n", valueOrUnset(os.Getenv("DUB_WORKSPACE")))
+	fmt.Fprintf(&sb, "- DUB_OUTPUT: %s\n", valueOrUnset(os.Getenv("DUB_OUTPUT")))
+
+	sb.WriteString("\n## Most Recent Error\n\n")
+	if le := loadLastError(); le != nil {
+		fmt.Fprintf(&sb, "- Time: %s\n", le.Time.Format(time.RFC3339))
+		fmt.Fprintf(&sb, "- Command: %s\n", le.Command)
+		fmt.Fprintf(&sb, "- Error: %s\n", redactSecrets(le.Error))
+	} else {
+		sb.WriteString("None recorded.\n")
+	}
+
+	sb.WriteString("\n## Debug Log Excerpt\n\n")
+	logPath, err := config.DebugLogPath()
+	var logData []byte
+	if err == nil {
+		logData, err = os.ReadFile(logPath)
+	}
+	if err != nil || len(logData) == 0 {
+		sb.WriteString("No debug log found. Re-run the failing command with --debug to capture one.\n")
+	} else {
+		sb.WriteString("```\n")
+		sb.WriteString(redactSecrets(tailLines(string(logData), debugLogExcerptLines)))
+		sb.WriteString("\n```\n")
+	}
+
+	return sb.String()
+}
+
+// buildIssueURL returns a GitHub new-issue URL prefilled with title and body.
+// The body is trimmed so the encoded URL stays within maxIssueURLLength.
+func buildIssueURL(title, body string) string {
+	build := func(b string) string {
+		params := url.Values{}
+		if title != "" {
+			params.Set("title", title)
+		}
+		params.Set("body", b)
+		return issuesNewURL + "?" + params.Encode()
+	}
+
+	const truncated = "\n\n_(truncated; run `dub feedback --out bundle.md` for the full bundle)_\n"
+
+	u := build(body)
+	for len(u) > maxIssueURLLength && len(body) > 0 {
+		// Shrink proportionally to the overshoot, at least a line at a time
+		cut := len(body) - (len(u)-maxIssueURLLength)/2 - len(truncated)
+		if cut < 0 {
+			cut = 0
+		}
+		if i := strings.LastIndex(body[:cut], "\n"); i >= 0 {
+			cut = i
+		}
+		body = body[:cut]
+		u = build(body + truncated)
+	}
+	return u
+}
+
+// redactSecrets masks API keys within s.
+func redactSecrets(s string) string {
+	return apiKeyPattern.ReplaceAllString(s, "dub_[REDACTED]")
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// envPresence reports whether a secret environment variable is set without revealing it.
+func envPresence(key string) string {
+	if os.Getenv(key) != "" {
+		return "set"
+	}
+	return "unset"
+}
+
+// valueOrUnset returns v, or "(unset)" if v is empty.
+func valueOrUnset(v string) string {
+	if v == "" {
+		return "(unset)"
+	}
+	return v
+}
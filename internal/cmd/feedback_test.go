@@ -0,0 +1,143 @@
+// internal/cmd/feedback_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFeedbackCmd_Alias(t *testing.T) {
+	cmd := NewRootCmd()
+
+	found, _, err := cmd.Find([]string{"bug"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found.Name() != "feedback" {
+		t.Errorf("expected 'bug' to resolve to feedback, got %q", found.Name())
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"no secrets here", "no secrets here"},
+		{"key=dub_abc123XYZ failed", "key=dub_[REDACTED] failed"},
+		{"Bearer dub_live_a1b2 and dub_x", "Bearer dub_[REDACTED] and dub_[REDACTED]"},
+	}
+
+	for _, tt := range tests {
+		if got := redactSecrets(tt.input); got != tt.want {
+			t.Errorf("redactSecrets(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	input := "one\ntwo\nthree\nfour\n"
+
+	if got := tailLines(input, 2); got != "three\nfour" {
+		t.Errorf("tailLines(2) = %q, want %q", got, "three\nfour")
+	}
+	if got := tailLines(input, 10); got != "one\ntwo\nthree\nfour" {
+		t.Errorf("tailLines(10) = %q", got)
+	}
+}
+
+func TestBuildIssueURL(t *testing.T) {
+	u := buildIssueURL("crash on list", "## Environment\n- Version: dev\n")
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		t.Fatalf("invalid URL: %v", err)
+	}
+	if !strings.HasPrefix(u, issuesNewURL) {
+		t.Errorf("expected URL to start with %s, got %s", issuesNewURL, u)
+	}
+	if got := parsed.Query().Get("title"); got != "crash on list" {
+		t.Errorf("expected title param, got %q", got)
+	}
+	if got := parsed.Query().Get("body"); !strings.Contains(got, "Version: dev") {
+		t.Errorf("expected body param to contain bundle, got %q", got)
+	}
+}
+
+func TestBuildIssueURL_Truncates(t *testing.T) {
+	body := strings.Repeat("a long debug log line that keeps going\n", 1000)
+
+	u := buildIssueURL("", body)
+	if len(u) > maxIssueURLLength {
+		t.Errorf("expected URL length <= %d, got %d", maxIssueURLLength, len(u))
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		t.Fatalf("invalid URL: %v", err)
+	}
+	if !strings.Contains(parsed.Query().Get("body"), "truncated") {
+		t.Error("expected truncated body to carry a truncation note")
+	}
+}
+
+func TestRecordAndLoadLastError(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	if le := loadLastError(); le != nil {
+		t.Fatalf("expected no recorded error, got %+v", le)
+	}
+
+	recordLastError(newLinksCreateCmd(), errors.New("request failed for dub_secret123"))
+
+	le := loadLastError()
+	if le == nil {
+		t.Fatal("expected recorded error")
+	}
+	if le.Command != "create" {
+		t.Errorf("expected command 'create', got %q", le.Command)
+	}
+	if strings.Contains(le.Error, "dub_secret123") {
+		t.Errorf("expected API key to be redacted, got %q", le.Error)
+	}
+}
+
+func TestFeedbackCmd_WritesBundle(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_API_KEY", "dub_shouldnotleak")
+
+	out := filepath.Join(t.TempDir(), "bundle.md")
+
+	var buf bytes.Buffer
+	if err := execute(context.Background(), []string{"feedback", "--out", out}, nil, &bytes.Buffer{}, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("expected bundle file: %v", err)
+	}
+	if info, err := os.Stat(out); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("expected a private bundle file, got %v, %v", info.Mode(), err)
+	}
+	bundle := string(data)
+
+	for _, want := range []string{"## Environment", "## Configuration", "## Most Recent Error", "## Debug Log Excerpt", "DUB_API_KEY: set"} {
+		if !strings.Contains(bundle, want) {
+			t.Errorf("expected bundle to contain %q", want)
+		}
+	}
+	if strings.Contains(bundle, "dub_shouldnotleak") {
+		t.Error("bundle must not contain the API key")
+	}
+	if !strings.Contains(buf.String(), out) {
+		t.Errorf("expected output to mention bundle path, got %q", buf.String())
+	}
+}
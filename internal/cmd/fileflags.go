@@ -0,0 +1,107 @@
+// internal/cmd/fileflags.go
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// expandFileFlags replaces string flag values of the form @path with the
+// contents of that file, or of stdin for @-. A single trailing newline is
+// dropped. A leading @@ escapes a literal @ (e.g. --name @@handle).
+func expandFileFlags(cmd *cobra.Command) error {
+	stdinUsed := false
+
+	read := func(flag, value string) (string, bool, error) {
+		if !strings.HasPrefix(value, "@") {
+			return value, false, nil
+		}
+		if strings.HasPrefix(value, "@@") {
+			return value[1:], true, nil
+		}
+
+		path := value[1:]
+		var data []byte
+		var err error
+		switch path {
+		case "":
+			return "", false, NewUsageErrorf("--%s: missing file name after @", flag)
+		case "-":
+			if stdinUsed {
+				return "", false, NewUsageErrorf("--%s: stdin (@-) can only be used by one flag", flag)
+			}
+			stdinUsed = true
+			data, err = io.ReadAll(cmd.InOrStdin())
+		default:
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read --%s from %s: %w", flag, value, err)
+		}
+
+		s := strings.TrimSuffix(string(data), "\n")
+		s = strings.TrimSuffix(s, "\r")
+		return s, true, nil
+	}
+
+	var firstErr error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if firstErr != nil {
+			return
+		}
+
+		switch f.Value.Type() {
+		case "string":
+			value, changed, err := read(f.Name, f.Value.String())
+			if err != nil {
+				firstErr = err
+				return
+			}
+			if changed {
+				firstErr = f.Value.Set(value)
+			}
+		case "stringSlice", "stringArray":
+			sv, ok := f.Value.(pflag.SliceValue)
+			if !ok {
+				return
+			}
+			items := sv.GetSlice()
+			expanded := false
+			for i, item := range items {
+				value, changed, err := read(f.Name, item)
+				if err != nil {
+					firstErr = err
+					return
+				}
+				if changed {
+					items[i] = value
+					expanded = true
+				}
+			}
+			if expanded {
+				firstErr = sv.Replace(items)
+			}
+		}
+	})
+
+	return firstErr
+}
+
+// splitLines splits multi-line flag input into trimmed, non-empty lines,
+// skipping # comments.
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
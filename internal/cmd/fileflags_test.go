@@ -0,0 +1,95 @@
+// internal/cmd/fileflags_test.go
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newFileFlagsTestCmd(name *string, tags *[]string) *cobra.Command {
+	cmd := &cobra.Command{Use: "test", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	cmd.Flags().StringVar(name, "name", "", "")
+	cmd.Flags().StringSliceVar(tags, "tag", nil, "")
+	return cmd
+}
+
+func TestExpandFileFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desc.txt")
+	if err := os.WriteFile(path, []byte("line one\nline two\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var name string
+	var tags []string
+	cmd := newFileFlagsTestCmd(&name, &tags)
+	cmd.SetIn(strings.NewReader("from-stdin\n"))
+	if err := cmd.ParseFlags([]string{"--name", "@" + path, "--tag", "@-", "--tag", "@@literal", "--tag", "plain"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := expandFileFlags(cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "line one\nline two" {
+		t.Errorf("expected file contents without trailing newline, got %q", name)
+	}
+	if strings.Join(tags, "|") != "from-stdin|@literal|plain" {
+		t.Errorf("unexpected tags: %v", tags)
+	}
+}
+
+func TestExpandFileFlags_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing file", []string{"--name", "@/nonexistent/file"}, "failed to read --name"},
+		{"empty path", []string{"--name", "@"}, "missing file name"},
+		{"stdin twice", []string{"--name", "@-", "--tag", "@-"}, "only be used by one flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var name string
+			var tags []string
+			cmd := newFileFlagsTestCmd(&name, &tags)
+			cmd.SetIn(strings.NewReader("x"))
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := expandFileFlags(cmd)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	got := splitLines("https://a.com\n\n# comment\n  https://b.com  \r\n")
+	if strings.Join(got, ",") != "https://a.com,https://b.com" {
+		t.Errorf("unexpected lines: %v", got)
+	}
+}
+
+func TestLinksCreate_MultipleURLsRejectsKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.txt")
+	if err := os.WriteFile(path, []byte("https://a.com\nhttps://b.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	root := NewRootCmd()
+	root.SetArgs([]string{"links", "create", "--url", "@" + path, "--key", "promo"})
+	root.SilenceErrors = true
+
+	err := root.Execute()
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error for --key with multiple URLs, got %v", err)
+	}
+}
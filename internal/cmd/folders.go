@@ -11,7 +11,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/config"
 	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
 )
 
 func newFoldersCmd() *cobra.Command {
@@ -24,6 +27,7 @@ func newFoldersCmd() *cobra.Command {
 	cmd.AddCommand(newFoldersCreateCmd())
 	cmd.AddCommand(newFoldersListCmd())
 	cmd.AddCommand(newFoldersUpdateCmd())
+	cmd.AddCommand(newFoldersMoveCmd())
 	cmd.AddCommand(newFoldersDeleteCmd())
 
 	return cmd
@@ -31,8 +35,9 @@ func newFoldersCmd() *cobra.Command {
 
 func newFoldersCreateCmd() *cobra.Command {
 	var (
-		name     string
-		parentID string
+		name      string
+		parentID  string
+		fromStdin stdinBody
 	)
 
 	cmd := &cobra.Command{
@@ -40,7 +45,13 @@ func newFoldersCreateCmd() *cobra.Command {
 		Short: "Create a folder",
 		Long:  "Create a new folder for organizing links.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if name == "" {
+			body := map[string]interface{}{}
+			if fromStdin.enabled {
+				var err error
+				if body, err = fromStdin.read(cmd); err != nil {
+					return err
+				}
+			} else if name == "" {
 				return fmt.Errorf("--name is required")
 			}
 
@@ -49,12 +60,15 @@ func newFoldersCreateCmd() *cobra.Command {
 				return err
 			}
 
-			body := map[string]interface{}{
-				"name": name,
+			if name != "" {
+				body["name"] = name
 			}
 			if parentID != "" {
 				body["parentId"] = parentID
 			}
+			if err := folderCreateSchema.validate(body, true); err != nil {
+				return err
+			}
 
 			resp, err := client.Post(cmd.Context(), "/folders", body)
 			if err != nil {
@@ -68,7 +82,9 @@ func newFoldersCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Folder name (required)")
 	cmd.Flags().StringVar(&parentID, "parent-id", "", "Parent folder ID (for nested folders)")
 
-	_ = cmd.MarkFlagRequired("name")
+	fromStdin.register(cmd, folderCreateSchema)
+
+	cmd.MarkFlagsOneRequired("name", "from-stdin")
 
 	return cmd
 }
@@ -133,6 +149,10 @@ func handleFoldersListResponse(cmd *cobra.Command, resp *http.Response, output s
 		return fmt.Errorf("%s", apiErr.Error())
 	}
 
+	if body, err = sortListBody(cmd, body); err != nil {
+		return err
+	}
+
 	// For JSON output, use the existing handler
 	if output == "json" {
 		var data interface{}
@@ -213,22 +233,27 @@ func formatAccessLevel(accessLevel interface{}) string {
 	return s
 }
 
-// formatFolderLinkCount extracts the link count from folder data.
-// The API returns link count in _count.links nested structure.
+// formatFolderLinkCount formats the link count from folder data.
 func formatFolderLinkCount(folder map[string]interface{}) string {
+	return formatClicks(folderLinkCount(folder))
+}
+
+// folderLinkCount extracts the link count from folder data.
+// The API returns link count in _count.links nested structure.
+func folderLinkCount(folder map[string]interface{}) int {
 	// Try _count.links nested structure first
 	if countObj, ok := folder["_count"].(map[string]interface{}); ok {
 		if links, ok := countObj["links"]; ok {
-			return formatClicks(outfmt.SafeInt(links))
+			return outfmt.SafeInt(links)
 		}
 	}
 
 	// Fallback to direct links field
 	if links, ok := folder["links"]; ok {
-		return formatClicks(outfmt.SafeInt(links))
+		return outfmt.SafeInt(links)
 	}
 
-	return "0"
+	return 0
 }
 
 func newFoldersUpdateCmd() *cobra.Command {
@@ -236,6 +261,8 @@ func newFoldersUpdateCmd() *cobra.Command {
 		id       string
 		name     string
 		parentID string
+		patch    patchFlag
+		expect   expectFlag
 	)
 
 	cmd := &cobra.Command{
@@ -246,6 +273,9 @@ func newFoldersUpdateCmd() *cobra.Command {
 			if id == "" {
 				return fmt.Errorf("--id is required")
 			}
+			if err := expect.validate(); err != nil {
+				return err
+			}
 
 			client, err := getClient(cmd.Context())
 			if err != nil {
@@ -260,9 +290,17 @@ func newFoldersUpdateCmd() *cobra.Command {
 				body["parentId"] = parentID
 			}
 
+			if err := patch.apply(body); err != nil {
+				return err
+			}
 			if len(body) == 0 {
-				return fmt.Errorf("at least one of --name or --parent-id must be specified")
+				return fmt.Errorf("at least one of --name, --parent-id, or --patch must be specified")
+			}
+
+			if err := expect.checkListed(cmd.Context(), client, "folder", "/folders", id); err != nil {
+				return err
 			}
+			invalidateNameCache(client, cache.KindFolder)
 
 			resp, err := client.Patch(cmd.Context(), "/folders/"+url.PathEscape(id), body)
 			if err != nil {
@@ -276,6 +314,8 @@ func newFoldersUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&id, "id", "", "Folder ID (required)")
 	cmd.Flags().StringVar(&name, "name", "", "New folder name")
 	cmd.Flags().StringVar(&parentID, "parent-id", "", "New parent folder ID")
+	patch.register(cmd)
+	expect.register(cmd)
 
 	_ = cmd.MarkFlagRequired("id")
 
@@ -284,15 +324,34 @@ func newFoldersUpdateCmd() *cobra.Command {
 
 func newFoldersDeleteCmd() *cobra.Command {
 	var (
-		id     string
-		dryRun bool
+		id        string
+		dryRun    bool
+		fromStdin stdinIDs
 	)
 
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a folder",
-		Long:  "Delete a folder from your workspace.",
+		Long: `Delete a folder from your workspace, or the folders whose IDs are piped in
+with --ids-from-stdin. Folders are snapshotted first so dub undo can
+recreate them.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromStdin.enabled {
+				ids, err := fromStdin.read(cmd, dryRun)
+				if err != nil {
+					return err
+				}
+				if dryRun {
+					printWouldDelete(cmd, "folder", ids)
+					return nil
+				}
+				client, err := getClient(cmd.Context())
+				if err != nil {
+					return err
+				}
+				return deleteFolders(cmd, client, ids)
+			}
+
 			if id == "" {
 				return fmt.Errorf("--id is required")
 			}
@@ -307,19 +366,54 @@ func newFoldersDeleteCmd() *cobra.Command {
 				return err
 			}
 
+			invalidateNameCache(client, cache.KindFolder)
+
+			snapshot, snapErr := snapshotListed(cmd.Context(), client, "/folders", id)
+
 			resp, err := client.Delete(cmd.Context(), "/folders/"+url.PathEscape(id))
 			if err != nil {
 				return err
 			}
+			if err := handleResponse(cmd, resp); err != nil {
+				return err
+			}
 
-			return handleResponse(cmd, resp)
+			if snapErr != nil {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), snapshotWarning("folder", id, snapErr))
+			}
+			recordHistory(cmd, newHistoryEntry("delete", "folder", id, outfmt.SafeString(snapshot["name"]), snapshot))
+			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&id, "id", "", "Folder ID (required)")
+	cmd.Flags().StringVar(&id, "id", "", "Folder ID (required unless --ids-from-stdin)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be deleted without actually deleting")
-
-	_ = cmd.MarkFlagRequired("id")
+	fromStdin.register(cmd, "id", "id")
 
 	return cmd
 }
+
+// deleteFolders deletes the folders with ids, recording each deletion in
+// the undo history with its snapshot from the folder listing.
+func deleteFolders(cmd *cobra.Command, client *api.Client, ids []string) error {
+	invalidateNameCache(client, cache.KindFolder)
+
+	snapshots := map[string]map[string]interface{}{}
+	var folders []map[string]interface{}
+	if err := getJSON(cmd.Context(), client, "/folders", &folders); err != nil {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(fmt.Sprintf("could not snapshot folders for undo: %v", err)))
+	}
+	for _, folder := range folders {
+		snapshots[outfmt.SafeString(folder["id"])] = folder
+	}
+
+	deleted, err := deleteByIDs(cmd, client, "folder", ids, func(id string) string {
+		return "/folders/" + url.PathEscape(id)
+	})
+	entries := make([]config.HistoryEntry, len(deleted))
+	for i, id := range deleted {
+		entries[i] = newHistoryEntry("delete", "folder", id, outfmt.SafeString(snapshots[id]["name"]), snapshots[id])
+	}
+	recordHistory(cmd, entries...)
+	return err
+}
@@ -15,7 +15,7 @@ func TestFoldersCmd_Name(t *testing.T) {
 
 func TestFoldersCmd_SubCommands(t *testing.T) {
 	cmd := newFoldersCmd()
-	subCmds := []string{"create", "list", "update", "delete"}
+	subCmds := []string{"create", "list", "update", "move", "delete"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range cmd.Commands() {
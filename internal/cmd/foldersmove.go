@@ -0,0 +1,350 @@
+// internal/cmd/foldersmove.go
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// folderTree is a declared folder hierarchy read by folders move
+// --from-file.
+type folderTree struct {
+	Folders []folderNode `yaml:"folders"`
+}
+
+// folderNode is one declared folder. ID binds it to an existing folder so
+// it can be renamed; otherwise it matches an existing folder by name.
+type folderNode struct {
+	Name     string       `yaml:"name"`
+	ID       string       `yaml:"id"`
+	Children []folderNode `yaml:"children"`
+}
+
+// existingFolder is a folder as listed by the API.
+type existingFolder struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	ParentID *string `json:"parentId"`
+}
+
+func (f existingFolder) parent() string {
+	if f.ParentID == nil {
+		return ""
+	}
+	return *f.ParentID
+}
+
+// folderChange is one folder of a declared tree and what reconciling it
+// takes. Folders already in place are kept in the plan, unchanged, so their
+// children can find their parent.
+type folderChange struct {
+	Path      string `json:"path"`
+	ID        string `json:"id,omitempty"`
+	Create    bool   `json:"create,omitempty"`
+	OldName   string `json:"oldName,omitempty"`
+	Moved     bool   `json:"moved,omitempty"`
+	OldParent string `json:"oldParent,omitempty"`
+
+	name   string
+	parent int // index of the parent change, or -1 at the top level
+}
+
+func (c folderChange) changed() bool {
+	return c.Create || c.OldName != "" || c.Moved
+}
+
+func newFoldersMoveCmd() *cobra.Command {
+	var (
+		id       string
+		parentID string
+		root     bool
+		fromFile string
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "move",
+		Short: "Move a folder, or reconcile the folder tree from a file",
+		Long: `Move a folder under another folder (--parent-id) or to the top level
+(--root).
+
+With --from-file, the whole folder tree is made to match a declared
+hierarchy: missing folders are created, and folders are moved and renamed
+into place. A declared folder matches an existing one by name, or by id,
+which lets it be renamed. Folders not in the file are left alone.
+
+  folders:
+    - name: Marketing
+      children:
+        - name: Campaigns
+        - name: Social
+          id: fold_abc   # renamed from whatever it's called now
+    - name: Support
+
+Examples:
+  dub folders move --id fold_abc --parent-id fold_def
+  dub folders move --id fold_abc --root
+  dub folders move --from-file structure.yaml --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromFile == "" && parentID == "" && !root {
+				return NewUsageErrorf("--parent-id or --root is required with --id")
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if fromFile != "" {
+				tree, err := loadFolderTree(fromFile)
+				if err != nil {
+					return err
+				}
+				var existing []existingFolder
+				if err := getJSON(cmd.Context(), client, "/folders", &existing); err != nil {
+					return fmt.Errorf("failed to list folders: %w", err)
+				}
+				changes, err := planFolderTree(tree, existing)
+				if err != nil {
+					return err
+				}
+				return reconcileFolders(cmd, client, changes, dryRun)
+			}
+
+			var parent interface{}
+			target := "the top level"
+			if !root {
+				parent = parentID
+				target = parentID
+			}
+			if dryRun {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Would move folder %s to %s\n", id, target)
+				return nil
+			}
+
+			invalidateNameCache(client, cache.KindFolder)
+			resp, err := client.Patch(cmd.Context(), "/folders/"+url.PathEscape(id), map[string]interface{}{"parentId": parent})
+			if err != nil {
+				return err
+			}
+			return handleResponse(cmd, resp)
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Folder ID to move")
+	cmd.Flags().StringVar(&parentID, "parent-id", "", "Move the folder under this folder")
+	cmd.Flags().BoolVar(&root, "root", false, "Move the folder to the top level")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Reconcile the folder tree to match this YAML file")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without changing anything")
+
+	cmd.MarkFlagsOneRequired("id", "from-file")
+	cmd.MarkFlagsMutuallyExclusive("id", "from-file")
+	cmd.MarkFlagsMutuallyExclusive("parent-id", "root")
+	cmd.MarkFlagsMutuallyExclusive("parent-id", "from-file")
+	cmd.MarkFlagsMutuallyExclusive("root", "from-file")
+
+	return cmd
+}
+
+// loadFolderTree reads and validates a folder structure file.
+func loadFolderTree(path string) (*folderTree, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read folder structure: %w", err)
+	}
+
+	var tree folderTree
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&tree); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid folder structure %s: %w", path, err)
+	}
+	if len(tree.Folders) == 0 {
+		return nil, fmt.Errorf("folder structure %s declares no folders", path)
+	}
+	return &tree, nil
+}
+
+// planFolderTree works out how to make existing match tree, listing every
+// declared folder parents first.
+func planFolderTree(tree *folderTree, existing []existingFolder) ([]folderChange, error) {
+	byID := map[string]existingFolder{}
+	byName := map[string][]existingFolder{}
+	for _, f := range existing {
+		byID[f.ID] = f
+		byName[strings.ToLower(f.Name)] = append(byName[strings.ToLower(f.Name)], f)
+	}
+
+	// pathOf names an existing folder by its current path
+	pathOf := func(id string) string {
+		var parts []string
+		for seen := map[string]bool{}; id != "" && !seen[id]; {
+			seen[id] = true
+			f, ok := byID[id]
+			if !ok {
+				break
+			}
+			parts = append([]string{f.Name}, parts...)
+			id = f.parent()
+		}
+		return strings.Join(parts, "/")
+	}
+
+	var changes []folderChange
+	claimed := map[string]string{}
+
+	var walk func(nodes []folderNode, parent int, parentPath string) error
+	walk = func(nodes []folderNode, parent int, parentPath string) error {
+		for _, node := range nodes {
+			name := strings.TrimSpace(node.Name)
+			if name == "" {
+				return fmt.Errorf("a folder under %q has no name", parentPath)
+			}
+			c := folderChange{Path: strings.TrimPrefix(parentPath+"/"+name, "/"), name: name, parent: parent}
+
+			var match *existingFolder
+			if node.ID != "" {
+				f, ok := byID[node.ID]
+				if !ok {
+					return fmt.Errorf("%s: folder %s not found", c.Path, node.ID)
+				}
+				match = &f
+			} else if found := byName[strings.ToLower(name)]; len(found) > 1 {
+				return fmt.Errorf("%s: %d folders are named %q; set id to pick one", c.Path, len(found), name)
+			} else if len(found) == 1 {
+				match = &found[0]
+			}
+
+			if match == nil {
+				c.Create = true
+			} else {
+				if other, ok := claimed[match.ID]; ok {
+					return fmt.Errorf("%s: folder %s is already declared as %s", c.Path, match.ID, other)
+				}
+				claimed[match.ID] = c.Path
+				c.ID = match.ID
+
+				if match.Name != name {
+					c.OldName = match.Name
+				}
+				wantParent := ""
+				if parent >= 0 {
+					wantParent = changes[parent].ID
+				}
+				if (parent >= 0 && changes[parent].Create) || match.parent() != wantParent {
+					c.Moved = true
+					c.OldParent = pathOf(match.parent())
+				}
+			}
+
+			changes = append(changes, c)
+			if err := walk(node.Children, len(changes)-1, c.Path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(tree.Folders, -1, ""); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// reconcileFolders applies changes in order, creating parents before their
+// children, and reports each change.
+func reconcileFolders(cmd *cobra.Command, client *api.Client, changes []folderChange, dryRun bool) error {
+	var changed []folderChange
+	for _, c := range changes {
+		if c.changed() {
+			changed = append(changed, c)
+		}
+	}
+
+	if !dryRun && len(changed) > 0 {
+		invalidateNameCache(client, cache.KindFolder)
+		ids := make([]string, len(changes))
+		for i, c := range changes {
+			ids[i] = c.ID
+			if !c.changed() {
+				continue
+			}
+
+			var parentID interface{}
+			if c.parent >= 0 {
+				parentID = ids[c.parent]
+			}
+			if c.Create {
+				body := map[string]interface{}{"name": c.name}
+				if parentID != nil {
+					body["parentId"] = parentID
+				}
+				var folder map[string]interface{}
+				if err := postJSON(cmd.Context(), client, "/folders", body, &folder); err != nil {
+					return fmt.Errorf("failed to create %s: %w", c.Path, err)
+				}
+				ids[i] = outfmt.SafeString(folder["id"])
+			} else {
+				body := map[string]interface{}{}
+				if c.OldName != "" {
+					body["name"] = c.name
+				}
+				if c.Moved {
+					body["parentId"] = parentID
+				}
+				if err := discardResponse(client.Patch(cmd.Context(), "/folders/"+url.PathEscape(c.ID), body)); err != nil {
+					return fmt.Errorf("failed to update %s: %w", c.Path, err)
+				}
+			}
+		}
+	}
+
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		if changed == nil {
+			changed = []folderChange{}
+		}
+		return outfmt.FormatJSON(cmd.OutOrStdout(), changed, outfmt.GetQuery(cmd.Context()))
+	}
+
+	w := cmd.OutOrStdout()
+	if len(changed) == 0 {
+		_, _ = fmt.Fprintln(w, "Folder tree already matches.")
+		return nil
+	}
+	verb := func(present, past string) string {
+		if dryRun {
+			return "Would " + present
+		}
+		return past
+	}
+	for _, c := range changed {
+		if c.Create {
+			_, _ = fmt.Fprintf(w, "%s %s\n", verb("create", "Created"), c.Path)
+			continue
+		}
+		if c.OldName != "" {
+			_, _ = fmt.Fprintf(w, "%s %s to %s\n", verb("rename", "Renamed"), c.OldName, c.Path)
+		}
+		if c.Moved {
+			from := c.OldParent
+			if from == "" {
+				from = "the top level"
+			}
+			_, _ = fmt.Fprintf(w, "%s %s from %s\n", verb("move", "Moved"), c.Path, from)
+		}
+	}
+	return nil
+}
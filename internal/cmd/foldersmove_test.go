@@ -0,0 +1,140 @@
+// internal/cmd/foldersmove_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlanFolderTree(t *testing.T) {
+	existing := []existingFolder{
+		{ID: "fold_mkt", Name: "Marketing"},
+		{ID: "fold_camp", Name: "Campaigns"},
+		{ID: "fold_old", Name: "Old social", ParentID: strPtr("fold_camp")},
+		{ID: "fold_sup", Name: "Support", ParentID: strPtr("fold_mkt")},
+	}
+	tree := &folderTree{Folders: []folderNode{
+		{Name: "Marketing", Children: []folderNode{
+			{Name: "Campaigns"},
+			{Name: "Social", ID: "fold_old"},
+			{Name: "Events", Children: []folderNode{{Name: "2026"}}},
+		}},
+		{Name: "Support"},
+	}}
+
+	changes, err := planFolderTree(tree, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []folderChange
+	for _, c := range changes {
+		c.name, c.parent = "", 0
+		got = append(got, c)
+	}
+	want := []folderChange{
+		{Path: "Marketing", ID: "fold_mkt"},
+		{Path: "Marketing/Campaigns", ID: "fold_camp", Moved: true},
+		{Path: "Marketing/Social", ID: "fold_old", OldName: "Old social", Moved: true, OldParent: "Campaigns"},
+		{Path: "Marketing/Events", Create: true},
+		{Path: "Marketing/Events/2026", Create: true},
+		{Path: "Support", ID: "fold_sup", Moved: true, OldParent: "Marketing"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("plan mismatch\ngot:  %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestPlanFolderTree_Errors(t *testing.T) {
+	existing := []existingFolder{{ID: "fold_1", Name: "Dup"}, {ID: "fold_2", Name: "dup"}}
+	for name, tree := range map[string]*folderTree{
+		"ambiguous": {Folders: []folderNode{{Name: "Dup"}}},
+		"unknown":   {Folders: []folderNode{{Name: "X", ID: "fold_missing"}}},
+		"claimed":   {Folders: []folderNode{{Name: "A", ID: "fold_1"}, {Name: "B", ID: "fold_1"}}},
+		"unnamed":   {Folders: []folderNode{{Name: " "}}},
+	} {
+		if _, err := planFolderTree(tree, existing); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestFoldersMove_FromFile(t *testing.T) {
+	var requests []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"id": "fold_camp", "name": "Campaigns", "parentId": null}]`))
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		data, _ := json.Marshal(body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(data))
+		_, _ = w.Write([]byte(`{"id": "fold_new"}`))
+	})
+
+	path := filepath.Join(t.TempDir(), "structure.yaml")
+	structure := "folders:\n  - name: Marketing\n    children:\n      - name: Campaigns\n"
+	if err := os.WriteFile(path, []byte(structure), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		var stdout bytes.Buffer
+		cmd := newFoldersMoveCmd()
+		cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+		cmd.SetOut(&stdout)
+		cmd.SetArgs(append([]string{"--from-file", path}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return stdout.String()
+	}
+
+	if out := run("--dry-run"); out != "Would create Marketing\nWould move Marketing/Campaigns from the top level\n" {
+		t.Errorf("unexpected dry-run output %q", out)
+	}
+	if len(requests) != 0 {
+		t.Fatalf("dry run sent %v", requests)
+	}
+
+	if out := run(); !strings.Contains(out, "Created Marketing\nMoved Marketing/Campaigns") {
+		t.Errorf("unexpected output %q", out)
+	}
+	want := []string{
+		`POST /folders {"name":"Marketing"}`,
+		`PATCH /folders/fold_camp {"parentId":"fold_new"}`,
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestFoldersMove_Root(t *testing.T) {
+	var body map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/folders/fold_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"id": "fold_1"}`))
+	})
+
+	cmd := newFoldersMoveCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--id", "fold_1", "--root"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := body["parentId"]; !ok || v != nil {
+		t.Errorf("expected parentId null, got %v", body)
+	}
+}
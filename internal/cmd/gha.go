@@ -0,0 +1,164 @@
+// internal/cmd/gha.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+)
+
+// ghaOutput is the --output value for GitHub Actions.
+const ghaOutput = "gha"
+
+// ghaActions name what each mutating method did, for annotations.
+var ghaActions = map[string]string{
+	http.MethodPost:   "Created",
+	http.MethodPut:    "Updated",
+	http.MethodPatch:  "Updated",
+	http.MethodDelete: "Deleted",
+}
+
+// ghaChange is one mutating request made by the command.
+type ghaChange struct {
+	Action   string
+	Resource string
+	Request  string
+	Status   int
+	Err      string
+}
+
+// ghaReporter reports a command's changes and errors as GitHub Actions
+// workflow commands, and adds a table of the changes to the job's step
+// summary. Output is otherwise text, so annotations go to stderr.
+type ghaReporter struct {
+	w           io.Writer
+	command     string
+	summaryPath string
+
+	mu      sync.Mutex
+	changes []ghaChange
+}
+
+func newGHAReporter(cmd *cobra.Command) *ghaReporter {
+	return &ghaReporter{
+		w:           cmd.ErrOrStderr(),
+		command:     cmd.CommandPath(),
+		summaryPath: os.Getenv("GITHUB_STEP_SUMMARY"),
+	}
+}
+
+// ghaMode reports whether --output gha was given, either globally or to a
+// command's own --output flag.
+func ghaMode(cmd *cobra.Command) bool {
+	f := cmd.Flags().Lookup("output")
+	return f != nil && f.Value.String() == ghaOutput
+}
+
+// getGHAReporter returns the command's reporter, or nil outside --output gha.
+func getGHAReporter(ctx context.Context) *ghaReporter {
+	if ctx == nil {
+		return nil
+	}
+	if v, ok := ctx.Value(ghaKey).(*ghaReporter); ok {
+		return v
+	}
+	return nil
+}
+
+// Audit records a mutating request, and annotates it once it succeeded.
+// Failures surface through the command's error.
+func (r *ghaReporter) Audit(e api.AuditEntry) {
+	change := ghaChange{
+		Action:   ghaActions[e.Method],
+		Resource: e.ResourceID,
+		Request:  e.Method + " " + e.Path,
+		Status:   e.StatusCode,
+	}
+	switch {
+	case e.Err != nil:
+		change.Err = e.Err.Error()
+	case e.StatusCode >= 400:
+		change.Err = http.StatusText(e.StatusCode)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, change)
+	if change.Err == "" {
+		msg := change.Action + " " + change.Request
+		if change.Resource != "" {
+			msg = change.Action + " " + change.Resource + " (" + change.Request + ")"
+		}
+		_, _ = fmt.Fprintf(r.w, "::notice title=%s::%s\n", ghaEscapeProperty(r.command), ghaEscape(msg))
+	}
+}
+
+// printError annotates the error the command failed with.
+func (r *ghaReporter) printError(err error) {
+	_, _ = fmt.Fprintf(r.w, "::error title=%s::%s\n", ghaEscapeProperty(r.command), ghaEscape(err.Error()))
+}
+
+// writeSummary appends the command's changes, and its error if it failed,
+// to the step summary. Commands that changed nothing and succeeded add
+// nothing.
+func (r *ghaReporter) writeSummary(err error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.summaryPath == "" || (len(r.changes) == 0 && err == nil) {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### `%s`\n\n", r.command)
+	if len(r.changes) > 0 {
+		b.WriteString("| Action | Resource | Request | Result |\n| --- | --- | --- | --- |\n")
+		for _, c := range r.changes {
+			result := fmt.Sprint(c.Status)
+			if c.Err != "" {
+				result = "failed: " + c.Err
+			}
+			fmt.Fprintf(&b, "| %s | %s | `%s` | %s |\n",
+				c.Action, ghaCell(dashIfEmpty(c.Resource)), ghaCell(c.Request), ghaCell(result))
+		}
+		b.WriteString("\n")
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "**Error:** %s\n\n", ghaCell(err.Error()))
+	}
+
+	f, ferr := os.OpenFile(r.summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if ferr != nil {
+		return fmt.Errorf("failed to write step summary: %w", ferr)
+	}
+	_, werr := f.WriteString(b.String())
+	if cerr := f.Close(); werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		return fmt.Errorf("failed to write step summary: %w", werr)
+	}
+	return nil
+}
+
+// ghaEscape escapes the message of a workflow command.
+func ghaEscape(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// ghaEscapeProperty escapes a workflow command property such as title.
+func ghaEscapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// ghaCell makes s safe inside a markdown table cell.
+func ghaCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r", "", "\n", " ").Replace(s)
+}
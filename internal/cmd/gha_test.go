@@ -0,0 +1,75 @@
+// internal/cmd/gha_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGHAOutput(t *testing.T) {
+	summary := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", summary)
+
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/tags":
+			_, _ = w.Write([]byte(`{"id": "tag_123", "name": "launch"}`))
+		case r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": "not_found", "message": "Tag not found"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"tags", "create", "--name", "launch", "--api-url", server.URL, "-o", "gha"}
+	if err := execute(context.Background(), args, nil, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if got := stderr.String(); got != "::notice title=dub tags create::Created tag_123 (POST /tags)\n" {
+		t.Errorf("unexpected annotations %q", got)
+	}
+	if !strings.Contains(stdout.String(), "tag_123") {
+		t.Errorf("expected the usual output on stdout, got %q", stdout.String())
+	}
+
+	stderr.Reset()
+	args = []string{"tags", "update", "--id", "tag_999", "--name", "x", "--api-url", server.URL, "-o", "gha"}
+	if err := execute(context.Background(), args, nil, &bytes.Buffer{}, &stderr); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := stderr.String(); !strings.HasPrefix(got, "::error title=dub tags update::") || strings.Contains(got, "Error:") {
+		t.Errorf("expected an error annotation, got %q", got)
+	}
+
+	data, err := os.ReadFile(summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"### `dub tags create`",
+		"| Created | tag_123 | `POST /tags` | 200 |",
+		"### `dub tags update`",
+		"| Updated | tag_999 | `PATCH /tags/tag_999` | failed: Not Found |",
+		"**Error:**",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in step summary:\n%s", want, data)
+		}
+	}
+}
+
+func TestGHAEscape(t *testing.T) {
+	if got := ghaEscape("50% done\nnext"); got != "50%25 done%0Anext" {
+		t.Errorf("ghaEscape() = %q", got)
+	}
+	if got := ghaEscapeProperty("a:b,c"); got != "a%3Ab%2Cc" {
+		t.Errorf("ghaEscapeProperty() = %q", got)
+	}
+}
@@ -0,0 +1,353 @@
+// internal/cmd/history.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// restorableFields are the fields of a snapshot sent back to the create
+// endpoint when a deleted resource is recreated.
+var restorableFields = map[string][]string{
+	"link": {
+		"url", "domain", "key", "externalId", "tenantId", "title", "description", "image", "video",
+		"folderId", "expiresAt", "expiredUrl", "password", "proxy", "rewrite", "doIndex", "ios", "android",
+		"geo", "comments", "trackConversion", "publicStats",
+		"utm_source", "utm_medium", "utm_campaign", "utm_term", "utm_content",
+	},
+	"tag":    {"name", "color"},
+	"folder": {"name", "accessLevel"},
+}
+
+func newHistoryCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List recent operations that can be undone",
+		Long: fmt.Sprintf(`List recent deletes and archives made by this CLI, newest first.
+
+Link, tag, and folder deletes keep a local snapshot of the resource so
+dub undo can recreate it, and link archives can be reversed. The last %d
+operations are kept.
+
+Examples:
+  dub history
+  dub history --all   # include operations already undone`, config.HistoryLimit),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			history, err := config.LoadHistory()
+			if err != nil {
+				return fmt.Errorf("failed to read history: %w", err)
+			}
+
+			var entries []config.HistoryEntry
+			for i := len(history) - 1; i >= 0; i-- {
+				if all || !history[i].Undone {
+					entries = append(entries, history[i])
+				}
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				if entries == nil {
+					entries = []config.HistoryEntry{}
+				}
+				return outfmt.FormatJSON(cmd.OutOrStdout(), entries, outfmt.GetQuery(cmd.Context()))
+			}
+			if len(entries) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No operations to undo.")
+				return nil
+			}
+
+			columns := []outfmt.Column{
+				{Name: "#", Width: 0, Align: outfmt.AlignRight},
+				{Name: "When", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Workspace", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Operation", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Resource", Width: 0, Align: outfmt.AlignLeft},
+				{Name: "Status", Width: 0, Align: outfmt.AlignLeft},
+			}
+			rows := make([][]string, len(entries))
+			for i, e := range entries {
+				status := "undoable"
+				switch {
+				case e.Undone:
+					status = "undone"
+				case e.Action == "delete" && e.Snapshot == nil:
+					status = "no snapshot"
+				}
+				rows[i] = []string{
+					strconv.Itoa(e.Seq),
+					e.Time.Local().Format("Jan 2 15:04"),
+					dashIfEmpty(e.Workspace),
+					e.Action + " " + e.Kind,
+					historyLabel(e),
+					status,
+				}
+			}
+			return outfmt.FormatTable(cmd.OutOrStdout(), columns, rows)
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Include operations that were already undone")
+
+	return cmd
+}
+
+func newUndoCmd() *cobra.Command {
+	var password string
+
+	cmd := &cobra.Command{
+		Use:   "undo [#]",
+		Short: "Undo the last delete or archive",
+		Long: `Reverse the most recent operation in dub history, or the one numbered #.
+
+Deleted links, tags, and folders are recreated from the snapshot taken
+before they were deleted. A recreated resource gets a new ID, and a link's
+clicks and analytics are not restored. Archived links are unarchived.
+
+Link passwords are not kept in the history, so recreating a
+password-protected link needs --password to set its password again.
+
+Examples:
+  dub undo
+  dub undo 12
+  dub undo 12 --password 's3cret'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			history, err := config.LoadHistory()
+			if err != nil {
+				return fmt.Errorf("failed to read history: %w", err)
+			}
+
+			idx := -1
+			if len(args) == 1 {
+				seq, err := strconv.Atoi(args[0])
+				if err != nil {
+					return NewUsageErrorf("invalid operation number %q", args[0])
+				}
+				for i, e := range history {
+					if e.Seq == seq {
+						idx = i
+					}
+				}
+				if idx < 0 {
+					return fmt.Errorf("operation %d is not in the history. Run: dub history", seq)
+				}
+				if history[idx].Undone {
+					return fmt.Errorf("operation %d was already undone", seq)
+				}
+			} else {
+				for i := len(history) - 1; i >= 0; i-- {
+					if !history[i].Undone {
+						idx = i
+						break
+					}
+				}
+				if idx < 0 {
+					return fmt.Errorf("nothing to undo")
+				}
+			}
+			entry := history[idx]
+			if entry.Action == "delete" && entry.Kind == "link" && entry.Snapshot["password"] == config.Redacted {
+				if password == "" {
+					return NewUsageErrorf("link %s was password-protected and its password is not kept in the history; pass --password to recreate it", historyLabel(entry))
+				}
+				entry.Snapshot = maps.Clone(entry.Snapshot)
+				entry.Snapshot["password"] = password
+			}
+
+			if ws, err := activeWorkspaceName(cmd.Context()); err == nil && entry.Workspace != "" && ws != entry.Workspace {
+				return fmt.Errorf("operation %d was made in workspace %q; run again with --workspace %s", entry.Seq, entry.Workspace, entry.Workspace)
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			restoredID, err := undoHistoryEntry(cmd.Context(), client, entry)
+			if err != nil {
+				return fmt.Errorf("failed to undo %s %s %s: %w", entry.Action, entry.Kind, historyLabel(entry), err)
+			}
+
+			history[idx].Undone = true
+			history[idx].RestoredID = restoredID
+			if err := config.SaveHistory(history); err != nil {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(fmt.Sprintf("could not update history: %v", err)))
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), history[idx], outfmt.GetQuery(cmd.Context()))
+			}
+			if entry.Action == "archive" {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Unarchived %s %s.\n", entry.Kind, historyLabel(entry))
+				return nil
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Recreated %s %s as %s.\n", entry.Kind, historyLabel(entry), restoredID)
+			if entry.Kind == "link" {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Clicks and analytics of the deleted link are not restored.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&password, "password", "", "Password for a recreated password-protected link")
+
+	return cmd
+}
+
+// undoHistoryEntry reverses entry and returns the ID of the restored resource.
+func undoHistoryEntry(ctx context.Context, client *api.Client, entry config.HistoryEntry) (string, error) {
+	switch entry.Action {
+	case "archive":
+		path := "/" + entry.Kind + "s/" + url.PathEscape(entry.ResourceID)
+		if err := discardResponse(client.Patch(ctx, path, map[string]interface{}{"archived": false})); err != nil {
+			return "", err
+		}
+		return entry.ResourceID, nil
+	case "delete":
+		if entry.Snapshot == nil {
+			return "", fmt.Errorf("no snapshot was taken before it was deleted")
+		}
+		resp, err := client.Post(ctx, "/"+entry.Kind+"s", restoreBody(entry.Kind, entry.Snapshot))
+		if err != nil {
+			return "", err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode >= 400 {
+			return "", api.ReadAPIError(resp)
+		}
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			return "", fmt.Errorf("invalid response: %w", err)
+		}
+		return created.ID, nil
+	default:
+		return "", fmt.Errorf("cannot undo %q operations", entry.Action)
+	}
+}
+
+// restoreBody builds the create request that recreates a deleted resource.
+func restoreBody(kind string, snapshot map[string]interface{}) map[string]interface{} {
+	body := map[string]interface{}{}
+	for _, field := range restorableFields[kind] {
+		if v, ok := snapshot[field]; ok && v != nil && v != config.Redacted {
+			body[field] = v
+		}
+	}
+
+	if kind == "link" {
+		var tagIDs []string
+		if tags, ok := snapshot["tags"].([]interface{}); ok {
+			for _, tag := range tags {
+				if t, ok := tag.(map[string]interface{}); ok {
+					tagIDs = append(tagIDs, outfmt.SafeString(t["id"]))
+				}
+			}
+		}
+		if len(tagIDs) > 0 {
+			body["tagIds"] = tagIDs
+		}
+	}
+	return body
+}
+
+// historyLabel describes the resource of entry for messages.
+func historyLabel(e config.HistoryEntry) string {
+	if e.Label != "" {
+		return e.Label
+	}
+	return e.ResourceID
+}
+
+// newHistoryEntry describes an operation on a resource.
+func newHistoryEntry(action, kind, id, label string, snapshot map[string]interface{}) config.HistoryEntry {
+	return config.HistoryEntry{
+		Time:       time.Now().UTC(),
+		Action:     action,
+		Kind:       kind,
+		ResourceID: id,
+		Label:      label,
+		Snapshot:   snapshot,
+	}
+}
+
+// recordHistory adds entries made in the active workspace to the undo
+// history, warning rather than failing when it can't be written since the
+// operations already happened.
+func recordHistory(cmd *cobra.Command, entries ...config.HistoryEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	workspace, _ := activeWorkspaceName(cmd.Context())
+	for i := range entries {
+		entries[i].Workspace = workspace
+	}
+	if err := config.AppendHistory(entries...); err != nil {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(fmt.Sprintf("could not record undo history: %v", err)))
+	}
+}
+
+// snapshotLink fetches a link before it is deleted, so the delete can be
+// undone.
+func snapshotLink(ctx context.Context, client *api.Client, id string) (map[string]interface{}, error) {
+	var link map[string]interface{}
+	if err := getJSON(ctx, client, "/links/"+url.PathEscape(id), &link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// snapshotLinks fetches the links with ids, up to concurrency at a time.
+func snapshotLinks(ctx context.Context, client *api.Client, ids []string, concurrency int) ([]map[string]interface{}, error) {
+	links := make([]map[string]interface{}, len(ids))
+	errs := batch.Run(ctx, len(ids), concurrency, func(ctx context.Context, i int) error {
+		link, err := snapshotLink(ctx, client, ids[i])
+		if err != nil {
+			return fmt.Errorf("%s: %w", ids[i], err)
+		}
+		links[i] = link
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// snapshotListed finds the resource with id in the listing at path, for
+// resources that can't be fetched individually.
+func snapshotListed(ctx context.Context, client *api.Client, path, id string) (map[string]interface{}, error) {
+	var items []map[string]interface{}
+	if err := getJSON(ctx, client, path, &items); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if outfmt.SafeString(item["id"]) == id {
+			return item, nil
+		}
+	}
+	return nil, fmt.Errorf("%s not found", id)
+}
+
+// snapshotWarning explains that a delete is going ahead without a way back.
+func snapshotWarning(kind, id string, err error) string {
+	return ui.Warning(fmt.Sprintf("could not snapshot %s %s (%v); this delete cannot be undone", kind, id, err))
+}
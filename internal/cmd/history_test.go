@@ -0,0 +1,160 @@
+// internal/cmd/history_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+func runHistoryCmd(t *testing.T, cmd *cobra.Command, serverURL string, args ...string) (string, error) {
+	t.Helper()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, serverURL))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestUndo_RecreatesDeletedLink(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var created map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": "link_1", "domain": "dub.sh", "key": "launch", "url": "https://example.com",
+				"clicks": 42, "comments": null, "tags": [{"id": "tag_1", "name": "Promo"}]}`))
+		case http.MethodDelete:
+			_, _ = w.Write([]byte(`{"id": "link_1"}`))
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"id": "link_2"}`))
+		}
+	})
+
+	if _, err := runHistoryCmd(t, newLinksDeleteCmd(), server.URL, "--id", "link_1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	out, err := runHistoryCmd(t, newHistoryCmd(), server.URL)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if !strings.Contains(out, "delete link") || !strings.Contains(out, "dub.sh/launch") || !strings.Contains(out, "undoable") {
+		t.Errorf("expected the delete in history, got:\n%s", out)
+	}
+
+	out, err = runHistoryCmd(t, newUndoCmd(), server.URL)
+	if err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+	if !strings.Contains(out, "Recreated link dub.sh/launch as link_2") {
+		t.Errorf("unexpected undo output: %s", out)
+	}
+
+	want := map[string]interface{}{
+		"domain": "dub.sh",
+		"key":    "launch",
+		"url":    "https://example.com",
+		"tagIds": []interface{}{"tag_1"},
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("expected recreate body %v, got %v", want, created)
+	}
+
+	if _, err := runHistoryCmd(t, newUndoCmd(), server.URL); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Errorf("expected nothing left to undo, got %v", err)
+	}
+
+	history, err := config.LoadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || !history[0].Undone || history[0].RestoredID != "link_2" {
+		t.Errorf("expected the delete marked undone, got %+v", history)
+	}
+}
+
+func TestUndo_PasswordProtectedLink(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var created map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": "link_1", "domain": "dub.sh", "key": "vip", "url": "https://example.com", "password": "hunter2"}`))
+		case http.MethodDelete:
+			_, _ = w.Write([]byte(`{"id": "link_1"}`))
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"id": "link_2"}`))
+		}
+	})
+
+	if _, err := runHistoryCmd(t, newLinksDeleteCmd(), server.URL, "--id", "link_1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	path, err := config.StateDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(path, "history.json"))
+	if err != nil || strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), config.Redacted) {
+		t.Errorf("expected the password redacted in the history file, got %s (%v)", data, err)
+	}
+
+	if _, err := runHistoryCmd(t, newUndoCmd(), server.URL); err == nil || !IsUsageError(err) || created != nil {
+		t.Fatalf("expected undo to refuse without --password, got %v (created %v)", err, created)
+	}
+	if _, err := runHistoryCmd(t, newUndoCmd(), server.URL, "--password", "s3cret"); err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+	if created["password"] != "s3cret" {
+		t.Errorf("expected the link recreated with the new password, got %v", created)
+	}
+}
+
+func TestUndo_UnarchivesLink(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var patched map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&patched)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	if err := config.AppendHistory(
+		config.HistoryEntry{Action: "archive", Kind: "link", ResourceID: "link_1", Label: "dub.sh/a"},
+		config.HistoryEntry{Action: "archive", Kind: "link", ResourceID: "link_2", Label: "dub.sh/b"},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runHistoryCmd(t, newUndoCmd(), server.URL, "1")
+	if err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+	if !strings.Contains(out, "Unarchived link dub.sh/a") {
+		t.Errorf("unexpected undo output: %s", out)
+	}
+	if patched["archived"] != false {
+		t.Errorf("expected archived=false, got %v", patched)
+	}
+
+	if _, err := runHistoryCmd(t, newUndoCmd(), server.URL, "1"); err == nil || !strings.Contains(err.Error(), "already undone") {
+		t.Errorf("expected already undone error, got %v", err)
+	}
+}
@@ -0,0 +1,611 @@
+// internal/cmd/import.go
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// importColumns are the CSV columns the importer understands. Tags are
+// separated by semicolons; tags and folder are names, resolved to IDs.
+var importColumns = []string{"url", "key", "domain", "externalId", "title", "description", "comments", "expiresAt", "tags", "folder"}
+
+// openCheckpointFile opens an import checkpoint. Tests replace it to make
+// checkpoint writes fail.
+var openCheckpointFile = os.OpenFile
+
+// importRetryDelay is the base wait before retrying a failed row. Tests
+// shorten it.
+var importRetryDelay = time.Second
+
+// importRow is one link to create. Row is its 1-based position below the
+// header and identifies it in the checkpoint.
+type importRow struct {
+	Row    int
+	Body   map[string]interface{}
+	Tags   []string
+	Folder string
+}
+
+// importFailure is a row that could not be created.
+type importFailure struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// importRowError is a problem with one row of an import file.
+type importRowError struct {
+	Row int
+	Err error
+}
+
+func (e *importRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *importRowError) Unwrap() error {
+	return e.Err
+}
+
+// importSummary is the result of an import run.
+type importSummary struct {
+	Imported int             `json:"imported"`
+	Skipped  int             `json:"skipped"`
+	Failed   []importFailure `json:"failed"`
+}
+
+func newLinksImportCmd() *cobra.Command {
+	var (
+		checkpointPath string
+		resume         bool
+		retries        int
+		concurrency    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file.csv>",
+		Short: "Create links from a CSV file",
+		Long: `Create one link per CSV row. The header names the columns:
+url (required), key, domain, externalId, title, description, comments,
+expiresAt, tags (names separated by ";"), and folder (a name).
+
+Progress is written to a checkpoint file as rows complete. If the import is
+interrupted or some rows fail, run it again with --resume to skip the rows
+that were created and retry only the rest. The checkpoint is removed once
+every row has been imported. If the checkpoint can't be written, the import
+stops and names any rows that were created without being recorded.
+
+Rows are retried after rate limits. After a network or server error, a row
+is retried only if it has an externalId or a domain and key, and only once
+a lookup shows the failed attempt didn't create the link.
+
+Examples:
+  dub links import links.csv
+  dub links import links.csv --resume`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+			if retries < 0 {
+				return NewUsageErrorf("--retries must not be negative")
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", args[0], err)
+			}
+			rows, err := parseImportCSV(bytes.NewReader(data))
+			if err != nil {
+				return err
+			}
+
+			if checkpointPath == "" {
+				checkpointPath = args[0] + ".checkpoint"
+			}
+			sum := sha256.Sum256(data)
+			checkpoint, err := openImportCheckpoint(checkpointPath, hex.EncodeToString(sum[:]), resume)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = checkpoint.Close() }()
+
+			var pending []importRow
+			for _, row := range rows {
+				if !checkpoint.Done(row.Row) {
+					pending = append(pending, row)
+				}
+			}
+			summary := importSummary{Skipped: len(rows) - len(pending), Failed: []importFailure{}}
+
+			if len(pending) > 0 {
+				client, err := getClient(cmd.Context())
+				if err != nil {
+					return err
+				}
+				if err := checkLinkQuota(cmd, client, len(pending)); err != nil {
+					if !resume {
+						// Nothing was imported, so don't require --resume next time
+						_ = checkpoint.Remove()
+					}
+					return err
+				}
+				if err := resolveImportNames(cmd.Context(), client, pending); err != nil {
+					return err
+				}
+
+				// A row the checkpoint can't record would be created again by
+				// --resume, so stop starting rows once a write fails. Rows in
+				// flight still run to completion under the command's context.
+				stop, cancel := context.WithCancel(cmd.Context())
+				defer cancel()
+				var (
+					mu         sync.Mutex
+					recordErr  error
+					unrecorded []int
+				)
+				errs := batch.Run(stop, len(pending), concurrency, func(_ context.Context, i int) error {
+					if err := stop.Err(); err != nil {
+						return err
+					}
+					id, err := createImportRow(cmd.Context(), client, pending[i], retries)
+					if rerr := checkpoint.Record(pending[i].Row, id, err); rerr != nil {
+						mu.Lock()
+						if recordErr == nil {
+							recordErr = rerr
+						}
+						if err == nil {
+							unrecorded = append(unrecorded, pending[i].Row)
+						}
+						mu.Unlock()
+						cancel()
+					}
+					return err
+				})
+				notStarted := 0
+				for i, err := range errs {
+					switch {
+					case err == nil:
+						summary.Imported++
+					case recordErr != nil && errors.Is(err, context.Canceled) && cmd.Context().Err() == nil:
+						notStarted++
+					default:
+						summary.Failed = append(summary.Failed, importFailure{Row: pending[i].Row, Error: err.Error()})
+					}
+				}
+
+				if recordErr != nil {
+					if err := writeImportSummary(cmd, summary, checkpointPath); err != nil && len(summary.Failed) == 0 {
+						return err
+					}
+					return importCheckpointError(checkpointPath, recordErr, unrecorded, notStarted)
+				}
+			}
+
+			if len(summary.Failed) == 0 {
+				_ = checkpoint.Remove()
+			}
+			return writeImportSummary(cmd, summary, checkpointPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&checkpointPath, "checkpoint", "", "Checkpoint file (default: <file>.checkpoint)")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Skip rows completed by a previous run and retry the rest")
+	cmd.Flags().IntVar(&retries, "retries", 2, "Times to retry a row after a rate limit, or a network or server error if the row has an externalId or a domain and key")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	return cmd
+}
+
+// parseImportCSV reads links from CSV, reporting every invalid row at once
+// alongside the valid ones.
+func parseImportCSV(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("CSV file is empty")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	columns := make(map[int]string, len(header))
+	hasURL := false
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		known := false
+		for _, c := range importColumns {
+			if strings.EqualFold(name, c) {
+				columns[i], known = c, true
+				hasURL = hasURL || c == "url"
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown CSV column %q (expected: %s)", name, strings.Join(importColumns, ", "))
+		}
+	}
+	if !hasURL {
+		return nil, fmt.Errorf("CSV header must include a url column")
+	}
+
+	var rows []importRow
+	var problems []error
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &importRowError{Row: n, Err: err}
+		}
+
+		row := importRow{Row: n, Body: map[string]interface{}{}}
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			switch columns[i] {
+			case "tags":
+				for _, tag := range strings.Split(value, ";") {
+					if tag = strings.TrimSpace(tag); tag != "" {
+						row.Tags = append(row.Tags, tag)
+					}
+				}
+			case "folder":
+				row.Folder = value
+			default:
+				row.Body[columns[i]] = value
+			}
+		}
+		if err := validateImportRow(row); err != nil {
+			problems = append(problems, &importRowError{Row: n, Err: err})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, errors.Join(problems...)
+}
+
+// validateImportRow checks a row locally before anything is sent.
+func validateImportRow(row importRow) error {
+	dest, _ := row.Body["url"].(string)
+	if dest == "" {
+		return fmt.Errorf("missing url")
+	}
+	u, err := url.Parse(dest)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q: must be an absolute http(s) URL", dest)
+	}
+	if expires, ok := row.Body["expiresAt"].(string); ok {
+		if _, err := time.Parse(time.RFC3339, expires); err != nil {
+			return fmt.Errorf("invalid expiresAt %q: must be an RFC 3339 timestamp", expires)
+		}
+	}
+	return nil
+}
+
+// resolveImportNames replaces tag and folder names with IDs, looking each
+// distinct name up once.
+func resolveImportNames(ctx context.Context, client *api.Client, rows []importRow) error {
+	resolve := func(kind cache.Kind, names []string) (map[string]string, error) {
+		seen := map[string]bool{}
+		var unique []string
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				unique = append(unique, name)
+			}
+		}
+		if len(unique) == 0 {
+			return nil, nil
+		}
+		ids, err := resolveNamesToIDs(ctx, client, kind, unique)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]string, len(unique))
+		for i, name := range unique {
+			out[name] = ids[i]
+		}
+		return out, nil
+	}
+
+	var tagNames, folderNames []string
+	for _, row := range rows {
+		tagNames = append(tagNames, row.Tags...)
+		if row.Folder != "" {
+			folderNames = append(folderNames, row.Folder)
+		}
+	}
+	tagIDs, err := resolve(cache.KindTag, tagNames)
+	if err != nil {
+		return err
+	}
+	folderIDs, err := resolve(cache.KindFolder, folderNames)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if len(row.Tags) > 0 {
+			ids := make([]string, len(row.Tags))
+			for i, name := range row.Tags {
+				ids[i] = tagIDs[name]
+			}
+			row.Body["tagIds"] = ids
+		}
+		if row.Folder != "" {
+			row.Body["folderId"] = folderIDs[row.Folder]
+		}
+	}
+	return nil
+}
+
+// createImportRow creates the row's link, retrying up to retries times. It
+// returns the new link's ID.
+//
+// POST /links isn't idempotent, so a request whose response was lost may
+// still have created the link. Such errors are only retried for rows that
+// can be looked up by externalId or domain and key, and the row is looked up
+// first; rate limits and 503s are always retried, as nothing was created.
+func createImportRow(ctx context.Context, client *api.Client, row importRow, retries int) (string, error) {
+	lookup := importRowLookup(row)
+	for attempt := 0; ; attempt++ {
+		id, status, err := postImportRow(ctx, client, row)
+		if err == nil || attempt >= retries || !importRetryable(ctx, err, status, lookup != nil) {
+			return id, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(importRetryDelay * time.Duration(attempt+1)):
+		}
+		if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+			continue
+		}
+		id, lookupErr := findLinkInfo(ctx, client, lookup)
+		if lookupErr != nil {
+			return "", fmt.Errorf("%w (and checking whether the link was created failed: %v)", err, lookupErr)
+		}
+		if id != "" {
+			return id, nil
+		}
+	}
+}
+
+// importRowLookup returns the /links/info query that finds the row's link
+// once created, or nil if the row has neither an externalId nor both a
+// domain and a key.
+func importRowLookup(row importRow) url.Values {
+	if ext := outfmt.SafeString(row.Body["externalId"]); ext != "" {
+		return url.Values{"externalId": {ext}}
+	}
+	domain, key := outfmt.SafeString(row.Body["domain"]), outfmt.SafeString(row.Body["key"])
+	if domain != "" && key != "" {
+		return url.Values{"domain": {domain}, "key": {key}}
+	}
+	return nil
+}
+
+// importRetryable reports whether a failed POST with the response status
+// (0 if none arrived) may be retried. canLookUp says whether the row can be
+// checked for a link created by the failed attempt.
+func importRetryable(ctx context.Context, err error, status int, canLookUp bool) bool {
+	switch {
+	case status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable:
+		return true
+	case !canLookUp:
+		return false
+	case status >= http.StatusInternalServerError:
+		return true
+	case status != 0:
+		return false
+	}
+	var permErr *api.PermissionError
+	return ctx.Err() == nil && !errors.As(err, &permErr) && !errors.Is(err, api.ErrCircuitOpen)
+}
+
+func postImportRow(ctx context.Context, client *api.Client, row importRow) (id string, status int, err error) {
+	resp, err := client.Post(ctx, "/links", row.Body)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return "", resp.StatusCode, api.ReadAPIError(resp)
+	}
+	var link struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&link); err != nil {
+		return "", resp.StatusCode, fmt.Errorf("invalid response: %w", err)
+	}
+	return link.ID, resp.StatusCode, nil
+}
+
+func writeImportSummary(cmd *cobra.Command, summary importSummary, checkpointPath string) error {
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		if err := outfmt.FormatJSON(cmd.OutOrStdout(), summary, outfmt.GetQuery(cmd.Context())); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range summary.Failed {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "row %d: %s\n", f.Row, f.Error)
+		}
+		line := fmt.Sprintf("Imported %d link(s)", summary.Imported)
+		if summary.Skipped > 0 {
+			line += fmt.Sprintf(", skipped %d already imported", summary.Skipped)
+		}
+		if len(summary.Failed) > 0 {
+			line += fmt.Sprintf(", %d failed", len(summary.Failed))
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), line)
+	}
+
+	if len(summary.Failed) > 0 {
+		return fmt.Errorf("%d row(s) failed; progress saved to %s, re-run with --resume to retry them", len(summary.Failed), checkpointPath)
+	}
+	return nil
+}
+
+// importCheckpointError reports an import stopped because the checkpoint
+// could not be written. Rows that were created without being recorded are
+// named, as --resume would create them again.
+func importCheckpointError(path string, err error, unrecorded []int, notStarted int) error {
+	msg := fmt.Sprintf("import stopped: failed to write checkpoint %s: %v", path, err)
+	if len(unrecorded) > 0 {
+		sort.Ints(unrecorded)
+		rows := make([]string, len(unrecorded))
+		for i, row := range unrecorded {
+			rows[i] = strconv.Itoa(row)
+		}
+		msg += fmt.Sprintf("; row(s) %s were created but not recorded, so --resume would create them again", strings.Join(rows, ", "))
+	}
+	if notStarted > 0 {
+		msg += fmt.Sprintf("; %d row(s) were not attempted", notStarted)
+	}
+	return errors.New(msg)
+}
+
+// importCheckpoint records which rows of an import file have been created.
+// It is a JSON lines file: a header with the file's checksum, then one entry
+// per attempted row. Later entries for a row replace earlier ones.
+type importCheckpoint struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+	done map[int]bool
+}
+
+type checkpointHeader struct {
+	SHA256 string `json:"sha256"`
+}
+
+type checkpointEntry struct {
+	Row   int    `json:"row"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// openImportCheckpoint starts a new checkpoint, or continues an existing one
+// when resume is set. An existing checkpoint is never silently replaced, as
+// re-running an import from scratch would create duplicate links.
+func openImportCheckpoint(path, sum string, resume bool) (*importCheckpoint, error) {
+	c := &importCheckpoint{path: path, done: map[int]bool{}}
+
+	existing, err := os.Open(path)
+	switch {
+	case err == nil:
+		defer func() { _ = existing.Close() }()
+		if !resume {
+			return nil, NewUsageErrorf("%s exists from a previous import; pass --resume to continue it, or delete it to start over", path)
+		}
+		if err := c.load(existing, sum); err != nil {
+			return nil, err
+		}
+		c.file, err = openCheckpointFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	c.file, err = openCheckpointFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.write(checkpointHeader{SHA256: sum}); err != nil {
+		_ = c.file.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *importCheckpoint) load(r io.Reader, sum string) error {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return fmt.Errorf("%s is empty; delete it to start over", c.path)
+	}
+	var header checkpointHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil || header.SHA256 == "" {
+		return fmt.Errorf("%s is not an import checkpoint", c.path)
+	}
+	if header.SHA256 != sum {
+		return fmt.Errorf("%s was written for a different version of the file; delete it to start over", c.path)
+	}
+
+	for scanner.Scan() {
+		var entry checkpointEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A run killed mid-write can leave a partial last line
+			continue
+		}
+		c.done[entry.Row] = entry.Error == ""
+	}
+	return scanner.Err()
+}
+
+// Done reports whether row was created by a previous run.
+func (c *importCheckpoint) Done(row int) bool {
+	return c.done[row]
+}
+
+// Record appends the outcome of a row.
+func (c *importCheckpoint) Record(row int, id string, err error) error {
+	entry := checkpointEntry{Row: row, ID: id}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.write(entry)
+}
+
+func (c *importCheckpoint) write(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = c.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the checkpoint file, keeping it for --resume.
+func (c *importCheckpoint) Close() error {
+	return c.file.Close()
+}
+
+// Remove deletes the checkpoint once the import is complete.
+func (c *importCheckpoint) Remove() error {
+	_ = c.file.Close()
+	return os.Remove(c.path)
+}
@@ -0,0 +1,190 @@
+// internal/cmd/import_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const importTestCSV = "url,key\nhttps://example.com/a,a\nhttps://example.com/b,b\nhttps://example.com/c,c\n"
+
+// runLinksImport imports a CSV against handler and returns the command error.
+// The workspace quota check is answered with plenty of links left.
+func runLinksImport(t *testing.T, csvPath string, handler http.HandlerFunc, args ...string) error {
+	t.Helper()
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/workspaces" {
+			_, _ = w.Write([]byte(`[{"slug": "acme", "plan": "pro", "linksUsage": 0, "linksLimit": 1000}]`))
+			return
+		}
+		handler(w, r)
+	})
+
+	cmd := newLinksImportCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{csvPath, "--concurrency", "1"}, args...))
+	return cmd.Execute()
+}
+
+func TestLinksImport_ResumeRetriesOnlyFailedRows(t *testing.T) {
+	orig := importRetryDelay
+	importRetryDelay = 0
+	defer func() { importRetryDelay = orig }()
+
+	csvPath := filepath.Join(t.TempDir(), "links.csv")
+	if err := os.WriteFile(csvPath, []byte(importTestCSV), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	handler := func(failKey string, failStatus int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			key, _ := body["key"].(string)
+			keys = append(keys, key)
+			if key == failKey {
+				w.WriteHeader(failStatus)
+				_, _ = w.Write([]byte(`{"error":{"code":"internal_server_error","message":"boom"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"link_` + key + `"}`))
+		}
+	}
+
+	// Row b keeps failing with a 503, so it is tried 1+2 times
+	err := runLinksImport(t, csvPath, handler("b", http.StatusServiceUnavailable))
+	if err == nil || !strings.Contains(err.Error(), "--resume") {
+		t.Fatalf("expected failure pointing at --resume, got %v", err)
+	}
+	if got := strings.Join(keys, ","); got != "a,b,b,b,c" {
+		t.Errorf("first run requests = %s", got)
+	}
+
+	// Re-running without --resume must not start over
+	if err := runLinksImport(t, csvPath, handler("", 0)); !IsUsageError(err) {
+		t.Fatalf("expected usage error without --resume, got %v", err)
+	}
+
+	keys = nil
+	if err := runLinksImport(t, csvPath, handler("", 0), "--resume"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(keys, ","); got != "b" {
+		t.Errorf("resume requests = %s, want only b", got)
+	}
+	if _, err := os.Stat(csvPath + ".checkpoint"); !os.IsNotExist(err) {
+		t.Errorf("expected checkpoint removed after a complete import, got %v", err)
+	}
+}
+
+func TestLinksImport_LooksUpRowBeforeRetrying(t *testing.T) {
+	orig := importRetryDelay
+	importRetryDelay = 0
+	defer func() { importRetryDelay = orig }()
+
+	csvPath := filepath.Join(t.TempDir(), "links.csv")
+	csv := "url,domain,key\nhttps://example.com/a,acme.link,a\nhttps://example.com/b,,b\n"
+	if err := os.WriteFile(csvPath, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every POST creates its link but answers with a server error, as if
+	// the response was lost
+	var requests []string
+	created := map[string]bool{}
+	err := runLinksImport(t, csvPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/links/info" {
+			requests = append(requests, "info "+r.URL.Query().Get("key"))
+			if !created[r.URL.Query().Get("key")] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id": "link_` + r.URL.Query().Get("key") + `"}`))
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		key, _ := body["key"].(string)
+		requests = append(requests, "create "+key)
+		created[key] = true
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	// Row a is found instead of being created again; row b has no domain
+	// to look it up by, so it isn't retried
+	if err == nil || !strings.Contains(err.Error(), "--resume") {
+		t.Fatalf("expected row b to fail, got %v", err)
+	}
+	if got := strings.Join(requests, ","); got != "create a,info a,create b" {
+		t.Errorf("requests = %s", got)
+	}
+}
+
+func TestParseImportCSV_ReportsInvalidRows(t *testing.T) {
+	input := "url,tags\nnot-a-url,\nhttps://example.com,docs; launch\n,x\n"
+	_, err := parseImportCSV(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "row 1: invalid url") || !strings.Contains(err.Error(), "row 3: missing url") {
+		t.Errorf("expected both invalid rows reported, got %v", err)
+	}
+
+	rows, err := parseImportCSV(strings.NewReader("url,tags\nhttps://example.com,docs; launch\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 1 || strings.Join(rows[0].Tags, "|") != "docs|launch" {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+
+	if _, err := parseImportCSV(strings.NewReader("link\nhttps://example.com\n")); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
+
+func TestLinksImport_StopsWhenCheckpointWriteFails(t *testing.T) {
+	var checkpoint *os.File
+	orig := openCheckpointFile
+	openCheckpointFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
+		f, err := orig(name, flag, perm)
+		checkpoint = f
+		return f, err
+	}
+	defer func() { openCheckpointFile = orig }()
+
+	csvPath := filepath.Join(t.TempDir(), "links.csv")
+	if err := os.WriteFile(csvPath, []byte(importTestCSV), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The checkpoint becomes unwritable while row a is being created
+	var keys []string
+	err := runLinksImport(t, csvPath, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		key, _ := body["key"].(string)
+		keys = append(keys, key)
+		_ = checkpoint.Close()
+		_, _ = w.Write([]byte(`{"id":"link_` + key + `"}`))
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "row(s) 1 were created but not recorded") {
+		t.Fatalf("expected row 1 reported as created but unrecorded, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "2 row(s) were not attempted") {
+		t.Errorf("expected remaining rows reported as not attempted, got %v", err)
+	}
+	if got := strings.Join(keys, ","); got != "a" {
+		t.Errorf("requests = %s, want only a", got)
+	}
+	if _, err := os.Stat(csvPath + ".checkpoint"); err != nil {
+		t.Errorf("expected checkpoint kept, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// internal/cmd/include.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// includedHeaders are the response headers --include shows, besides any
+// rate-limit header. They are what Dub support asks for when debugging.
+var includedHeaders = []string{
+	"X-Request-Id",
+	"X-Vercel-Id",
+	"Retry-After",
+	"X-Vercel-Cache",
+	"Cf-Cache-Status",
+	"X-Cache",
+	"Age",
+}
+
+// headerPrinter writes the selected headers of each API response, like
+// curl -i.
+type headerPrinter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// newHeaderPrinter prints above the body on stdout in JSON mode, where the
+// output is already meant for inspection, and to stderr otherwise so tables
+// stay clean. List commands have their own -o flag, so the command's flag
+// is checked rather than the global one.
+func newHeaderPrinter(cmd *cobra.Command) *headerPrinter {
+	if f := cmd.Flags().Lookup("output"); f != nil && f.Value.String() == "json" {
+		return &headerPrinter{w: cmd.OutOrStdout()}
+	}
+	return &headerPrinter{w: cmd.ErrOrStderr()}
+}
+
+func getHeaderPrinter(ctx context.Context) *headerPrinter {
+	p, _ := ctx.Value(includeKey).(*headerPrinter)
+	return p
+}
+
+func (p *headerPrinter) print(_ *http.Request, resp *http.Response) {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%s %s\n", resp.Proto, resp.Status)
+	for _, name := range selectedHeaders(resp.Header) {
+		for _, v := range resp.Header.Values(name) {
+			_, _ = fmt.Fprintf(&b, "%s: %s\n", name, v)
+		}
+	}
+	b.WriteString("\n")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = io.WriteString(p.w, b.String())
+}
+
+// selectedHeaders returns the names of h that --include shows, sorted.
+func selectedHeaders(h http.Header) []string {
+	var names []string
+	for name := range h {
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "ratelimit") || slices.ContainsFunc(includedHeaders, func(s string) bool { return strings.EqualFold(s, name) }) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
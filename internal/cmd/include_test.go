@@ -0,0 +1,43 @@
+// internal/cmd/include_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIncludeFlag(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1")
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		w.Header().Set("X-Powered-By", "Next.js")
+		_, _ = w.Write([]byte(`[{"id": "tag_1", "name": "launch", "color": "red"}]`))
+	})
+
+	const headers = "HTTP/1.1 200 OK\nX-Ratelimit-Remaining: 59\nX-Request-Id: req_1\n\n"
+
+	var stdout, stderr bytes.Buffer
+	err := execute(context.Background(), []string{"--api-url", server.URL, "--include", "-o", "json", "tags", "list"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), headers+"[") {
+		t.Errorf("expected headers above the JSON body, got %q", stdout.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	err = execute(context.Background(), []string{"--api-url", server.URL, "--include", "tags", "list"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stderr.String() != headers {
+		t.Errorf("expected headers on stderr in table mode, got %q", stderr.String())
+	}
+	if strings.Contains(stdout.String(), "req_1") {
+		t.Errorf("expected no headers in table output, got %q", stdout.String())
+	}
+}
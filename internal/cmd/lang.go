@@ -0,0 +1,120 @@
+// internal/cmd/lang.go
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/dub-cli/internal/i18n"
+)
+
+// usageHeadings are the section titles of cobra's usage template.
+var usageHeadings = []string{
+	"Usage:",
+	"Aliases:",
+	"Examples:",
+	"Available Commands:",
+	"Additional Commands:",
+	"Flags:",
+	"Global Flags:",
+	"Additional help topics:",
+}
+
+// usageFooter is the last line of cobra's usage template, with the command
+// path as %s.
+const usageFooter = `Use "%s [command] --help" for more information about a command.`
+
+// langFromArgs returns the --lang value in args, or DUB_LANG. Help and
+// argument errors are printed before flags are parsed, so the language is
+// picked from the raw arguments.
+func langFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if v, ok := strings.CutPrefix(arg, "--lang="); ok {
+			return v
+		}
+		if arg == "--lang" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv("DUB_LANG")
+}
+
+// setLanguage picks the locale for args and translates the help of root
+// into it.
+func setLanguage(root *cobra.Command, args []string) {
+	if err := i18n.SetLocale(i18n.Detect(langFromArgs(args))); err != nil {
+		_ = i18n.SetLocale(i18n.English)
+	}
+	if i18n.Locale() != i18n.English {
+		localizeCommands(root)
+	}
+}
+
+// localizeCommands translates the descriptions and flag usages of root and
+// its subcommands, and the headings of the usage template.
+func localizeCommands(root *cobra.Command) {
+	// Add cobra's help command and flags now so they're translated too
+	root.InitDefaultHelpCmd()
+	root.InitDefaultVersionFlag()
+	root.SetUsageTemplate(localizeUsageTemplate(root.UsageTemplate()))
+
+	seen := map[*pflag.Flag]bool{}
+	localizeFlag := func(f *pflag.Flag) {
+		if !seen[f] {
+			seen[f] = true
+			f.Usage = i18n.T(f.Usage)
+		}
+	}
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		c.InitDefaultHelpFlag()
+		c.Short = i18n.T(c.Short)
+		c.Long = localizeText(c.Long)
+		c.PersistentFlags().VisitAll(localizeFlag)
+		c.Flags().VisitAll(localizeFlag)
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
+
+// localizeText translates long help paragraph by paragraph, so a
+// translation survives edits to other paragraphs. A paragraph without a
+// translation still gets its heading translated, e.g. "Examples:".
+func localizeText(text string) string {
+	if text == "" {
+		return text
+	}
+	paragraphs := strings.Split(text, "\n\n")
+	for i, p := range paragraphs {
+		if t := i18n.T(p); t != p {
+			paragraphs[i] = t
+			continue
+		}
+		heading, rest, ok := strings.Cut(p, "\n")
+		if ok && strings.HasSuffix(heading, ":") {
+			paragraphs[i] = i18n.T(heading) + "\n" + rest
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// localizeUsageTemplate translates the fixed text of a usage template.
+func localizeUsageTemplate(tmpl string) string {
+	for _, heading := range usageHeadings {
+		re := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(heading))
+		tmpl = re.ReplaceAllLiteralString(tmpl, i18n.T(heading))
+	}
+	path := "{{.CommandPath}}"
+	return strings.Replace(tmpl, fmt.Sprintf(usageFooter, path), i18n.Sprintf(usageFooter, path), 1)
+}
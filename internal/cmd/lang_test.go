@@ -0,0 +1,95 @@
+// internal/cmd/lang_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/i18n"
+)
+
+func TestLangFromArgs(t *testing.T) {
+	t.Setenv("DUB_LANG", "es")
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--lang", "de", "links", "list"}, "de"},
+		{[]string{"links", "list", "--lang=de"}, "de"},
+		{[]string{"links", "list"}, "es"},
+		{[]string{"cron", "--", "--lang", "de"}, "es"},
+	}
+	for _, tt := range tests {
+		if got := langFromArgs(tt.args); got != tt.want {
+			t.Errorf("langFromArgs(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestLocalizedHelp(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+	t.Cleanup(func() { _ = i18n.SetLocale(i18n.English) })
+
+	var stdout bytes.Buffer
+	if err := execute(context.Background(), []string{"--lang", "de", "links", "--help"}, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	help := stdout.String()
+	for _, want := range []string{
+		"Verwendung:",
+		"Verfügbare Befehle:",
+		"Globale Optionen:",
+		"Kurzlinks erstellen, auflisten, ändern und löschen.",
+		"Links auflisten",
+		"Bestätigungsabfragen überspringen",
+	} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected %q in help:\n%s", want, help)
+		}
+	}
+	if !strings.Contains(help, `Mit "dub links [Befehl] --help" erhalten Sie`) {
+		t.Errorf("expected translated footer in help:\n%s", help)
+	}
+}
+
+func TestLocalizedError(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+	t.Cleanup(func() { _ = i18n.SetLocale(i18n.English) })
+
+	var stderr bytes.Buffer
+	err := execute(context.Background(), []string{"--lang", "es", "nonexistent"}, nil, &bytes.Buffer{}, &stderr)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := stderr.String(); !strings.Contains(got, `Error: comando desconocido "nonexistent" para "dub"`) {
+		t.Errorf("expected a translated error, got %q", got)
+	}
+	// The error itself stays English for callers matching on it
+	if !strings.Contains(err.Error(), "unknown command") {
+		t.Errorf("expected the returned error unchanged, got %v", err)
+	}
+}
+
+func TestLangFlag_Unsupported(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+	err := execute(context.Background(), []string{"--lang", "xx", "version"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) || !strings.Contains(err.Error(), "available: en, de, es") {
+		t.Errorf("expected usage error listing the languages, got %v", err)
+	}
+}
+
+func TestLocalizeText(t *testing.T) {
+	t.Cleanup(func() { _ = i18n.SetLocale(i18n.English) })
+	if err := i18n.SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+
+	long := "List all links in the workspace.\n\nSome paragraph nobody translated.\n\nExamples:\n  dub links list"
+	want := "Alle Links im Workspace auflisten.\n\nSome paragraph nobody translated.\n\nBeispiele:\n  dub links list"
+	if got := localizeText(long); got != want {
+		t.Errorf("localizeText() = %q, want %q", got, want)
+	}
+}
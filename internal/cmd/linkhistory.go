@@ -0,0 +1,325 @@
+// internal/cmd/linkhistory.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// linkHistoryFields are the link fields compared between snapshots and
+// restored by links revert. Tags are compared separately, by ID.
+var linkHistoryFields = append(slices.Clone(restorableFields["link"]), "archived")
+
+// linkFieldChange is one field that differs between two link snapshots.
+type linkFieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+func newLinksHistoryCmd() *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the changes made to a link by this CLI",
+		Long: fmt.Sprintf(`Show the changes dub links update and dub links revert made to a link,
+newest first, with the fields each one changed.
+
+A snapshot of the link is kept before and after every update, so any
+change can be reverted with dub links revert. Passwords are not kept, so a
+revert leaves them as they are. Changes made elsewhere (the dashboard,
+other API clients) are not recorded. The last %d changes of each link are
+kept, for the %d links changed most recently.
+
+Examples:
+  dub links history --id link_abc`, config.LinkHistoryLimit, config.LinkHistoryMaxLinks),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changes, err := config.LoadLinkHistory(id)
+			if err != nil {
+				return fmt.Errorf("failed to read link history: %w", err)
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				type entry struct {
+					config.LinkChange
+					Diff []linkFieldChange `json:"diff"`
+				}
+				entries := []entry{}
+				for i := len(changes) - 1; i >= 0; i-- {
+					entries = append(entries, entry{changes[i], linkDiff(changes[i].Before, changes[i].After)})
+				}
+				return outfmt.FormatJSON(cmd.OutOrStdout(), entries, outfmt.GetQuery(cmd.Context()))
+			}
+
+			w := cmd.OutOrStdout()
+			if len(changes) == 0 {
+				_, _ = fmt.Fprintf(w, "No changes recorded for link %s.\n", id)
+				return nil
+			}
+			for i := len(changes) - 1; i >= 0; i-- {
+				c := changes[i]
+				_, _ = fmt.Fprintf(w, "#%d  %s  %s\n", c.Seq, c.Time.Local().Format("Jan 2 15:04"), c.Command)
+				diff := linkDiff(c.Before, c.After)
+				if c.After == nil {
+					_, _ = fmt.Fprintln(w, "  (result not recorded)")
+				} else if len(diff) == 0 {
+					_, _ = fmt.Fprintln(w, "  (no field changes)")
+				}
+				for _, d := range diff {
+					_, _ = fmt.Fprintf(w, "  %s: %s -> %s\n", d.Field, formatHistoryValue(d.Before), formatHistoryValue(d.After))
+				}
+				if i > 0 {
+					_, _ = fmt.Fprintln(w)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Link ID (required)")
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func newLinksRevertCmd() *cobra.Command {
+	var (
+		id     string
+		to     int
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revert",
+		Short: "Put a link back as it was before a recorded change",
+		Long: `Restore a link to the state it was in before change # in dub links history,
+undoing that change and every later one. Only the fields that differ from
+the link as it is now are sent. The revert is itself recorded as a change.
+
+Examples:
+  dub links revert --id link_abc --to 3 --dry-run
+  dub links revert --id link_abc --to 3`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			changes, err := config.LoadLinkHistory(id)
+			if err != nil {
+				return fmt.Errorf("failed to read link history: %w", err)
+			}
+			idx := slices.IndexFunc(changes, func(c config.LinkChange) bool { return c.Seq == to })
+			if idx < 0 {
+				return fmt.Errorf("change %d of link %s is not in the history. Run: dub links history --id %s", to, id, id)
+			}
+			target := changes[idx]
+
+			if ws, err := activeWorkspaceName(cmd.Context()); err == nil && target.Workspace != "" && ws != target.Workspace {
+				return fmt.Errorf("change %d was made in workspace %q; run again with --workspace %s", to, target.Workspace, target.Workspace)
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			current, err := snapshotLink(cmd.Context(), client, id)
+			if err != nil {
+				return fmt.Errorf("failed to fetch link %s: %w", id, err)
+			}
+			if err := checkProtected(cmd.Context(), []map[string]interface{}{current}, "revert"); err != nil {
+				return err
+			}
+
+			diff, redacted := withoutRedacted(linkDiff(current, target.Before))
+			if len(redacted) > 0 {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Leaving %s as it is; secrets aren't kept in the link history.\n", strings.Join(redacted, ", "))
+			}
+			w := cmd.OutOrStdout()
+			if len(diff) == 0 {
+				_, _ = fmt.Fprintf(w, "Link %s already matches its state before change %d.\n", id, to)
+				return nil
+			}
+			if dryRun {
+				_, _ = fmt.Fprintf(w, "Would revert link %s to its state before change %d:\n", id, to)
+				for _, d := range diff {
+					_, _ = fmt.Fprintf(w, "  %s: %s -> %s\n", d.Field, formatHistoryValue(d.Before), formatHistoryValue(d.After))
+				}
+				return nil
+			}
+
+			resp, err := client.Patch(cmd.Context(), "/links/"+url.PathEscape(id), revertBody(diff))
+			if err != nil {
+				return err
+			}
+			after, err := writeResponse(cmd, resp)
+			if err != nil {
+				return err
+			}
+			recordLinkChange(cmd, id, current, after)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Link ID (required)")
+	cmd.Flags().IntVar(&to, "to", 0, "Change number from dub links history to revert (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the fields that would change without changing them")
+	_ = cmd.MarkFlagRequired("id")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// fetchLinkForUpdate snapshots a link before it is updated, refusing when it
+// is protected or doesn't meet expect. Without --force or expectations, a
+// failed fetch is an error, since the link can't be checked; otherwise the
+// update goes ahead unrecorded.
+func fetchLinkForUpdate(cmd *cobra.Command, client *api.Client, id string, expect *expectFlag) (map[string]interface{}, error) {
+	ctx := cmd.Context()
+	var link map[string]interface{}
+	header, err := getJSONHeader(ctx, client, "/links/"+url.PathEscape(id), &link)
+	if err != nil {
+		if expect.active() {
+			return nil, fmt.Errorf("failed to fetch link %s to check --expect/--if-match: %w", id, err)
+		}
+		if !GetForce(ctx) {
+			return nil, fmt.Errorf("failed to check link protection:\n%s: %w", id, err)
+		}
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(fmt.Sprintf("could not snapshot link %s (%v); this change won't be in dub links history", id, err)))
+		return nil, nil
+	}
+	if err := checkProtected(ctx, []map[string]interface{}{link}, "update"); err != nil {
+		return nil, err
+	}
+	if err := expect.check("link "+id, link, header); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// recordLinkChange adds an update of the link with id to its history,
+// warning rather than failing since the update already happened. after is
+// the API's response, and is left out if it isn't a link.
+func recordLinkChange(cmd *cobra.Command, id string, before map[string]interface{}, after interface{}) {
+	if before == nil {
+		return
+	}
+	recordLinkChanges(cmd, map[string]config.LinkChange{id: newLinkChange(cmd, before, after)})
+}
+
+// recordLinkChanges is recordLinkChange for several links, keyed by ID,
+// writing the history file once.
+func recordLinkChanges(cmd *cobra.Command, changes map[string]config.LinkChange) {
+	if len(changes) == 0 {
+		return
+	}
+	if err := config.AppendLinkHistories(changes); err != nil {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(fmt.Sprintf("could not record link history: %v", err)))
+	}
+}
+
+// newLinkChange describes an update made by cmd, from the link before it
+// to the API's response.
+func newLinkChange(cmd *cobra.Command, before map[string]interface{}, after interface{}) config.LinkChange {
+	afterLink, _ := after.(map[string]interface{})
+	workspace, _ := activeWorkspaceName(cmd.Context())
+	return config.LinkChange{
+		Time:      time.Now().UTC(),
+		Workspace: workspace,
+		Command:   cmd.CommandPath(),
+		Before:    before,
+		After:     afterLink,
+	}
+}
+
+// linkDiff lists the fields that differ from before to after. It returns
+// nil when after is nil.
+func linkDiff(before, after map[string]interface{}) []linkFieldChange {
+	if after == nil {
+		return nil
+	}
+	var diff []linkFieldChange
+	for _, field := range linkHistoryFields {
+		b, a := before[field], after[field]
+		if !reflect.DeepEqual(b, a) {
+			diff = append(diff, linkFieldChange{Field: field, Before: b, After: a})
+		}
+	}
+	if b, a := snapshotTagIDs(before), snapshotTagIDs(after); !slices.Equal(b, a) {
+		diff = append(diff, linkFieldChange{Field: "tagIds", Before: b, After: a})
+	}
+	return diff
+}
+
+// snapshotTagIDs returns the sorted IDs of the tags in a link snapshot.
+func snapshotTagIDs(link map[string]interface{}) []string {
+	ids := []string{}
+	tags, _ := link["tags"].([]interface{})
+	for _, tag := range tags {
+		if t, ok := tag.(map[string]interface{}); ok {
+			ids = append(ids, outfmt.SafeString(t["id"]))
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// withoutRedacted drops the changes back to a secret that the history only
+// kept as config.Redacted, returning the fields dropped.
+func withoutRedacted(diff []linkFieldChange) ([]linkFieldChange, []string) {
+	var kept []linkFieldChange
+	var redacted []string
+	for _, d := range diff {
+		if d.After == config.Redacted {
+			redacted = append(redacted, d.Field)
+			continue
+		}
+		kept = append(kept, d)
+	}
+	return kept, redacted
+}
+
+// revertBody builds the update that applies the After side of diff.
+func revertBody(diff []linkFieldChange) map[string]interface{} {
+	body := map[string]interface{}{}
+	for _, d := range diff {
+		body[d.Field] = d.After
+	}
+	return body
+}
+
+// formatHistoryValue renders a snapshot field for the change log.
+func formatHistoryValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	case []string:
+		if len(v) == 0 {
+			return "(none)"
+		}
+		return strings.Join(v, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
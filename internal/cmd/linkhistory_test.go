@@ -0,0 +1,93 @@
+// internal/cmd/linkhistory_test.go
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+func TestLinkDiff(t *testing.T) {
+	before := map[string]interface{}{"url": "https://a.example", "title": nil, "tags": []interface{}{map[string]interface{}{"id": "tag_1"}}}
+	after := map[string]interface{}{"url": "https://b.example", "title": nil, "clicks": 5.0, "tags": []interface{}{}}
+
+	got := linkDiff(before, after)
+	want := []linkFieldChange{
+		{Field: "url", Before: "https://a.example", After: "https://b.example"},
+		{Field: "tagIds", Before: []string{"tag_1"}, After: []string{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("linkDiff = %+v, want %+v", got, want)
+	}
+	if linkDiff(before, nil) != nil {
+		t.Error("expected no diff without an after snapshot")
+	}
+}
+
+func TestLinksHistoryAndRevert(t *testing.T) {
+	link := map[string]interface{}{"id": "link_1", "url": "https://old.example", "password": "hunter2", "tags": []interface{}{}}
+	var patches []map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/links/link_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Method == http.MethodPatch {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			patches = append(patches, body)
+			for k, v := range body {
+				link[k] = v
+			}
+		}
+		_ = json.NewEncoder(w).Encode(link)
+	})
+	stateDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateDir)
+
+	if _, err := runHistoryCmd(t, newLinksUpdateCmd(), server.URL, "--id", "link_1", "--url", "https://new.example"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(stateDir, "dub-cli", "link-history.json"))
+	if err != nil || strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), config.Redacted) {
+		t.Errorf("expected the password redacted in the history file, got %v:\n%s", err, data)
+	}
+
+	out, err := runHistoryCmd(t, newLinksHistoryCmd(), server.URL, "--id", "link_1")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if !strings.Contains(out, "#1  ") || !strings.Contains(out, "url: https://old.example -> https://new.example") {
+		t.Errorf("unexpected history:\n%s", out)
+	}
+
+	out, err = runHistoryCmd(t, newLinksRevertCmd(), server.URL, "--id", "link_1", "--to", "1", "--dry-run")
+	if err != nil {
+		t.Fatalf("revert --dry-run: %v", err)
+	}
+	if !strings.Contains(out, "url: https://new.example -> https://old.example") || len(patches) != 1 {
+		t.Errorf("unexpected dry run %q after %d patches", out, len(patches))
+	}
+
+	if _, err := runHistoryCmd(t, newLinksRevertCmd(), server.URL, "--id", "link_1", "--to", "1"); err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+	if want := map[string]interface{}{"url": "https://old.example"}; len(patches) != 2 || !reflect.DeepEqual(patches[1], want) {
+		t.Errorf("revert sent %v, want %v", patches, want)
+	}
+
+	out, _ = runHistoryCmd(t, newLinksHistoryCmd(), server.URL, "--id", "link_1")
+	if !strings.HasPrefix(out, "#2  ") {
+		t.Errorf("expected the revert to be recorded as change 2:\n%s", out)
+	}
+
+	if _, err := runHistoryCmd(t, newLinksRevertCmd(), server.URL, "--id", "link_1", "--to", "9"); err == nil {
+		t.Error("expected error for an unknown change")
+	}
+}
@@ -4,56 +4,195 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/config"
 	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 )
 
+// maxBulkLinks is the API's limit on links per bulk request.
+const maxBulkLinks = 100
+
+// linksPageSize is the number of links requested per page when scanning
+// every link in a workspace.
+const linksPageSize = 100
+
 func handleResponse(cmd *cobra.Command, resp *http.Response) error {
+	_, err := writeResponse(cmd, resp)
+	return err
+}
+
+// writeResponse prints resp as handleResponse does and returns the decoded
+// body, or nil if it wasn't JSON.
+func writeResponse(cmd *cobra.Command, resp *http.Response) (interface{}, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if resp.StatusCode >= 400 {
 		apiErr := api.ParseAPIError(body)
-		return fmt.Errorf("%s", apiErr.Error())
+		return nil, fmt.Errorf("%s", apiErr.Error())
+	}
+
+	if body, err = sortListBody(cmd, body); err != nil {
+		return nil, err
 	}
 
 	var data interface{}
 	if err := json.Unmarshal(body, &data); err != nil {
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(body))
-		return nil
+		return nil, nil
 	}
 
 	query := outfmt.GetQuery(cmd.Context())
-	return outfmt.FormatJSON(cmd.OutOrStdout(), data, query)
+	if query == "" && outfmt.GetFormat(cmd.Context()) == "table" {
+		switch v := data.(type) {
+		case map[string]interface{}:
+			return data, outfmt.FormatDetail(cmd.OutOrStdout(), v)
+		case []interface{}:
+			// Lists without a hand-written table get inferred columns
+			if ok, err := outfmt.FormatAutoTable(cmd.OutOrStdout(), v); ok || err != nil {
+				return data, err
+			}
+		}
+	}
+	return data, outfmt.FormatJSON(cmd.OutOrStdout(), data, query)
+}
+
+// sortListBody sorts a list response by the global --sort-by and --desc
+// flags, leaving other responses unchanged.
+func sortListBody(cmd *cobra.Command, body []byte) ([]byte, error) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return body, nil
+	}
+	return outfmt.SortJSON(body, outfmt.GetSortBy(ctx), outfmt.GetDesc(ctx))
 }
 
 // Link represents a Dub link from the API response.
 type Link struct {
-	ID          string  `json:"id"`
-	Domain      string  `json:"domain"`
-	Key         string  `json:"key"`
-	URL         string  `json:"url"`
-	Clicks      int     `json:"clicks"`
-	LastClicked *string `json:"lastClicked"`
+	ID          string    `json:"id"`
+	Domain      string    `json:"domain"`
+	Key         string    `json:"key"`
+	URL         string    `json:"url"`
+	Clicks      int       `json:"clicks"`
+	LastClicked *string   `json:"lastClicked"`
+	UserID      string    `json:"userId"`
+	User        *LinkUser `json:"user"`
+}
+
+// LinkUser is the workspace member who created a link, included when
+// links are listed with includeUser=true.
+type LinkUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// linkRecord holds the link fields used when scanning a whole workspace.
+type linkRecord struct {
+	ID        string  `json:"id"`
+	Domain    string  `json:"domain"`
+	Key       string  `json:"key"`
+	URL       string  `json:"url"`
+	Clicks    int     `json:"clicks"`
+	Archived  bool    `json:"archived"`
+	CreatedAt string  `json:"createdAt"`
+	ExpiresAt *string `json:"expiresAt"`
+	Tags      []struct {
+		Name string `json:"name"`
+	} `json:"tags,omitempty"`
+}
+
+// protected reports whether the link carries the protected tag.
+func (l linkRecord) protected() bool {
+	for _, tag := range l.Tags {
+		if strings.EqualFold(tag.Name, protectedTag) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchAllLinks pages through every link matching params, decoding each
+// into T.
+func fetchAllLinks[T any](ctx context.Context, client *api.Client, params url.Values) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		params.Set("pageSize", strconv.Itoa(linksPageSize))
+
+		var links []T
+		if err := getJSON(ctx, client, "/links?"+params.Encode(), &links); err != nil {
+			return nil, fmt.Errorf("failed to list links: %w", err)
+		}
+		all = append(all, links...)
+		if len(links) < linksPageSize {
+			return all, nil
+		}
+	}
+}
+
+// fetchLinksCreatedBetween returns, as a JSON array, the links matching
+// params that were created between after and before; a zero time leaves
+// that end open. The links API has no creation date filter, so links are
+// listed newest first and paging stops at the first link created before
+// after.
+func fetchLinksCreatedBetween(ctx context.Context, client *api.Client, params url.Values, after, before time.Time) ([]byte, error) {
+	params.Set("sortBy", "createdAt")
+	params.Set("sortOrder", "desc")
+	matched := []json.RawMessage{}
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		params.Set("pageSize", strconv.Itoa(linksPageSize))
+
+		var links []json.RawMessage
+		if err := getJSON(ctx, client, "/links?"+params.Encode(), &links); err != nil {
+			return nil, fmt.Errorf("failed to list links: %w", err)
+		}
+		for _, raw := range links {
+			var link struct {
+				CreatedAt string `json:"createdAt"`
+			}
+			if err := json.Unmarshal(raw, &link); err != nil {
+				return nil, fmt.Errorf("failed to parse links: %w", err)
+			}
+			created, err := time.Parse(time.RFC3339, link.CreatedAt)
+			if err != nil {
+				continue
+			}
+			if !after.IsZero() && created.Before(after) {
+				return json.Marshal(matched)
+			}
+			if before.IsZero() || !created.After(before) {
+				matched = append(matched, raw)
+			}
+		}
+		if len(links) < linksPageSize {
+			return json.Marshal(matched)
+		}
+	}
 }
 
 // handleLinksListResponse handles the response for links list command,
-// formatting output as table or JSON based on the output flag.
-func handleLinksListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all bool) error {
+// formatting output as table or JSON based on the output flag. When showUser
+// is set, the table includes the user who created each link.
+func handleLinksListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all, showUser, quiet bool) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
@@ -66,8 +205,18 @@ func handleLinksListResponse(cmd *cobra.Command, resp *http.Response, output str
 		return fmt.Errorf("%s", apiErr.Error())
 	}
 
+	return writeLinksList(cmd, body, output, limit, all, showUser, quiet)
+}
+
+// writeLinksList writes a JSON array of links as a table, JSON, or IDs.
+func writeLinksList(cmd *cobra.Command, body []byte, output string, limit int, all, showUser, quiet bool) error {
+	body, err := sortListBody(cmd, body)
+	if err != nil {
+		return err
+	}
+
 	// For JSON output, use the existing handler
-	if output == "json" {
+	if output == "json" && !quiet {
 		var data interface{}
 		if err := json.Unmarshal(body, &data); err != nil {
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(body))
@@ -96,6 +245,14 @@ func handleLinksListResponse(cmd *cobra.Command, resp *http.Response, output str
 
 	displayLinks := links[:displayLimit]
 
+	// Quiet output is just the IDs, for piping into other commands
+	if quiet {
+		for _, link := range displayLinks {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), link.ID)
+		}
+		return nil
+	}
+
 	// Define table columns
 	columns := []outfmt.Column{
 		{Name: "Short Link", Width: 0, Align: outfmt.AlignLeft},
@@ -103,16 +260,22 @@ func handleLinksListResponse(cmd *cobra.Command, resp *http.Response, output str
 		{Name: "Clicks", Width: 0, Align: outfmt.AlignRight},
 		{Name: "Last Clicked", Width: 0, Align: outfmt.AlignLeft},
 	}
+	if showUser {
+		columns = append(columns, outfmt.Column{Name: "Created By", Width: 0, Align: outfmt.AlignLeft})
+	}
 
 	// Build rows
 	rows := make([][]string, len(displayLinks))
 	for i, link := range displayLinks {
 		rows[i] = []string{
 			buildShortLink(link.Domain, link.Key),
-			outfmt.Truncate(link.URL, 40),
+			link.URL,
 			formatClicks(link.Clicks),
 			formatLastClicked(link.LastClicked),
 		}
+		if showUser {
+			rows[i] = append(rows[i], formatLinkUser(link))
+		}
 	}
 
 	// Write table
@@ -128,6 +291,23 @@ func handleLinksListResponse(cmd *cobra.Command, resp *http.Response, output str
 	return nil
 }
 
+// formatLinkUser describes the creator of a link, preferring name, then
+// email, then user ID.
+func formatLinkUser(link Link) string {
+	if link.User != nil {
+		if link.User.Name != "" {
+			return link.User.Name
+		}
+		if link.User.Email != "" {
+			return link.User.Email
+		}
+	}
+	if link.UserID != "" {
+		return link.UserID
+	}
+	return "-"
+}
+
 // buildShortLink combines domain and key into a short link.
 func buildShortLink(domain, key string) string {
 	return domain + "/" + key
@@ -194,77 +374,392 @@ func newLinksCmd() *cobra.Command {
 	cmd.AddCommand(newLinksListCmd())
 	cmd.AddCommand(newLinksGetCmd())
 	cmd.AddCommand(newLinksCountCmd())
+	cmd.AddCommand(newLinksTopCmd())
+	cmd.AddCommand(newLinksFindCmd())
 	cmd.AddCommand(newLinksUpdateCmd())
+	cmd.AddCommand(newLinksHistoryCmd())
+	cmd.AddCommand(newLinksRevertCmd())
 	cmd.AddCommand(newLinksUpsertCmd())
 	cmd.AddCommand(newLinksDeleteCmd())
+	cmd.AddCommand(newLinksTagCmd())
+	cmd.AddCommand(newLinksMoveCmd())
+	cmd.AddCommand(newLinksLockCmd())
+	cmd.AddCommand(newLinksUnlockCmd())
 	cmd.AddCommand(newLinksBulkCmd())
+	cmd.AddCommand(newLinksRewriteCmd())
+	cmd.AddCommand(newLinksImportCmd())
+	cmd.AddCommand(newLinksDedupeCmd())
+	cmd.AddCommand(newLinksExpireCmd())
+	cmd.AddCommand(newLinksPasswordCmd())
 
 	return cmd
 }
 
 func newLinksCreateCmd() *cobra.Command {
 	var (
-		linkURL string
-		key     string
-		domain  string
+		linkURL    string
+		key        string
+		domain     string
+		tagNames   []string
+		folderName string
+		fields     linkFields
+		open       bool
+		preview    bool
+		userAgents []string
+		fromStdin  stdinBody
 	)
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new short link",
-		Long:  "Create a new short link with the specified URL.",
+		Long: `Create a new short link with the specified URL.
+
+Pass --url @file (or @- for stdin) with one URL per line to create many
+links at once. Blank lines and lines starting with # are ignored.
+
+With --from-stdin, the whole request body is read as a JSON object, for
+payloads generated by scripts. Unknown fields and fields of the wrong type
+are rejected before anything is sent, and flags override the body's fields.
+
+Destinations are checked before anything is sent: app links (myapp://...)
+must have a valid scheme, and you are warned when visitors without the app
+would have no web fallback. With --preview, nothing is created; instead
+the command shows where iOS, Android, and desktop visitors (or each
+--user-agent) would be sent.
+
+Examples:
+  dub links create --url https://example.com --tag-name promo
+  dub links create --url https://example.com/app --ios myapp://open --preview
+  echo '{"url":"https://x.com","tagNames":["a"]}' | dub links create --from-stdin`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			body := map[string]interface{}{}
+			if fromStdin.enabled {
+				var err error
+				if body, err = fromStdin.read(cmd); err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed("url") {
+					linkURL, _ = body["url"].(string)
+				}
+			}
 			if linkURL == "" {
 				return fmt.Errorf("--url is required")
 			}
 
+			urls := splitLines(linkURL)
+			if len(urls) > 1 && fromStdin.enabled {
+				return NewUsageErrorf("--from-stdin creates a single link; pass one URL")
+			}
+			if len(urls) > 1 && key != "" {
+				return NewUsageErrorf("--key cannot be used when creating multiple links")
+			}
+			if len(urls) > 1 && open {
+				return NewUsageErrorf("--open cannot be used when creating multiple links")
+			}
+			if len(urls) > maxBulkLinks {
+				return NewUsageErrorf("at most %d links can be created at once, got %d URLs", maxBulkLinks, len(urls))
+			}
+			if len(userAgents) > 0 && !preview {
+				return NewUsageErrorf("--user-agent requires --preview")
+			}
+			if preview {
+				if len(urls) > 1 {
+					return NewUsageErrorf("--preview shows a single link; pass one URL")
+				}
+				body["url"] = linkURL
+				fields.apply(cmd, body, true)
+				if err := warnDeepLinks(cmd, body); err != nil {
+					return err
+				}
+				return writeDeepLinkPreview(cmd, body, userAgents)
+			}
+			if _, ok := body["domain"]; !ok && domain == "" {
+				domain = GetDefaultDomain(cmd.Context())
+			}
+			_, hasTagIDs := body["tagIds"]
+			_, hasTagNames := body["tagNames"]
+			if len(tagNames) == 0 && !flagHasValue(cmd.Flags(), "tag-id") && !hasTagIDs && !hasTagNames {
+				tagNames = GetDefaultTags(cmd.Context())
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			body := map[string]interface{}{
-				"url": linkURL,
+			if len(urls) > 1 {
+				// Resolve shared fields once, then apply them to every link
+				shared := map[string]interface{}{}
+				if domain != "" {
+					shared["domain"] = domain
+				}
+				if err := applyLinkOrganization(cmd.Context(), client, shared, tagNames, folderName); err != nil {
+					return err
+				}
+				fields.apply(cmd, shared, true)
+				if err := warnDeepLinks(cmd, shared); err != nil {
+					return err
+				}
+
+				links := make([]map[string]interface{}, len(urls))
+				for i, u := range urls {
+					links[i] = map[string]interface{}{"url": u}
+					if err := warnDeepLinks(cmd, links[i]); err != nil {
+						return err
+					}
+					for k, v := range shared {
+						links[i][k] = v
+					}
+				}
+
+				resp, err := client.Post(cmd.Context(), "/links/bulk", links)
+				if err != nil {
+					return err
+				}
+
+				return handleResponse(cmd, resp)
 			}
+
+			body["url"] = linkURL
 			if key != "" {
 				body["key"] = key
 			}
 			if domain != "" {
 				body["domain"] = domain
 			}
+			if err := applyLinkOrganization(cmd.Context(), client, body, tagNames, folderName); err != nil {
+				return err
+			}
+			fields.apply(cmd, body, true)
+			if fromStdin.enabled {
+				if err := linkCreateSchema.validate(body, true); err != nil {
+					return err
+				}
+			}
+			if err := warnDeepLinks(cmd, body); err != nil {
+				return err
+			}
 
 			resp, err := client.Post(cmd.Context(), "/links", body)
 			if err != nil {
 				return err
 			}
 
-			return handleResponse(cmd, resp)
+			link, err := writeResponse(cmd, resp)
+			if err != nil || !open {
+				return err
+			}
+			return openDashboard(cmd, client, link, linkDashboardPage)
 		},
 	}
 
-	cmd.Flags().StringVar(&linkURL, "url", "", "Destination URL (required)")
+	cmd.Flags().StringVar(&linkURL, "url", "", "Destination URL, or @file with one URL per line (required)")
+	cmd.Flags().BoolVar(&open, "open", false, "Open the new link in the Dub dashboard")
 	cmd.Flags().StringVar(&key, "key", "", "Custom short key (optional)")
 	cmd.Flags().StringVar(&domain, "domain", "", "Domain for the short link (optional)")
+	cmd.Flags().StringSliceVar(&tagNames, "tag-name", nil, "Tag name to apply (repeatable)")
+	cmd.Flags().StringVar(&folderName, "folder-name", "", "Folder name to place the link in")
+	cmd.Flags().BoolVar(&preview, "preview", false, "Show where each device would be sent, without creating the link")
+	cmd.Flags().StringArrayVar(&userAgents, "user-agent", nil, "User agent to preview (repeatable; default: iPhone, Android, and desktop)")
+	fields.register(cmd)
+	fromStdin.register(cmd, linkCreateSchema)
 
-	_ = cmd.MarkFlagRequired("url")
+	cmd.MarkFlagsOneRequired("url", "from-stdin")
 
 	return cmd
 }
 
+// applyLinkOrganization resolves tag and folder names into the link payload.
+func applyLinkOrganization(ctx context.Context, client *api.Client, body map[string]interface{}, tagNames []string, folderName string) error {
+	if len(tagNames) > 0 {
+		tagIDs, err := resolveNamesToIDs(ctx, client, cache.KindTag, tagNames)
+		if err != nil {
+			return err
+		}
+		body["tagIds"] = tagIDs
+	}
+	if folderName != "" {
+		folderIDs, err := resolveNamesToIDs(ctx, client, cache.KindFolder, []string{folderName})
+		if err != nil {
+			return err
+		}
+		body["folderId"] = folderIDs[0]
+	}
+	return nil
+}
+
+// linkFields holds the mutable link attributes shared by create and update.
+type linkFields struct {
+	tagIDs          []string
+	folderID        string
+	expiresAt       string
+	expiredURL      string
+	password        string
+	comments        string
+	utmSource       string
+	utmMedium       string
+	utmCampaign     string
+	utmTerm         string
+	utmContent      string
+	ios             string
+	android         string
+	geo             map[string]string
+	archived        bool
+	trackConversion bool
+}
+
+// linkFieldFlags maps flag names to their API field names.
+var linkFieldFlags = map[string]string{
+	"folder-id":    "folderId",
+	"expires-at":   "expiresAt",
+	"expired-url":  "expiredUrl",
+	"password":     "password",
+	"comments":     "comments",
+	"utm-source":   "utm_source",
+	"utm-medium":   "utm_medium",
+	"utm-campaign": "utm_campaign",
+	"utm-term":     "utm_term",
+	"utm-content":  "utm_content",
+	"ios":          "ios",
+	"android":      "android",
+}
+
+// register adds the link field flags to cmd.
+func (f *linkFields) register(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&f.tagIDs, "tag-id", nil, "Tag ID to apply (repeatable)")
+	cmd.Flags().StringVar(&f.folderID, "folder-id", "", "Folder ID to place the link in")
+	cmd.Flags().StringVar(&f.expiresAt, "expires-at", "", "Expiration date (e.g. 2025-12-31T23:59:59Z)")
+	cmd.Flags().StringVar(&f.expiredURL, "expired-url", "", "URL to redirect to after expiration")
+	cmd.Flags().StringVar(&f.password, "password", "", "Password required to access the link")
+	cmd.Flags().StringVar(&f.comments, "comments", "", "Internal comments")
+	cmd.Flags().StringVar(&f.utmSource, "utm-source", "", "UTM source")
+	cmd.Flags().StringVar(&f.utmMedium, "utm-medium", "", "UTM medium")
+	cmd.Flags().StringVar(&f.utmCampaign, "utm-campaign", "", "UTM campaign")
+	cmd.Flags().StringVar(&f.utmTerm, "utm-term", "", "UTM term")
+	cmd.Flags().StringVar(&f.utmContent, "utm-content", "", "UTM content")
+	cmd.Flags().StringVar(&f.ios, "ios", "", "Destination URL for iOS devices")
+	cmd.Flags().StringVar(&f.android, "android", "", "Destination URL for Android devices")
+	cmd.Flags().StringToStringVar(&f.geo, "geo", nil, "Country-specific destination (e.g. US=https://example.com/us, repeatable)")
+	cmd.Flags().BoolVar(&f.archived, "archived", false, "Archive the link")
+	cmd.Flags().BoolVar(&f.trackConversion, "track-conversion", false, "Track conversions for the link")
+
+	cmd.MarkFlagsMutuallyExclusive("tag-id", "tag-name")
+	cmd.MarkFlagsMutuallyExclusive("folder-id", "folder-name")
+}
+
+// apply copies every link field flag that was set into body. Creates pass
+// withDefaults to take fields from environment and config defaults too;
+// updates take only the flags given on the command line, so a default never
+// changes a field of an existing link.
+func (f *linkFields) apply(cmd *cobra.Command, body map[string]interface{}, withDefaults bool) {
+	flags := cmd.Flags()
+	set := flags.Changed
+	if withDefaults {
+		set = func(name string) bool { return flagHasValue(flags, name) }
+	}
+	for flag, field := range linkFieldFlags {
+		if !set(flag) {
+			continue
+		}
+		body[field] = flags.Lookup(flag).Value.String()
+	}
+	if set("tag-id") {
+		body["tagIds"] = f.tagIDs
+	}
+	if set("geo") {
+		body["geo"] = f.geo
+	}
+	if set("archived") {
+		body["archived"] = f.archived
+	}
+	if set("track-conversion") {
+		body["trackConversion"] = f.trackConversion
+	}
+
+	for _, r := range linkRemovalFlags {
+		if !set(r.flag) {
+			continue
+		}
+		if remove, err := flags.GetBool(r.flag); err == nil && remove {
+			for _, field := range r.fields {
+				body[field] = r.value
+			}
+		}
+	}
+}
+
+// linkRemovalFlags are the update-only flags that clear a field. A flag left
+// empty is indistinguishable from one not given, so clearing is explicit.
+var linkRemovalFlags = []struct {
+	flag      string
+	usage     string
+	fields    []string
+	value     interface{}
+	conflicts []string
+}{
+	{"remove-password", "Remove the link password", []string{"password"}, nil, []string{"password"}},
+	{"remove-expiration", "Remove the expiration date and expired URL", []string{"expiresAt", "expiredUrl"}, nil, []string{"expires-at", "expired-url"}},
+	{"remove-comments", "Remove the link comments", []string{"comments"}, nil, []string{"comments"}},
+	{"remove-geo", "Remove all country-specific destinations", []string{"geo"}, nil, []string{"geo"}},
+	{"remove-folder", "Move the link out of its folder", []string{"folderId"}, nil, []string{"folder-id", "folder-name"}},
+	{"clear-tags", "Remove all tags from the link", []string{"tagIds"}, []string{}, []string{"tag-id", "tag-name"}},
+}
+
+// registerLinkRemovals adds the --remove-<field> and --clear-tags flags to cmd.
+// It must be called after linkFields.register so conflicts can be declared.
+func registerLinkRemovals(cmd *cobra.Command) {
+	for _, r := range linkRemovalFlags {
+		cmd.Flags().Bool(r.flag, false, r.usage)
+		for _, c := range r.conflicts {
+			cmd.MarkFlagsMutuallyExclusive(r.flag, c)
+		}
+	}
+}
+
 func newLinksListCmd() *cobra.Command {
 	var (
-		search string
-		domain string
-		output string
-		limit  int
-		all    bool
+		search     string
+		domain     string
+		tagNames   []string
+		folderName string
+		userID     string
+		showUser   bool
+		quiet      bool
+		output     string
+		limit      int
+		all        bool
+		after      string
+		before     string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List links",
-		Long:  "List all links in the workspace.",
+		Long: `List all links in the workspace.
+
+--created-after and --created-before take a date such as 2025-01-31, a month,
+"yesterday", or "14 days ago"; --created-before includes the whole day it
+names. With either, every page of matching links is fetched, newest first.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var createdAfter, createdBefore time.Time
+			if after != "" {
+				t, err := parseDateFlag(after, false)
+				if err != nil {
+					return NewUsageErrorf("--created-after: %v", err)
+				}
+				createdAfter = t
+			}
+			if before != "" {
+				t, err := parseDateFlag(before, true)
+				if err != nil {
+					return NewUsageErrorf("--created-before: %v", err)
+				}
+				createdBefore = t
+			}
+			if !createdAfter.IsZero() && !createdBefore.IsZero() && createdBefore.Before(createdAfter) {
+				return NewUsageErrorf("--created-before must not be earlier than --created-after")
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
@@ -277,6 +772,34 @@ func newLinksListCmd() *cobra.Command {
 			if domain != "" {
 				params.Set("domain", domain)
 			}
+			if len(tagNames) > 0 {
+				tagIDs, err := resolveNamesToIDs(cmd.Context(), client, cache.KindTag, tagNames)
+				if err != nil {
+					return err
+				}
+				params.Set("tagIds", strings.Join(tagIDs, ","))
+			}
+			if folderName != "" {
+				folderIDs, err := resolveNamesToIDs(cmd.Context(), client, cache.KindFolder, []string{folderName})
+				if err != nil {
+					return err
+				}
+				params.Set("folderId", folderIDs[0])
+			}
+			if userID != "" {
+				params.Set("userId", userID)
+			}
+			if showUser {
+				params.Set("includeUser", "true")
+			}
+
+			if after != "" || before != "" {
+				body, err := fetchLinksCreatedBetween(cmd.Context(), client, params, createdAfter, createdBefore)
+				if err != nil {
+					return err
+				}
+				return writeLinksList(cmd, body, output, limit, all, showUser, quiet)
+			}
 
 			path := "/links"
 			if len(params) > 0 {
@@ -288,12 +811,19 @@ func newLinksListCmd() *cobra.Command {
 				return err
 			}
 
-			return handleLinksListResponse(cmd, resp, output, limit, all)
+			return handleLinksListResponse(cmd, resp, output, limit, all, showUser, quiet)
 		},
 	}
 
 	cmd.Flags().StringVar(&search, "search", "", "Search query")
 	cmd.Flags().StringVar(&domain, "domain", "", "Filter by domain")
+	cmd.Flags().StringSliceVar(&tagNames, "tag-name", nil, "Filter by tag name (repeatable)")
+	cmd.Flags().StringVar(&folderName, "folder-name", "", "Filter by folder name")
+	cmd.Flags().StringVar(&userID, "user-id", "", "Filter by the ID of the user who created the link")
+	cmd.Flags().StringVar(&after, "created-after", "", "Only links created on or after this date, e.g. 2025-01-01 or \"14 days ago\"")
+	cmd.Flags().StringVar(&before, "created-before", "", "Only links created on or before this date, e.g. 2025-01-31")
+	cmd.Flags().BoolVar(&showUser, "show-user", false, "Show the user who created each link")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print only link IDs, one per line")
 	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of links to show")
 	cmd.Flags().BoolVar(&all, "all", false, "Show all links (ignore limit)")
@@ -410,20 +940,42 @@ func resolveLink(ctx context.Context, client *api.Client, domain, key string) (s
 
 func newLinksUpdateCmd() *cobra.Command {
 	var (
-		id      string
-		domain  string
-		linkURL string
-		key     string
+		id         string
+		domain     string
+		linkURL    string
+		key        string
+		tagNames   []string
+		folderName string
+		fields     linkFields
+		patch      patchFlag
+		expect     expectFlag
 	)
 
 	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update a link",
-		Long:  "Update an existing link by ID or by domain and key.",
+		Long: `Update an existing link by ID or by domain and key. The link is
+snapshotted before and after, so the change shows in dub links history and
+can be reverted with dub links revert.
+
+--patch sends API fields that have no flag: a JSON merge patch whose fields
+override the flags, with null clearing a field.
+
+--expect and --if-match guard against overwriting someone else's edit: the
+link is checked first, and the update is refused if it no longer matches.
+
+Examples:
+  dub links update --id link_abc --url https://example.com/new
+  dub links update --id link_abc --url https://new.com --expect url=https://old.com
+  dub links update --id link_abc --patch '{"utm_source":"x","comments":null}'
+  dub links update --id link_abc --patch @patch.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if id == "" && (domain == "" || key == "") {
 				return fmt.Errorf("either --id or both --domain and --key are required")
 			}
+			if err := expect.validate(); err != nil {
+				return err
+			}
 
 			client, err := getClient(cmd.Context())
 			if err != nil {
@@ -441,16 +993,30 @@ func newLinksUpdateCmd() *cobra.Command {
 			}
 
 			body := map[string]interface{}{}
-			if linkURL != "" {
+			if cmd.Flags().Changed("url") {
 				body["url"] = linkURL
 			}
 			// key is only a field to update when identifying by --id
 			if id != "" && key != "" {
 				body["key"] = key
 			}
+			if err := applyLinkOrganization(cmd.Context(), client, body, tagNames, folderName); err != nil {
+				return err
+			}
+			fields.apply(cmd, body, false)
 
+			if err := patch.apply(body); err != nil {
+				return err
+			}
 			if len(body) == 0 {
-				return fmt.Errorf("at least one update field (--url) must be specified")
+				return fmt.Errorf("at least one field must be specified for update")
+			}
+			if err := warnDeepLinks(cmd, body); err != nil {
+				return err
+			}
+			before, err := fetchLinkForUpdate(cmd, client, linkID, &expect)
+			if err != nil {
+				return err
 			}
 
 			resp, err := client.Patch(cmd.Context(), "/links/"+url.PathEscape(linkID), body)
@@ -458,7 +1024,12 @@ func newLinksUpdateCmd() *cobra.Command {
 				return err
 			}
 
-			return handleResponse(cmd, resp)
+			after, err := writeResponse(cmd, resp)
+			if err != nil {
+				return err
+			}
+			recordLinkChange(cmd, linkID, before, after)
+			return nil
 		},
 	}
 
@@ -466,6 +1037,12 @@ func newLinksUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&domain, "domain", "", "Domain (used with --key to identify link)")
 	cmd.Flags().StringVar(&linkURL, "url", "", "New destination URL")
 	cmd.Flags().StringVar(&key, "key", "", "Short key (used with --domain to identify link, or with --id to rename)")
+	cmd.Flags().StringSliceVar(&tagNames, "tag-name", nil, "Replace tags with these tag names (repeatable)")
+	cmd.Flags().StringVar(&folderName, "folder-name", "", "Move the link to this folder")
+	fields.register(cmd)
+	registerLinkRemovals(cmd)
+	patch.register(cmd)
+	expect.register(cmd)
 
 	return cmd
 }
@@ -485,6 +1062,9 @@ func newLinksUpsertCmd() *cobra.Command {
 			if linkURL == "" {
 				return fmt.Errorf("--url is required")
 			}
+			if domain == "" {
+				domain = GetDefaultDomain(cmd.Context())
+			}
 
 			client, err := getClient(cmd.Context())
 			if err != nil {
@@ -521,15 +1101,58 @@ func newLinksUpsertCmd() *cobra.Command {
 
 func newLinksDeleteCmd() *cobra.Command {
 	var (
-		id     string
-		dryRun bool
+		id          string
+		dryRun      bool
+		concurrency int
+		picker      linkPicker
+		fromStdin   stdinIDs
 	)
 
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete a link",
-		Long:  "Delete a link by ID.",
+		Long: `Delete a link by ID, several links chosen from a checklist with
+--interactive, or the links whose IDs are piped in with --ids-from-stdin.
+Links are snapshotted first so dub undo can recreate them.
+
+Examples:
+  dub links delete --id link_abc123
+  dub links list --search promo -q --all | dub links delete --ids-from-stdin --yes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromStdin.enabled {
+				ids, err := fromStdin.read(cmd, dryRun)
+				if err != nil {
+					return err
+				}
+				if err := validateConcurrency(concurrency); err != nil {
+					return err
+				}
+				client, err := getClient(cmd.Context())
+				if err != nil {
+					return err
+				}
+				links, err := picker.linksByIDOrPick(cmd, client, ids, "delete")
+				if err != nil {
+					return err
+				}
+				return deleteLinks(cmd, client, links, concurrency, dryRun)
+			}
+
+			if picker.interactive {
+				if err := validateConcurrency(concurrency); err != nil {
+					return err
+				}
+				client, err := getClient(cmd.Context())
+				if err != nil {
+					return err
+				}
+				links, err := picker.pick(cmd, client, "delete")
+				if err != nil {
+					return err
+				}
+				return deleteLinks(cmd, client, links, concurrency, dryRun)
+			}
+
 			if id == "" {
 				return fmt.Errorf("--id is required")
 			}
@@ -544,23 +1167,83 @@ func newLinksDeleteCmd() *cobra.Command {
 				return err
 			}
 
+			snapshot, snapErr := snapshotLink(cmd.Context(), client, id)
+			if snapErr == nil {
+				if err := checkProtected(cmd.Context(), []map[string]interface{}{snapshot}, "delete"); err != nil {
+					return err
+				}
+			} else if !GetForce(cmd.Context()) {
+				return fmt.Errorf("failed to check link protection:\n%s: %w", id, snapErr)
+			}
+
 			resp, err := client.Delete(cmd.Context(), "/links/"+url.PathEscape(id))
 			if err != nil {
 				return err
 			}
+			if err := handleResponse(cmd, resp); err != nil {
+				return err
+			}
 
-			return handleResponse(cmd, resp)
+			label := ""
+			if snapErr != nil {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), snapshotWarning("link", id, snapErr))
+			} else {
+				label = buildShortLink(outfmt.SafeString(snapshot["domain"]), outfmt.SafeString(snapshot["key"]))
+			}
+			recordHistory(cmd, newHistoryEntry("delete", "link", id, label, snapshot))
+			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&id, "id", "", "Link ID (required)")
+	cmd.Flags().StringVar(&id, "id", "", "Link ID (required unless --interactive)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be deleted without actually deleting")
-
-	_ = cmd.MarkFlagRequired("id")
+	addConcurrencyFlag(cmd, &concurrency)
+	picker.register(cmd)
+	fromStdin.register(cmd, "id", "id")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "ids-from-stdin")
 
 	return cmd
 }
 
+// deleteLinks deletes the listed links concurrently, recording each
+// deletion in the undo history.
+func deleteLinks(cmd *cobra.Command, client *api.Client, links []map[string]interface{}, concurrency int, dryRun bool) error {
+	if err := checkProtected(cmd.Context(), links, "delete"); err != nil {
+		return err
+	}
+	if dryRun {
+		for _, link := range links {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Would delete %s (%s)\n", linkLabel(link), outfmt.SafeString(link["id"]))
+		}
+		return nil
+	}
+
+	errs := batch.Run(cmd.Context(), len(links), concurrency, func(ctx context.Context, i int) error {
+		if err := discardResponse(client.Delete(ctx, "/links/"+url.PathEscape(outfmt.SafeString(links[i]["id"])))); err != nil {
+			return fmt.Errorf("%s: %w", linkLabel(links[i]), err)
+		}
+		return nil
+	})
+
+	var failed []error
+	var deleted []config.HistoryEntry
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		label := linkLabel(links[i])
+		deleted = append(deleted, newHistoryEntry("delete", "link", outfmt.SafeString(links[i]["id"]), label, links[i]))
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Deleted %s\n", label)
+	}
+	recordHistory(cmd, deleted...)
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d of %d link(s):\n%w", len(failed), len(links), errors.Join(failed...))
+	}
+	return nil
+}
+
 func newLinksBulkCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "bulk",
@@ -576,24 +1259,37 @@ func newLinksBulkCmd() *cobra.Command {
 }
 
 func newLinksBulkCreateCmd() *cobra.Command {
+	var bodyTemplate bodyTemplateFlags
+
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Bulk create links",
-		Long:  "Create multiple links from JSON input (reads from stdin).",
+		Long: `Create multiple links from JSON input (reads from stdin).
+
+With --data-template, the JSON is rendered from a Go template instead, with
+values from --var key=value. The json, split, and env functions help build
+valid payloads.
+
+Example template (links.tmpl):
+  [{{range $i, $key := split .keys ","}}{{if $i}},{{end}}
+    {"url": {{json $.url}}, "key": {{json $key}}}{{end}}]
+
+  dub links bulk create --data-template links.tmpl \
+    --var url=https://example.com --var keys=spring,summer`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			input, err := io.ReadAll(os.Stdin)
+			body, err := bodyTemplate.readBody(cmd)
 			if err != nil {
-				return fmt.Errorf("failed to read stdin: %w", err)
+				return err
 			}
-
-			var body interface{}
-			if err := json.Unmarshal(input, &body); err != nil {
-				return fmt.Errorf("invalid JSON input: %w", err)
+			if links, ok := body.([]interface{}); ok {
+				if err := checkLinkQuota(cmd, client, len(links)); err != nil {
+					return err
+				}
 			}
 
 			resp, err := client.Post(cmd.Context(), "/links/bulk", body)
@@ -605,28 +1301,99 @@ func newLinksBulkCreateCmd() *cobra.Command {
 		},
 	}
 
+	bodyTemplate.register(cmd)
+
 	return cmd
 }
 
 func newLinksBulkUpdateCmd() *cobra.Command {
+	var (
+		concurrency  int
+		bodyTemplate bodyTemplateFlags
+		sets         []string
+		search       string
+		domain       string
+		dryRun       bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Bulk update links",
-		Long:  "Update multiple links from JSON input (reads from stdin).",
+		Long: `Update multiple links from JSON input (reads from stdin).
+
+Links can be identified by ID ("linkIds") or by domain and key ("links").
+Domain/key pairs are resolved to IDs concurrently before the update is sent.
+
+Example input:
+  {
+    "links": [{"domain": "dub.sh", "key": "promo"}, {"domain": "dub.sh", "key": "sale"}],
+    "data": {"url": "https://example.com/new"}
+  }
+
+With --set, the links matching --search and --domain are updated instead,
+with no JSON input. Each --set is field=value, setting a link field on
+every match, or url-prefix-replace=OLD:NEW, rewriting the start of each
+destination URL (OLD may leave out the scheme, and then only matches up to
+a "/", "?", "#", or ":", so a host doesn't match a longer one). The changes
+are previewed per link, then applied in bulk updates of up to 100 links;
+this requires --yes (or --dry-run). Protected links are skipped unless
+--force is given.
+
+Examples:
+  dub links bulk update --search old.example.com --set url-prefix-replace=old.example.com:new.example.com --dry-run
+  dub links bulk update --domain go.acme.com --set utm_source=newsletter --set trackConversion=true --yes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+			if len(sets) == 0 && (search != "" || domain != "" || dryRun) {
+				return NewUsageErrorf("--search, --domain, and --dry-run select links for --set")
+			}
+			var parsedSets []linkSet
+			for _, s := range sets {
+				set, err := parseLinkSet(s)
+				if err != nil {
+					return err
+				}
+				parsedSets = append(parsedSets, set)
+			}
+			if len(parsedSets) > 0 {
+				if search == "" && domain == "" {
+					return NewUsageErrorf("--set requires --search or --domain to select the links to update")
+				}
+				if !dryRun && !outfmt.GetYes(cmd.Context()) {
+					return NewUsageErrorf("--set updates every matching link and requires --yes (or --dry-run)")
+				}
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			input, err := io.ReadAll(os.Stdin)
+			if len(parsedSets) > 0 {
+				filter := url.Values{}
+				if search != "" {
+					filter.Set("search", search)
+				}
+				if domain != "" {
+					filter.Set("domain", domain)
+				}
+				return runLinksBulkSet(cmd, client, filter, parsedSets, concurrency, dryRun, writeLinksBulkSet)
+			}
+
+			body, err := bodyTemplate.readBody(cmd)
 			if err != nil {
-				return fmt.Errorf("failed to read stdin: %w", err)
+				return err
 			}
 
-			var body interface{}
-			if err := json.Unmarshal(input, &body); err != nil {
-				return fmt.Errorf("invalid JSON input: %w", err)
+			if m, ok := body.(map[string]interface{}); ok {
+				if err := resolveBulkLinkRefs(cmd.Context(), client, m, concurrency); err != nil {
+					return err
+				}
+				if err := checkProtectedIDs(cmd.Context(), client, bulkLinkIDs(m), "update", concurrency); err != nil {
+					return err
+				}
 			}
 
 			resp, err := client.Patch(cmd.Context(), "/links/bulk", body)
@@ -638,28 +1405,177 @@ func newLinksBulkUpdateCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringArrayVar(&sets, "set", nil, "Change matching links: field=value or url-prefix-replace=OLD:NEW (repeatable)")
+	cmd.Flags().StringVar(&search, "search", "", "With --set, update the links matching this search")
+	cmd.Flags().StringVar(&domain, "domain", "", "With --set, update the links on this domain")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "With --set, show the changes without making them")
+	addConcurrencyFlag(cmd, &concurrency)
+	bodyTemplate.register(cmd)
+
+	cmd.MarkFlagsMutuallyExclusive("set", "data-template")
+
 	return cmd
 }
 
+// linkRef identifies a link by domain and short key.
+type linkRef struct {
+	Domain string
+	Key    string
+}
+
+// resolveBulkLinkRefs replaces the "links" array of domain/key pairs in a bulk
+// update body with their IDs, merged into "linkIds", resolving up to
+// concurrency pairs at a time.
+func resolveBulkLinkRefs(ctx context.Context, client *api.Client, body map[string]interface{}, concurrency int) error {
+	raw, ok := body["links"]
+	if !ok {
+		return nil
+	}
+
+	items, ok := raw.([]interface{})
+	if !ok {
+		return fmt.Errorf(`"links" must be an array of {"domain", "key"} objects`)
+	}
+
+	refs := make([]linkRef, 0, len(items))
+	for i, item := range items {
+		obj, _ := item.(map[string]interface{})
+		ref := linkRef{
+			Domain: outfmt.SafeString(obj["domain"]),
+			Key:    outfmt.SafeString(obj["key"]),
+		}
+		if ref.Domain == "" || ref.Key == "" {
+			return fmt.Errorf("links[%d]: both domain and key are required", i)
+		}
+		refs = append(refs, ref)
+	}
+
+	ids, err := resolveLinks(ctx, client, refs, concurrency)
+	if err != nil {
+		return err
+	}
+
+	// Merge with any explicit IDs, preserving order and skipping duplicates
+	var linkIDs []interface{}
+	seen := map[string]bool{}
+	if existing, ok := body["linkIds"].([]interface{}); ok {
+		for _, id := range existing {
+			s := outfmt.SafeString(id)
+			if !seen[s] {
+				seen[s] = true
+				linkIDs = append(linkIDs, s)
+			}
+		}
+	}
+	for _, ref := range refs {
+		id := ids[ref]
+		if !seen[id] {
+			seen[id] = true
+			linkIDs = append(linkIDs, id)
+		}
+	}
+
+	body["linkIds"] = linkIDs
+	delete(body, "links")
+	return nil
+}
+
+// bulkLinkIDs returns the "linkIds" of a bulk request body.
+func bulkLinkIDs(body map[string]interface{}) []string {
+	raw, _ := body["linkIds"].([]interface{})
+	ids := make([]string, 0, len(raw))
+	for _, id := range raw {
+		ids = append(ids, outfmt.SafeString(id))
+	}
+	return ids
+}
+
+// resolveLinks resolves domain/key pairs to link IDs using up to concurrency
+// parallel lookups. Each distinct pair is looked up only once.
+func resolveLinks(ctx context.Context, client *api.Client, refs []linkRef, concurrency int) (map[linkRef]string, error) {
+	var unique []linkRef
+	seen := map[linkRef]bool{}
+	for _, ref := range refs {
+		if !seen[ref] {
+			seen[ref] = true
+			unique = append(unique, ref)
+		}
+	}
+
+	ids := make([]string, len(unique))
+	errs := batch.Run(ctx, len(unique), concurrency, func(ctx context.Context, i int) error {
+		id, err := resolveLink(ctx, client, unique[i].Domain, unique[i].Key)
+		ids[i] = id
+		return err
+	})
+
+	result := make(map[linkRef]string, len(unique))
+	var failures []error
+	for i, ref := range unique {
+		if errs[i] != nil {
+			failures = append(failures, errs[i])
+			continue
+		}
+		result[ref] = ids[i]
+	}
+
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("failed to resolve %d of %d link(s):\n%w", len(failures), len(unique), errors.Join(failures...))
+	}
+
+	return result, nil
+}
+
 func newLinksBulkDeleteCmd() *cobra.Command {
+	var (
+		planOut      string
+		concurrency  int
+		bodyTemplate bodyTemplateFlags
+	)
+
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Bulk delete links",
-		Long:  "Delete multiple links from JSON input (reads from stdin).",
+		Long: `Delete multiple links from JSON input (reads from stdin).
+
+With --plan-out, nothing is deleted: the links are recorded in a plan file for
+review, and dub apply-plan deletes exactly that set later. Links can be given
+by ID ("linkIds") or, with --plan-out, by domain and key ("links").
+
+Examples:
+  echo '{"linkIds": ["link_abc", "link_def"]}' | dub links bulk delete
+  dub links bulk delete --plan-out plan.json < ids.json
+  dub apply-plan plan.json --yes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
-			input, err := io.ReadAll(os.Stdin)
+			body, err := bodyTemplate.readBody(cmd)
 			if err != nil {
-				return fmt.Errorf("failed to read stdin: %w", err)
+				return err
+			}
+			m, _ := body.(map[string]interface{})
+
+			if planOut != "" {
+				if m == nil {
+					return fmt.Errorf(`expected a JSON object with "linkIds" or "links"`)
+				}
+				if err := resolveBulkLinkRefs(cmd.Context(), client, m, concurrency); err != nil {
+					return err
+				}
+				return writeDeletePlan(cmd, client, bulkLinkIDs(m), concurrency, planOut)
 			}
 
-			var body interface{}
-			if err := json.Unmarshal(input, &body); err != nil {
-				return fmt.Errorf("invalid JSON input: %w", err)
+			if m != nil {
+				if err := checkProtectedIDs(cmd.Context(), client, bulkLinkIDs(m), "delete", concurrency); err != nil {
+					return err
+				}
 			}
 
 			resp, err := client.DeleteWithBody(cmd.Context(), "/links/bulk", body)
@@ -671,5 +1587,9 @@ func newLinksBulkDeleteCmd() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&planOut, "plan-out", "", "Write the deletions to a plan file for dub apply-plan instead of deleting")
+	addConcurrencyFlag(cmd, &concurrency)
+	bodyTemplate.register(cmd)
+
 	return cmd
 }
@@ -4,18 +4,26 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 )
 
 func TestLinksCmd_SubCommands(t *testing.T) {
 	cmd := newLinksCmd()
 
-	subCmds := []string{"create", "list", "get", "count", "update", "upsert", "delete", "bulk"}
+	subCmds := []string{"create", "list", "get", "count", "top", "update", "history", "revert", "upsert", "delete", "tag", "move", "lock", "unlock", "bulk", "import", "dedupe", "password"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range cmd.Commands() {
@@ -194,6 +202,59 @@ func TestLinksListCmd_Flags(t *testing.T) {
 	}
 }
 
+func TestLinksListCmd_CreatedRange(t *testing.T) {
+	var pages []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sortBy") != "createdAt" || q.Get("sortOrder") != "desc" || q.Get("domain") != "dub.sh" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		pages = append(pages, q.Get("page"))
+		// Page 1 is full: 50 links from March, then 50 from February
+		var links []map[string]string
+		switch q.Get("page") {
+		case "1":
+			for i := 0; i < linksPageSize; i++ {
+				link := map[string]string{"id": fmt.Sprintf("mar_%d", i), "createdAt": "2025-03-10T12:00:00.000Z"}
+				if i >= 50 {
+					link = map[string]string{"id": fmt.Sprintf("feb_%d", i), "createdAt": "2025-02-28T23:30:00.000Z"}
+				}
+				links = append(links, link)
+			}
+		case "2":
+			links = append(links,
+				map[string]string{"id": "feb_first", "createdAt": "2025-02-01T00:00:00Z"},
+				map[string]string{"id": "jan", "createdAt": "2025-01-31T23:59:59Z"},
+			)
+		}
+		_ = json.NewEncoder(w).Encode(links)
+	})
+
+	var stdout bytes.Buffer
+	args := []string{"links", "list", "--api-url", server.URL, "--domain", "dub.sh",
+		"--created-after", "2025-02", "--created-before", "2025-02-28", "--all", "-q"}
+	if err := execute(context.Background(), args, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	ids := strings.Fields(stdout.String())
+	if len(ids) != 51 || ids[0] != "feb_50" || ids[50] != "feb_first" {
+		t.Errorf("expected the 51 February links, got %d: %v", len(ids), ids)
+	}
+	if !reflect.DeepEqual(pages, []string{"1", "2"}) {
+		t.Errorf("expected paging to stop at page 2, fetched %v", pages)
+	}
+
+	for _, args := range [][]string{
+		{"links", "list", "--created-after", "someday"},
+		{"links", "list", "--created-after", "2025-03-01", "--created-before", "2025-02-01"},
+	} {
+		err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err == nil || !IsUsageError(err) {
+			t.Errorf("%v: expected usage error, got %v", args, err)
+		}
+	}
+}
+
 func TestFormatClicks(t *testing.T) {
 	tests := []struct {
 		input    int
@@ -286,7 +347,7 @@ func TestHandleLinksListResponse_TableOutput(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	err := handleLinksListResponse(cmd, resp, "table", 25, false)
+	err := handleLinksListResponse(cmd, resp, "table", 25, false, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -322,6 +383,49 @@ func TestHandleLinksListResponse_TableOutput(t *testing.T) {
 	}
 }
 
+func TestHandleLinksListResponse_SortBy(t *testing.T) {
+	jsonBody := `[
+		{"id": "1", "domain": "dub.sh", "key": "few", "url": "https://a.com", "clicks": 9, "lastClicked": "2024-03-01T00:00:00Z"},
+		{"id": "2", "domain": "dub.sh", "key": "many", "url": "https://b.com", "clicks": 100, "lastClicked": "2024-01-01T00:00:00Z"},
+		{"id": "3", "domain": "dub.sh", "key": "some", "url": "https://c.com", "clicks": 10, "lastClicked": null}
+	]`
+
+	tests := []struct {
+		sortBy string
+		desc   bool
+		want   []string
+	}{
+		{"clicks", true, []string{"many", "some"}},
+		{"Last Clicked", false, []string{"many", "few"}},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(jsonBody))}
+		ctx := outfmt.WithDesc(outfmt.WithSortBy(context.Background(), tt.sortBy), tt.desc)
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+
+		if err := handleLinksListResponse(cmd, resp, "table", 2, false, false, false); err != nil {
+			t.Fatalf("--sort-by %s: %v", tt.sortBy, err)
+		}
+		lines := strings.Split(buf.String(), "\n")
+		for i, key := range tt.want {
+			if !strings.Contains(lines[i+1], "dub.sh/"+key) {
+				t.Errorf("--sort-by %q: row %d = %q, want dub.sh/%s", tt.sortBy, i+1, lines[i+1], key)
+			}
+		}
+	}
+
+	resp := &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(jsonBody))}
+	cmd := &cobra.Command{}
+	cmd.SetContext(outfmt.WithSortBy(context.Background(), "nope"))
+	cmd.SetOut(io.Discard)
+	if err := handleLinksListResponse(cmd, resp, "table", 25, false, false, false); err == nil || !strings.Contains(err.Error(), "unknown sort field") {
+		t.Errorf("expected an unknown sort field error, got %v", err)
+	}
+}
+
 func TestHandleLinksListResponse_JSONOutput(t *testing.T) {
 	jsonBody := `[{"id": "1", "domain": "dub.sh", "key": "abc123", "url": "https://example.com", "clicks": 100}]`
 
@@ -335,7 +439,7 @@ func TestHandleLinksListResponse_JSONOutput(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	err := handleLinksListResponse(cmd, resp, "json", 25, false)
+	err := handleLinksListResponse(cmd, resp, "json", 25, false, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -372,7 +476,7 @@ func TestHandleLinksListResponse_Limit(t *testing.T) {
 	cmd.SetOut(&buf)
 
 	// Limit to 2
-	err := handleLinksListResponse(cmd, resp, "table", 2, false)
+	err := handleLinksListResponse(cmd, resp, "table", 2, false, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -417,7 +521,7 @@ func TestHandleLinksListResponse_AllFlag(t *testing.T) {
 	cmd.SetOut(&buf)
 
 	// With --all flag, should show all links even with limit=1
-	err := handleLinksListResponse(cmd, resp, "table", 1, true)
+	err := handleLinksListResponse(cmd, resp, "table", 1, true, false, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -440,3 +544,278 @@ func TestHandleLinksListResponse_AllFlag(t *testing.T) {
 		t.Error("expected output NOT to contain pagination message when --all is used")
 	}
 }
+
+func TestHandleResponse_InfersListTable(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(`[{"id": "bnty_1", "name": "Spring", "nested": {"a": 1}}]`)),
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(outfmt.WithFormat(context.Background(), "table"))
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	if err := handleResponse(cmd, resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "NAME") || !strings.Contains(out, "Spring") || strings.Contains(out, "{") {
+		t.Errorf("expected an inferred table, got:\n%s", out)
+	}
+}
+
+func TestHandleLinksListResponse_Quiet(t *testing.T) {
+	jsonBody := `[{"id": "link_1", "domain": "dub.sh", "key": "a"}, {"id": "link_2", "domain": "dub.sh", "key": "b"}]`
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(jsonBody)),
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	if err := handleLinksListResponse(cmd, resp, "json", 25, false, false, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "link_1\nlink_2\n" {
+		t.Errorf("expected only IDs, got %q", buf.String())
+	}
+}
+
+func newLinkInfoServer(t *testing.T, missing string) (*api.Client, *int32) {
+	t.Helper()
+	var calls int32
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		key := r.URL.Query().Get("key")
+		if key == missing {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"Link not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"link_` + key + `"}`))
+	})
+
+	client := api.NewClient("dub_test")
+	client.SetBaseURL(server.URL)
+	return client, &calls
+}
+
+func TestResolveBulkLinkRefs(t *testing.T) {
+	client, calls := newLinkInfoServer(t, "")
+
+	body := map[string]interface{}{
+		"linkIds": []interface{}{"link_existing", "link_a"},
+		"links": []interface{}{
+			map[string]interface{}{"domain": "dub.sh", "key": "a"},
+			map[string]interface{}{"domain": "dub.sh", "key": "b"},
+			map[string]interface{}{"domain": "dub.sh", "key": "b"},
+		},
+		"data": map[string]interface{}{"url": "https://example.com"},
+	}
+
+	if err := resolveBulkLinkRefs(context.Background(), client, body, batch.DefaultConcurrency); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := body["links"]; ok {
+		t.Error("expected 'links' to be removed from body")
+	}
+	ids, _ := body["linkIds"].([]interface{})
+	want := []string{"link_existing", "link_a", "link_b"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected linkIds %v, got %v", want, ids)
+	}
+	for i, id := range want {
+		if ids[i] != id {
+			t.Errorf("linkIds[%d] = %v, want %s", i, ids[i], id)
+		}
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("expected 2 lookups for distinct pairs, got %d", got)
+	}
+}
+
+func TestResolveBulkLinkRefs_MissingKey(t *testing.T) {
+	body := map[string]interface{}{
+		"links": []interface{}{map[string]interface{}{"domain": "dub.sh"}},
+	}
+
+	err := resolveBulkLinkRefs(context.Background(), api.NewClient("dub_test"), body, batch.DefaultConcurrency)
+	if err == nil || !strings.Contains(err.Error(), "links[0]") {
+		t.Errorf("expected links[0] validation error, got %v", err)
+	}
+}
+
+func TestResolveLinks_AggregatesFailures(t *testing.T) {
+	client, _ := newLinkInfoServer(t, "gone")
+
+	refs := []linkRef{{"dub.sh", "a"}, {"dub.sh", "gone"}, {"dub.sh", "c"}}
+	_, err := resolveLinks(context.Background(), client, refs, 2)
+	if err == nil {
+		t.Fatal("expected error for unresolvable link")
+	}
+	if !strings.Contains(err.Error(), "failed to resolve 1 of 3") || !strings.Contains(err.Error(), "dub.sh/gone") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHandleLinksListResponse_ShowUser(t *testing.T) {
+	jsonBody := `[
+		{"id": "1", "domain": "dub.sh", "key": "a", "url": "https://a.com", "userId": "user_1", "user": {"id": "user_1", "name": "Ada"}},
+		{"id": "2", "domain": "dub.sh", "key": "b", "url": "https://b.com", "userId": "user_2"}
+	]`
+
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(jsonBody)),
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	if err := handleLinksListResponse(cmd, resp, "table", 25, false, true, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"CREATED BY", "Ada", "user_2"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+// runLinksUpdate runs links update against a test server and returns the
+// decoded PATCH body.
+func runLinksUpdate(t *testing.T, args ...string) (map[string]interface{}, error) {
+	t.Helper()
+	var body map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/links/link_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"id":"link_1"}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"id":"link_1"}`))
+	})
+
+	cmd := newLinksUpdateCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"--id", "link_1"}, args...))
+	err := cmd.Execute()
+	return body, err
+}
+
+func TestLinksUpdateCmd_SendsOnlyChangedFields(t *testing.T) {
+	body, err := runLinksUpdate(t,
+		"--utm-source", "newsletter",
+		"--archived",
+		"--geo", "US=https://example.com/us",
+		"--tag-id", "tag_1,tag_2",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"utm_source": "newsletter",
+		"archived":   true,
+		"geo":        map[string]interface{}{"US": "https://example.com/us"},
+		"tagIds":     []interface{}{"tag_1", "tag_2"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("expected body %v, got %v", want, body)
+	}
+}
+
+func TestLinksUpdateCmd_ArchivedFalse(t *testing.T) {
+	body, err := runLinksUpdate(t, "--archived=false")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := body["archived"]; !ok || v != false {
+		t.Errorf("expected archived=false to be sent, got %v", body)
+	}
+}
+
+func TestLinksUpdateCmd_RequiresField(t *testing.T) {
+	if _, err := runLinksUpdate(t); err == nil || !strings.Contains(err.Error(), "at least one field") {
+		t.Errorf("expected missing field error, got %v", err)
+	}
+}
+
+func TestLinksUpdateCmd_TagFlagsExclusive(t *testing.T) {
+	if _, err := runLinksUpdate(t, "--tag-id", "tag_1", "--tag-name", "Marketing"); err == nil {
+		t.Error("expected error when combining --tag-id and --tag-name")
+	}
+}
+
+func TestLinksUpdateCmd_RemovalFlags(t *testing.T) {
+	body, err := runLinksUpdate(t, "--remove-password", "--remove-expiration", "--clear-tags")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"password":   nil,
+		"expiresAt":  nil,
+		"expiredUrl": nil,
+		"tagIds":     []interface{}{},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("expected body %v, got %v", want, body)
+	}
+}
+
+func TestLinksUpdateCmd_RemovalConflicts(t *testing.T) {
+	if _, err := runLinksUpdate(t, "--remove-password", "--password", "secret"); err == nil {
+		t.Error("expected error when combining --remove-password and --password")
+	}
+	if _, err := runLinksUpdate(t, "--clear-tags", "--tag-name", "Marketing"); err == nil {
+		t.Error("expected error when combining --clear-tags and --tag-name")
+	}
+}
+
+func TestLinksCreateCmd_Open(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/links":
+			_, _ = w.Write([]byte(`{"id":"link_1","domain":"dub.sh","key":"launch","workspaceId":"ws_1"}`))
+		case "/workspaces/ws_1":
+			_, _ = w.Write([]byte(`{"id":"ws_1","slug":"acme"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	var opened string
+	origOpen := openBrowser
+	openBrowser = func(url string) error {
+		opened = url
+		return nil
+	}
+	defer func() { openBrowser = origOpen }()
+
+	cmd := newLinksCreateCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--url", "https://example.com", "--open"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "https://app.dub.co/acme/links/dub.sh/launch"; opened != want {
+		t.Errorf("opened %q, want %q", opened, want)
+	}
+}
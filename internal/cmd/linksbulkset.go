@@ -0,0 +1,338 @@
+// internal/cmd/linksbulkset.go
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// urlPrefixReplace is the --set transformation that rewrites the start of
+// each link's destination URL.
+const urlPrefixReplace = "url-prefix-replace"
+
+// linkSet is one --set of links bulk update: a field set to the same value
+// on every link, or a transformation of each link's own value.
+type linkSet struct {
+	field string
+	value interface{}
+	// from and to are the prefixes of url-prefix-replace
+	from, to string
+	// re and repl rewrite the field, as in links rewrite
+	re   *regexp.Regexp
+	repl string
+}
+
+// parseLinkSet parses a --set value: field=value for any link field, typed
+// as the API expects, or url-prefix-replace=OLD:NEW.
+func parseLinkSet(s string) (linkSet, error) {
+	field, value, ok := strings.Cut(s, "=")
+	if !ok || field == "" {
+		return linkSet{}, NewUsageErrorf("--set %q: expected field=value", s)
+	}
+	if field == urlPrefixReplace {
+		from, to, ok := cutPrefixPair(value)
+		if !ok || from == "" {
+			return linkSet{}, NewUsageErrorf("--set %q: expected %s=OLD:NEW, e.g. old.example.com:new.example.com", s, urlPrefixReplace)
+		}
+		return linkSet{field: "url", from: from, to: to}, nil
+	}
+
+	kind, known := linkCreateSchema.fields[field]
+	if !known {
+		return linkSet{}, NewUsageErrorf("--set %q: unknown link field %q", s, field)
+	}
+	if field == "key" || field == "domain" {
+		return linkSet{}, NewUsageErrorf("--set %q: %s must be unique per link and can't be set in bulk", s, field)
+	}
+	set := linkSet{field: field, value: value}
+	var err error
+	switch kind {
+	case "boolean":
+		set.value, err = strconv.ParseBool(value)
+	case "number":
+		set.value, err = strconv.ParseFloat(value, 64)
+	case "array", "object":
+		err = json.Unmarshal([]byte(value), &set.value)
+	}
+	if err != nil {
+		return linkSet{}, NewUsageErrorf("--set %q: %s must be a %s", s, field, kind)
+	}
+	return set, nil
+}
+
+// cutPrefixPair splits OLD:NEW at the first colon that doesn't start "://",
+// so that either side may be a full URL.
+func cutPrefixPair(s string) (from, to string, ok bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == ':' && !strings.HasPrefix(s[i:], "://") {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// apply returns the value link should have for the set's field.
+func (s linkSet) apply(link map[string]interface{}) interface{} {
+	if s.re != nil {
+		current, ok := link[s.field].(string)
+		if !ok {
+			return link[s.field]
+		}
+		return s.re.ReplaceAllString(current, s.repl)
+	}
+	if s.from == "" {
+		return s.value
+	}
+	current := outfmt.SafeString(link[s.field])
+	if strings.HasPrefix(current, s.from) {
+		return s.to + strings.TrimPrefix(current, s.from)
+	}
+	// A prefix without a scheme matches after the scheme, up to a host
+	// boundary, so old.example.com leaves old.example.com.evil.net alone
+	if scheme, rest, ok := strings.Cut(current, "://"); ok && !strings.Contains(s.from, "://") && strings.HasPrefix(rest, s.from) {
+		if tail := strings.TrimPrefix(rest, s.from); atURLBoundary(s.from, tail) {
+			return scheme + "://" + s.to + tail
+		}
+	}
+	return current
+}
+
+// atURLBoundary reports whether a URL prefix ends where a host, port, or
+// path segment does: tail, what follows the prefix, is empty or starts a
+// path, query, fragment, or port, or the prefix itself ends in one of those
+// separators.
+func atURLBoundary(prefix, tail string) bool {
+	return tail == "" || strings.ContainsAny(tail[:1], "/?#:") || strings.ContainsAny(prefix[len(prefix)-1:], "/?#:")
+}
+
+// linkSetUpdate is the update planned for one link.
+type linkSetUpdate struct {
+	ID        string            `json:"id"`
+	ShortLink string            `json:"shortLink"`
+	Changes   []linkFieldChange `json:"changes"`
+
+	data   map[string]interface{}
+	before map[string]interface{}
+}
+
+// planLinkSets returns the updates sets make to links, leaving out links
+// they don't change. Only changed fields are sent.
+func planLinkSets(links []map[string]interface{}, sets []linkSet) []linkSetUpdate {
+	var updates []linkSetUpdate
+	for _, link := range links {
+		data := map[string]interface{}{}
+		var fields []string
+		for _, set := range sets {
+			if _, ok := data[set.field]; !ok {
+				fields = append(fields, set.field)
+			}
+			data[set.field] = set.apply(mergeLink(link, data))
+		}
+
+		update := linkSetUpdate{
+			ID:        outfmt.SafeString(link["id"]),
+			ShortLink: linkLabel(link),
+			data:      data,
+			before:    link,
+		}
+		for _, field := range fields {
+			if jsonEqual(link[field], data[field]) {
+				delete(data, field)
+				continue
+			}
+			update.Changes = append(update.Changes, linkFieldChange{Field: field, Before: link[field], After: data[field]})
+		}
+		if len(update.Changes) > 0 {
+			updates = append(updates, update)
+		}
+	}
+	return updates
+}
+
+// mergeLink returns link with the fields of data applied, so that later
+// --set values see earlier ones.
+func mergeLink(link, data map[string]interface{}) map[string]interface{} {
+	if len(data) == 0 {
+		return link
+	}
+	merged := make(map[string]interface{}, len(link)+len(data))
+	for k, v := range link {
+		merged[k] = v
+	}
+	for k, v := range data {
+		merged[k] = v
+	}
+	return merged
+}
+
+// jsonEqual reports whether a and b encode to the same JSON.
+func jsonEqual(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// applyLinkSets sends the updates as bulk updates: links getting the same
+// data share requests of up to maxBulkLinks links, up to concurrency at a
+// time. It returns the updates that were applied.
+func applyLinkSets(ctx context.Context, client *api.Client, updates []linkSetUpdate, concurrency int) ([]linkSetUpdate, error) {
+	groups := map[string][]int{}
+	var keys []string
+	for i, update := range updates {
+		data, err := json.Marshal(update.data)
+		if err != nil {
+			return nil, err
+		}
+		key := string(data)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+	sort.Strings(keys)
+
+	var chunks [][]int
+	for _, key := range keys {
+		members := groups[key]
+		for start := 0; start < len(members); start += maxBulkLinks {
+			chunks = append(chunks, members[start:min(start+maxBulkLinks, len(members))])
+		}
+	}
+
+	errs := batch.Run(ctx, len(chunks), concurrency, func(ctx context.Context, i int) error {
+		ids := make([]string, len(chunks[i]))
+		for j, u := range chunks[i] {
+			ids[j] = updates[u].ID
+		}
+		body := map[string]interface{}{"linkIds": ids, "data": updates[chunks[i][0]].data}
+		if err := discardResponse(client.Patch(ctx, "/links/bulk", body)); err != nil {
+			label := updates[chunks[i][0]].ShortLink
+			if len(ids) > 1 {
+				label = fmt.Sprintf("%s and %d more", label, len(ids)-1)
+			}
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		return nil
+	})
+
+	var applied []linkSetUpdate
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		for _, u := range chunks[i] {
+			applied = append(applied, updates[u])
+		}
+	}
+	if len(failed) > 0 {
+		return applied, fmt.Errorf("failed to update %d of %d batch(es) of links:\n%w", len(failed), len(chunks), errors.Join(failed...))
+	}
+	return applied, nil
+}
+
+// linkSetWriter writes the updates made, or that would be made on a dry run.
+type linkSetWriter func(cmd *cobra.Command, updates []linkSetUpdate, unchanged int, dryRun bool) error
+
+// runLinksBulkSet lists the links matching filter, previews the changes sets
+// make to them with write, and applies those unless dryRun is set. Updated
+// links are recorded in their link history.
+func runLinksBulkSet(cmd *cobra.Command, client *api.Client, filter url.Values, sets []linkSet, concurrency int, dryRun bool, write linkSetWriter) error {
+	links, err := fetchAllLinks[map[string]interface{}](cmd.Context(), client, filter)
+	if err != nil {
+		return err
+	}
+	links, skipped := withoutProtected(cmd.Context(), links, isProtected)
+	if skipped > 0 {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Skipping %d protected link(s); pass --force to update them too.\n", skipped)
+	}
+
+	updates := planLinkSets(links, sets)
+	unchanged := len(links) - len(updates)
+	var applyErr error
+	if !dryRun && len(updates) > 0 {
+		var applied []linkSetUpdate
+		applied, applyErr = applyLinkSets(cmd.Context(), client, updates, concurrency)
+		changes := make(map[string]config.LinkChange, len(applied))
+		for _, u := range applied {
+			changes[u.ID] = newLinkChange(cmd, u.before, mergeLink(u.before, u.data))
+		}
+		recordLinkChanges(cmd, changes)
+		updates = applied
+	}
+
+	if err := write(cmd, updates, unchanged, dryRun); err != nil {
+		return err
+	}
+	return applyErr
+}
+
+func writeLinksBulkSet(cmd *cobra.Command, updates []linkSetUpdate, unchanged int, dryRun bool) error {
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		if updates == nil {
+			updates = []linkSetUpdate{}
+		}
+		return outfmt.FormatJSON(w, map[string]interface{}{
+			"updated":   updates,
+			"unchanged": unchanged,
+			"dryRun":    dryRun,
+		}, outfmt.GetQuery(cmd.Context()))
+	}
+
+	if len(updates) == 0 {
+		_, _ = fmt.Fprintf(w, "No links to update (%d matching link(s) already up to date).\n", unchanged)
+		return nil
+	}
+
+	columns := []outfmt.Column{
+		{Name: "Link"},
+		{Name: "Field"},
+		{Name: "Before", Width: 50},
+		{Name: "After", Width: 50},
+	}
+	var rows [][]string
+	for _, u := range updates {
+		for _, c := range u.Changes {
+			rows = append(rows, []string{u.ShortLink, c.Field, dashIfEmpty(formatSetValue(c.Before)), dashIfEmpty(formatSetValue(c.After))})
+		}
+	}
+	if err := outfmt.FormatTable(w, columns, rows); err != nil {
+		return err
+	}
+
+	verb := "Updated"
+	if dryRun {
+		verb = "Would update"
+	}
+	_, _ = fmt.Fprintf(w, "\n%s %d link(s); %d matching link(s) unchanged.\n", verb, len(updates), unchanged)
+	return nil
+}
+
+// formatSetValue formats a field value for the preview table.
+func formatSetValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,123 @@
+// internal/cmd/linksbulkset_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+func TestParseLinkSet(t *testing.T) {
+	set, err := parseLinkSet("url-prefix-replace=https://old.example.com:https://new.example.com/v2")
+	if err != nil || set.from != "https://old.example.com" || set.to != "https://new.example.com/v2" {
+		t.Errorf("unexpected prefix replace %+v, %v", set, err)
+	}
+	if set, err := parseLinkSet("trackConversion=true"); err != nil || set.value != true {
+		t.Errorf("expected a boolean, got %+v, %v", set, err)
+	}
+	if set, err := parseLinkSet("comments=a=b"); err != nil || set.value != "a=b" {
+		t.Errorf("expected the value after the first =, got %+v, %v", set, err)
+	}
+	for _, bad := range []string{"url", "nope=1", "key=x", "archived=maybe", "url-prefix-replace=old.example.com"} {
+		if _, err := parseLinkSet(bad); err == nil || !IsUsageError(err) {
+			t.Errorf("parseLinkSet(%q): expected a usage error, got %v", bad, err)
+		}
+	}
+}
+
+func TestLinkSetApply(t *testing.T) {
+	set, _ := parseLinkSet("url-prefix-replace=old.example.com:new.example.com")
+	tests := map[string]string{
+		"https://old.example.com/a?b=c":    "https://new.example.com/a?b=c",
+		"http://old.example.com":           "http://new.example.com",
+		"https://www.old.example.com/a":    "https://www.old.example.com/a",
+		"https://example.com/old.example":  "https://example.com/old.example",
+		"https://old.example.com.evil.net": "https://old.example.com.evil.net",
+		"https://old.example.com:8080/a":   "https://new.example.com:8080/a",
+		"https://old.example.com?a=b":      "https://new.example.com?a=b",
+	}
+	for in, want := range tests {
+		if got := set.apply(map[string]interface{}{"url": in}); got != want {
+			t.Errorf("apply(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLinksBulkUpdate_Set(t *testing.T) {
+	var patches []map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/links":
+			if r.URL.Query().Get("search") != "old.example.com" {
+				t.Errorf("expected a search, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[
+				{"id": "l1", "domain": "dub.sh", "key": "a", "url": "https://old.example.com/a", "utm_source": null},
+				{"id": "l2", "domain": "dub.sh", "key": "b", "url": "https://old.example.com/b", "utm_source": "mail"},
+				{"id": "l3", "domain": "dub.sh", "key": "c", "url": "https://other.example.com/old.example.com", "utm_source": "mail"},
+				{"id": "l4", "domain": "dub.sh", "key": "d", "url": "https://old.example.com/d", "tags": [{"name": "protected"}]}
+			]`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/links/bulk":
+			body, _ := io.ReadAll(r.Body)
+			var patch map[string]interface{}
+			_ = json.Unmarshal(body, &patch)
+			patches = append(patches, patch)
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	args := []string{"links", "bulk", "update", "--api-url", server.URL, "--search", "old.example.com",
+		"--set", "url-prefix-replace=old.example.com:new.example.com", "--set", "utm_source=mail"}
+
+	if err := execute(context.Background(), args, nil, &bytes.Buffer{}, &bytes.Buffer{}); err == nil || !IsUsageError(err) {
+		t.Fatalf("expected --yes to be required, got %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := execute(context.Background(), append(args, "--dry-run"), nil, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "https://new.example.com/a") || !strings.Contains(out, "Would update 2 link(s); 1 matching link(s) unchanged.") || len(patches) != 0 {
+		t.Errorf("unexpected dry run:\n%s", out)
+	}
+	if !strings.Contains(stderr.String(), "Skipping 1 protected link(s)") {
+		t.Errorf("expected the protected link to be skipped, got %q", stderr.String())
+	}
+
+	if err := execute(context.Background(), append(args, "--yes"), nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("expected one bulk update per distinct change, got %v", patches)
+	}
+	for _, patch := range patches {
+		ids := patch["linkIds"].([]interface{})
+		data := patch["data"].(map[string]interface{})
+		switch ids[0] {
+		case "l1":
+			if data["url"] != "https://new.example.com/a" || data["utm_source"] != "mail" {
+				t.Errorf("unexpected update for l1: %v", data)
+			}
+		case "l2":
+			if _, ok := data["utm_source"]; ok || data["url"] != "https://new.example.com/b" {
+				t.Errorf("expected only the url of l2 to change, got %v", data)
+			}
+		default:
+			t.Errorf("unexpected update %v", patch)
+		}
+	}
+	for id, want := range map[string]string{"l1": "https://new.example.com/a", "l2": "https://new.example.com/b"} {
+		if history, err := config.LoadLinkHistory(id); err != nil || len(history) != 1 || history[0].After["url"] != want {
+			t.Errorf("expected %s's update in its history, got %v, %v", id, history, err)
+		}
+	}
+}
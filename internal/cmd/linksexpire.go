@@ -0,0 +1,362 @@
+// internal/cmd/linksexpire.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// expirePolicy is the policy file read by dub links expire.
+type expirePolicy struct {
+	Rules []expireRule `yaml:"rules"`
+}
+
+// expireRule sets the lifecycle of the links it matches.
+type expireRule struct {
+	Name  string `yaml:"name"`
+	Match struct {
+		Tags   []string `yaml:"tags"`
+		Domain string   `yaml:"domain"`
+		Folder string   `yaml:"folder"`
+	} `yaml:"match"`
+	ExpireAfter    string `yaml:"expireAfter"`
+	ArchiveAfter   string `yaml:"archiveAfter"`
+	ArchiveExpired bool   `yaml:"archiveExpired"`
+
+	expireAfter  time.Duration
+	archiveAfter time.Duration
+	folderID     string
+}
+
+// expireChange is a change the policy calls for on one link.
+type expireChange struct {
+	ID        string     `json:"id"`
+	ShortLink string     `json:"shortLink"`
+	Rule      string     `json:"rule"`
+	Action    string     `json:"action"` // "expire" or "archive"
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Reason    string     `json:"reason"`
+	Error     string     `json:"error,omitempty"`
+}
+
+func newLinksExpireCmd() *cobra.Command {
+	var (
+		policyPath  string
+		dryRun      bool
+		output      string
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "expire",
+		Short: "Set expirations and archive links according to a policy file",
+		Long: `Apply a lifecycle policy to the workspace's links: give matching links an
+expiration date, and archive them once they're old enough or expired.
+
+The policy is a YAML file of rules. Each link is handled by the first rule
+that matches it; a rule without match settings matches every link.
+
+  rules:
+    - name: temporary links
+      match:
+        tags: [temp]           # any of these tags
+        domain: go.acme.com    # optional
+        folder: Campaigns      # optional, by name
+      expireAfter: 30d         # links without an expiration get createdAt + 30d
+      archiveAfter: 90d        # archive links created more than 90 days ago
+    - name: everything else
+      archiveExpired: true     # archive links whose expiration has passed
+
+Ages are given in days (30d), weeks (4w), or hours (12h). Archived links are
+recorded in the undo history.
+
+Examples:
+  dub links expire --policy expire.yaml --dry-run
+  dub links expire --policy expire.yaml -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+			policy, err := loadExpirePolicy(policyPath)
+			if err != nil {
+				return NewUsageError(err)
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := resolveExpireFolders(cmd.Context(), client, policy.Rules); err != nil {
+				return err
+			}
+
+			links, err := fetchAllLinks[map[string]interface{}](cmd.Context(), client, url.Values{})
+			if err != nil {
+				return err
+			}
+			links, _ = withoutProtected(cmd.Context(), links, isProtected)
+			changes := planExpirations(links, policy.Rules, time.Now())
+
+			if !dryRun && len(changes) > 0 {
+				err = applyExpirations(cmd, client, changes, concurrency)
+			}
+			if werr := writeExpireChanges(cmd, changes, output, dryRun); werr != nil {
+				return werr
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&policyPath, "policy", "", "Policy file (YAML, required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without changing anything")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	_ = cmd.MarkFlagRequired("policy")
+
+	return cmd
+}
+
+// loadExpirePolicy reads and validates a policy file.
+func loadExpirePolicy(path string) (*expirePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var policy expirePolicy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	if len(policy.Rules) == 0 {
+		return nil, fmt.Errorf("policy %s has no rules", path)
+	}
+
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if rule.Name == "" {
+			rule.Name = "rule " + strconv.Itoa(i+1)
+		}
+		if rule.ExpireAfter != "" {
+			if rule.expireAfter, err = parseAge(rule.ExpireAfter); err != nil {
+				return nil, fmt.Errorf("%s: expireAfter: %w", rule.Name, err)
+			}
+		}
+		if rule.ArchiveAfter != "" {
+			if rule.archiveAfter, err = parseAge(rule.ArchiveAfter); err != nil {
+				return nil, fmt.Errorf("%s: archiveAfter: %w", rule.Name, err)
+			}
+		}
+		if rule.expireAfter == 0 && rule.archiveAfter == 0 && !rule.ArchiveExpired {
+			return nil, fmt.Errorf("%s: set expireAfter, archiveAfter, or archiveExpired", rule.Name)
+		}
+	}
+	return &policy, nil
+}
+
+var ageRe = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// parseAge parses an age such as 30d, 4w, or any Go duration like 12h.
+func parseAge(s string) (time.Duration, error) {
+	if m := ageRe.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		days := n
+		if m[2] == "w" {
+			days = n * 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("expected an age such as 30d, 4w, or 12h, got %q", s)
+	}
+	return d, nil
+}
+
+// resolveExpireFolders looks up the IDs of folders named by rules.
+func resolveExpireFolders(ctx context.Context, client *api.Client, rules []expireRule) error {
+	for i := range rules {
+		if rules[i].Match.Folder == "" {
+			continue
+		}
+		ids, err := resolveNamesToIDs(ctx, client, cache.KindFolder, []string{rules[i].Match.Folder})
+		if err != nil {
+			return fmt.Errorf("%s: %w", rules[i].Name, err)
+		}
+		rules[i].folderID = ids[0]
+	}
+	return nil
+}
+
+// matches reports whether link is covered by the rule.
+func (r *expireRule) matches(link map[string]interface{}) bool {
+	if r.Match.Domain != "" && !strings.EqualFold(outfmt.SafeString(link["domain"]), r.Match.Domain) {
+		return false
+	}
+	if r.folderID != "" && outfmt.SafeString(link["folderId"]) != r.folderID {
+		return false
+	}
+	if len(r.Match.Tags) == 0 {
+		return true
+	}
+	tags, _ := link["tags"].([]interface{})
+	for _, tag := range tags {
+		t, _ := tag.(map[string]interface{})
+		for _, name := range r.Match.Tags {
+			if strings.EqualFold(outfmt.SafeString(t["name"]), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// planExpirations returns the change, if any, the first matching rule calls
+// for on each unarchived link. Archiving takes precedence over setting an
+// expiration.
+func planExpirations(links []map[string]interface{}, rules []expireRule, now time.Time) []expireChange {
+	var changes []expireChange
+	for _, link := range links {
+		if archived, _ := link["archived"].(bool); archived {
+			continue
+		}
+		var rule *expireRule
+		for i := range rules {
+			if rules[i].matches(link) {
+				rule = &rules[i]
+				break
+			}
+		}
+		if rule == nil {
+			continue
+		}
+
+		created, err := time.Parse(time.RFC3339, outfmt.SafeString(link["createdAt"]))
+		if err != nil {
+			continue
+		}
+		var expires *time.Time
+		if t, err := time.Parse(time.RFC3339, outfmt.SafeString(link["expiresAt"])); err == nil {
+			expires = &t
+		}
+
+		change := expireChange{ID: outfmt.SafeString(link["id"]), ShortLink: linkLabel(link), Rule: rule.Name}
+		switch {
+		case rule.archiveAfter > 0 && !created.Add(rule.archiveAfter).After(now):
+			change.Action = "archive"
+			change.Reason = "created " + created.Format("Jan 2, 2006")
+		case rule.ArchiveExpired && expires != nil && !expires.After(now):
+			change.Action = "archive"
+			change.Reason = "expired " + expires.Format("Jan 2, 2006")
+		case rule.expireAfter > 0 && expires == nil:
+			at := created.Add(rule.expireAfter).UTC()
+			change.Action = "expire"
+			change.ExpiresAt = &at
+			change.Reason = "expires " + at.Format("Jan 2, 2006")
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// applyExpirations makes the planned changes, up to concurrency at a time,
+// recording each failure on its change. Archived links are recorded in the
+// undo history.
+func applyExpirations(cmd *cobra.Command, client *api.Client, changes []expireChange, concurrency int) error {
+	errs := batch.Run(cmd.Context(), len(changes), concurrency, func(ctx context.Context, i int) error {
+		c := changes[i]
+		body := map[string]interface{}{"archived": true}
+		if c.Action == "expire" {
+			body = map[string]interface{}{"expiresAt": c.ExpiresAt.Format(time.RFC3339)}
+		}
+		if err := discardResponse(client.Patch(ctx, "/links/"+url.PathEscape(c.ID), body)); err != nil {
+			return fmt.Errorf("%s: %w", c.ShortLink, err)
+		}
+		return nil
+	})
+
+	var failed []error
+	var archived []config.HistoryEntry
+	for i, err := range errs {
+		if err != nil {
+			changes[i].Error = err.Error()
+			failed = append(failed, err)
+			continue
+		}
+		if changes[i].Action == "archive" {
+			archived = append(archived, newHistoryEntry("archive", "link", changes[i].ID, changes[i].ShortLink, nil))
+		}
+	}
+	recordHistory(cmd, archived...)
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update %d of %d link(s):\n%w", len(failed), len(changes), errors.Join(failed...))
+	}
+	return nil
+}
+
+// writeExpireChanges reports the planned or applied changes.
+func writeExpireChanges(cmd *cobra.Command, changes []expireChange, output string, dryRun bool) error {
+	if output == "json" {
+		if changes == nil {
+			changes = []expireChange{}
+		}
+		return outfmt.FormatJSON(cmd.OutOrStdout(), changes, outfmt.GetQuery(cmd.Context()))
+	}
+
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No links need changes.")
+		return nil
+	}
+
+	columns := []outfmt.Column{
+		{Name: "Short Link", Width: 40, Align: outfmt.AlignLeft},
+		{Name: "Action", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Reason", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Rule", Width: 30, Align: outfmt.AlignLeft},
+	}
+	rows := make([][]string, len(changes))
+	counts := map[string]int{}
+	for i, c := range changes {
+		action := c.Action
+		if c.Error != "" {
+			action += " (failed)"
+		} else {
+			counts[c.Action]++
+		}
+		rows[i] = []string{c.ShortLink, action, c.Reason, c.Rule}
+	}
+	if err := outfmt.FormatTable(cmd.OutOrStdout(), columns, rows); err != nil {
+		return err
+	}
+
+	if dryRun {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nWould set the expiration of %d link(s) and archive %d link(s).\n", counts["expire"], counts["archive"])
+		return nil
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nSet the expiration of %d link(s) and archived %d link(s).\n", counts["expire"], counts["archive"])
+	return nil
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "expire.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadExpirePolicy(t *testing.T) {
+	policy, err := loadExpirePolicy(writePolicy(t, `
+rules:
+  - match:
+      tags: [temp]
+    expireAfter: 30d
+    archiveAfter: 2w
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rule := policy.Rules[0]
+	if rule.Name != "rule 1" || rule.expireAfter != 30*24*time.Hour || rule.archiveAfter != 14*24*time.Hour {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+
+	for _, tt := range []struct {
+		policy, want string
+	}{
+		{"rules: []", "no rules"},
+		{"rules:\n  - name: x\n", "set expireAfter"},
+		{"rules:\n  - expireAfter: soon\n", "expected an age"},
+		{"rules:\n  - expireAfter: 1d\n    archiveAfterr: 2d\n", "not found"},
+	} {
+		if _, err := loadExpirePolicy(writePolicy(t, tt.policy)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("policy %q: expected error containing %q, got %v", tt.policy, tt.want, err)
+		}
+	}
+}
+
+func TestPlanExpirations(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var links []map[string]interface{}
+	if err := json.Unmarshal([]byte(`[
+		{"id": "new", "domain": "dub.sh", "key": "new", "createdAt": "2026-02-20T00:00:00Z", "tags": [{"name": "Temp"}]},
+		{"id": "old", "domain": "dub.sh", "key": "old", "createdAt": "2025-10-01T00:00:00Z", "tags": [{"name": "temp"}]},
+		{"id": "set", "domain": "dub.sh", "key": "set", "createdAt": "2026-02-20T00:00:00Z", "expiresAt": "2026-12-01T00:00:00Z", "tags": [{"name": "temp"}]},
+		{"id": "expired", "domain": "dub.sh", "key": "gone", "createdAt": "2026-01-01T00:00:00Z", "expiresAt": "2026-02-01T00:00:00Z"},
+		{"id": "archived", "domain": "dub.sh", "key": "x", "createdAt": "2020-01-01T00:00:00Z", "archived": true, "tags": [{"name": "temp"}]},
+		{"id": "other", "domain": "go.acme.com", "key": "y", "createdAt": "2026-01-01T00:00:00Z", "expiresAt": "2026-02-01T00:00:00Z"}
+	]`), &links); err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []expireRule{
+		{Name: "temp", expireAfter: 30 * 24 * time.Hour, archiveAfter: 90 * 24 * time.Hour},
+		{Name: "dub.sh", ArchiveExpired: true},
+	}
+	rules[0].Match.Tags = []string{"temp"}
+	rules[1].Match.Domain = "dub.sh"
+
+	changes := planExpirations(links, rules, now)
+	got := map[string]string{}
+	for _, c := range changes {
+		got[c.ID] = c.Rule + ":" + c.Action
+	}
+	want := map[string]string{"new": "temp:expire", "old": "temp:archive", "expired": "dub.sh:archive"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for id, w := range want {
+		if got[id] != w {
+			t.Errorf("%s: expected %s, got %s", id, w, got[id])
+		}
+	}
+	if changes[0].ExpiresAt == nil || !changes[0].ExpiresAt.Equal(time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected expiration 30 days after creation, got %v", changes[0].ExpiresAt)
+	}
+}
+
+func TestLinksExpireCmd(t *testing.T) {
+	var (
+		patches []string
+	)
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			patches = append(patches, r.URL.Path+" "+string(body))
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"id": "a", "domain": "dub.sh", "key": "a", "createdAt": "2026-01-01T00:00:00Z", "tags": [{"name": "temp"}]},
+			{"id": "b", "domain": "dub.sh", "key": "b", "createdAt": "2020-01-01T00:00:00Z", "tags": [{"name": "temp"}]},
+			{"id": "c", "domain": "dub.sh", "key": "c", "createdAt": "2020-01-01T00:00:00Z"}
+		]`))
+	})
+
+	policy := writePolicy(t, "rules:\n  - match: {tags: [temp]}\n    expireAfter: 30d\n    archiveAfter: 365d\n")
+	run := func(args ...string) string {
+		cmd := newLinksExpireCmd()
+		cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"--policy", policy}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return out.String()
+	}
+
+	out := run("--dry-run")
+	if len(patches) != 0 {
+		t.Fatalf("expected no changes on dry run, got %v", patches)
+	}
+	if !strings.Contains(out, "Would set the expiration of 1 link(s) and archive 1 link(s).") {
+		t.Errorf("expected dry-run summary, got:\n%s", out)
+	}
+
+	run()
+	sort.Strings(patches)
+	want := []string{
+		`/links/a {"expiresAt":"2026-01-31T00:00:00Z"}`,
+		`/links/b {"archived":true}`,
+	}
+	if strings.Join(patches, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected patches %v, got %v", want, patches)
+	}
+}
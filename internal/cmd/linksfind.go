@@ -0,0 +1,133 @@
+// internal/cmd/linksfind.go
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/mirror"
+)
+
+func newLinksFindCmd() *cobra.Command {
+	var (
+		domain string
+		source string
+		maxAge time.Duration
+		dbPath string
+		quiet  bool
+		output string
+		limit  int
+		all    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "find <text>",
+		Short: "Search links by key, URL, title, or comments",
+		Long: `Search links for every word of text in their key, destination URL, title,
+description, or comments, best matches first.
+
+When the local mirror (see dub mirror) was synced within --max-age, the
+search runs against it instantly and offline, matching words as prefixes and
+ignoring case and accents. Otherwise it falls back to the API's search,
+fetching every page of matches.
+--source forces one or the other.
+
+Examples:
+  dub links find "summer sale"
+  dub links find launch --domain go.acme.com --limit 10
+  dub links find promo --source mirror -q | dub links delete --ids-from-stdin --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if source != "auto" && source != "mirror" && source != "api" {
+				return NewUsageErrorf("--source must be auto, mirror, or api, got %q", source)
+			}
+
+			var links interface{}
+			if source != "api" {
+				found, err := findInMirror(cmd, dbPath, args[0], domain, source == "auto", maxAge)
+				if err != nil {
+					return err
+				}
+				if found != nil {
+					links = found
+				}
+			}
+
+			if links == nil {
+				client, err := getClient(cmd.Context())
+				if err != nil {
+					return err
+				}
+				params := url.Values{"search": {args[0]}}
+				if domain != "" {
+					params.Set("domain", domain)
+				}
+				found, err := fetchAllLinks[json.RawMessage](cmd.Context(), client, params)
+				if err != nil {
+					return err
+				}
+				links = append([]json.RawMessage{}, found...)
+			}
+
+			body, err := json.Marshal(links)
+			if err != nil {
+				return err
+			}
+			return writeLinksList(cmd, body, output, limit, all, false, quiet)
+		},
+	}
+
+	cmd.Flags().StringVar(&domain, "domain", "", "Only search links on this domain")
+	cmd.Flags().StringVar(&source, "source", "auto", "Where to search: auto, mirror, or api")
+	cmd.Flags().DurationVar(&maxAge, "max-age", 24*time.Hour, "With --source auto, use the mirror only if synced within this long")
+	cmd.Flags().StringVar(&dbPath, "db", "", "Path of the mirror database (default: per workspace)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print only link IDs, one per line")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of links to show")
+	cmd.Flags().BoolVar(&all, "all", false, "Show all matches (ignore limit)")
+
+	return cmd
+}
+
+// findInMirror searches the local mirror. With fallback set, it returns nil
+// links instead of an error when the mirror is missing or older than maxAge,
+// so the caller can search the API instead.
+func findInMirror(cmd *cobra.Command, dbPath, text, domain string, fallback bool, maxAge time.Duration) ([]map[string]interface{}, error) {
+	path, err := mirrorPath(cmd.Context(), dbPath)
+	if err != nil {
+		return nil, err
+	}
+	store, err := mirror.OpenExisting(path)
+	if errors.Is(err, mirror.ErrNotSynced) && fallback {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = store.Close() }()
+
+	syncedAt, ok, err := store.LinksSyncedAt()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if fallback {
+			return nil, nil
+		}
+		return nil, mirror.ErrNotSynced
+	}
+	if age := time.Since(syncedAt); age > maxAge {
+		if fallback {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Mirror was last synced %s ago; searching via the API. Run: dub mirror sync\n", age.Round(time.Minute))
+			return nil, nil
+		}
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Mirror was last synced %s ago; results may be out of date.\n", age.Round(time.Minute))
+	}
+
+	return store.SearchLinks(cmd.Context(), text, domain)
+}
@@ -0,0 +1,100 @@
+// internal/cmd/linksfind_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/mirror"
+)
+
+func TestLinksFindCmd(t *testing.T) {
+	var apiSearches []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte(`[{"id": "api_2", "domain": "dub.sh", "key": "page-two", "url": "https://example.com"}]`))
+			return
+		}
+		apiSearches = append(apiSearches, r.URL.Query().Get("search"))
+		links := make([]map[string]interface{}, linksPageSize)
+		for i := range links {
+			links[i] = map[string]interface{}{"id": fmt.Sprintf("api_1_%d", i), "domain": "dub.sh", "key": "from-api", "url": "https://example.com"}
+		}
+		_ = json.NewEncoder(w).Encode(links)
+	})
+
+	db := filepath.Join(t.TempDir(), "mirror.db")
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		cmd := newLinksFindCmd()
+		cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+		var stdout, stderr bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+		cmd.SetArgs(append(args, "--db", db))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return stdout.String(), stderr.String()
+	}
+
+	// Without a mirror, the API is searched, across every page
+	out, _ := run("summer sale")
+	if !strings.Contains(out, "dub.sh/from-api") || len(apiSearches) != 1 || apiSearches[0] != "summer sale" {
+		t.Fatalf("expected an API search, got %v:\n%s", apiSearches, out)
+	}
+	apiSearches = nil
+	out, _ = run("summer sale", "--all", "-q")
+	if ids := strings.Fields(out); len(ids) != linksPageSize+1 || ids[len(ids)-1] != "api_2" {
+		t.Errorf("expected every page of API matches, got %d id(s)", len(ids))
+	}
+
+	store, err := mirror.Open(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.SyncLinks([]map[string]interface{}{
+		{"id": "l1", "domain": "dub.sh", "key": "sommer", "url": "https://shop.example.com/summer-sale", "clicks": float64(3)},
+		{"id": "l2", "domain": "dub.sh", "key": "winter", "url": "https://shop.example.com/winter", "comments": "Summer Sale leftovers"},
+		{"id": "l3", "domain": "go.acme.com", "key": "summer", "url": "https://acme.example.com", "title": "Summer café"},
+		{"id": "l4", "domain": "dub.sh", "key": "sale", "url": "https://shop.example.com/clearance"},
+	}, true)
+	_ = store.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiSearches = nil
+	out, _ = run("summ sale", "-q")
+	if ids := strings.Fields(out); len(ids) != 2 || !strings.Contains(out, "l1") || !strings.Contains(out, "l2") {
+		t.Errorf("expected l1 and l2 from the mirror, got %q", out)
+	}
+	if len(apiSearches) != 0 {
+		t.Errorf("a fresh mirror should not hit the API, got %v", apiSearches)
+	}
+
+	out, _ = run("CAFE", "-q")
+	if strings.TrimSpace(out) != "l3" {
+		t.Errorf("expected an accent-insensitive match on l3, got %q", out)
+	}
+	out, _ = run("summer", "--domain", "go.acme.com", "-q")
+	if strings.TrimSpace(out) != "l3" {
+		t.Errorf("expected --domain to narrow results to l3, got %q", out)
+	}
+
+	// A stale mirror falls back to the API, unless --source mirror
+	_, stderr := run("summer", "--max-age", "0s")
+	if len(apiSearches) != 1 || !strings.Contains(stderr, "dub mirror sync") {
+		t.Errorf("expected a stale mirror to fall back to the API, got %v, %q", apiSearches, stderr)
+	}
+	out, stderr = run("summer", "--max-age", "0s", "--source", "mirror", "-q")
+	if len(apiSearches) != 1 || !strings.Contains(out, "l3") || !strings.Contains(stderr, "out of date") {
+		t.Errorf("expected --source mirror to search the stale mirror, got %q, %q", out, stderr)
+	}
+}
@@ -0,0 +1,218 @@
+// internal/cmd/linksorganize.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+func newLinksTagCmd() *cobra.Command {
+	var (
+		ids         []string
+		tagNames    []string
+		remove      bool
+		concurrency int
+		picker      linkPicker
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Add or remove tags on links",
+		Long: `Add tags to links, keeping the tags they already have, or remove them
+with --remove. Links are given by --id or chosen from a checklist with
+--interactive.
+
+Examples:
+  dub links tag --tag-name launch --id link_abc --id link_def
+  dub links tag --tag-name launch --interactive --search spring
+  dub links tag --tag-name old --remove --interactive --with-tag old`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(ids) == 0 && !picker.interactive {
+				return NewUsageErrorf("--id or --interactive is required")
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			tagIDs, err := resolveNamesToIDs(cmd.Context(), client, cache.KindTag, tagNames)
+			if err != nil {
+				return err
+			}
+
+			links, err := picker.linksByIDOrPick(cmd, client, ids, "tag")
+			if err != nil {
+				return err
+			}
+
+			verb := "Tagged"
+			if remove {
+				verb = "Untagged"
+			}
+			return updateLinks(cmd, client, links, concurrency, verb, func(link map[string]interface{}) map[string]interface{} {
+				return map[string]interface{}{"tagIds": mergeTagIDs(link, tagIDs, remove)}
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&ids, "id", nil, "Link ID (repeatable)")
+	cmd.Flags().StringSliceVar(&tagNames, "tag-name", nil, "Tag name to add (repeatable, required)")
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remove the tags instead of adding them")
+	addConcurrencyFlag(cmd, &concurrency)
+	picker.register(cmd)
+
+	_ = cmd.MarkFlagRequired("tag-name")
+
+	return cmd
+}
+
+func newLinksMoveCmd() *cobra.Command {
+	var (
+		ids         []string
+		folderName  string
+		concurrency int
+		picker      linkPicker
+	)
+
+	cmd := &cobra.Command{
+		Use:   "move",
+		Short: "Move links to a folder",
+		Long: `Move links to a folder. Links are given by --id or chosen from a checklist
+with --interactive.
+
+Examples:
+  dub links move --folder-name Campaigns --id link_abc
+  dub links move --folder-name Campaigns --interactive --domain go.acme.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(ids) == 0 && !picker.interactive {
+				return NewUsageErrorf("--id or --interactive is required")
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			folderIDs, err := resolveNamesToIDs(cmd.Context(), client, cache.KindFolder, []string{folderName})
+			if err != nil {
+				return err
+			}
+
+			links, err := picker.linksByIDOrPick(cmd, client, ids, "move")
+			if err != nil {
+				return err
+			}
+
+			return updateLinks(cmd, client, links, concurrency, "Moved", func(map[string]interface{}) map[string]interface{} {
+				return map[string]interface{}{"folderId": folderIDs[0]}
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&ids, "id", nil, "Link ID (repeatable)")
+	cmd.Flags().StringVar(&folderName, "folder-name", "", "Folder to move the links to (required)")
+	addConcurrencyFlag(cmd, &concurrency)
+	picker.register(cmd)
+
+	_ = cmd.MarkFlagRequired("folder-name")
+
+	return cmd
+}
+
+// linksByIDOrPick fetches the links with ids, or lets the user pick links
+// when --interactive is set.
+func (p *linkPicker) linksByIDOrPick(cmd *cobra.Command, client *api.Client, ids []string, verb string) ([]map[string]interface{}, error) {
+	if p.interactive {
+		return p.pick(cmd, client, verb)
+	}
+
+	links := make([]map[string]interface{}, len(ids))
+	errs := batch.Run(cmd.Context(), len(ids), batch.DefaultConcurrency, func(ctx context.Context, i int) error {
+		link, err := snapshotLink(ctx, client, ids[i])
+		if err != nil {
+			return fmt.Errorf("%s: %w", ids[i], err)
+		}
+		links[i] = link
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// mergeTagIDs returns a link's tag IDs with tagIDs added, or removed when
+// remove is set.
+func mergeTagIDs(link map[string]interface{}, tagIDs []string, remove bool) []string {
+	drop := map[string]bool{}
+	if remove {
+		for _, id := range tagIDs {
+			drop[id] = true
+		}
+	}
+
+	merged := []string{}
+	seen := map[string]bool{}
+	add := func(id string) {
+		if id != "" && !seen[id] && !drop[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	if tags, ok := link["tags"].([]interface{}); ok {
+		for _, tag := range tags {
+			if t, ok := tag.(map[string]interface{}); ok {
+				add(outfmt.SafeString(t["id"]))
+			}
+		}
+	}
+	if !remove {
+		for _, id := range tagIDs {
+			add(id)
+		}
+	}
+	return merged
+}
+
+// updateLinks patches each link with the body built for it, reporting each
+// success as "<verb> <short link>" and failing if any update failed.
+func updateLinks(cmd *cobra.Command, client *api.Client, links []map[string]interface{}, concurrency int, verb string, body func(map[string]interface{}) map[string]interface{}) error {
+	errs := batch.Run(cmd.Context(), len(links), concurrency, func(ctx context.Context, i int) error {
+		path := "/links/" + url.PathEscape(outfmt.SafeString(links[i]["id"]))
+		if err := discardResponse(client.Patch(ctx, path, body(links[i]))); err != nil {
+			return fmt.Errorf("%s: %w", linkLabel(links[i]), err)
+		}
+		return nil
+	})
+
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", verb, linkLabel(links[i]))
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update %d of %d link(s):\n%w", len(failed), len(links), errors.Join(failed...))
+	}
+	return nil
+}
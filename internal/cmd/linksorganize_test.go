@@ -0,0 +1,176 @@
+// internal/cmd/linksorganize_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// newOrganizeServer serves two links, one tag lookup, and one folder
+// lookup, recording the body of every PATCH and the path of every DELETE.
+func newOrganizeServer(t *testing.T) (*httptest.Server, map[string]map[string]interface{}, *[]string) {
+	t.Helper()
+	patches := map[string]map[string]interface{}{}
+	var deletes []string
+	links := map[string]string{
+		"link_a": `{"id": "link_a", "domain": "dub.sh", "key": "a", "url": "https://example.com/a", "tags": [{"id": "tag_old", "name": "old"}]}`,
+		"link_b": `{"id": "link_b", "domain": "dub.sh", "key": "b", "url": "https://example.com/b", "tags": []}`,
+	}
+
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/tags":
+			_, _ = w.Write([]byte(`[{"id": "tag_old", "name": "old"}, {"id": "tag_new", "name": "launch"}]`))
+		case r.URL.Path == "/folders":
+			_, _ = w.Write([]byte(`[{"id": "fold_1", "name": "Campaigns"}]`))
+		case r.URL.Path == "/links":
+			if r.URL.Query().Get("search") != "example" {
+				t.Errorf("expected search filter, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte("[" + links["link_a"] + "," + links["link_b"] + "]"))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(links[strings.TrimPrefix(r.URL.Path, "/links/")]))
+		case r.Method == http.MethodPatch:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			patches[strings.TrimPrefix(r.URL.Path, "/links/")] = body
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+	return server, patches, &deletes
+}
+
+// stubSelection makes the checklist choose the options at indexes.
+func stubSelection(t *testing.T, indexes ...int) {
+	t.Helper()
+	orig := selectOptions
+	selectOptions = func(cmd *cobra.Command, title string, options []string) ([]int, error) {
+		return indexes, nil
+	}
+	t.Cleanup(func() { selectOptions = orig })
+}
+
+func runOrganizeCmd(t *testing.T, cmd *cobra.Command, serverURL string, args ...string) string {
+	t.Helper()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, serverURL))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return stdout.String()
+}
+
+func TestLinksTagCmd_KeepsExistingTags(t *testing.T) {
+	server, patches, _ := newOrganizeServer(t)
+
+	out := runOrganizeCmd(t, newLinksTagCmd(), server.URL, "--tag-name", "launch", "--id", "link_a", "--id", "link_b")
+
+	if got := patches["link_a"]["tagIds"]; !reflect.DeepEqual(got, []interface{}{"tag_old", "tag_new"}) {
+		t.Errorf("expected existing tag kept, got %v", got)
+	}
+	if got := patches["link_b"]["tagIds"]; !reflect.DeepEqual(got, []interface{}{"tag_new"}) {
+		t.Errorf("unexpected tags %v", got)
+	}
+	if !strings.Contains(out, "Tagged dub.sh/a") || !strings.Contains(out, "Tagged dub.sh/b") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestLinksTagCmd_InteractiveRemove(t *testing.T) {
+	server, patches, _ := newOrganizeServer(t)
+	stubSelection(t, 0)
+
+	runOrganizeCmd(t, newLinksTagCmd(), server.URL, "--tag-name", "old", "--remove", "--interactive", "--search", "example")
+
+	if len(patches) != 1 {
+		t.Fatalf("expected only the selected link updated, got %v", patches)
+	}
+	if got := patches["link_a"]["tagIds"]; !reflect.DeepEqual(got, []interface{}{}) {
+		t.Errorf("expected tag removed, got %v", got)
+	}
+}
+
+func TestLinksMoveCmd_Interactive(t *testing.T) {
+	server, patches, _ := newOrganizeServer(t)
+	stubSelection(t, 1)
+
+	out := runOrganizeCmd(t, newLinksMoveCmd(), server.URL, "--folder-name", "Campaigns", "--interactive", "--search", "example")
+
+	if len(patches) != 1 || patches["link_b"]["folderId"] != "fold_1" {
+		t.Errorf("expected only link_b moved, got %v", patches)
+	}
+	if !strings.Contains(out, "Moved dub.sh/b") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestLinksDeleteCmd_Interactive(t *testing.T) {
+	server, _, deletes := newOrganizeServer(t)
+	stubSelection(t, 0, 1)
+
+	out := runOrganizeCmd(t, newLinksDeleteCmd(), server.URL, "--interactive", "--search", "example")
+
+	if len(*deletes) != 2 {
+		t.Errorf("expected both links deleted, got %v", *deletes)
+	}
+	if !strings.Contains(out, "Deleted dub.sh/a") || !strings.Contains(out, "Deleted dub.sh/b") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestLinksTagCmd_RequiresIDOrInteractive(t *testing.T) {
+	cmd := newLinksTagCmd()
+	cmd.SetArgs([]string{"--tag-name", "launch"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--interactive") {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
+
+func TestLinksMoveCmd_IDAndInteractiveExclusive(t *testing.T) {
+	cmd := newLinksMoveCmd()
+	cmd.SetArgs([]string{"--folder-name", "Campaigns", "--id", "link_a", "--interactive"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error when both --id and --interactive are given")
+	}
+}
+
+func TestLinksDeleteCmd_IDsFromStdin(t *testing.T) {
+	server, _, deletes := newOrganizeServer(t)
+
+	cmd := newLinksDeleteCmd()
+	cmd.SetIn(strings.NewReader("link_a\nlink_b\n"))
+	cmd.SetContext(outfmt.WithYes(context.WithValue(context.Background(), apiURLKey, server.URL), true))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--ids-from-stdin"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*deletes) != 2 {
+		t.Errorf("expected both links deleted, got %v", *deletes)
+	}
+	if !strings.Contains(stdout.String(), "Deleted dub.sh/a") {
+		t.Errorf("unexpected output: %q", stdout.String())
+	}
+}
@@ -0,0 +1,115 @@
+// internal/cmd/linksrewrite.go
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// rewriteFields are the link fields links rewrite can change.
+var rewriteFields = []string{"url", "ios", "android", "expiredUrl"}
+
+func newLinksRewriteCmd() *cobra.Command {
+	var (
+		match       string
+		replace     string
+		field       string
+		search      string
+		domain      string
+		dryRun      bool
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rewrite",
+		Short: "Rewrite link destinations with a regular expression",
+		Long: `Replace every match of the regular expression --match in link destinations
+with --replace, e.g. to rename a campaign across all its links. --replace
+may refer to capture groups as $1 or ${name}.
+
+Every link is checked, or only those matching --search and --domain. The
+changes are shown as a diff per link, then applied in bulk updates of up
+to 100 links; this requires --yes (or --dry-run). Protected links are
+skipped unless --force is given.
+
+Examples:
+  dub links rewrite --match 'utm_campaign=spring' --replace 'utm_campaign=summer' --dry-run
+  dub links rewrite --match '^http://' --replace 'https://' --domain go.acme.com --yes
+  dub links rewrite --field ios --match 'myapp://v1/(\w+)' --replace 'myapp://v2/$1' --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			re, err := regexp.Compile(match)
+			if err != nil {
+				return NewUsageErrorf("--match: %v", err)
+			}
+			if !slices.Contains(rewriteFields, field) {
+				return NewUsageErrorf("--field must be one of: url, ios, android, expiredUrl")
+			}
+			if !dryRun && !outfmt.GetYes(cmd.Context()) {
+				return NewUsageErrorf("links rewrite changes every matching link and requires --yes (or --dry-run)")
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			filter := url.Values{}
+			if search != "" {
+				filter.Set("search", search)
+			}
+			if domain != "" {
+				filter.Set("domain", domain)
+			}
+			sets := []linkSet{{field: field, re: re, repl: replace}}
+			return runLinksBulkSet(cmd, client, filter, sets, concurrency, dryRun, writeLinksRewrite)
+		},
+	}
+
+	cmd.Flags().StringVar(&match, "match", "", "Regular expression to find in destinations (required)")
+	cmd.Flags().StringVar(&replace, "replace", "", "Replacement, with $1 or ${name} for capture groups (required; may be empty)")
+	cmd.Flags().StringVar(&field, "field", "url", "Field to rewrite: url, ios, android, expiredUrl")
+	cmd.Flags().StringVar(&search, "search", "", "Only rewrite links matching this search")
+	cmd.Flags().StringVar(&domain, "domain", "", "Only rewrite links on this domain")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes without making them")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	_ = cmd.MarkFlagRequired("match")
+	_ = cmd.MarkFlagRequired("replace")
+
+	return cmd
+}
+
+// writeLinksRewrite shows each rewritten field as a diff.
+func writeLinksRewrite(cmd *cobra.Command, updates []linkSetUpdate, unchanged int, dryRun bool) error {
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		return writeLinksBulkSet(cmd, updates, unchanged, dryRun)
+	}
+
+	for _, u := range updates {
+		_, _ = fmt.Fprintf(w, "%s (%s)\n", ui.Bold(u.ShortLink), u.ID)
+		for _, c := range u.Changes {
+			_, _ = fmt.Fprintln(w, ui.Error("- "+formatSetValue(c.Before)))
+			_, _ = fmt.Fprintln(w, ui.Success("+ "+formatSetValue(c.After)))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	verb := "Rewrote"
+	if dryRun {
+		verb = "Would rewrite"
+	}
+	_, _ = fmt.Fprintf(w, "%s %d of %d link(s).\n", verb, len(updates), len(updates)+unchanged)
+	return nil
+}
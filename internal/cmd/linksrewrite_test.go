@@ -0,0 +1,70 @@
+// internal/cmd/linksrewrite_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLinksRewrite(t *testing.T) {
+	var patches []map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/links":
+			_, _ = w.Write([]byte(`[
+				{"id": "l1", "domain": "dub.sh", "key": "a", "url": "https://example.com/?utm_campaign=spring"},
+				{"id": "l2", "domain": "dub.sh", "key": "b", "url": "https://example.com/?utm_campaign=spring-sale&x=1"},
+				{"id": "l3", "domain": "dub.sh", "key": "c", "url": "https://example.com/?utm_campaign=winter"}
+			]`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/links/bulk":
+			body, _ := io.ReadAll(r.Body)
+			var patch map[string]interface{}
+			_ = json.Unmarshal(body, &patch)
+			patches = append(patches, patch)
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	args := []string{"links", "rewrite", "--api-url", server.URL, "--match", `utm_campaign=spring(\b|-)`, "--replace", "utm_campaign=summer$1"}
+
+	var stdout bytes.Buffer
+	if err := execute(context.Background(), append(args, "--dry-run"), nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"dub.sh/a (l1)\n- https://example.com/?utm_campaign=spring\n+ https://example.com/?utm_campaign=summer\n",
+		"+ https://example.com/?utm_campaign=summer-sale&x=1",
+		"Would rewrite 2 of 3 link(s).",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected %q in preview:\n%s", want, stdout.String())
+		}
+	}
+	if len(patches) != 0 {
+		t.Fatalf("expected no updates on a dry run, got %v", patches)
+	}
+
+	if err := execute(context.Background(), append(args, "--yes"), nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("expected an update per rewritten destination, got %v", patches)
+	}
+
+	for _, bad := range [][]string{
+		{"links", "rewrite", "--match", "(", "--replace", "x", "--dry-run"},
+		{"links", "rewrite", "--match", "a", "--replace", "b", "--field", "title", "--dry-run"},
+		{"links", "rewrite", "--match", "a", "--replace", "b"},
+	} {
+		if err := execute(context.Background(), bad, nil, &bytes.Buffer{}, &bytes.Buffer{}); err == nil || !IsUsageError(err) {
+			t.Errorf("%v: expected a usage error, got %v", bad, err)
+		}
+	}
+}
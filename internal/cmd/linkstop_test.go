@@ -0,0 +1,67 @@
+// internal/cmd/linkstop_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func runLinksTop(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	var query string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`[
+			{"shortLink":"dub.sh/a","url":"https://example.com/a","clicks":900,"sales":2,"saleAmount":1000},
+			{"shortLink":"dub.sh/b","url":"https://example.com/b","clicks":100,"sales":1,"saleAmount":25050},
+			{"shortLink":"dub.sh/c","url":"https://example.com/c","clicks":50,"sales":0,"saleAmount":0}
+		]`))
+	})
+
+	var stdout bytes.Buffer
+	cmd := newLinksTopCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&stdout)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), query, err
+}
+
+func TestLinksTop(t *testing.T) {
+	out, query, err := runLinksTop(t, "--limit", "2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"event=clicks", "groupBy=top_links", "interval=7d"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("expected query to contain %q, got %q", want, query)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "dub.sh/a") || !strings.Contains(lines[2], "dub.sh/b") {
+		t.Errorf("unexpected table:\n%s", out)
+	}
+}
+
+func TestLinksTop_BySaleAmount(t *testing.T) {
+	out, query, err := runLinksTop(t, "--by", "saleAmount")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, "event=sales") {
+		t.Errorf("expected sales event, got %q", query)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if !strings.Contains(strings.ToLower(lines[0]), "revenue") || !strings.Contains(lines[1], "dub.sh/b") || !strings.Contains(lines[1], "$250.50") {
+		t.Errorf("expected links ranked by revenue:\n%s", out)
+	}
+}
+
+func TestLinksTop_InvalidBy(t *testing.T) {
+	if _, _, err := runLinksTop(t, "--by", "views"); err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
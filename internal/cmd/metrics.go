@@ -0,0 +1,348 @@
+// internal/cmd/metrics.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// metricsMinInterval keeps scrapes of the API well inside rate limits.
+const metricsMinInterval = time.Minute
+
+func newMetricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Export workspace metrics to monitoring systems",
+	}
+
+	cmd.AddCommand(newMetricsServeCmd())
+
+	return cmd
+}
+
+func newMetricsServeCmd() *cobra.Command {
+	var (
+		host     string
+		port     int
+		interval time.Duration
+		windows  []string
+		topLinks int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve workspace analytics as Prometheus metrics",
+		Long: `Periodically pull workspace analytics and link counts and serve them as
+Prometheus gauges on /metrics, so you can graph and alert on them with your
+existing monitoring stack.
+
+Metrics:
+  dub_links                            links in the workspace
+  dub_clicks{window}                   clicks over each --window
+  dub_leads{window}                    leads over each --window
+  dub_sales{window}                    sales over each --window
+  dub_sale_amount_dollars{window}      revenue over each --window
+  dub_link_clicks{window,link_id,short_link}
+                                       clicks of the --top-links busiest links
+  dub_up                               1 if the last pull succeeded
+  dub_last_success_timestamp_seconds   when the last pull succeeded
+  dub_pull_duration_seconds            how long the last pull took
+
+Values are pulled every --interval, not on each scrape. When a pull fails,
+the previous values are kept and dub_up drops to 0.
+
+The exporter listens on 127.0.0.1 by default, since the metrics describe
+your workspace. Pass --host 0.0.0.0 to let a remote Prometheus scrape it.
+
+Examples:
+  dub metrics serve --port 9090 --interval 5m
+  dub metrics serve --host 0.0.0.0 --window 1h --window 24h --top-links 0`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < metricsMinInterval {
+				return NewUsageErrorf("--interval must be at least %s", metricsMinInterval)
+			}
+			if topLinks < 0 {
+				return NewUsageErrorf("--top-links must not be negative")
+			}
+			if len(windows) == 0 {
+				return NewUsageErrorf("at least one --window is required")
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			ln, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			c := &metricsCollector{client: client, windows: windows, topLinks: topLinks}
+			go c.run(ctx, interval, func(err error) {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s failed to pull metrics: %v\n", time.Now().Format(time.RFC3339), err)
+			})
+
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", c)
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintln(w, "dub metrics exporter: see /metrics")
+			})
+			srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "dub metrics listening on http://%s/metrics\n", ln.Addr())
+			if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&host, "host", "127.0.0.1", "Address to listen on")
+	cmd.Flags().IntVar(&port, "port", 9090, "Port to listen on")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Minute, "How often to pull analytics from Dub")
+	cmd.Flags().StringSliceVar(&windows, "window", []string{"1h", "24h"}, "Analytics window to export: 1h, 24h, 7d, 30d, 90d (repeatable)")
+	cmd.Flags().IntVar(&topLinks, "top-links", 10, "Export clicks of this many of the busiest links per window (0 to disable)")
+
+	return cmd
+}
+
+// metricsSnapshot is the result of one pull.
+type metricsSnapshot struct {
+	links  int
+	totals map[string]map[string]interface{} // window -> composite counts
+	top    map[string][]map[string]interface{}
+}
+
+// metricsCollector pulls analytics on an interval and serves the latest
+// values in the Prometheus text format.
+type metricsCollector struct {
+	client   *api.Client
+	windows  []string
+	topLinks int
+
+	mu          sync.Mutex
+	last        *metricsSnapshot
+	up          bool
+	lastSuccess time.Time
+	duration    time.Duration
+}
+
+// run pulls immediately and then every interval until ctx is done.
+func (c *metricsCollector) run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.pull(ctx); err != nil && ctx.Err() == nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pull fetches a snapshot, keeping the previous one if it fails.
+func (c *metricsCollector) pull(ctx context.Context) error {
+	start := time.Now()
+	snap, err := c.fetch(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.duration = time.Since(start)
+	c.up = err == nil
+	if err != nil {
+		return err
+	}
+	c.last = snap
+	c.lastSuccess = time.Now()
+	return nil
+}
+
+func (c *metricsCollector) fetch(ctx context.Context) (*metricsSnapshot, error) {
+	snap := &metricsSnapshot{
+		totals: make(map[string]map[string]interface{}, len(c.windows)),
+		top:    make(map[string][]map[string]interface{}, len(c.windows)),
+	}
+
+	type query struct {
+		window, groupBy string
+	}
+	var queries []query
+	for _, w := range c.windows {
+		queries = append(queries, query{w, "count"})
+		if c.topLinks > 0 {
+			queries = append(queries, query{w, "top_links"})
+		}
+	}
+	results := make([]interface{}, len(queries))
+
+	var mu sync.Mutex
+	errs := batch.Run(ctx, len(queries)+1, batch.DefaultConcurrency, func(ctx context.Context, i int) error {
+		if i == len(queries) {
+			var count int
+			if err := getJSON(ctx, c.client, "/links/count", &count); err != nil {
+				return fmt.Errorf("link count: %w", err)
+			}
+			mu.Lock()
+			snap.links = count
+			mu.Unlock()
+			return nil
+		}
+
+		q := queries[i]
+		params := url.Values{"event": {"composite"}, "groupBy": {q.groupBy}, "interval": {q.window}}
+		if err := getJSON(ctx, c.client, "/analytics?"+params.Encode(), &results[i]); err != nil {
+			return fmt.Errorf("%s analytics for %s: %w", q.groupBy, q.window, err)
+		}
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	for i, q := range queries {
+		switch q.groupBy {
+		case "count":
+			totals, _ := results[i].(map[string]interface{})
+			snap.totals[q.window] = totals
+		case "top_links":
+			items, _ := results[i].([]interface{})
+			for _, item := range items {
+				if link, ok := item.(map[string]interface{}); ok && len(snap.top[q.window]) < c.topLinks {
+					snap.top[q.window] = append(snap.top[q.window], link)
+				}
+			}
+		}
+	}
+	return snap, nil
+}
+
+func (c *metricsCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(c.render())
+}
+
+// render writes the latest values in the Prometheus text exposition format.
+func (c *metricsCollector) render() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var b bytes.Buffer
+	up := 0.0
+	if c.up {
+		up = 1
+	}
+	writeGauge(&b, "dub_up", "Whether the last pull of Dub analytics succeeded.", metricSample{value: up})
+
+	if c.last != nil {
+		writeGauge(&b, "dub_last_success_timestamp_seconds", "Unix time of the last successful pull.",
+			metricSample{value: float64(c.lastSuccess.UnixMilli()) / 1000})
+		writeGauge(&b, "dub_links", "Links in the workspace.", metricSample{value: float64(c.last.links)})
+
+		windows := make([]string, 0, len(c.last.totals))
+		for w := range c.last.totals {
+			windows = append(windows, w)
+		}
+		sort.Strings(windows)
+
+		for _, m := range []struct{ name, field, help string }{
+			{"dub_clicks", "clicks", "Clicks over the window."},
+			{"dub_leads", "leads", "Leads over the window."},
+			{"dub_sales", "sales", "Sales over the window."},
+		} {
+			samples := make([]metricSample, len(windows))
+			for i, w := range windows {
+				samples[i] = metricSample{labels: [][2]string{{"window", w}}, value: outfmt.SafeFloat(c.last.totals[w][m.field])}
+			}
+			writeGauge(&b, m.name, m.help, samples...)
+		}
+
+		// The API reports amounts in cents
+		samples := make([]metricSample, len(windows))
+		for i, w := range windows {
+			samples[i] = metricSample{labels: [][2]string{{"window", w}}, value: outfmt.SafeFloat(c.last.totals[w]["saleAmount"]) / 100}
+		}
+		writeGauge(&b, "dub_sale_amount_dollars", "Revenue over the window, in dollars.", samples...)
+
+		var top []metricSample
+		for _, w := range windows {
+			for _, link := range c.last.top[w] {
+				id := outfmt.SafeString(link["id"])
+				if id == "" {
+					id = outfmt.SafeString(link["link"])
+				}
+				top = append(top, metricSample{
+					labels: [][2]string{{"window", w}, {"link_id", id}, {"short_link", outfmt.SafeString(link["shortLink"])}},
+					value:  outfmt.SafeFloat(link["clicks"]),
+				})
+			}
+		}
+		if len(top) > 0 {
+			writeGauge(&b, "dub_link_clicks", "Clicks over the window of the busiest links.", top...)
+		}
+	}
+
+	if c.duration > 0 {
+		writeGauge(&b, "dub_pull_duration_seconds", "Duration of the last pull of Dub analytics.",
+			metricSample{value: c.duration.Seconds()})
+	}
+	return b.Bytes()
+}
+
+// metricSample is one value of a metric with its label pairs.
+type metricSample struct {
+	labels [][2]string
+	value  float64
+}
+
+func writeGauge(b *bytes.Buffer, name, help string, samples ...metricSample) {
+	_, _ = fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	for _, s := range samples {
+		b.WriteString(name)
+		if len(s.labels) > 0 {
+			pairs := make([]string, len(s.labels))
+			for i, l := range s.labels {
+				pairs[i] = l[0] + `="` + escapeLabelValue(l[1]) + `"`
+			}
+			b.WriteString("{" + strings.Join(pairs, ",") + "}")
+		}
+		b.WriteString(" " + strconv.FormatFloat(s.value, 'g', -1, 64) + "\n")
+	}
+}
+
+// escapeLabelValue escapes a label value as the text format requires.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,99 @@
+// internal/cmd/metrics_test.go
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	var failing atomic.Bool
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"code":"internal_server_error","message":"down"}}`))
+			return
+		}
+		if r.URL.Path == "/links/count" {
+			_, _ = w.Write([]byte(`42`))
+			return
+		}
+		switch r.URL.Query().Get("groupBy") {
+		case "count":
+			_, _ = w.Write([]byte(`{"clicks":120,"leads":4,"sales":2,"saleAmount":15050}`))
+		case "top_links":
+			_, _ = w.Write([]byte(`[{"id":"link_1","shortLink":"https://dub.sh/\"a\"","clicks":90},{"id":"link_2","shortLink":"https://dub.sh/b","clicks":30}]`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	client := api.NewClient("dub_test")
+	client.SetBaseURL(server.URL)
+	c := &metricsCollector{client: client, windows: []string{"24h", "1h"}, topLinks: 1}
+
+	if err := c.pull(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Result().Body)
+	out := string(body)
+
+	for _, want := range []string{
+		"# TYPE dub_links gauge\ndub_links 42\n",
+		"dub_up 1\n",
+		`dub_clicks{window="1h"} 120`,
+		`dub_clicks{window="24h"} 120`,
+		`dub_sales{window="24h"} 2`,
+		`dub_sale_amount_dollars{window="24h"} 150.5`,
+		`dub_link_clicks{window="1h",link_id="link_1",short_link="https://dub.sh/\"a\""} 90`,
+		"dub_pull_duration_seconds ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "link_2") {
+		t.Errorf("expected top links limited to 1:\n%s", out)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	// A failed pull keeps the previous values
+	failing.Store(true)
+	if err := c.pull(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	out = string(c.render())
+	if !strings.Contains(out, "dub_up 0\n") || !strings.Contains(out, "dub_links 42\n") {
+		t.Errorf("expected stale values with dub_up 0:\n%s", out)
+	}
+}
+
+func TestMetricsServeCmd_RejectsShortInterval(t *testing.T) {
+	cmd := newMetricsServeCmd()
+	cmd.SetArgs([]string{"--interval", "10s"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--interval") {
+		t.Fatalf("expected interval error, got %v", err)
+	}
+}
+
+func TestMetricsServeCmd_ListensOnLoopbackByDefault(t *testing.T) {
+	if got := newMetricsServeCmd().Flags().Lookup("host").DefValue; got != "127.0.0.1" {
+		t.Errorf("expected --host to default to 127.0.0.1, got %q", got)
+	}
+}
@@ -0,0 +1,256 @@
+// internal/cmd/mirror.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/mirror"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+func newMirrorCmd() *cobra.Command {
+	var dbPath string
+
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Query a local copy of your links with SQL",
+		Long: `Keep a local SQLite copy of the workspace's links, tags, and domains, and
+query it with SQL, instantly and offline.
+
+Tables:
+  links      id, domain, key, url, short_link, title, description, comments,
+             archived, clicks, leads, sales, sale_amount (cents), folder_id,
+             user_id, external_id, created_at, updated_at, last_clicked,
+             expires_at
+  link_tags  link_id, tag_id
+  tags       id, name, color
+  domains    id, slug, verified, is_primary, archived, placeholder, created_at
+
+Every table also has a raw column with the full API object as JSON, for
+json_extract(raw, '$.field'). Each workspace has its own mirror under the
+state directory, or use --db.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&dbPath, "db", "", "Path of the mirror database (default: per workspace)")
+
+	cmd.AddCommand(newMirrorSyncCmd(&dbPath))
+	cmd.AddCommand(newMirrorQueryCmd(&dbPath))
+
+	return cmd
+}
+
+func newMirrorSyncCmd(dbPath *string) *cobra.Command {
+	var newOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Pull links, tags, and domains into the local mirror",
+		Long: `Pull links, tags, and domains into the local mirror. Only links that
+changed are rewritten, and links deleted in Dub are removed.
+
+With --new-only, paging stops at the first page of links already in the
+mirror, so frequent syncs only fetch new links. Click counts and edits to
+older links aren't refreshed, and deletions aren't noticed, until the next
+full sync.
+
+Examples:
+  dub mirror sync
+  dub mirror sync --new-only`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			path, err := mirrorPath(cmd.Context(), *dbPath)
+			if err != nil {
+				return err
+			}
+
+			store, err := mirror.Open(path)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = store.Close() }()
+
+			var tags, domains []map[string]interface{}
+			if err := getJSON(cmd.Context(), client, "/tags", &tags); err != nil {
+				return fmt.Errorf("failed to list tags: %w", err)
+			}
+			if err := getJSON(cmd.Context(), client, "/domains", &domains); err != nil {
+				return fmt.Errorf("failed to list domains: %w", err)
+			}
+			if err := store.ReplaceTags(tags); err != nil {
+				return err
+			}
+			if err := store.ReplaceDomains(domains); err != nil {
+				return err
+			}
+
+			links, err := fetchMirrorLinks(cmd.Context(), client, store, newOnly)
+			if err != nil {
+				return err
+			}
+			stats, err := store.SyncLinks(links, !newOnly)
+			if err != nil {
+				return err
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), map[string]interface{}{
+					"path":    path,
+					"links":   stats,
+					"tags":    len(tags),
+					"domains": len(domains),
+				}, outfmt.GetQuery(cmd.Context()))
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Synced %s\n", path)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Links:   %d added, %d updated, %d removed, %d unchanged\n",
+				stats.Added, stats.Updated, stats.Removed, stats.Unchanged)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Tags:    %d\n", len(tags))
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  Domains: %d\n", len(domains))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&newOnly, "new-only", false, "Only fetch links created since the last sync")
+
+	return cmd
+}
+
+// fetchMirrorLinks pages through links newest first. With newOnly it stops
+// after the first page whose links are all already mirrored.
+func fetchMirrorLinks(ctx context.Context, client *api.Client, store *mirror.Store, newOnly bool) ([]map[string]interface{}, error) {
+	params := url.Values{"sortBy": {"createdAt"}, "sortOrder": {"desc"}}
+	var all []map[string]interface{}
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		params.Set("pageSize", strconv.Itoa(linksPageSize))
+
+		var links []map[string]interface{}
+		if err := getJSON(ctx, client, "/links?"+params.Encode(), &links); err != nil {
+			return nil, fmt.Errorf("failed to list links: %w", err)
+		}
+		all = append(all, links...)
+		if len(links) < linksPageSize {
+			return all, nil
+		}
+
+		if newOnly {
+			ids := make([]string, len(links))
+			for i, link := range links {
+				ids[i] = outfmt.SafeString(link["id"])
+			}
+			known, err := store.HasLinks(ids)
+			if err != nil {
+				return nil, err
+			}
+			if known == len(ids) {
+				return all, nil
+			}
+		}
+	}
+}
+
+func newMirrorQueryCmd(dbPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "query <sql>",
+		Short: "Run a SQL query against the local mirror",
+		Long: `Run a read-only SQL query against the local mirror. No network access is
+needed, so it's instant even for very large workspaces.
+
+Examples:
+  dub mirror query "SELECT key, clicks FROM links WHERE clicks > 100 ORDER BY clicks DESC"
+  dub mirror query "SELECT t.name, count(*) AS links FROM link_tags lt JOIN tags t ON t.id = lt.tag_id GROUP BY t.name"
+  dub mirror query "SELECT domain, sum(clicks) FROM links GROUP BY domain" -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := mirrorPath(cmd.Context(), *dbPath)
+			if err != nil {
+				return err
+			}
+
+			store, err := mirror.OpenReadOnly(path)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = store.Close() }()
+
+			result, err := store.Query(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("query failed: %w", err)
+			}
+			return writeMirrorResult(cmd, result)
+		},
+	}
+}
+
+// mirrorPath returns the mirror database for the active workspace, unless
+// --db names one.
+func mirrorPath(ctx context.Context, dbPath string) (string, error) {
+	if dbPath != "" {
+		return dbPath, nil
+	}
+	scope := GetWorkspace(ctx)
+	if scope == "" {
+		client, err := getClient(ctx)
+		if err != nil {
+			return "", err
+		}
+		scope = cache.Scope(client.APIKey())
+	}
+	return config.MirrorPath(scope)
+}
+
+// writeMirrorResult prints query results as JSON objects or as a table with
+// numeric columns right-aligned.
+func writeMirrorResult(cmd *cobra.Command, result *mirror.Result) error {
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		items := make([]map[string]interface{}, len(result.Rows))
+		for i, row := range result.Rows {
+			items[i] = make(map[string]interface{}, len(row))
+			for j, v := range row {
+				items[i][result.Columns[j]] = v
+			}
+		}
+		return outfmt.FormatJSON(cmd.OutOrStdout(), items, outfmt.GetQuery(cmd.Context()))
+	}
+
+	if len(result.Rows) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No rows.")
+		return nil
+	}
+
+	columns := make([]outfmt.Column, len(result.Columns))
+	for i, name := range result.Columns {
+		columns[i] = outfmt.Column{Name: name, Width: 40, Align: outfmt.AlignRight}
+		for _, row := range result.Rows {
+			switch row[i].(type) {
+			case int64, float64, nil:
+			default:
+				columns[i].Align = outfmt.AlignLeft
+			}
+		}
+	}
+
+	rows := make([][]string, len(result.Rows))
+	for i, row := range result.Rows {
+		rows[i] = make([]string, len(row))
+		for j, v := range row {
+			if v == nil {
+				rows[i][j] = "-"
+				continue
+			}
+			rows[i][j] = outfmt.SafeString(v)
+		}
+	}
+	return outfmt.FormatTable(cmd.OutOrStdout(), columns, rows)
+}
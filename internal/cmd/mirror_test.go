@@ -0,0 +1,91 @@
+// internal/cmd/mirror_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+func TestMirrorSyncAndQuery(t *testing.T) {
+	// 150 links over two pages, newest first
+	total := 150
+	var linkPages []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tags":
+			_, _ = w.Write([]byte(`[{"id": "t1", "name": "launch"}]`))
+		case "/domains":
+			_, _ = w.Write([]byte(`[{"id": "d1", "slug": "dub.sh", "verified": true}]`))
+		case "/links":
+			q := r.URL.Query()
+			if q.Get("sortBy") != "createdAt" || q.Get("sortOrder") != "desc" {
+				t.Errorf("expected newest links first, got %s", r.URL.RawQuery)
+			}
+			linkPages = append(linkPages, q.Get("page"))
+			var items []string
+			first := (len(linkPages) - 1) * linksPageSize
+			for i := first; i < total && i < first+linksPageSize; i++ {
+				items = append(items, fmt.Sprintf(`{"id": "l%d", "domain": "dub.sh", "key": "k%d", "clicks": %d, "tags": [{"id": "t1"}]}`, i, i, i))
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	db := filepath.Join(t.TempDir(), "mirror.db")
+	run := func(format string, args ...string) string {
+		t.Helper()
+		cmd := newMirrorCmd()
+		ctx := context.WithValue(context.Background(), apiURLKey, server.URL)
+		cmd.SetContext(outfmt.WithFormat(ctx, format))
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs(append(args, "--db", db))
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		return stdout.String()
+	}
+
+	out := run("table", "sync")
+	if !strings.Contains(out, "150 added, 0 updated, 0 removed, 0 unchanged") || !strings.Contains(out, "Tags:    1") {
+		t.Errorf("unexpected sync output:\n%s", out)
+	}
+
+	// With --new-only, paging stops at the first fully known page
+	linkPages = nil
+	out = run("json", "sync", "--new-only")
+	if len(linkPages) != 1 || !strings.Contains(out, `"unchanged": 100`) {
+		t.Errorf("expected a single page to be fetched, got pages %v:\n%s", linkPages, out)
+	}
+
+	out = run("table", "query", "SELECT key, clicks FROM links WHERE clicks > 147 ORDER BY clicks DESC")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "KEY") || !strings.Contains(lines[1], "k149") || !strings.HasSuffix(lines[1], "149") {
+		t.Errorf("unexpected query output:\n%s", out)
+	}
+
+	out = run("json", "query", "SELECT count(*) AS n FROM link_tags")
+	if !strings.Contains(out, `"n": 150`) {
+		t.Errorf("unexpected JSON query output:\n%s", out)
+	}
+}
+
+func TestMirrorQuery_NotSynced(t *testing.T) {
+	cmd := newMirrorCmd()
+	cmd.SetContext(context.Background())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"query", "SELECT 1", "--db", filepath.Join(t.TempDir(), "missing.db")})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "dub mirror sync") {
+		t.Errorf("expected a hint to sync first, got %v", err)
+	}
+}
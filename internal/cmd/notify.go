@@ -0,0 +1,44 @@
+// internal/cmd/notify.go
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/notify"
+)
+
+// addNotifyFlag registers the repeatable --notify flag of monitoring commands.
+func addNotifyFlag(cmd *cobra.Command, specs *[]string) {
+	cmd.Flags().StringArrayVar(specs, "notify", nil, "Post alerts to a chat webhook: slack:URL or discord:URL (repeatable)")
+}
+
+// parseNotifiers parses --notify values, so bad ones fail before any work.
+func parseNotifiers(specs []string) ([]notify.Notifier, error) {
+	notifiers := make([]notify.Notifier, 0, len(specs))
+	for _, spec := range specs {
+		n, err := notify.Parse(spec)
+		if err != nil {
+			return nil, NewUsageErrorf("--notify: %v", err)
+		}
+		notifiers = append(notifiers, n)
+	}
+	return notifiers, nil
+}
+
+// sendNotification posts msg to every notifier, returning an error naming
+// those that failed.
+func sendNotification(cmd *cobra.Command, notifiers []notify.Notifier, msg notify.Message) error {
+	var errs []error
+	for _, n := range notifiers {
+		if err := n.Notify(cmd.Context(), msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to send notification:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
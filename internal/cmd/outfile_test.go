@@ -0,0 +1,74 @@
+// internal/cmd/outfile_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOutFlag(t *testing.T) {
+	fail := false
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"code":"internal_server_error","message":"boom"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"tag_1","name":"launch","color":"red"}]`))
+	})
+
+	path := filepath.Join(t.TempDir(), "tags.json")
+	run := func(args ...string) (string, error) {
+		var stdout bytes.Buffer
+		err := execute(context.Background(), append([]string{"--api-url", server.URL, "--no-circuit-breaker", "-o", "json", "--out", path}, args...), nil, &stdout, &bytes.Buffer{})
+		return stdout.String(), err
+	}
+
+	stdout, err := run("tags", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout, got %q", stdout)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "tag_1") {
+		t.Fatalf("expected tags in %s, got %q", path, data)
+	}
+
+	// A failed run leaves the previous output in place
+	fail = true
+	if _, err := run("tags", "list"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if again, _ := os.ReadFile(path); !bytes.Equal(again, data) {
+		t.Errorf("failed run changed %s: %q", path, again)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("expected no temp files left, found %d entries", len(entries))
+	}
+}
+
+func TestAppendFlag(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+	path := filepath.Join(t.TempDir(), "versions.txt")
+	for i := 0; i < 2; i++ {
+		if err := execute(context.Background(), []string{"--out", path, "--append", "version"}, nil, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	if n := strings.Count(string(data), "dub"); n < 2 {
+		t.Errorf("expected two runs appended, got %q", data)
+	}
+
+	err := execute(context.Background(), []string{"--append", "version"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error for --append without --out, got %v", err)
+	}
+}
@@ -160,6 +160,10 @@ func handlePartnersListResponse(cmd *cobra.Command, resp *http.Response, output
 		return fmt.Errorf("%s", apiErr.Error())
 	}
 
+	if body, err = sortListBody(cmd, body); err != nil {
+		return err
+	}
+
 	// For JSON output, use the existing handler
 	if output == "json" {
 		var data interface{}
@@ -306,12 +310,13 @@ func newPartnersLinksCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "links",
 		Short: "Manage partner links",
-		Long:  "Create and list partner referral links.",
+		Long:  "Create, list, and measure partner referral links.",
 	}
 
 	cmd.AddCommand(newPartnersLinksCreateCmd())
 	cmd.AddCommand(newPartnersLinksUpsertCmd())
 	cmd.AddCommand(newPartnersLinksListCmd())
+	cmd.AddCommand(newPartnersLinksStatsCmd())
 
 	return cmd
 }
@@ -496,6 +501,10 @@ func handlePartnersLinksListResponse(cmd *cobra.Command, resp *http.Response, ou
 		return fmt.Errorf("%s", apiErr.Error())
 	}
 
+	if body, err = sortListBody(cmd, body); err != nil {
+		return err
+	}
+
 	// For JSON output, use the existing handler
 	if output == "json" {
 		var data interface{}
@@ -539,7 +548,7 @@ func handlePartnersLinksListResponse(cmd *cobra.Command, resp *http.Response, ou
 	for i, link := range displayLinks {
 		rows[i] = []string{
 			buildShortLink(outfmt.SafeString(link["domain"]), outfmt.SafeString(link["key"])),
-			outfmt.Truncate(outfmt.SafeString(link["url"]), 50),
+			outfmt.SafeString(link["url"]),
 			formatClicks(outfmt.SafeInt(link["clicks"])),
 			outfmt.FormatDate(link["createdAt"]),
 		}
@@ -566,12 +575,23 @@ func newPartnersAnalyticsCmd() *cobra.Command {
 		start     string
 		end       string
 		groupBy   string
+		output    string
+		limit     int
+		all       bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "analytics",
 		Short: "Get partner analytics",
-		Long:  "Retrieve analytics for partners.",
+		Long: `Retrieve analytics for partners: clicks, leads, sales, revenue, and the
+commission earnings they generated.
+
+Results are shown as a table for --group-by count, timeseries, top_links,
+and partner breakdowns, and as JSON otherwise.
+
+Examples:
+  dub partners analytics --program-id prog_abc --partner-id pn_abc --interval 30d
+  dub partners analytics --program-id prog_abc --partner-id pn_abc --group-by top_links`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if programID == "" {
 				return fmt.Errorf("--program-id is required")
@@ -590,11 +610,8 @@ func newPartnersAnalyticsCmd() *cobra.Command {
 			if interval != "" {
 				params.Set("interval", interval)
 			}
-			if start != "" {
-				params.Set("start", start)
-			}
-			if end != "" {
-				params.Set("end", end)
+			if err := setDateRange(params, "--start", start, "--end", end); err != nil {
+				return err
 			}
 			if groupBy != "" {
 				params.Set("groupBy", groupBy)
@@ -605,18 +622,172 @@ func newPartnersAnalyticsCmd() *cobra.Command {
 				return err
 			}
 
-			return handleResponse(cmd, resp)
+			return handlePartnerAnalyticsResponse(cmd, resp, groupBy, output, limit, all)
 		},
 	}
 
 	cmd.Flags().StringVar(&programID, "program-id", "", "Program ID (required)")
 	cmd.Flags().StringVar(&partnerID, "partner-id", "", "Filter by partner ID")
 	cmd.Flags().StringVar(&interval, "interval", "", "Time interval")
-	cmd.Flags().StringVar(&start, "start", "", "Start date (ISO 8601)")
-	cmd.Flags().StringVar(&end, "end", "", "End date (ISO 8601)")
-	cmd.Flags().StringVar(&groupBy, "group-by", "", "Property to group by")
+	cmd.Flags().StringVar(&start, "start", "", `Start date: ISO 8601, 2025-01, yesterday, or "7 days ago"`)
+	cmd.Flags().StringVar(&end, "end", "", "End date, in the same forms as --start (dates include the whole day)")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Property to group by: count, timeseries, top_links")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of rows to show (for grouped results)")
+	cmd.Flags().BoolVar(&all, "all", false, "Show all rows (ignore limit)")
 
 	_ = cmd.MarkFlagRequired("program-id")
 
 	return cmd
 }
+
+// handlePartnerAnalyticsResponse handles the response for partners analytics,
+// formatting output as table or JSON based on the output flag and group-by
+// value. Sale amounts and earnings are in cents, as reported by the API.
+func handlePartnerAnalyticsResponse(cmd *cobra.Command, resp *http.Response, groupBy, output string, limit int, all bool) error {
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := api.ParseAPIError(body)
+		return fmt.Errorf("%s", apiErr.Error())
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(body))
+		return nil
+	}
+
+	// For JSON output, use the existing handler
+	if output == "json" {
+		query := outfmt.GetQuery(cmd.Context())
+		return outfmt.FormatJSON(cmd.OutOrStdout(), data, query)
+	}
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		return formatPartnerAnalyticsCount(cmd, v)
+	case []interface{}:
+		column, label := partnerAnalyticsGroup(groupBy, v)
+		if label != nil {
+			return formatPartnerAnalyticsGrouped(cmd, v, column, label, limit, all)
+		}
+	}
+	// Unknown group-by, fall back to JSON
+	return outfmt.FormatJSON(cmd.OutOrStdout(), data, "")
+}
+
+// formatPartnerAnalyticsCount formats partner totals as a vertical table.
+func formatPartnerAnalyticsCount(cmd *cobra.Command, data map[string]interface{}) error {
+	columns := []outfmt.Column{
+		{Name: "Metric", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Value", Width: 0, Align: outfmt.AlignRight},
+	}
+
+	rows := [][]string{}
+	for _, metric := range []struct {
+		key, label string
+		currency   bool
+	}{
+		{"clicks", "Clicks", false},
+		{"leads", "Leads", false},
+		{"sales", "Sales", false},
+		{"saleAmount", "Revenue", true},
+		{"earnings", "Earnings", true},
+	} {
+		val, ok := data[metric.key]
+		if !ok {
+			continue
+		}
+		value := formatMetricValue(val)
+		if metric.currency {
+			value = formatCurrency(outfmt.SafeFloat(val))
+		}
+		rows = append(rows, []string{metric.label, value})
+	}
+
+	return outfmt.FormatTable(cmd.OutOrStdout(), columns, rows)
+}
+
+// partnerAnalyticsGroup returns the header and row label of the first
+// column for grouped partner analytics, or a nil label for groupings
+// without a table.
+func partnerAnalyticsGroup(groupBy string, items []interface{}) (string, func(map[string]interface{}) string) {
+	switch groupBy {
+	case "timeseries":
+		return "Date", func(item map[string]interface{}) string {
+			return outfmt.FormatDate(item["start"])
+		}
+	case "top_links":
+		return "Short Link", func(item map[string]interface{}) string {
+			if shortLink := outfmt.SafeString(item["shortLink"]); shortLink != "" {
+				return shortLink
+			}
+			return buildShortLink(outfmt.SafeString(item["domain"]), outfmt.SafeString(item["key"]))
+		}
+	}
+	// Breakdowns by partner carry the partner on each row
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return "", nil
+		}
+		if _, ok := obj["partner"]; !ok && obj["partnerId"] == nil {
+			return "", nil
+		}
+	}
+	return "Partner", formatPartner
+}
+
+// formatPartnerAnalyticsGrouped formats grouped partner analytics with
+// clicks, leads, sales, revenue, and commission earnings per row.
+func formatPartnerAnalyticsGrouped(cmd *cobra.Command, items []interface{}, column string, label func(map[string]interface{}) string, limit int, all bool) error {
+	totalCount := len(items)
+
+	// Apply limit unless --all is set
+	displayLimit := limit
+	if all {
+		displayLimit = totalCount
+	}
+	if displayLimit > totalCount {
+		displayLimit = totalCount
+	}
+
+	columns := []outfmt.Column{
+		{Name: column, Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Clicks", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Leads", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Sales", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Revenue", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Earnings", Width: 0, Align: outfmt.AlignRight},
+	}
+
+	rows := make([][]string, 0, displayLimit)
+	for _, item := range items[:displayLimit] {
+		obj, _ := item.(map[string]interface{})
+		rows = append(rows, []string{
+			label(obj),
+			formatMetricValue(obj["clicks"]),
+			formatMetricValue(obj["leads"]),
+			formatMetricValue(obj["sales"]),
+			formatCurrency(outfmt.SafeFloat(obj["saleAmount"])),
+			formatCurrency(outfmt.SafeFloat(obj["earnings"])),
+		})
+	}
+
+	if err := outfmt.FormatTable(cmd.OutOrStdout(), columns, rows); err != nil {
+		return err
+	}
+
+	// Show pagination message if limited
+	if displayLimit < totalCount {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d of %d rows. Use --limit or --all for more.\n", displayLimit, totalCount)
+	}
+
+	return nil
+}
@@ -2,6 +2,9 @@
 package cmd
 
 import (
+	"bytes"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -47,7 +50,7 @@ func TestPartnersLinksCmd_SubCommands(t *testing.T) {
 		t.Fatal("expected links subcommand to exist")
 	}
 
-	subCmds := []string{"create", "upsert", "list"}
+	subCmds := []string{"create", "upsert", "list", "stats"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range linksCmd.Commands() {
@@ -277,3 +280,65 @@ func TestPartnersAnalyticsCmd_Flags(t *testing.T) {
 		}
 	}
 }
+
+func TestHandlePartnerAnalyticsResponse_CountFormat(t *testing.T) {
+	cmd := newPartnersAnalyticsCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	body := `{"clicks": 1234, "leads": 45, "sales": 12, "saleAmount": 250000, "earnings": 37550}`
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       mockReadCloser{strings.NewReader(body)},
+	}
+
+	if err := handlePartnerAnalyticsResponse(cmd, resp, "", "table", 25, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"METRIC", "1,234", "Revenue", "$2,500.00", "Earnings", "$375.50"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestHandlePartnerAnalyticsResponse_GroupedFormat(t *testing.T) {
+	tests := []struct {
+		groupBy string
+		body    string
+		want    []string
+	}{
+		{
+			groupBy: "top_links",
+			body:    `[{"shortLink": "https://dub.sh/alice", "clicks": 900, "leads": 10, "sales": 3, "saleAmount": 30000, "earnings": 4500}]`,
+			want:    []string{"SHORT LINK", "EARNINGS", "https://dub.sh/alice", "$300.00", "$45.00"},
+		},
+		{
+			groupBy: "partnerId",
+			body:    `[{"partner": {"id": "pn_1", "name": "Alice"}, "clicks": 5, "earnings": 1200}, {"partnerId": "pn_2", "clicks": 1}]`,
+			want:    []string{"PARTNER", "Alice", "pn_2", "$12.00", "$0.00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			cmd := newPartnersAnalyticsCmd()
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+
+			resp := &http.Response{
+				StatusCode: 200,
+				Body:       mockReadCloser{strings.NewReader(tt.body)},
+			}
+			if err := handlePartnerAnalyticsResponse(cmd, resp, tt.groupBy, "table", 25, false); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// internal/cmd/partnerstats.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// partnerLinkStats is the performance of one partner link. SaleAmount is in
+// cents, as reported by the API.
+type partnerLinkStats struct {
+	ID         string  `json:"id"`
+	ShortLink  string  `json:"shortLink"`
+	URL        string  `json:"url"`
+	Clicks     int     `json:"clicks"`
+	Leads      int     `json:"leads"`
+	Sales      int     `json:"sales"`
+	SaleAmount float64 `json:"saleAmount"`
+}
+
+// partnerStats is a partner's links with their combined totals.
+type partnerStats struct {
+	Links  []partnerLinkStats `json:"links"`
+	Totals partnerLinkStats   `json:"totals"`
+}
+
+func newPartnersLinksStatsCmd() *cobra.Command {
+	var (
+		programID string
+		partnerID string
+		interval  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show performance of a partner's links",
+		Long: `Show clicks, leads, sales, and revenue for each of a partner's links,
+busiest first, with totals across all of them.
+
+Examples:
+  dub partners links stats --program-id prog_abc --partner-id pn_abc
+  dub partners links stats --program-id prog_abc --partner-id pn_abc --interval 90d -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			stats, err := fetchPartnerStats(cmd.Context(), client, programID, partnerID, interval)
+			if err != nil {
+				return err
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				return outfmt.FormatJSON(cmd.OutOrStdout(), stats, outfmt.GetQuery(cmd.Context()))
+			}
+			return writePartnerStats(cmd, stats)
+		},
+	}
+
+	cmd.Flags().StringVar(&programID, "program-id", "", "Program ID (required)")
+	cmd.Flags().StringVar(&partnerID, "partner-id", "", "Partner ID (required)")
+	cmd.Flags().StringVar(&interval, "interval", "30d", "Time interval: 24h, 7d, 30d, 90d, 1y, all")
+
+	_ = cmd.MarkFlagRequired("program-id")
+	_ = cmd.MarkFlagRequired("partner-id")
+
+	return cmd
+}
+
+// fetchPartnerStats lists a partner's links and fetches the analytics of
+// each concurrently.
+func fetchPartnerStats(ctx context.Context, client *api.Client, programID, partnerID, interval string) (*partnerStats, error) {
+	var links []map[string]interface{}
+	params := url.Values{"programId": {programID}, "partnerId": {partnerID}}
+	if err := getJSON(ctx, client, "/partners/links?"+params.Encode(), &links); err != nil {
+		return nil, fmt.Errorf("failed to list partner links: %w", err)
+	}
+
+	stats := &partnerStats{Links: make([]partnerLinkStats, len(links))}
+	errs := batch.Run(ctx, len(links), batch.DefaultConcurrency, func(ctx context.Context, i int) error {
+		link := links[i]
+		row := partnerLinkStats{
+			ID:        outfmt.SafeString(link["id"]),
+			ShortLink: outfmt.SafeString(link["shortLink"]),
+			URL:       outfmt.SafeString(link["url"]),
+		}
+		if row.ShortLink == "" {
+			row.ShortLink = buildShortLink(outfmt.SafeString(link["domain"]), outfmt.SafeString(link["key"]))
+		}
+
+		var counts map[string]interface{}
+		params := url.Values{"linkId": {row.ID}, "event": {"composite"}, "groupBy": {"count"}, "interval": {interval}}
+		if err := getJSON(ctx, client, "/analytics?"+params.Encode(), &counts); err != nil {
+			return fmt.Errorf("failed to get analytics for %s: %w", row.ShortLink, err)
+		}
+		row.Clicks = outfmt.SafeInt(counts["clicks"])
+		row.Leads = outfmt.SafeInt(counts["leads"])
+		row.Sales = outfmt.SafeInt(counts["sales"])
+		row.SaleAmount = outfmt.SafeFloat(counts["saleAmount"])
+		stats.Links[i] = row
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(stats.Links, func(i, j int) bool {
+		return stats.Links[i].Clicks > stats.Links[j].Clicks
+	})
+	for _, row := range stats.Links {
+		stats.Totals.Clicks += row.Clicks
+		stats.Totals.Leads += row.Leads
+		stats.Totals.Sales += row.Sales
+		stats.Totals.SaleAmount += row.SaleAmount
+	}
+	return stats, nil
+}
+
+func writePartnerStats(cmd *cobra.Command, stats *partnerStats) error {
+	if len(stats.Links) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No links found for this partner.")
+		return nil
+	}
+
+	columns := []outfmt.Column{
+		{Name: "Short Link", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "URL", Width: 40, Align: outfmt.AlignLeft},
+		{Name: "Clicks", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Leads", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Sales", Width: 0, Align: outfmt.AlignRight},
+		{Name: "Revenue", Width: 0, Align: outfmt.AlignRight},
+	}
+
+	rows := make([][]string, 0, len(stats.Links)+1)
+	for _, row := range stats.Links {
+		rows = append(rows, partnerStatsRow(row.ShortLink, row.URL, row))
+	}
+	rows = append(rows, partnerStatsRow("Total", "", stats.Totals))
+
+	return outfmt.FormatTable(cmd.OutOrStdout(), columns, rows)
+}
+
+func partnerStatsRow(label, url string, s partnerLinkStats) []string {
+	return []string{
+		label,
+		url,
+		formatClicks(s.Clicks),
+		formatClicks(s.Leads),
+		formatClicks(s.Sales),
+		formatCurrency(s.SaleAmount),
+	}
+}
@@ -0,0 +1,59 @@
+// internal/cmd/partnerstats_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPartnersLinksStatsCmd(t *testing.T) {
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/partners/links":
+			if r.URL.Query().Get("partnerId") != "pn_1" {
+				t.Errorf("expected partnerId filter, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[
+				{"id": "link_a", "domain": "dub.sh", "key": "quiet", "url": "https://example.com/a"},
+				{"id": "link_b", "shortLink": "https://dub.sh/busy", "url": "https://example.com/b"}
+			]`))
+		case "/analytics":
+			q := r.URL.Query()
+			if q.Get("event") != "composite" || q.Get("interval") != "90d" {
+				t.Errorf("unexpected analytics query: %s", r.URL.RawQuery)
+			}
+			if q.Get("linkId") == "link_b" {
+				_, _ = w.Write([]byte(`{"clicks": 1200, "leads": 30, "sales": 4, "saleAmount": 19900}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"clicks": 5, "leads": 1, "sales": 1, "saleAmount": 4900}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	cmd := newPartnersLinksStatsCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs([]string{"--program-id", "prog_1", "--partner-id", "pn_1", "--interval", "90d"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := stdout.String()
+	busy, quiet := strings.Index(out, "dub.sh/busy"), strings.Index(out, "dub.sh/quiet")
+	if busy < 0 || quiet < 0 || busy > quiet {
+		t.Errorf("expected both links, busiest first:\n%s", out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	total := lines[len(lines)-1]
+	for _, want := range []string{"Total", "1,205", "31", "5", "$248.00"} {
+		if !strings.Contains(total, want) {
+			t.Errorf("expected %q in totals row %q", want, total)
+		}
+	}
+}
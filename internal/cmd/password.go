@@ -0,0 +1,191 @@
+// internal/cmd/password.go
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// linkPasswordStatus reports whether a link is password protected. The
+// password itself is never printed.
+type linkPasswordStatus struct {
+	ID                string `json:"id"`
+	PasswordProtected bool   `json:"passwordProtected"`
+}
+
+func newLinksPasswordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "password",
+		Short: "Manage link password protection",
+		Long: `Set, remove, or check a link's password.
+
+The password is read from a hidden prompt, or from the first line of stdin
+when it isn't a terminal, so it never ends up in shell history.
+
+Examples:
+  dub links password set --id link_abc123
+  dub links password check --id link_abc123
+  dub links password unset --id link_abc123`,
+	}
+
+	cmd.AddCommand(newLinksPasswordSetCmd())
+	cmd.AddCommand(newLinksPasswordUnsetCmd())
+	cmd.AddCommand(newLinksPasswordCheckCmd())
+
+	return cmd
+}
+
+func newLinksPasswordSetCmd() *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Protect a link with a password",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			password, err := readPassword(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			resp, err := client.Patch(cmd.Context(), "/links/"+url.PathEscape(id), map[string]interface{}{"password": password})
+			if err := discardResponse(resp, err); err != nil {
+				return err
+			}
+
+			return writeLinkPasswordStatus(cmd, linkPasswordStatus{ID: id, PasswordProtected: true}, "Password set for link %s\n")
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Link ID (required)")
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func newLinksPasswordUnsetCmd() *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Remove a link's password",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			resp, err := client.Patch(cmd.Context(), "/links/"+url.PathEscape(id), map[string]interface{}{"password": nil})
+			if err := discardResponse(resp, err); err != nil {
+				return err
+			}
+
+			return writeLinkPasswordStatus(cmd, linkPasswordStatus{ID: id}, "Password removed from link %s\n")
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Link ID (required)")
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func newLinksPasswordCheckCmd() *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Show whether a link is password protected",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			var link struct {
+				Password *string `json:"password"`
+			}
+			if err := getJSON(cmd.Context(), client, "/links/"+url.PathEscape(id), &link); err != nil {
+				return err
+			}
+
+			status := linkPasswordStatus{ID: id, PasswordProtected: link.Password != nil && *link.Password != ""}
+			format := "Link %s is not password protected\n"
+			if status.PasswordProtected {
+				format = "Link %s is password protected\n"
+			}
+			return writeLinkPasswordStatus(cmd, status, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Link ID (required)")
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func writeLinkPasswordStatus(cmd *cobra.Command, status linkPasswordStatus, format string) error {
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		return outfmt.FormatJSON(cmd.OutOrStdout(), status, outfmt.GetQuery(cmd.Context()))
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), format, status.ID)
+	return nil
+}
+
+// readPassword prompts twice for a password without echoing it when stdin
+// is a terminal. Otherwise it reads the first line of stdin.
+func readPassword(cmd *cobra.Command) (string, error) {
+	in := cmd.InOrStdin()
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		secret, err := promptHidden(cmd, f, "Password: ")
+		if err != nil {
+			return "", err
+		}
+		again, err := promptHidden(cmd, f, "Confirm password: ")
+		if err != nil {
+			return "", err
+		}
+		if again != secret {
+			return "", errors.New("passwords do not match")
+		}
+		return secret, nil
+	}
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	secret := strings.TrimRight(line, "\r\n")
+	if secret == "" {
+		return "", errors.New("no password given on stdin")
+	}
+	return secret, nil
+}
+
+func promptHidden(cmd *cobra.Command, f *os.File, prompt string) (string, error) {
+	_, _ = fmt.Fprint(cmd.ErrOrStderr(), prompt)
+	secret, err := term.ReadPassword(int(f.Fd()))
+	_, _ = fmt.Fprintln(cmd.ErrOrStderr())
+	if err != nil {
+		return "", err
+	}
+	if len(secret) == 0 {
+		return "", errors.New("password cannot be empty")
+	}
+	return string(secret), nil
+}
@@ -0,0 +1,68 @@
+// internal/cmd/password_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func runLinksPassword(t *testing.T, handler http.HandlerFunc, stdin string, args ...string) (string, error) {
+	t.Helper()
+	server := newTestAPI(t, handler)
+
+	var out bytes.Buffer
+	cmd := newLinksPasswordCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestLinksPasswordSet_ReadsStdin(t *testing.T) {
+	var body map[string]interface{}
+	out, err := runLinksPassword(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/links/link_1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"id":"link_1","password":"hunter2"}`))
+	}, "hunter2\n", "set", "--id", "link_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body["password"] != "hunter2" {
+		t.Errorf("expected password from stdin, got body %v", body)
+	}
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("password must not be printed, got %q", out)
+	}
+}
+
+func TestLinksPasswordSet_RequiresInput(t *testing.T) {
+	_, err := runLinksPassword(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}, "", "set", "--id", "link_1")
+	if err == nil {
+		t.Error("expected error for empty stdin")
+	}
+}
+
+func TestLinksPasswordCheck(t *testing.T) {
+	out, err := runLinksPassword(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"link_1","password":"hunter2"}`))
+	}, "", "check", "--id", "link_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "Link link_1 is password protected\n" {
+		t.Errorf("unexpected output %q", out)
+	}
+}
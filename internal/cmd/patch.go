@@ -0,0 +1,109 @@
+// internal/cmd/patch.go
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// patchFlag lets update commands send API fields that have no flag yet.
+type patchFlag struct {
+	raw string
+}
+
+func (p *patchFlag) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&p.raw, "patch", "", `JSON merge patch (or JSON Patch array) applied to the request body, e.g. '{"comments":null}' (@file reads a file)`)
+}
+
+// apply merges --patch into body. A JSON object is an RFC 7396 merge patch:
+// its fields override those set by flags and null clears a field. A JSON
+// array is an RFC 6902 JSON Patch limited to add, replace, and remove, where
+// remove clears the field.
+func (p *patchFlag) apply(body map[string]interface{}) error {
+	if strings.TrimSpace(p.raw) == "" {
+		return nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(p.raw))
+	// Keep numbers as written so IDs and amounts aren't rounded
+	dec.UseNumber()
+	var patch interface{}
+	if err := dec.Decode(&patch); err != nil {
+		return NewUsageErrorf("--patch: invalid JSON: %v", err)
+	}
+	if dec.More() {
+		return NewUsageErrorf("--patch: expected a single JSON value")
+	}
+
+	switch patch := patch.(type) {
+	case map[string]interface{}:
+		mergePatch(body, patch)
+		return nil
+	case []interface{}:
+		return applyJSONPatch(body, patch)
+	default:
+		return NewUsageErrorf("--patch: expected a JSON object or JSON Patch array")
+	}
+}
+
+// mergePatch merges patch into target, recursing into objects present in
+// both. Nulls are kept so the API clears those fields.
+func mergePatch(target, patch map[string]interface{}) {
+	for k, v := range patch {
+		pv, ok := v.(map[string]interface{})
+		tv, tok := target[k].(map[string]interface{})
+		if ok && tok {
+			mergePatch(tv, pv)
+			continue
+		}
+		target[k] = v
+	}
+}
+
+// applyJSONPatch applies add, replace, and remove operations to body.
+func applyJSONPatch(body map[string]interface{}, ops []interface{}) error {
+	for i, raw := range ops {
+		op, _ := raw.(map[string]interface{})
+		name, _ := op["op"].(string)
+		path, _ := op["path"].(string)
+		if !strings.HasPrefix(path, "/") || path == "/" {
+			return NewUsageErrorf("--patch: operation %d: path must point at a field, e.g. /comments", i)
+		}
+
+		var value interface{}
+		switch name {
+		case "add", "replace":
+			v, ok := op["value"]
+			if !ok {
+				return NewUsageErrorf("--patch: operation %d: %s requires a value", i, name)
+			}
+			value = v
+		case "remove":
+			value = nil
+		default:
+			return NewUsageErrorf("--patch: operation %d: unsupported op %q (use add, replace, or remove)", i, name)
+		}
+
+		// Walk the pointer, creating objects along the way
+		parts := strings.Split(path[1:], "/")
+		target := body
+		for _, part := range parts[:len(parts)-1] {
+			part = unescapePointer(part)
+			next, ok := target[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				target[part] = next
+			}
+			target = next
+		}
+		target[unescapePointer(parts[len(parts)-1])] = value
+	}
+	return nil
+}
+
+// unescapePointer decodes a JSON Pointer reference token.
+func unescapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,78 @@
+// internal/cmd/patch_test.go
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPatchFlag_MergePatch(t *testing.T) {
+	body := map[string]interface{}{
+		"url": "https://example.com",
+		"geo": map[string]interface{}{"US": "https://example.com/us"},
+	}
+	p := patchFlag{raw: `{"comments": null, "geo": {"CA": "https://example.com/ca"}, "url": "https://example.com/new", "clicks": 12345678901234567}`}
+	if err := p.apply(body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"url":      "https://example.com/new",
+		"comments": nil,
+		"geo":      map[string]interface{}{"US": "https://example.com/us", "CA": "https://example.com/ca"},
+		"clicks":   json.Number("12345678901234567"),
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("expected %v, got %v", want, body)
+	}
+}
+
+func TestPatchFlag_JSONPatch(t *testing.T) {
+	body := map[string]interface{}{"comments": "old"}
+	p := patchFlag{raw: `[
+		{"op": "remove", "path": "/comments"},
+		{"op": "add", "path": "/geo/US", "value": "https://example.com/us"},
+		{"op": "replace", "path": "/a~1b", "value": true}
+	]`}
+	if err := p.apply(body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"comments": nil,
+		"geo":      map[string]interface{}{"US": "https://example.com/us"},
+		"a/b":      true,
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("expected %v, got %v", want, body)
+	}
+}
+
+func TestPatchFlag_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		`{"a": 1`,
+		`"comments"`,
+		`{} {}`,
+		`[{"op": "move", "from": "/a", "path": "/b"}]`,
+		`[{"op": "add", "path": "/a"}]`,
+		`[{"op": "remove", "path": "a"}]`,
+	} {
+		p := patchFlag{raw: raw}
+		if err := p.apply(map[string]interface{}{}); err == nil {
+			t.Errorf("expected error for %s", raw)
+		}
+	}
+}
+
+func TestLinksUpdateCmd_Patch(t *testing.T) {
+	body, err := runLinksUpdate(t, "--utm-source", "flag", "--patch", `{"utm_source": "patch", "comments": null}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{"utm_source": "patch", "comments": nil}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("expected body %v, got %v", want, body)
+	}
+}
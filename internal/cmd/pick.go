@@ -0,0 +1,103 @@
+// internal/cmd/pick.go
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// selectOptions shows a checklist and returns the indexes of the chosen
+// options. Replaced in tests.
+var selectOptions = func(cmd *cobra.Command, title string, options []string) ([]int, error) {
+	f, ok := cmd.InOrStdin().(*os.File)
+	if !ok {
+		return nil, errors.New("interactive selection requires a terminal")
+	}
+	return ui.MultiSelect(f, cmd.ErrOrStderr(), title, options)
+}
+
+// linkPicker holds the flags that let a command act on links chosen from a
+// checklist instead of by ID.
+type linkPicker struct {
+	interactive bool
+	search      string
+	domain      string
+	withTags    []string
+	inFolder    string
+}
+
+func (p *linkPicker) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&p.interactive, "interactive", "i", false, "Choose links from a checklist")
+	cmd.Flags().StringVar(&p.search, "search", "", "With --interactive, only list links matching this query")
+	cmd.Flags().StringVar(&p.domain, "domain", "", "With --interactive, only list links on this domain")
+	cmd.Flags().StringSliceVar(&p.withTags, "with-tag", nil, "With --interactive, only list links with this tag name (repeatable)")
+	cmd.Flags().StringVar(&p.inFolder, "in-folder", "", "With --interactive, only list links in this folder")
+	cmd.MarkFlagsMutuallyExclusive("id", "interactive")
+}
+
+// pick lists the links matching the picker's filters and returns those the
+// user selects, erroring when none are.
+func (p *linkPicker) pick(cmd *cobra.Command, client *api.Client, verb string) ([]map[string]interface{}, error) {
+	params := url.Values{}
+	if p.search != "" {
+		params.Set("search", p.search)
+	}
+	if p.domain != "" {
+		params.Set("domain", p.domain)
+	}
+	if len(p.withTags) > 0 {
+		tagIDs, err := resolveNamesToIDs(cmd.Context(), client, cache.KindTag, p.withTags)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("tagIds", strings.Join(tagIDs, ","))
+	}
+	if p.inFolder != "" {
+		folderIDs, err := resolveNamesToIDs(cmd.Context(), client, cache.KindFolder, []string{p.inFolder})
+		if err != nil {
+			return nil, err
+		}
+		params.Set("folderId", folderIDs[0])
+	}
+
+	links, err := fetchAllLinks[map[string]interface{}](cmd.Context(), client, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, errors.New("no links match the given filters")
+	}
+
+	options := make([]string, len(links))
+	for i, link := range links {
+		options[i] = fmt.Sprintf("%s  %s", linkLabel(link), ui.Dim(outfmt.Truncate(outfmt.SafeString(link["url"]), 50)))
+	}
+	chosen, err := selectOptions(cmd, fmt.Sprintf("Select links to %s", verb), options)
+	if err != nil {
+		return nil, err
+	}
+	if len(chosen) == 0 {
+		return nil, errors.New("no links selected")
+	}
+
+	picked := make([]map[string]interface{}, len(chosen))
+	for i, idx := range chosen {
+		picked[i] = links[idx]
+	}
+	return picked, nil
+}
+
+// linkLabel returns a link's short link for display.
+func linkLabel(link map[string]interface{}) string {
+	return buildShortLink(outfmt.SafeString(link["domain"]), outfmt.SafeString(link["key"]))
+}
@@ -0,0 +1,183 @@
+// internal/cmd/plan.go
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+const (
+	planVersion     = 1
+	planDeleteLinks = "delete-links"
+)
+
+// changePlan records a destructive change for review, so that apply-plan
+// executes exactly the reviewed set instead of re-evaluating a filter.
+type changePlan struct {
+	Version   int                      `json:"version"`
+	Action    string                   `json:"action"`
+	Workspace string                   `json:"workspace,omitempty"`
+	CreatedAt time.Time                `json:"createdAt"`
+	Links     []map[string]interface{} `json:"links"`
+}
+
+func newApplyPlanCmd() *cobra.Command {
+	var (
+		dryRun      bool
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply-plan <plan.json>",
+		Short: "Execute a reviewed plan file",
+		Long: `Execute a plan written by dub links bulk delete --plan-out. Exactly the
+links in the plan are changed, whatever a filter would match today.
+
+Each link is checked first: if one was deleted or its domain, key, or
+destination changed since the plan was written, nothing is applied (pass
+--force to apply anyway). Deleted links can be restored with dub undo.
+
+Requires --yes (or --dry-run).
+
+Examples:
+  dub links bulk delete --plan-out plan.json < ids.json
+  dub apply-plan plan.json --dry-run
+  dub apply-plan plan.json --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !dryRun && !outfmt.GetYes(cmd.Context()) {
+				return NewUsageErrorf("applying a plan requires --yes (or --dry-run)")
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			plan, err := loadPlan(args[0])
+			if err != nil {
+				return err
+			}
+			if workspace, _ := activeWorkspaceName(cmd.Context()); plan.Workspace != "" && workspace != "" && workspace != plan.Workspace {
+				return fmt.Errorf("plan was written for workspace %q, but %q is active", plan.Workspace, workspace)
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			// Protection is checked, and undo snapshots taken, on the links
+			// as they are now rather than as they were planned
+			current, err := currentPlanLinks(cmd, client, plan, concurrency)
+			if err != nil {
+				return err
+			}
+			return deleteLinks(cmd, client, current, concurrency, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what the plan would change without changing anything")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	return cmd
+}
+
+// writeDeletePlan snapshots the links with ids and writes a plan deleting
+// them to path.
+func writeDeletePlan(cmd *cobra.Command, client *api.Client, ids []string, concurrency int, path string) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("no links to plan; pass \"linkIds\" or \"links\"")
+	}
+	links, err := snapshotLinks(cmd.Context(), client, ids, concurrency)
+	if err != nil {
+		return fmt.Errorf("failed to look up links:\n%w", err)
+	}
+
+	workspace, _ := activeWorkspaceName(cmd.Context())
+	plan := changePlan{
+		Version:   planVersion,
+		Action:    planDeleteLinks,
+		Workspace: workspace,
+		CreatedAt: time.Now().UTC(),
+		Links:     links,
+	}
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Plans hold full link objects, passwords included
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	for _, link := range links {
+		_, _ = fmt.Fprintf(w, "Would delete %s (%s)\n", linkLabel(link), outfmt.SafeString(link["id"]))
+	}
+	_, _ = fmt.Fprintf(w, "Wrote plan to delete %d link(s) to %s. Review it, then run: dub apply-plan %s --yes\n", len(links), path, path)
+	return nil
+}
+
+func loadPlan(path string) (*changePlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	var plan changePlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("invalid plan %s: %w", path, err)
+	}
+	if plan.Version != planVersion {
+		return nil, fmt.Errorf("unsupported plan version %d (expected %d)", plan.Version, planVersion)
+	}
+	if plan.Action != planDeleteLinks {
+		return nil, fmt.Errorf("unsupported plan action %q", plan.Action)
+	}
+	for i, link := range plan.Links {
+		if outfmt.SafeString(link["id"]) == "" {
+			return nil, fmt.Errorf("invalid plan %s: links[%d] has no id", path, i)
+		}
+	}
+	return &plan, nil
+}
+
+// currentPlanLinks fetches the links of plan as they are now. It refuses
+// when any of them is gone or was repointed since the plan was written,
+// unless --force was given, in which case links that can't be fetched are
+// taken as planned.
+func currentPlanLinks(cmd *cobra.Command, client *api.Client, plan *changePlan, concurrency int) ([]map[string]interface{}, error) {
+	ids := make([]string, len(plan.Links))
+	for i, link := range plan.Links {
+		ids[i] = outfmt.SafeString(link["id"])
+	}
+	current, err := snapshotLinks(cmd.Context(), client, ids, concurrency)
+	if GetForce(cmd.Context()) {
+		if err != nil {
+			return plan.Links, nil
+		}
+		return current, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("plan is out of date; links changed since it was written:\n%w", err)
+	}
+
+	var drifted []string
+	for i, link := range plan.Links {
+		for _, field := range []string{"domain", "key", "url"} {
+			if outfmt.SafeString(link[field]) != outfmt.SafeString(current[i][field]) {
+				drifted = append(drifted, fmt.Sprintf("%s: %s changed", linkLabel(link), field))
+				break
+			}
+		}
+	}
+	if len(drifted) > 0 {
+		return nil, fmt.Errorf("plan is out of date; links changed since it was written (pass --force to apply anyway):\n%s", strings.Join(drifted, "\n"))
+	}
+	return current, nil
+}
@@ -0,0 +1,143 @@
+// internal/cmd/plan_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newPlanServer serves link_a and link_b and records DELETE paths. Links
+// whose destination is set in urls are served with that destination, and
+// locked links with the protected tag.
+func newPlanServer(t *testing.T, urls map[string]string, locked ...string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var deletes []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/links/")
+		switch {
+		case r.Method == http.MethodGet && (id == "link_a" || id == "link_b"):
+			dest := urls[id]
+			if dest == "" {
+				dest = "https://example.com/" + id
+			}
+			tags := `[]`
+			if slices.Contains(locked, id) {
+				tags = `[{"id": "tag_p", "name": "protected"}]`
+			}
+			_, _ = w.Write([]byte(`{"id": "` + id + `", "domain": "dub.sh", "key": "` + id + `", "url": "` + dest + `", "tags": ` + tags + `}`))
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	return server, &deletes
+}
+
+func writeTestPlan(t *testing.T, serverURL string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plan.json")
+	var stdout bytes.Buffer
+	err := execute(context.Background(),
+		[]string{"--api-url", serverURL, "links", "bulk", "delete", "--plan-out", path},
+		strings.NewReader(`{"linkIds": ["link_a", "link_b"]}`), &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error writing plan: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Wrote plan to delete 2 link(s)") {
+		t.Errorf("unexpected output: %q", stdout.String())
+	}
+	return path
+}
+
+func TestApplyPlan(t *testing.T) {
+	server, deletes := newPlanServer(t, nil)
+	path := writeTestPlan(t, server.URL)
+	if len(*deletes) != 0 {
+		t.Fatalf("--plan-out should not delete, got %v", *deletes)
+	}
+
+	plan, err := loadPlan(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan.Action != planDeleteLinks || len(plan.Links) != 2 {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+
+	err = execute(context.Background(), []string{"--api-url", server.URL, "apply-plan", path}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "requires --yes") {
+		t.Fatalf("expected --yes error, got %v", err)
+	}
+
+	var stdout bytes.Buffer
+	err = execute(context.Background(), []string{"--api-url", server.URL, "--yes", "apply-plan", path}, nil, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(*deletes)
+	if want := []string{"/links/link_a", "/links/link_b"}; !reflect.DeepEqual(*deletes, want) {
+		t.Errorf("expected %v deleted, got %v", want, *deletes)
+	}
+}
+
+func TestApplyPlan_RefusesDrift(t *testing.T) {
+	server, _ := newPlanServer(t, nil)
+	path := writeTestPlan(t, server.URL)
+
+	// link_b was repointed after review
+	drifted, deletes := newPlanServer(t, map[string]string{"link_b": "https://example.com/other"})
+	err := execute(context.Background(), []string{"--api-url", drifted.URL, "--yes", "apply-plan", path}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "dub.sh/link_b: url changed") {
+		t.Fatalf("expected drift error, got %v", err)
+	}
+	if len(*deletes) != 0 {
+		t.Errorf("expected no deletes, got %v", *deletes)
+	}
+}
+
+func TestApplyPlan_ChecksCurrentProtection(t *testing.T) {
+	server, _ := newPlanServer(t, nil)
+	path := writeTestPlan(t, server.URL)
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the plan to be private, got %v (err %v)", info.Mode().Perm(), err)
+	}
+
+	// link_b was locked after review
+	locked, deletes := newPlanServer(t, nil, "link_b")
+	err := execute(context.Background(), []string{"--api-url", locked.URL, "--yes", "apply-plan", path}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "refusing to delete protected link(s): dub.sh/link_b") {
+		t.Fatalf("expected a protection error, got %v", err)
+	}
+	if len(*deletes) != 0 {
+		t.Errorf("expected no deletes, got %v", *deletes)
+	}
+}
+
+func TestLoadPlan_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"version": `{"version": 2, "action": "delete-links"}`,
+		"action":  `{"version": 1, "action": "archive-links"}`,
+		"id":      `{"version": 1, "action": "delete-links", "links": [{"key": "x"}]}`,
+	} {
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadPlan(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
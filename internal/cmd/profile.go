@@ -0,0 +1,161 @@
+// internal/cmd/profile.go
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"slices"
+	"strings"
+
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+const profilerKey contextKey = "profiler"
+
+// profileKinds are the profiles --profile can capture.
+var profileKinds = []string{"cpu", "heap", "allocs", "goroutine", "block", "mutex", "trace"}
+
+// profiler captures the profiles requested with --profile while a command
+// runs.
+type profiler struct {
+	paths map[string]string
+	cpu   *os.File
+	trace *os.File
+}
+
+// parseProfileSpec parses --profile, e.g. cpu=cpu.out,heap=heap.out.
+func parseProfileSpec(spec string) (map[string]string, error) {
+	paths := map[string]string{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kind, path, ok := strings.Cut(part, "=")
+		if !ok || path == "" {
+			return nil, fmt.Errorf("expected kind=file, got %q", part)
+		}
+		if !slices.Contains(profileKinds, kind) {
+			return nil, fmt.Errorf("unknown profile %q (available: %s)", kind, strings.Join(profileKinds, ", "))
+		}
+		paths[kind] = path
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("no profiles given")
+	}
+	return paths, nil
+}
+
+// startProfiling begins capturing the profiles in spec.
+func startProfiling(spec string) (*profiler, error) {
+	paths, err := parseProfileSpec(spec)
+	if err != nil {
+		return nil, NewUsageErrorf("--profile: %v", err)
+	}
+	p := &profiler{paths: paths}
+
+	if path, ok := paths["cpu"]; ok {
+		if p.cpu, err = os.Create(path); err != nil {
+			return nil, fmt.Errorf("--profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(p.cpu); err != nil {
+			_ = p.cpu.Close()
+			return nil, fmt.Errorf("--profile: %w", err)
+		}
+	}
+	if path, ok := paths["trace"]; ok {
+		if p.trace, err = os.Create(path); err != nil {
+			p.stopCPU()
+			return nil, fmt.Errorf("--profile: %w", err)
+		}
+		if err := trace.Start(p.trace); err != nil {
+			p.stopCPU()
+			_ = p.trace.Close()
+			return nil, fmt.Errorf("--profile: %w", err)
+		}
+	}
+	if _, ok := paths["block"]; ok {
+		runtime.SetBlockProfileRate(1)
+	}
+	if _, ok := paths["mutex"]; ok {
+		runtime.SetMutexProfileFraction(1)
+	}
+	return p, nil
+}
+
+func (p *profiler) stopCPU() {
+	if p.cpu != nil {
+		pprof.StopCPUProfile()
+		_ = p.cpu.Close()
+		p.cpu = nil
+	}
+}
+
+// stop ends capturing and writes every profile, reporting each file to w.
+func (p *profiler) stop(w io.Writer) error {
+	var errs []error
+	if p.cpu != nil {
+		p.stopCPU()
+	}
+	if p.trace != nil {
+		trace.Stop()
+		if err := p.trace.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, kind := range profileKinds {
+		path, ok := p.paths[kind]
+		if !ok || kind == "cpu" || kind == "trace" {
+			continue
+		}
+		if kind == "heap" || kind == "allocs" {
+			// Collect garbage first so the profile reflects live memory
+			runtime.GC()
+		}
+		if err := writeProfile(kind, path); err != nil {
+			errs = append(errs, fmt.Errorf("%s profile: %w", kind, err))
+			delete(p.paths, kind)
+		}
+	}
+
+	for _, kind := range profileKinds {
+		if path, ok := p.paths[kind]; ok {
+			_, _ = fmt.Fprintf(w, "Wrote %s profile to %s\n", kind, path)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func writeProfile(kind, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := pprof.Lookup(kind).WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// stopProfiling writes the profiles started for the command that ran with
+// ctx, if any. Failures are warnings since the command itself has finished.
+func stopProfiling(ctx context.Context, w io.Writer) {
+	if ctx == nil {
+		return
+	}
+	p, ok := ctx.Value(profilerKey).(*profiler)
+	if !ok {
+		return
+	}
+	if err := p.stop(w); err != nil {
+		_, _ = fmt.Fprintln(w, ui.Warning(fmt.Sprintf("could not write profiles: %v", err)))
+	}
+}
@@ -0,0 +1,51 @@
+// internal/cmd/profile_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseProfileSpec(t *testing.T) {
+	paths, err := parseProfileSpec("cpu=cpu.out, heap=heap.out")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if paths["cpu"] != "cpu.out" || paths["heap"] != "heap.out" || len(paths) != 2 {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+
+	for _, spec := range []string{"", "cpu", "cpu=", "disk=disk.out"} {
+		if _, err := parseProfileSpec(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestProfileFlag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	dir := t.TempDir()
+	cpu := filepath.Join(dir, "cpu.out")
+	heap := filepath.Join(dir, "heap.out")
+
+	var stdout, stderr bytes.Buffer
+	err := execute(context.Background(), []string{"--profile", "cpu=" + cpu + ",heap=" + heap, "version"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{cpu, heap} {
+		info, err := os.Stat(path)
+		if err != nil || info.Size() == 0 {
+			t.Errorf("expected profile at %s, got %v", path, err)
+		}
+	}
+	if !strings.Contains(stderr.String(), "Wrote cpu profile to "+cpu) {
+		t.Errorf("expected profile report on stderr, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,76 @@
+// internal/cmd/project.go
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// projectDefaults are the settings a .dub.toml applied to this command.
+type projectDefaults struct {
+	DefaultDomain string
+	DefaultTags   []string
+	// Overrides describes user config values the project file replaced,
+	// e.g. `workspace "acme" (was "personal")`
+	Overrides []string
+	Path      string
+}
+
+// findProject returns the nearest .dub.toml above the working directory,
+// or nil if there is none.
+func findProject() (*config.Project, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, nil
+	}
+	return config.FindProject(dir)
+}
+
+// applyProjectConfig applies project settings on top of the user config and
+// the current context. Flags and environment variables still win, including
+// a context chosen with --context or DUB_CONTEXT, whose workspace and default
+// domain the project file doesn't replace.
+func applyProjectConfig(cmd *cobra.Command, flags *rootFlags, project *config.Project, defaultDomain string) projectDefaults {
+	defaults := projectDefaults{DefaultDomain: defaultDomain}
+	if project == nil {
+		return defaults
+	}
+	defaults.Path = project.Path
+	defaults.DefaultTags = project.DefaultTags
+
+	persistent := cmd.Root().PersistentFlags()
+	explicitContext := flagHasValue(persistent, "context") || os.Getenv("DUB_CONTEXT") != ""
+	if project.Workspace != "" && !explicitContext && !flagHasValue(persistent, "workspace") && os.Getenv("DUB_WORKSPACE") == "" {
+		previous := flags.Workspace
+		if previous == "" {
+			previous, _ = config.GetDefaultWorkspace()
+		}
+		if previous != "" && previous != project.Workspace {
+			defaults.Overrides = append(defaults.Overrides, fmt.Sprintf("workspace %q (was %q)", project.Workspace, previous))
+		}
+		flags.Workspace = project.Workspace
+	}
+	if project.DefaultDomain != "" && !explicitContext {
+		if defaultDomain != "" && defaultDomain != project.DefaultDomain {
+			defaults.Overrides = append(defaults.Overrides, fmt.Sprintf("default domain %q (was %q)", project.DefaultDomain, defaultDomain))
+		}
+		defaults.DefaultDomain = project.DefaultDomain
+	}
+
+	return defaults
+}
+
+// overrideNotice is the warning shown when a project file replaces user
+// config values, or "" when it didn't.
+func (d projectDefaults) overrideNotice() string {
+	if len(d.Overrides) == 0 {
+		return ""
+	}
+	return ui.Warning(fmt.Sprintf("%s overrides your config: %s", d.Path, strings.Join(d.Overrides, ", ")))
+}
@@ -0,0 +1,170 @@
+// internal/cmd/protect.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// protectedTag marks links that commands refuse to delete or change without
+// --force.
+const protectedTag = "protected"
+
+func newLinksLockCmd() *cobra.Command {
+	return newLinksProtectCmd(true)
+}
+
+func newLinksUnlockCmd() *cobra.Command {
+	return newLinksProtectCmd(false)
+}
+
+// newLinksProtectCmd builds links lock, or links unlock when lock is false.
+func newLinksProtectCmd(lock bool) *cobra.Command {
+	var (
+		ids         []string
+		concurrency int
+		picker      linkPicker
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Protect links from deletion and changes",
+		Long: `Protect business-critical links by tagging them "` + protectedTag + `". Protected links
+are refused by links delete, update, and bulk operations, and skipped by
+cleanup, dedupe --archive, and links expire, unless --force is given (--yes
+alone is not enough). The tag is created if it doesn't exist.
+
+Examples:
+  dub links lock --id link_abc
+  dub links lock --interactive --domain go.acme.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(ids) == 0 && !picker.interactive {
+				return NewUsageErrorf("--id or --interactive is required")
+			}
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			tagID, err := protectedTagID(cmd.Context(), client, lock)
+			if err != nil {
+				return err
+			}
+
+			verb := "Locked"
+			if !lock {
+				verb = "Unlocked"
+			}
+			links, err := picker.linksByIDOrPick(cmd, client, ids, strings.ToLower(verb[:len(verb)-2]))
+			if err != nil {
+				return err
+			}
+			return updateLinks(cmd, client, links, concurrency, verb, func(link map[string]interface{}) map[string]interface{} {
+				return map[string]interface{}{"tagIds": mergeTagIDs(link, []string{tagID}, !lock)}
+			})
+		},
+	}
+	if !lock {
+		cmd.Use = "unlock"
+		cmd.Short = "Remove protection from links"
+		cmd.Long = `Remove the "` + protectedTag + `" tag from links so they can be deleted and changed
+again.
+
+Examples:
+  dub links unlock --id link_abc
+  dub links unlock --interactive --with-tag ` + protectedTag
+	}
+
+	cmd.Flags().StringSliceVar(&ids, "id", nil, "Link ID (repeatable)")
+	addConcurrencyFlag(cmd, &concurrency)
+	picker.register(cmd)
+
+	return cmd
+}
+
+// protectedTagID returns the ID of the protected tag, creating the tag when
+// create is set and it doesn't exist yet.
+func protectedTagID(ctx context.Context, client *api.Client, create bool) (string, error) {
+	ids, err := resolveNamesToIDs(ctx, client, cache.KindTag, []string{protectedTag})
+	if err == nil {
+		return ids[0], nil
+	}
+	if !create || !strings.Contains(err.Error(), "not found") {
+		return "", err
+	}
+
+	var tag map[string]interface{}
+	if err := postJSON(ctx, client, "/tags", map[string]interface{}{"name": protectedTag, "color": "red"}, &tag); err != nil {
+		return "", fmt.Errorf("failed to create tag %q: %w", protectedTag, err)
+	}
+	invalidateNameCache(client, cache.KindTag)
+	return outfmt.SafeString(tag["id"]), nil
+}
+
+// isProtected reports whether link carries the protected tag.
+func isProtected(link map[string]interface{}) bool {
+	tags, _ := link["tags"].([]interface{})
+	for _, tag := range tags {
+		if t, ok := tag.(map[string]interface{}); ok && strings.EqualFold(outfmt.SafeString(t["name"]), protectedTag) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkProtected refuses to verb links when any of them is protected,
+// unless --force was given.
+func checkProtected(ctx context.Context, links []map[string]interface{}, verb string) error {
+	if GetForce(ctx) {
+		return nil
+	}
+	var labels []string
+	for _, link := range links {
+		if isProtected(link) {
+			labels = append(labels, linkLabel(link))
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return fmt.Errorf("refusing to %s protected link(s): %s (run dub links unlock, or pass --force)", verb, strings.Join(labels, ", "))
+}
+
+// checkProtectedIDs fetches the links with ids and refuses to verb them when
+// any is protected, unless --force was given.
+func checkProtectedIDs(ctx context.Context, client *api.Client, ids []string, verb string, concurrency int) error {
+	if GetForce(ctx) || len(ids) == 0 {
+		return nil
+	}
+	links, err := snapshotLinks(ctx, client, ids, concurrency)
+	if err != nil {
+		return fmt.Errorf("failed to check link protection:\n%w", err)
+	}
+	return checkProtected(ctx, links, verb)
+}
+
+// withoutProtected drops protected links from automatically chosen ones,
+// unless --force was given, and returns how many were dropped.
+func withoutProtected[T any](ctx context.Context, links []T, protected func(T) bool) ([]T, int) {
+	if GetForce(ctx) {
+		return links, 0
+	}
+	kept := links[:0:0]
+	for _, link := range links {
+		if !protected(link) {
+			kept = append(kept, link)
+		}
+	}
+	return kept, len(links) - len(kept)
+}
@@ -0,0 +1,174 @@
+// internal/cmd/protect_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newProtectServer serves a protected link_p and an unprotected link_u,
+// recording PATCH bodies and DELETE paths. The protected tag exists only
+// when tagExists is set; POST /tags creates it as tag_new.
+func newProtectServer(t *testing.T, tagExists bool) (*httptest.Server, map[string]map[string]interface{}, *[]string) {
+	t.Helper()
+	patches := map[string]map[string]interface{}{}
+	var deletes []string
+	links := map[string]string{
+		"link_p": `{"id": "link_p", "domain": "dub.sh", "key": "pricing", "tags": [{"id": "tag_prot", "name": "Protected"}]}`,
+		"link_u": `{"id": "link_u", "domain": "dub.sh", "key": "promo", "tags": []}`,
+	}
+
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/tags" && r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"id": "tag_new", "name": "protected"}`))
+		case r.URL.Path == "/tags":
+			if tagExists {
+				_, _ = w.Write([]byte(`[{"id": "tag_prot", "name": "protected"}]`))
+			} else {
+				_, _ = w.Write([]byte(`[]`))
+			}
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(links[strings.TrimPrefix(r.URL.Path, "/links/")]))
+		case r.Method == http.MethodPatch:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			patches[strings.TrimPrefix(r.URL.Path, "/links/")] = body
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+	return server, patches, &deletes
+}
+
+func TestLinksLock_CreatesTag(t *testing.T) {
+	server, patches, _ := newProtectServer(t, false)
+
+	var stdout bytes.Buffer
+	err := execute(context.Background(), []string{"--api-url", server.URL, "links", "lock", "--id", "link_u"}, nil, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{"tagIds": []interface{}{"tag_new"}}
+	if !reflect.DeepEqual(patches["link_u"], want) {
+		t.Errorf("expected %v, got %v", want, patches["link_u"])
+	}
+	if !strings.Contains(stdout.String(), "Locked dub.sh/promo") {
+		t.Errorf("unexpected output: %q", stdout.String())
+	}
+}
+
+func TestLinksUnlock_RemovesTag(t *testing.T) {
+	server, patches, _ := newProtectServer(t, true)
+
+	err := execute(context.Background(), []string{"--api-url", server.URL, "links", "unlock", "--id", "link_p"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]interface{}{"tagIds": []interface{}{}}
+	if !reflect.DeepEqual(patches["link_p"], want) {
+		t.Errorf("expected %v, got %v", want, patches["link_p"])
+	}
+}
+
+func TestLinksUnlock_MissingTag(t *testing.T) {
+	server, _, _ := newProtectServer(t, false)
+
+	err := execute(context.Background(), []string{"--api-url", server.URL, "links", "unlock", "--id", "link_p"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected tag not found error, got %v", err)
+	}
+}
+
+func TestLinksDelete_RefusesProtected(t *testing.T) {
+	server, _, deletes := newProtectServer(t, true)
+
+	// --yes skips prompts but does not override protection
+	err := execute(context.Background(), []string{"--api-url", server.URL, "--yes", "links", "delete", "--id", "link_p"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "refusing to delete protected link(s): dub.sh/pricing") {
+		t.Fatalf("expected protection error, got %v", err)
+	}
+	if len(*deletes) != 0 {
+		t.Fatalf("expected no deletes, got %v", *deletes)
+	}
+
+	err = execute(context.Background(), []string{"--api-url", server.URL, "--force", "links", "delete", "--id", "link_p"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*deletes, []string{"/links/link_p"}) {
+		t.Errorf("expected link_p deleted, got %v", *deletes)
+	}
+}
+
+func TestLinksDelete_ForceFalseKeepsProtection(t *testing.T) {
+	server, _, deletes := newProtectServer(t, true)
+
+	err := execute(context.Background(), []string{"--api-url", server.URL, "--yes", "--force=false", "links", "delete", "--id", "link_p"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "refusing to delete protected link(s)") {
+		t.Fatalf("expected protection error, got %v", err)
+	}
+	if len(*deletes) != 0 {
+		t.Errorf("expected no deletes, got %v", *deletes)
+	}
+}
+
+func TestLinksDelete_RefusesUncheckedLink(t *testing.T) {
+	server, _, deletes := newProtectServer(t, true)
+
+	// link_x can't be fetched, so its protection is unknown
+	err := execute(context.Background(), []string{"--api-url", server.URL, "links", "delete", "--id", "link_x"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "failed to check link protection") {
+		t.Fatalf("expected protection check error, got %v", err)
+	}
+	if len(*deletes) != 0 {
+		t.Fatalf("expected no deletes, got %v", *deletes)
+	}
+
+	err = execute(context.Background(), []string{"--api-url", server.URL, "--force", "links", "delete", "--id", "link_x"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*deletes, []string{"/links/link_x"}) {
+		t.Errorf("expected link_x deleted, got %v", *deletes)
+	}
+}
+
+func TestLinksUpdate_RefusesProtected(t *testing.T) {
+	server, patches, _ := newProtectServer(t, true)
+
+	err := execute(context.Background(), []string{"--api-url", server.URL, "links", "update", "--id", "link_p", "--url", "https://example.com"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "refusing to update") {
+		t.Fatalf("expected protection error, got %v", err)
+	}
+	if len(patches) != 0 {
+		t.Errorf("expected no updates, got %v", patches)
+	}
+}
+
+func TestWithoutProtected(t *testing.T) {
+	links := []linkRecord{{ID: "link_u"}, {ID: "link_p"}}
+	links[1].Tags = append(links[1].Tags, struct {
+		Name string `json:"name"`
+	}{Name: "PROTECTED"})
+
+	kept, skipped := withoutProtected(context.Background(), links, linkRecord.protected)
+	if len(kept) != 1 || kept[0].ID != "link_u" || skipped != 1 {
+		t.Errorf("expected only link_u kept, got %v (%d skipped)", kept, skipped)
+	}
+
+	forced := context.WithValue(context.Background(), forceKey, true)
+	if kept, skipped := withoutProtected(forced, links, linkRecord.protected); len(kept) != 2 || skipped != 0 {
+		t.Errorf("expected --force to keep all links, got %v", kept)
+	}
+}
@@ -0,0 +1,80 @@
+// internal/cmd/quota.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// quotaWarnRatio is the share of the link quota past which creating links
+// prints a warning.
+const quotaWarnRatio = 0.9
+
+// workspaceUsage is the part of a workspace describing its plan limits.
+type workspaceUsage struct {
+	Slug       string `json:"slug"`
+	Plan       string `json:"plan"`
+	LinksUsage int    `json:"linksUsage"`
+	LinksLimit int    `json:"linksLimit"`
+}
+
+// fetchWorkspaceUsage returns the usage of the active workspace, or of the
+// only workspace the API key can see.
+func fetchWorkspaceUsage(ctx context.Context, client *api.Client) (*workspaceUsage, error) {
+	var workspaces []workspaceUsage
+	if err := getJSON(ctx, client, "/workspaces", &workspaces); err != nil {
+		return nil, err
+	}
+	if name, _ := activeWorkspaceName(ctx); name != "" {
+		for i := range workspaces {
+			if strings.EqualFold(workspaces[i].Slug, name) {
+				return &workspaces[i], nil
+			}
+		}
+	}
+	if len(workspaces) == 1 {
+		return &workspaces[0], nil
+	}
+	return nil, fmt.Errorf("could not tell which of %d workspaces is in use", len(workspaces))
+}
+
+// checkLinkQuota refuses to create n links when the workspace has fewer than
+// n left on its plan, unless --force was given, so a large operation doesn't
+// fail partway through. If usage can't be read, it only warns.
+func checkLinkQuota(cmd *cobra.Command, client *api.Client, n int) error {
+	if n == 0 {
+		return nil
+	}
+	w := cmd.ErrOrStderr()
+
+	usage, err := fetchWorkspaceUsage(cmd.Context(), client)
+	if err != nil {
+		_, _ = fmt.Fprintln(w, ui.Warning(fmt.Sprintf("could not check the workspace's link quota: %v", err)))
+		return nil
+	}
+	if usage.LinksLimit <= 0 {
+		return nil
+	}
+
+	remaining := max(usage.LinksLimit-usage.LinksUsage, 0)
+	if n > remaining {
+		msg := fmt.Sprintf("creating %d link(s) would exceed the workspace's link quota: %d of %d used on the %s plan, %d left",
+			n, usage.LinksUsage, usage.LinksLimit, usage.Plan, remaining)
+		if !GetForce(cmd.Context()) {
+			return fmt.Errorf("%s (pass --force to try anyway)", msg)
+		}
+		_, _ = fmt.Fprintln(w, ui.Warning(msg+"; continuing because of --force"))
+		return nil
+	}
+	if float64(usage.LinksUsage+n) >= quotaWarnRatio*float64(usage.LinksLimit) {
+		_, _ = fmt.Fprintln(w, ui.Warning(fmt.Sprintf("this brings the workspace to %d of %d links on the %s plan",
+			usage.LinksUsage+n, usage.LinksLimit, usage.Plan)))
+	}
+	return nil
+}
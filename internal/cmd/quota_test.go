@@ -0,0 +1,77 @@
+// internal/cmd/quota_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// runQuotaImport imports importTestCSV into a workspace with linksUsage of
+// its 100 links used, returning stderr, the links created, and the error.
+func runQuotaImport(t *testing.T, linksUsage string, force bool) (string, int32, error) {
+	t.Helper()
+	var created int32
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/workspaces" {
+			_, _ = w.Write([]byte(`[{"slug": "acme", "plan": "free", "linksUsage": ` + linksUsage + `, "linksLimit": 100}]`))
+			return
+		}
+		atomic.AddInt32(&created, 1)
+		_, _ = w.Write([]byte(`{"id": "link_1"}`))
+	})
+
+	csvPath := filepath.Join(t.TempDir(), "links.csv")
+	if err := os.WriteFile(csvPath, []byte(importTestCSV), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := newLinksImportCmd()
+	ctx := context.WithValue(context.Background(), apiURLKey, server.URL)
+	cmd.SetContext(context.WithValue(ctx, forceKey, force))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{csvPath})
+	err := cmd.Execute()
+
+	if _, statErr := os.Stat(csvPath + ".checkpoint"); err != nil && statErr == nil {
+		t.Error("expected no checkpoint to be left after the quota check failed")
+	}
+	return stderr.String(), created, err
+}
+
+func TestCheckLinkQuota_Aborts(t *testing.T) {
+	_, created, err := runQuotaImport(t, "98", false)
+	if err == nil || !strings.Contains(err.Error(), "98 of 100 used on the free plan, 2 left") {
+		t.Fatalf("expected quota error, got %v", err)
+	}
+	if created != 0 {
+		t.Errorf("expected no links created, got %d", created)
+	}
+}
+
+func TestCheckLinkQuota_Force(t *testing.T) {
+	stderr, created, err := runQuotaImport(t, "98", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created != 3 || !strings.Contains(stderr, "continuing because of --force") {
+		t.Errorf("expected 3 links created with a warning, got %d and %q", created, stderr)
+	}
+}
+
+func TestCheckLinkQuota_WarnsNearLimit(t *testing.T) {
+	stderr, created, err := runQuotaImport(t, "90", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created != 3 || !strings.Contains(stderr, "93 of 100 links") {
+		t.Errorf("expected 3 links created with a warning, got %d and %q", created, stderr)
+	}
+}
@@ -0,0 +1,355 @@
+// internal/cmd/report.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// reportData is everything rendered into a report.
+type reportData struct {
+	Workspace  string
+	Interval   string
+	Generated  time.Time
+	Clicks     int
+	Leads      int
+	Sales      int
+	SaleAmount float64
+	TopLinks   []reportRow
+	Countries  []reportRow
+	Trend      []reportPoint
+}
+
+// reportRow is a labelled click count in a ranked table.
+type reportRow struct {
+	Label  string
+	Detail string
+	Clicks int
+}
+
+// reportPoint is one bucket of the click trend.
+type reportPoint struct {
+	Start  time.Time
+	Clicks int
+}
+
+func newReportCmd() *cobra.Command {
+	var (
+		interval string
+		html     bool
+		top      int
+		rawCodes bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate a shareable analytics report",
+		Long: `Generate a Markdown (or HTML) summary of workspace analytics.
+
+The report includes totals, top links, a country breakdown, and a click trend
+chart, ready to paste into stakeholder updates.
+
+Examples:
+  dub report --interval 30d --out report.md
+  dub report --interval 90d --html --out report.html`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if top < 1 {
+				return NewUsageErrorf("--top must be at least 1")
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			data, err := fetchReportData(cmd.Context(), client, interval, top, rawCodes)
+			if err != nil {
+				return err
+			}
+			data.Workspace = GetWorkspace(cmd.Context())
+
+			var rendered string
+			if html {
+				rendered, err = renderReportHTML(data)
+				if err != nil {
+					return err
+				}
+			} else {
+				rendered = renderReportMarkdown(data)
+			}
+
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), rendered)
+			if path := outFilePath(cmd.Context()); path != "" {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Report written to %s\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&interval, "interval", "30d", "Time interval: 24h, 7d, 30d, 90d, 1y, all")
+	cmd.Flags().BoolVar(&html, "html", false, "Render HTML instead of Markdown")
+	cmd.Flags().IntVar(&top, "top", 10, "Number of top links and countries to include")
+	cmd.Flags().BoolVar(&rawCodes, "raw-codes", false, "Show country codes instead of flags and names")
+
+	return cmd
+}
+
+// fetchReportData queries the analytics endpoints a report needs concurrently.
+func fetchReportData(ctx context.Context, client *api.Client, interval string, top int, rawCodes bool) (reportData, error) {
+	data := reportData{Interval: interval, Generated: time.Now().UTC()}
+
+	var (
+		totals     map[string]interface{}
+		topLinks   []map[string]interface{}
+		countries  []map[string]interface{}
+		timeseries []map[string]interface{}
+	)
+	queries := []struct {
+		groupBy string
+		dest    interface{}
+	}{
+		{"count", &totals},
+		{"top_links", &topLinks},
+		{"countries", &countries},
+		{"timeseries", &timeseries},
+	}
+
+	errs := batch.Run(ctx, len(queries), batch.DefaultConcurrency, func(ctx context.Context, i int) error {
+		params := url.Values{}
+		params.Set("event", "composite")
+		params.Set("groupBy", queries[i].groupBy)
+		params.Set("interval", interval)
+		return getJSON(ctx, client, "/analytics?"+params.Encode(), queries[i].dest)
+	})
+	for i, err := range errs {
+		if err != nil {
+			return data, fmt.Errorf("failed to fetch %s analytics: %w", queries[i].groupBy, err)
+		}
+	}
+
+	data.Clicks = outfmt.SafeInt(totals["clicks"])
+	data.Leads = outfmt.SafeInt(totals["leads"])
+	data.Sales = outfmt.SafeInt(totals["sales"])
+	data.SaleAmount = outfmt.SafeFloat(totals["saleAmount"])
+
+	for i, link := range topLinks {
+		if i >= top {
+			break
+		}
+		data.TopLinks = append(data.TopLinks, reportRow{
+			Label:  outfmt.SafeString(link["shortLink"]),
+			Detail: outfmt.SafeString(link["url"]),
+			Clicks: outfmt.SafeInt(link["clicks"]),
+		})
+	}
+
+	for i, c := range countries {
+		if i >= top {
+			break
+		}
+		label := outfmt.SafeString(c["country"])
+		if !rawCodes {
+			label = outfmt.FormatCountry(label)
+		}
+		data.Countries = append(data.Countries, reportRow{Label: label, Clicks: outfmt.SafeInt(c["clicks"])})
+	}
+
+	for _, p := range timeseries {
+		start, err := time.Parse(time.RFC3339, outfmt.SafeString(p["start"]))
+		if err != nil {
+			continue
+		}
+		data.Trend = append(data.Trend, reportPoint{Start: start, Clicks: outfmt.SafeInt(p["clicks"])})
+	}
+
+	return data, nil
+}
+
+// trendValues returns the click counts of the trend for charting.
+func (r reportData) trendValues() []float64 {
+	values := make([]float64, len(r.Trend))
+	for i, p := range r.Trend {
+		values[i] = float64(p.Clicks)
+	}
+	return values
+}
+
+// reportTitle returns the report heading.
+func (r reportData) reportTitle() string {
+	if r.Workspace != "" {
+		return fmt.Sprintf("Dub report: %s (%s)", r.Workspace, r.Interval)
+	}
+	return fmt.Sprintf("Dub report (%s)", r.Interval)
+}
+
+// renderReportMarkdown renders a report as Markdown.
+func renderReportMarkdown(r reportData) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", r.reportTitle())
+	fmt.Fprintf(&sb, "_Generated %s_\n\n", r.Generated.Format("Jan 2, 2006 15:04 MST"))
+
+	sb.WriteString("## Totals\n\n")
+	sb.WriteString("| Clicks | Leads | Sales | Sale Amount |\n")
+	sb.WriteString("| ---: | ---: | ---: | ---: |\n")
+	fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n\n", formatClicks(r.Clicks), formatClicks(r.Leads), formatClicks(r.Sales), formatCurrency(r.SaleAmount))
+
+	if len(r.Trend) > 0 {
+		sb.WriteString("## Click Trend\n\n")
+		fmt.Fprintf(&sb, "`%s`\n\n", outfmt.Sparkline(r.trendValues()))
+		fmt.Fprintf(&sb, "%s → %s\n\n", r.Trend[0].Start.Format("Jan 2"), r.Trend[len(r.Trend)-1].Start.Format("Jan 2"))
+	}
+
+	sb.WriteString("## Top Links\n\n")
+	if len(r.TopLinks) == 0 {
+		sb.WriteString("No link activity in this period.\n\n")
+	} else {
+		sb.WriteString("| Link | Destination | Clicks |\n")
+		sb.WriteString("| --- | --- | ---: |\n")
+		for _, row := range r.TopLinks {
+			fmt.Fprintf(&sb, "| %s | %s | %s |\n", escapeMarkdownCell(row.Label), escapeMarkdownCell(row.Detail), formatClicks(row.Clicks))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Countries\n\n")
+	if len(r.Countries) == 0 {
+		sb.WriteString("No country data in this period.\n")
+	} else {
+		sb.WriteString("| Country | Clicks |\n")
+		sb.WriteString("| --- | ---: |\n")
+		for _, row := range r.Countries {
+			fmt.Fprintf(&sb, "| %s | %s |\n", escapeMarkdownCell(row.Label), formatClicks(row.Clicks))
+		}
+	}
+
+	return sb.String()
+}
+
+// escapeMarkdownCell keeps pipes in values from breaking table columns.
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// formatCurrency formats a sale amount in cents as dollars.
+func formatCurrency(cents float64) string {
+	dollars := int(cents) / 100
+	return fmt.Sprintf("$%s.%02d", formatClicks(dollars), int(cents)%100)
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"count":    formatClicks,
+	"currency": formatCurrency,
+	"date":     func(t time.Time) string { return t.Format("Jan 2") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 48rem; margin: 2rem auto; color: #111; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5rem; }
+th, td { padding: 0.4rem 0.6rem; border-bottom: 1px solid #eee; text-align: left; }
+td.num, th.num { text-align: right; }
+.muted { color: #666; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="muted">Generated {{.Data.Generated.Format "Jan 2, 2006 15:04 MST"}}</p>
+
+<h2>Totals</h2>
+<table>
+<tr><th class="num">Clicks</th><th class="num">Leads</th><th class="num">Sales</th><th class="num">Sale Amount</th></tr>
+<tr><td class="num">{{count .Data.Clicks}}</td><td class="num">{{count .Data.Leads}}</td><td class="num">{{count .Data.Sales}}</td><td class="num">{{currency .Data.SaleAmount}}</td></tr>
+</table>
+{{if .Data.Trend}}
+<h2>Click Trend</h2>
+<svg viewBox="0 0 {{.ChartWidth}} {{.ChartHeight}}" width="100%" height="80" preserveAspectRatio="none" role="img" aria-label="Click trend">
+<polyline fill="none" stroke="#2563eb" stroke-width="2" points="{{.ChartPoints}}"/>
+</svg>
+<p class="muted">{{date (index .Data.Trend 0).Start}} → {{date .LastPoint.Start}}</p>
+{{end}}
+<h2>Top Links</h2>
+{{if .Data.TopLinks}}<table>
+<tr><th>Link</th><th>Destination</th><th class="num">Clicks</th></tr>
+{{range .Data.TopLinks}}<tr><td>{{.Label}}</td><td>{{.Detail}}</td><td class="num">{{count .Clicks}}</td></tr>
+{{end}}</table>{{else}}<p>No link activity in this period.</p>{{end}}
+
+<h2>Countries</h2>
+{{if .Data.Countries}}<table>
+<tr><th>Country</th><th class="num">Clicks</th></tr>
+{{range .Data.Countries}}<tr><td>{{.Label}}</td><td class="num">{{count .Clicks}}</td></tr>
+{{end}}</table>{{else}}<p>No country data in this period.</p>{{end}}
+</body>
+</html>
+`))
+
+// renderReportHTML renders a report as a standalone HTML page with an
+// inline SVG trend chart.
+func renderReportHTML(r reportData) (string, error) {
+	const width, height = 600, 80
+
+	view := struct {
+		Title       string
+		Data        reportData
+		ChartWidth  int
+		ChartHeight int
+		ChartPoints string
+		LastPoint   reportPoint
+	}{
+		Title:       r.reportTitle(),
+		Data:        r,
+		ChartWidth:  width,
+		ChartHeight: height,
+		ChartPoints: chartPoints(r.trendValues(), width, height),
+	}
+	if len(r.Trend) > 0 {
+		view.LastPoint = r.Trend[len(r.Trend)-1]
+	}
+
+	var buf bytes.Buffer
+	if err := reportHTMLTemplate.Execute(&buf, view); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// chartPoints scales values into SVG polyline coordinates within width×height.
+func chartPoints(values []float64, width, height int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	hi := 0.0
+	for _, v := range values {
+		if v > hi {
+			hi = v
+		}
+	}
+
+	points := make([]string, len(values))
+	for i, v := range values {
+		x := 0.0
+		if len(values) > 1 {
+			x = float64(i) * float64(width) / float64(len(values)-1)
+		}
+		y := float64(height)
+		if hi > 0 {
+			y = float64(height) - v/hi*float64(height)
+		}
+		points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+	return strings.Join(points, " ")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+)
+
+func newReportServer(t *testing.T) *api.Client {
+	t.Helper()
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("groupBy") {
+		case "count":
+			_, _ = w.Write([]byte(`{"clicks":12345,"leads":12,"sales":3,"saleAmount":15000}`))
+		case "top_links":
+			_, _ = w.Write([]byte(`[{"shortLink":"dub.sh/a","url":"https://example.com/a","clicks":900},{"shortLink":"dub.sh/b","url":"https://example.com/b","clicks":100}]`))
+		case "countries":
+			_, _ = w.Write([]byte(`[{"country":"US","clicks":700},{"country":"DE","clicks":300}]`))
+		case "timeseries":
+			_, _ = w.Write([]byte(`[{"start":"2026-01-01T00:00:00Z","clicks":1},{"start":"2026-01-02T00:00:00Z","clicks":8}]`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+
+	client := api.NewClient("dub_test")
+	client.SetBaseURL(server.URL)
+	return client
+}
+
+func TestFetchReportData(t *testing.T) {
+	client := newReportServer(t)
+
+	data, err := fetchReportData(context.Background(), client, "30d", 1, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if data.Clicks != 12345 || data.SaleAmount != 15000 {
+		t.Errorf("unexpected totals: %+v", data)
+	}
+	if len(data.TopLinks) != 1 || data.TopLinks[0].Label != "dub.sh/a" {
+		t.Errorf("expected top links limited to 1, got %+v", data.TopLinks)
+	}
+	if len(data.Countries) != 1 || !strings.Contains(data.Countries[0].Label, "United States") {
+		t.Errorf("expected expanded country name, got %+v", data.Countries)
+	}
+	if len(data.Trend) != 2 {
+		t.Errorf("expected 2 trend points, got %d", len(data.Trend))
+	}
+}
+
+func TestRenderReportMarkdown(t *testing.T) {
+	data := reportData{
+		Workspace:  "acme",
+		Interval:   "30d",
+		Generated:  time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+		Clicks:     12345,
+		SaleAmount: 15050,
+		TopLinks:   []reportRow{{Label: "dub.sh/a", Detail: "https://example.com/?a|b", Clicks: 900}},
+		Countries:  []reportRow{{Label: "US", Clicks: 700}},
+		Trend: []reportPoint{
+			{Start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Clicks: 0},
+			{Start: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Clicks: 8},
+		},
+	}
+
+	out := renderReportMarkdown(data)
+
+	for _, want := range []string{
+		"# Dub report: acme (30d)",
+		"| 12,345 | 0 | 0 | $150.50 |",
+		"`▁█`",
+		"Jan 1 → Jan 2",
+		`https://example.com/?a\|b`,
+		"| US | 700 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderReportHTML(t *testing.T) {
+	data := reportData{
+		Interval:  "7d",
+		TopLinks:  []reportRow{{Label: "<script>", Clicks: 1}},
+		Countries: nil,
+		Trend: []reportPoint{
+			{Start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Clicks: 0},
+			{Start: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Clicks: 4},
+		},
+	}
+
+	out, err := renderReportHTML(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out, "<title>Dub report (7d)</title>") {
+		t.Error("expected title")
+	}
+	if !strings.Contains(out, `points="0.0,80.0 600.0,0.0"`) {
+		t.Errorf("expected SVG chart points, got:\n%s", out)
+	}
+	if strings.Contains(out, "<td><script>") {
+		t.Error("expected values to be HTML-escaped")
+	}
+	if !strings.Contains(out, "No country data in this period.") {
+		t.Error("expected empty countries placeholder")
+	}
+}
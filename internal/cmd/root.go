@@ -4,29 +4,72 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/config"
 	"github.com/salmonumbrella/dub-cli/internal/debug"
+	"github.com/salmonumbrella/dub-cli/internal/i18n"
 	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 	"github.com/salmonumbrella/dub-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 type rootFlags struct {
-	Workspace string
-	Output    string
-	Query     string
-	Yes       bool
-	Debug     bool
-	Limit     int
-	SortBy    string
-	Desc      bool
-	Color     string
+	Context          string
+	Workspace        string
+	AllWorkspaces    bool
+	KeyRole          string
+	APIURL           string
+	Rate             string
+	NoCircuitBreaker bool
+	CBThreshold      int
+	CBCooldown       time.Duration
+	Output           string
+	Query            string
+	Yes              bool
+	Force            bool
+	Debug            bool
+	Include          bool
+	Limit            int
+	SortBy           string
+	Desc             bool
+	Color            string
+	Lang             string
+	Compact          bool
+	Wrap             bool
+	MaxWidth         int
+	MaxTime          time.Duration
+	Profile          string
+	Out              string
+	Append           bool
 }
 
 type contextKey string
 
-const workspaceKey contextKey = "workspace"
+const (
+	workspaceKey     contextKey = "workspace"
+	keyRoleKey       contextKey = "keyRole"
+	apiURLKey        contextKey = "apiURL"
+	defaultDomainKey contextKey = "defaultDomain"
+	defaultTagsKey   contextKey = "defaultTags"
+	rateLimiterKey   contextKey = "rateLimiter"
+	breakerKey       contextKey = "circuitBreaker"
+	auditLogKey      contextKey = "auditLog"
+	forceKey         contextKey = "force"
+	includeKey       contextKey = "include"
+	outFileKey       contextKey = "outFile"
+	ghaKey           contextKey = "gha"
+)
+
+// breakerSettings configures the API client's circuit breaker.
+type breakerSettings struct {
+	Threshold int // 0 disables the breaker
+	Cooldown  time.Duration
+}
 
 // GetWorkspace returns the workspace name from context
 func GetWorkspace(ctx context.Context) string {
@@ -36,6 +79,64 @@ func GetWorkspace(ctx context.Context) string {
 	return ""
 }
 
+// GetKeyRole returns the API key role selected with --as from context
+func GetKeyRole(ctx context.Context) string {
+	if v, ok := ctx.Value(keyRoleKey).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// GetForce reports whether --force was given. It overrides link protection
+// (see dub links lock) and the quota check, but does not skip prompts.
+func GetForce(ctx context.Context) bool {
+	if v, ok := ctx.Value(forceKey).(bool); ok {
+		return v
+	}
+	return false
+}
+
+// GetAPIURL returns the API base URL override from context
+func GetAPIURL(ctx context.Context) string {
+	if v, ok := ctx.Value(apiURLKey).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// GetDefaultDomain returns the default short link domain from context
+func GetDefaultDomain(ctx context.Context) string {
+	if v, ok := ctx.Value(defaultDomainKey).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// GetDefaultTags returns the tag names applied to new links from context
+func GetDefaultTags(ctx context.Context) []string {
+	if v, ok := ctx.Value(defaultTagsKey).([]string); ok {
+		return v
+	}
+	return nil
+}
+
+// GetRateLimiter returns the client-side rate limiter from context, if any
+func GetRateLimiter(ctx context.Context) *api.RateLimiter {
+	if v, ok := ctx.Value(rateLimiterKey).(*api.RateLimiter); ok {
+		return v
+	}
+	return nil
+}
+
+// getBreakerSettings returns the circuit breaker configuration from context,
+// falling back to the API client's defaults
+func getBreakerSettings(ctx context.Context) breakerSettings {
+	if v, ok := ctx.Value(breakerKey).(breakerSettings); ok {
+		return v
+	}
+	return breakerSettings{Threshold: api.CircuitBreakerThreshold, Cooldown: api.CircuitBreakerCooldown}
+}
+
 func NewRootCmd() *cobra.Command {
 	// flags is local to this function to avoid package-level mutable state
 	// that could cause issues with parallel tests
@@ -48,11 +149,95 @@ func NewRootCmd() *cobra.Command {
 		Version:      Version,
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Initialize debug logging based on --debug flag
-			debug.Init(flags.Debug)
+			// Fill unset flags from DUB_<COMMAND>_<FLAG> environment variables,
+			// then from the defaults in the project and user config
+			if err := applyEnvFlags(cmd); err != nil {
+				return err
+			}
+			projectFile, err := findProject()
+			if err != nil {
+				return err
+			}
+			if err := applyConfigDefaults(cmd, projectFile); err != nil {
+				return err
+			}
+
+			// Apply the active context as defaults for flags not set explicitly
+			defaultDomain, err := applyActiveContext(cmd, &flags)
+			if err != nil {
+				return err
+			}
+
+			// A .dub.toml in the project takes precedence over user config
+			project := applyProjectConfig(cmd, &flags, projectFile, defaultDomain)
+			if flags.APIURL != "" {
+				if err := validateAPIURL(flags.APIURL); err != nil {
+					return err
+				}
+			}
+
+			var limiter *api.RateLimiter
+			if flags.Rate != "" {
+				limiter, err = api.ParseRate(flags.Rate)
+				if err != nil {
+					return NewUsageErrorf("--rate: %v", err)
+				}
+			}
+
+			breaker := breakerSettings{Threshold: flags.CBThreshold, Cooldown: flags.CBCooldown}
+			if flags.NoCircuitBreaker {
+				breaker.Threshold = 0
+			} else if breaker.Threshold < 1 {
+				return NewUsageErrorf("--cb-threshold must be at least 1 (use --no-circuit-breaker to disable)")
+			}
+			if breaker.Cooldown <= 0 {
+				return NewUsageErrorf("--cb-cooldown must be positive")
+			}
+
+			// Initialize debug logging based on --debug flag, keeping a copy
+			// of the log for `dub feedback`
+			logPath, _ := config.DebugLogPath()
+			debug.Configure(flags.Debug, logPath, cmd.ErrOrStderr())
 
 			// Initialize UI color output based on --color flag
-			ui.Init(flags.Color)
+			// Output written to a file has no use for color codes
+			color := flags.Color
+			if flags.Out != "" && color == "auto" {
+				color = "never"
+			}
+			ui.Configure(color, cmd.OutOrStdout())
+
+			// --lang may also come from config, too late for help output
+			if flags.Lang != "" {
+				lang, ok := i18n.Match(flags.Lang)
+				if !ok {
+					return NewUsageErrorf("--lang: unsupported language %q (available: %s)", flags.Lang, strings.Join(i18n.Locales(), ", "))
+				}
+				if err := i18n.SetLocale(lang); err != nil {
+					return err
+				}
+			}
+
+			if flags.MaxWidth < 0 {
+				return NewUsageErrorf("--max-width must not be negative")
+			}
+			outfmt.SetTableLayout(outfmt.TableLayout{Wrap: flags.Wrap, MaxWidth: flags.MaxWidth})
+			outfmt.SetCompactJSON(flags.Compact)
+			if flags.MaxTime < 0 {
+				return NewUsageErrorf("--max-time must not be negative")
+			}
+			if flags.Append && flags.Out == "" {
+				return NewUsageErrorf("--append requires --out")
+			}
+
+			if notice := project.overrideNotice(); notice != "" {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), notice)
+			}
+
+			// Expand @file / @- flag values before commands read them
+			if err := expandFileFlags(cmd); err != nil {
+				return err
+			}
 
 			if flags.Desc && flags.SortBy == "" {
 				return fmt.Errorf("--desc requires --sort-by to be specified")
@@ -66,31 +251,93 @@ func NewRootCmd() *cobra.Command {
 			ctx = outfmt.WithFormat(ctx, flags.Output)
 			ctx = outfmt.WithQuery(ctx, flags.Query)
 			ctx = outfmt.WithYes(ctx, flags.Yes)
+			ctx = context.WithValue(ctx, forceKey, flags.Force)
 			ctx = outfmt.WithLimit(ctx, flags.Limit)
 			ctx = outfmt.WithSortBy(ctx, flags.SortBy)
 			ctx = outfmt.WithDesc(ctx, flags.Desc)
 			ctx = context.WithValue(ctx, workspaceKey, flags.Workspace)
+			ctx = context.WithValue(ctx, keyRoleKey, flags.KeyRole)
+			ctx = context.WithValue(ctx, apiURLKey, flags.APIURL)
+			ctx = context.WithValue(ctx, defaultDomainKey, project.DefaultDomain)
+			ctx = context.WithValue(ctx, defaultTagsKey, project.DefaultTags)
+			if limiter != nil {
+				ctx = context.WithValue(ctx, rateLimiterKey, limiter)
+			}
+			ctx = context.WithValue(ctx, breakerKey, breaker)
+			if audit := newAuditLog(cmd); audit != nil {
+				ctx = context.WithValue(ctx, auditLogKey, audit)
+			}
+			if ghaMode(cmd) {
+				ctx = context.WithValue(ctx, ghaKey, newGHAReporter(cmd))
+			}
+			if flags.Include {
+				ctx = context.WithValue(ctx, includeKey, newHeaderPrinter(cmd))
+			}
+			if flags.MaxTime > 0 {
+				ctx = withMaxTime(ctx, flags.MaxTime)
+			}
+			var outFile *outfmt.FileWriter
+			if flags.Out != "" {
+				if outFile, err = outfmt.CreateFile(flags.Out, flags.Append); err != nil {
+					return err
+				}
+				cmd.SetOut(outFile)
+				ctx = context.WithValue(ctx, outFileKey, outFile)
+			}
+			// Start profiling last so it's stopped whenever it was started
+			if flags.Profile != "" {
+				p, err := startProfiling(flags.Profile)
+				if err != nil {
+					if outFile != nil {
+						outFile.Abort()
+					}
+					return err
+				}
+				ctx = context.WithValue(ctx, profilerKey, p)
+			}
 			cmd.SetContext(ctx)
 
+			if flags.AllWorkspaces {
+				return enableAllWorkspaces(cmd)
+			}
 			return nil
 		},
 	}
 
+	cmd.PersistentFlags().StringVar(&flags.Context, "context", "", "Context to use instead of the current one (or DUB_CONTEXT env)")
 	cmd.PersistentFlags().StringVarP(&flags.Workspace, "workspace", "w", os.Getenv("DUB_WORKSPACE"), "Workspace name (or DUB_WORKSPACE env)")
-	cmd.PersistentFlags().StringVarP(&flags.Output, "output", "o", getEnvOrDefault("DUB_OUTPUT", "text"), "Output format: text|json")
+	cmd.PersistentFlags().BoolVar(&flags.AllWorkspaces, "all-workspaces", false, "Run a read command against every stored workspace and merge the results")
+	cmd.PersistentFlags().StringVar(&flags.KeyRole, "as", "", "Use the workspace's API key stored under this role, e.g. read-only (or DUB_AS env)")
+	cmd.PersistentFlags().StringVar(&flags.APIURL, "api-url", "", "API base URL (or DUB_API_URL env)")
+	cmd.PersistentFlags().StringVar(&flags.Rate, "rate", "", "Client-side request rate limit, e.g. 5/s or 300/m (or DUB_RATE env)")
+	cmd.PersistentFlags().BoolVar(&flags.NoCircuitBreaker, "no-circuit-breaker", false, "Keep sending requests even after repeated server errors")
+	cmd.PersistentFlags().IntVar(&flags.CBThreshold, "cb-threshold", api.CircuitBreakerThreshold, "Consecutive server errors before pausing requests")
+	cmd.PersistentFlags().DurationVar(&flags.CBCooldown, "cb-cooldown", api.CircuitBreakerCooldown, "How long to pause requests after repeated server errors")
+	cmd.PersistentFlags().StringVarP(&flags.Output, "output", "o", getEnvOrDefault("DUB_OUTPUT", "text"), "Output format: text|json|table|gha (GitHub Actions annotations and step summary)")
 	cmd.PersistentFlags().StringVar(&flags.Query, "query", "", "JQ filter expression for JSON output")
+	cmd.PersistentFlags().BoolVar(&flags.Compact, "compact", false, "Write JSON output on one line instead of indented")
 	cmd.PersistentFlags().BoolVarP(&flags.Yes, "yes", "y", false, "Skip confirmation prompts")
-	cmd.PersistentFlags().BoolVar(&flags.Yes, "force", false, "Skip confirmation prompts (alias for --yes)")
+	cmd.PersistentFlags().BoolVar(&flags.Force, "force", false, "Override link protection and the link quota check (prompts still need --yes)")
 	cmd.PersistentFlags().BoolVar(&flags.Debug, "debug", false, "Enable debug output")
+	cmd.PersistentFlags().BoolVar(&flags.Include, "include", false, "Show request ID, rate-limit, and cache headers of each response (above JSON output, else on stderr)")
 	cmd.PersistentFlags().IntVar(&flags.Limit, "limit", 0, "Limit number of results (0 = no limit)")
-	cmd.PersistentFlags().StringVar(&flags.SortBy, "sort-by", "", "Field name to sort by")
+	cmd.PersistentFlags().StringVar(&flags.SortBy, "sort-by", "", "Sort list output by a field or column, e.g. clicks or createdAt")
 	cmd.PersistentFlags().BoolVar(&flags.Desc, "desc", false, "Sort descending (requires --sort-by)")
 	cmd.PersistentFlags().StringVar(&flags.Color, "color", "auto", "Color output: auto|always|never")
+	cmd.PersistentFlags().StringVar(&flags.Lang, "lang", "", "Language for messages, e.g. de or es (default from LANG)")
+	cmd.PersistentFlags().BoolVar(&flags.Wrap, "wrap", false, "Wrap long table cells onto further lines instead of truncating them")
+	cmd.PersistentFlags().IntVar(&flags.MaxWidth, "max-width", 0, "Maximum width of long table columns like URLs (0 = each table's default)")
+	cmd.PersistentFlags().StringVar(&flags.Out, "out", "", "Write output to this file, replacing it only once the command succeeds")
+	cmd.PersistentFlags().BoolVar(&flags.Append, "append", false, "With --out, append to the file instead, e.g. for NDJSON")
+	cmd.PersistentFlags().DurationVar(&flags.MaxTime, "max-time", 0, "Abort the command if it runs longer than this, e.g. 30s or 5m (0 = no limit)")
+	cmd.PersistentFlags().StringVar(&flags.Profile, "profile", "", "Write runtime profiles on exit, e.g. cpu=cpu.out,heap=heap.out (also allocs, goroutine, block, mutex, trace)")
+	cmd.PersistentFlags().Bool("no-daemon", false, "Run locally even if a dub daemon is running (or DUB_NO_DAEMON env)")
 
 	cmd.AddCommand(newAuthCmd())
 	cmd.AddCommand(newLinksCmd())
 	cmd.AddCommand(newAnalyticsCmd())
 	cmd.AddCommand(newEventsCmd())
+	cmd.AddCommand(newReportCmd())
 	cmd.AddCommand(newDomainsCmd())
 	cmd.AddCommand(newPartnersCmd())
 	cmd.AddCommand(newCustomersCmd())
@@ -99,11 +346,30 @@ func NewRootCmd() *cobra.Command {
 	cmd.AddCommand(newTagsCmd())
 	cmd.AddCommand(newFoldersCmd())
 	cmd.AddCommand(newWorkspacesCmd())
+	cmd.AddCommand(newContextCmd())
+	cmd.AddCommand(newCacheCmd())
+	cmd.AddCommand(newCleanupCmd())
+	cmd.AddCommand(newMirrorCmd())
+	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newUndoCmd())
+	cmd.AddCommand(newApplyPlanCmd())
+	cmd.AddCommand(newValidateCmd())
 	cmd.AddCommand(newQRCmd())
+	cmd.AddCommand(newDashboardCmd())
+	cmd.AddCommand(newMetricsCmd())
+	cmd.AddCommand(newBenchCmd())
 	cmd.AddCommand(newEmbedCmd())
+	cmd.AddCommand(newExamplesCmd())
 	cmd.AddCommand(newVersionCmd())
 	cmd.AddCommand(newUpgradeCmd())
+	cmd.AddCommand(newUninstallCmd())
 	cmd.AddCommand(newCompletionCmd())
+	cmd.AddCommand(newCompletionServerCmd())
+	cmd.AddCommand(newFeedbackCmd())
+	cmd.AddCommand(newTelemetryCmd())
+	cmd.AddCommand(newCronCmd())
+	cmd.AddCommand(newCICmd())
+	cmd.AddCommand(newDaemonCmd())
 
 	return cmd
 }
@@ -116,13 +382,90 @@ func getEnvOrDefault(key, def string) string {
 }
 
 func Execute(args []string) error {
-	cmd := NewRootCmd()
-	cmd.SetArgs(args)
-	return cmd.Execute()
+	return ExecuteContext(context.Background(), args)
 }
 
+// ExecuteContext runs args, delegating to a running daemon when there is one.
 func ExecuteContext(ctx context.Context, args []string) error {
+	if shouldDelegate(args) {
+		if socket, err := daemonSocketPath(); err == nil {
+			if ok, err := delegateToDaemon(ctx, socket, args, os.Stdin, os.Stdout, os.Stderr); ok {
+				return err
+			}
+		}
+	}
+	return execute(ctx, args, nil, nil, nil)
+}
+
+// execute runs args in this process. Nil streams default to the process's own.
+func execute(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
 	cmd := NewRootCmd()
 	cmd.SetArgs(args)
-	return cmd.ExecuteContext(ctx)
+	setLanguage(cmd, args)
+	if stdin != nil {
+		cmd.SetIn(stdin)
+	}
+	if stdout != nil {
+		cmd.SetOut(stdout)
+	}
+	if stderr != nil {
+		cmd.SetErr(stderr)
+	}
+	// Errors are printed here, once they say whether the command was cut short
+	cmd.SilenceErrors = true
+	start := time.Now()
+	executed, err := cmd.ExecuteContextC(ctx)
+	if executed != nil {
+		stopMaxTime(executed.Context())
+		err = finishOutFile(executed.Context(), err)
+		stopProfiling(executed.Context(), executed.ErrOrStderr())
+	}
+	if err != nil {
+		printer := cmd
+		if executed != nil {
+			err = cancellationError(ctx, executed.Context(), err)
+			printer = executed
+		}
+		if gha := getGHAReporter(printer.Context()); gha != nil {
+			gha.printError(err)
+		} else if printer == cmd || !printer.SilenceErrors {
+			printer.PrintErrln(i18n.T(printer.ErrPrefix()), i18n.T(err.Error()))
+		}
+		recordLastError(executed, err)
+	}
+	if executed != nil {
+		if gha := getGHAReporter(executed.Context()); gha != nil {
+			if serr := gha.writeSummary(err); serr != nil {
+				_, _ = fmt.Fprintln(executed.ErrOrStderr(), serr)
+			}
+		}
+	}
+	reportTelemetry(ctx, executed, time.Since(start), err)
+	return err
+}
+
+// outFilePath returns the file given with --out, or "" when output goes to
+// stdout.
+func outFilePath(ctx context.Context) string {
+	if w, ok := ctx.Value(outFileKey).(*outfmt.FileWriter); ok {
+		return w.Path()
+	}
+	return ""
+}
+
+// finishOutFile moves --out output into place after a successful command,
+// or discards it after a failed one.
+func finishOutFile(ctx context.Context, err error) error {
+	if ctx == nil {
+		return err
+	}
+	w, ok := ctx.Value(outFileKey).(*outfmt.FileWriter)
+	if !ok {
+		return err
+	}
+	if err != nil {
+		w.Abort()
+		return err
+	}
+	return w.Commit()
 }
@@ -3,7 +3,11 @@ package cmd
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
 )
 
 func TestRootCommand_Help(t *testing.T) {
@@ -27,10 +31,80 @@ func TestRootCommand_GlobalFlags(t *testing.T) {
 	cmd := NewRootCmd()
 
 	// Check persistent flags exist
-	flags := []string{"workspace", "output", "query", "yes", "debug", "limit", "sort-by", "desc"}
+	flags := []string{"workspace", "rate", "output", "query", "yes", "debug", "limit", "sort-by", "desc", "wrap", "max-width"}
 	for _, name := range flags {
 		if cmd.PersistentFlags().Lookup(name) == nil {
 			t.Errorf("expected persistent flag %q to exist", name)
 		}
 	}
 }
+
+func TestRootCommand_RateFlag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_RATE", "")
+
+	run := func(args ...string) (bool, error) {
+		var limited bool
+		root := NewRootCmd()
+		root.AddCommand(&cobra.Command{
+			Use: "probe",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				limited = GetRateLimiter(cmd.Context()) != nil
+				return nil
+			},
+		})
+		root.SetArgs(append([]string{"probe"}, args...))
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		return limited, root.Execute()
+	}
+
+	if limited, err := run(); err != nil || limited {
+		t.Errorf("expected no limiter by default, got %v %v", limited, err)
+	}
+	if limited, err := run("--rate", "5/s"); err != nil || !limited {
+		t.Errorf("expected limiter with --rate, got %v %v", limited, err)
+	}
+	if _, err := run("--rate", "fast"); err == nil || !strings.Contains(err.Error(), "--rate") {
+		t.Errorf("expected invalid --rate error, got %v", err)
+	}
+}
+
+func TestRootCommand_CircuitBreakerFlags(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	run := func(args ...string) (breakerSettings, error) {
+		var got breakerSettings
+		root := NewRootCmd()
+		root.AddCommand(&cobra.Command{
+			Use: "probe",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				got = getBreakerSettings(cmd.Context())
+				return nil
+			},
+		})
+		root.SetArgs(append([]string{"probe"}, args...))
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		return got, root.Execute()
+	}
+
+	if got, err := run("--cb-threshold", "3", "--cb-cooldown", "1m"); err != nil || got.Threshold != 3 || got.Cooldown != time.Minute {
+		t.Errorf("expected threshold 3 and 1m cooldown, got %+v %v", got, err)
+	}
+	if got, err := run("--no-circuit-breaker"); err != nil || got.Threshold != 0 {
+		t.Errorf("expected disabled breaker, got %+v %v", got, err)
+	}
+	if _, err := run("--cb-threshold", "0"); err == nil {
+		t.Error("expected error for --cb-threshold 0")
+	}
+}
+
+func TestCircuitOpenNotice(t *testing.T) {
+	notice := circuitOpenNotice("api.dub.co", 30*time.Second)
+	for _, want := range []string{"api.dub.co", "30s", "--no-circuit-breaker"} {
+		if !strings.Contains(notice, want) {
+			t.Errorf("expected notice to contain %q, got %q", want, notice)
+		}
+	}
+}
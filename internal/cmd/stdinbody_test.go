@@ -0,0 +1,133 @@
+// internal/cmd/stdinbody_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBodySchema_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		complete bool
+		wantErr  string
+	}{
+		{name: "valid", body: `{"url":"https://x.com","tagNames":["a"],"archived":false,"geo":{"US":"https://us.x.com"}}`, complete: true},
+		{name: "partial", body: `{"tagNames":["a"]}`},
+		{name: "null", body: `{"url":"https://x.com","comments":null}`, complete: true},
+		{name: "unknown field", body: `{"url":"https://x.com","tags":["a"]}`, wantErr: `unknown field "tags"`},
+		{name: "wrong type", body: `{"url":"https://x.com","archived":"yes"}`, wantErr: "archived must be a boolean, got string"},
+		{name: "missing url", body: `{"tagNames":["a"]}`, complete: true, wantErr: "url is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := json.NewDecoder(strings.NewReader(tt.body))
+			dec.UseNumber()
+			var body map[string]interface{}
+			if err := dec.Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			err := linkCreateSchema.validate(body, tt.complete)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !IsUsageError(err) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected usage error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStdinBody_RejectsNonObject(t *testing.T) {
+	for _, in := range []string{"", "[]", "null", `{"url":"a"} {"url":"b"}`} {
+		s := stdinBody{enabled: true, schema: linkCreateSchema}
+		cmd := newLinksCreateCmd()
+		cmd.SetIn(strings.NewReader(in))
+		if _, err := s.read(cmd); err == nil || !IsUsageError(err) {
+			t.Errorf("stdin %q: expected usage error, got %v", in, err)
+		}
+	}
+}
+
+func runLinksCreateFromStdin(t *testing.T, stdin string, args ...string) (map[string]interface{}, error) {
+	t.Helper()
+	var got map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":"link_1","shortLink":"https://dub.sh/x"}`))
+	})
+
+	cmd := newLinksCreateCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"--from-stdin"}, args...))
+	err := cmd.Execute()
+	return got, err
+}
+
+func TestLinksCreate_FromStdin(t *testing.T) {
+	got, err := runLinksCreateFromStdin(t, `{"url":"https://x.com","tagNames":["a"],"keyLength":7}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["url"] != "https://x.com" {
+		t.Errorf("expected url from stdin, got %v", got["url"])
+	}
+	if tags, _ := got["tagNames"].([]interface{}); len(tags) != 1 || tags[0] != "a" {
+		t.Errorf("expected tagNames [a], got %v", got["tagNames"])
+	}
+	if got["keyLength"] != 7.0 {
+		t.Errorf("expected keyLength 7, got %v", got["keyLength"])
+	}
+}
+
+func TestLinksCreate_FromStdinFlagsOverride(t *testing.T) {
+	got, err := runLinksCreateFromStdin(t, `{"url":"https://x.com","key":"old"}`, "--key", "new")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["key"] != "new" || got["url"] != "https://x.com" {
+		t.Errorf("expected --key to override the body, got %v", got)
+	}
+}
+
+func TestLinksCreate_FromStdinRequiresURL(t *testing.T) {
+	_, err := runLinksCreateFromStdin(t, `{"tagNames":["a"]}`)
+	if err == nil || !strings.Contains(err.Error(), "url is required") {
+		t.Errorf("expected missing url error, got %v", err)
+	}
+}
+
+func TestTagsCreate_FromStdin(t *testing.T) {
+	var got map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"id":"tag_1","name":"promo"}`))
+	})
+
+	cmd := newTagsCreateCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetIn(strings.NewReader(`{"name":"promo","color":"red"}`))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--from-stdin", "--color", "blue"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["name"] != "promo" || got["color"] != "blue" {
+		t.Errorf("unexpected request body: %v", got)
+	}
+}
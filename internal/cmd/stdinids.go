@@ -0,0 +1,129 @@
+// internal/cmd/stdinids.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/batch"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// stdinIDs is the --ids-from-stdin flag of a destructive command, which
+// lets it act on the output of another command, like
+//
+//	dub links list -q | dub links delete --ids-from-stdin --yes
+type stdinIDs struct {
+	enabled bool
+	// field is read from objects in a JSON array, e.g. "id" or "slug"
+	field string
+}
+
+func (s *stdinIDs) register(cmd *cobra.Command, idFlag, field string) {
+	s.field = field
+	cmd.Flags().BoolVar(&s.enabled, "ids-from-stdin", false,
+		fmt.Sprintf("Read %ss from stdin, one per line or as a JSON array (requires --yes)", idFlag))
+	cmd.MarkFlagsMutuallyExclusive(idFlag, "ids-from-stdin")
+}
+
+// read returns the IDs on stdin, refusing to run without --yes (or
+// --dry-run) since there's no chance to confirm.
+func (s *stdinIDs) read(cmd *cobra.Command, dryRun bool) ([]string, error) {
+	if !dryRun && !outfmt.GetYes(cmd.Context()) {
+		return nil, NewUsageErrorf("--ids-from-stdin requires --yes (or --dry-run)")
+	}
+	data, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	ids, err := parseStdinIDs(data, s.field)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("no IDs given on stdin")
+	}
+	return ids, nil
+}
+
+// parseStdinIDs parses newline-separated IDs, skipping blank lines and #
+// comments, or a JSON array of IDs or of objects with field. Duplicates are
+// dropped.
+func parseStdinIDs(data []byte, field string) ([]string, error) {
+	var raw []string
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("[")) {
+		var items []interface{}
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("invalid JSON on stdin: %w", err)
+		}
+		for i, item := range items {
+			if obj, ok := item.(map[string]interface{}); ok {
+				item = obj[field]
+			}
+			id := outfmt.SafeString(item)
+			if id == "" {
+				return nil, fmt.Errorf("stdin[%d]: expected a string or an object with %q", i, field)
+			}
+			raw = append(raw, id)
+		}
+	} else {
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				raw = append(raw, line)
+			}
+		}
+	}
+
+	var ids []string
+	seen := map[string]bool{}
+	for _, id := range raw {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// deleteByIDs deletes the resource at path(id) for each id concurrently,
+// printing each deletion. It returns the IDs deleted and an error if any
+// deletion failed.
+func deleteByIDs(cmd *cobra.Command, client *api.Client, kind string, ids []string, path func(string) string) ([]string, error) {
+	errs := batch.Run(cmd.Context(), len(ids), batch.DefaultConcurrency, func(ctx context.Context, i int) error {
+		if err := discardResponse(client.Delete(ctx, path(ids[i]))); err != nil {
+			return fmt.Errorf("%s: %w", ids[i], err)
+		}
+		return nil
+	})
+
+	var deleted []string
+	var failed []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+			continue
+		}
+		deleted = append(deleted, ids[i])
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Deleted %s %s\n", kind, ids[i])
+	}
+	if len(failed) > 0 {
+		return deleted, fmt.Errorf("failed to delete %d of %d %s(s):\n%w", len(failed), len(ids), kind, errors.Join(failed...))
+	}
+	return deleted, nil
+}
+
+// printWouldDelete prints the --dry-run listing of a batch delete.
+func printWouldDelete(cmd *cobra.Command, kind string, ids []string) {
+	for _, id := range ids {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Would delete %s %s\n", kind, id)
+	}
+}
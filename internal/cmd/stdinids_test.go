@@ -0,0 +1,87 @@
+// internal/cmd/stdinids_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+func TestParseStdinIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"lines", "link_1\n\n  link_2  \n# comment\nlink_1\n", []string{"link_1", "link_2"}},
+		{"string array", `["link_1", "link_2"]`, []string{"link_1", "link_2"}},
+		{"object array", `[{"id": "link_1", "key": "a"}, {"id": "link_2"}]`, []string{"link_1", "link_2"}},
+		{"empty", "\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStdinIDs([]byte(tt.input), "id")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseStdinIDs([]byte(`[{"slug": "acme.com"}]`), "id"); err == nil {
+		t.Error("expected error for objects missing the field")
+	}
+}
+
+func TestDomainsDeleteCmd_IDsFromStdin(t *testing.T) {
+	var deleted []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		deleted = append(deleted, r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	run := func(yes bool, args ...string) (string, error) {
+		cmd := newDomainsDeleteCmd()
+		ctx := outfmt.WithYes(context.WithValue(context.Background(), apiURLKey, server.URL), yes)
+		cmd.SetContext(ctx)
+		cmd.SetIn(strings.NewReader(`[{"slug": "a.com"}, {"slug": "b.com"}]`))
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	if _, err := run(false, "--ids-from-stdin"); err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error without --yes, got %v", err)
+	}
+
+	out, err := run(false, "--ids-from-stdin", "--dry-run")
+	if err != nil || !strings.Contains(out, "Would delete domain b.com") || len(deleted) != 0 {
+		t.Errorf("unexpected dry run: %q, %v, %v", out, err, deleted)
+	}
+
+	out, err = run(true, "--ids-from-stdin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(deleted)
+	if !reflect.DeepEqual(deleted, []string{"/domains/a.com", "/domains/b.com"}) {
+		t.Errorf("unexpected deletes %v", deleted)
+	}
+	if !strings.Contains(out, "Deleted domain a.com") {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
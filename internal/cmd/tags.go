@@ -7,10 +7,13 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
 	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 )
 
@@ -18,20 +21,23 @@ func newTagsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tags",
 		Short: "Manage tags",
-		Long:  "Create, list, and update tags for organizing links.",
+		Long:  "Create, list, update, rename, and merge tags for organizing links.",
 	}
 
 	cmd.AddCommand(newTagsCreateCmd())
 	cmd.AddCommand(newTagsListCmd())
 	cmd.AddCommand(newTagsUpdateCmd())
+	cmd.AddCommand(newTagsRenameCmd())
+	cmd.AddCommand(newTagsMergeCmd())
 
 	return cmd
 }
 
 func newTagsCreateCmd() *cobra.Command {
 	var (
-		name  string
-		color string
+		name      string
+		color     string
+		fromStdin stdinBody
 	)
 
 	cmd := &cobra.Command{
@@ -39,7 +45,13 @@ func newTagsCreateCmd() *cobra.Command {
 		Short: "Create a tag",
 		Long:  "Create a new tag for organizing links.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if name == "" {
+			body := map[string]interface{}{}
+			if fromStdin.enabled {
+				var err error
+				if body, err = fromStdin.read(cmd); err != nil {
+					return err
+				}
+			} else if name == "" {
 				return fmt.Errorf("--name is required")
 			}
 
@@ -48,12 +60,15 @@ func newTagsCreateCmd() *cobra.Command {
 				return err
 			}
 
-			body := map[string]interface{}{
-				"name": name,
+			if name != "" {
+				body["name"] = name
 			}
 			if color != "" {
 				body["color"] = color
 			}
+			if err := tagCreateSchema.validate(body, true); err != nil {
+				return err
+			}
 
 			resp, err := client.Post(cmd.Context(), "/tags", body)
 			if err != nil {
@@ -67,7 +82,9 @@ func newTagsCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Tag name (required)")
 	cmd.Flags().StringVar(&color, "color", "", "Tag color (e.g., red, blue, green)")
 
-	_ = cmd.MarkFlagRequired("name")
+	fromStdin.register(cmd, tagCreateSchema)
+
+	cmd.MarkFlagsOneRequired("name", "from-stdin")
 
 	return cmd
 }
@@ -78,13 +95,24 @@ func newTagsListCmd() *cobra.Command {
 		output string
 		limit  int
 		all    bool
+		opts   tagListOptions
 	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List tags",
-		Long:  "List all tags in your workspace.",
+		Long: `List all tags in your workspace.
+
+Examples:
+  dub tags list --sort links
+  dub tags list --max-links 0    # unused tags worth deleting`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch opts.sort {
+			case "", "name", "links":
+			default:
+				return NewUsageErrorf("--sort must be one of: name, links")
+			}
+
 			client, err := getClient(cmd.Context())
 			if err != nil {
 				return err
@@ -105,7 +133,7 @@ func newTagsListCmd() *cobra.Command {
 				return err
 			}
 
-			return handleTagsListResponse(cmd, resp, output, limit, all)
+			return handleTagsListResponse(cmd, resp, output, limit, all, opts)
 		},
 	}
 
@@ -113,15 +141,20 @@ func newTagsListCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
 	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of tags to show")
 	cmd.Flags().BoolVar(&all, "all", false, "Show all tags (ignore limit)")
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort by: name (A-Z), links (most first)")
+	cmd.Flags().IntVar(&opts.minLinks, "min-links", -1, "Only show tags with at least this many links")
+	cmd.Flags().IntVar(&opts.maxLinks, "max-links", -1, "Only show tags with at most this many links (0 finds unused tags)")
 
 	return cmd
 }
 
 func newTagsUpdateCmd() *cobra.Command {
 	var (
-		id    string
-		name  string
-		color string
+		id     string
+		name   string
+		color  string
+		patch  patchFlag
+		expect expectFlag
 	)
 
 	cmd := &cobra.Command{
@@ -132,6 +165,9 @@ func newTagsUpdateCmd() *cobra.Command {
 			if id == "" {
 				return fmt.Errorf("--id is required")
 			}
+			if err := expect.validate(); err != nil {
+				return err
+			}
 
 			client, err := getClient(cmd.Context())
 			if err != nil {
@@ -146,9 +182,17 @@ func newTagsUpdateCmd() *cobra.Command {
 				body["color"] = color
 			}
 
+			if err := patch.apply(body); err != nil {
+				return err
+			}
 			if len(body) == 0 {
-				return fmt.Errorf("at least one of --name or --color must be specified")
+				return fmt.Errorf("at least one of --name, --color, or --patch must be specified")
+			}
+
+			if err := expect.checkListed(cmd.Context(), client, "tag", "/tags", id); err != nil {
+				return err
 			}
+			invalidateNameCache(client, cache.KindTag)
 
 			resp, err := client.Patch(cmd.Context(), "/tags/"+url.PathEscape(id), body)
 			if err != nil {
@@ -162,15 +206,58 @@ func newTagsUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&id, "id", "", "Tag ID (required)")
 	cmd.Flags().StringVar(&name, "name", "", "New tag name")
 	cmd.Flags().StringVar(&color, "color", "", "New tag color")
+	patch.register(cmd)
+	expect.register(cmd)
 
 	_ = cmd.MarkFlagRequired("id")
 
 	return cmd
 }
 
+// tagListOptions controls local filtering and sorting of tags list output.
+// Negative link bounds disable the corresponding filter.
+type tagListOptions struct {
+	sort     string
+	minLinks int
+	maxLinks int
+}
+
+// active reports whether any filtering or sorting was requested.
+func (o tagListOptions) active() bool {
+	return o.sort != "" || o.minLinks >= 0 || o.maxLinks >= 0
+}
+
+// apply filters tags by link count and sorts them in place.
+func (o tagListOptions) apply(tags []map[string]interface{}) []map[string]interface{} {
+	filtered := tags[:0]
+	for _, tag := range tags {
+		n := tagLinkCount(tag)
+		if o.minLinks >= 0 && n < o.minLinks {
+			continue
+		}
+		if o.maxLinks >= 0 && n > o.maxLinks {
+			continue
+		}
+		filtered = append(filtered, tag)
+	}
+
+	switch o.sort {
+	case "name":
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return strings.ToLower(outfmt.SafeString(filtered[i]["name"])) < strings.ToLower(outfmt.SafeString(filtered[j]["name"]))
+		})
+	case "links":
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return tagLinkCount(filtered[i]) > tagLinkCount(filtered[j])
+		})
+	}
+
+	return filtered
+}
+
 // handleTagsListResponse handles the response for tags list command,
 // formatting output as table or JSON based on the output flag.
-func handleTagsListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all bool) error {
+func handleTagsListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all bool, opts tagListOptions) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
@@ -183,8 +270,12 @@ func handleTagsListResponse(cmd *cobra.Command, resp *http.Response, output stri
 		return fmt.Errorf("%s", apiErr.Error())
 	}
 
+	if body, err = sortListBody(cmd, body); err != nil {
+		return err
+	}
+
 	// For JSON output, use the existing handler
-	if output == "json" {
+	if output == "json" && !opts.active() {
 		var data interface{}
 		if err := json.Unmarshal(body, &data); err != nil {
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(body))
@@ -199,6 +290,11 @@ func handleTagsListResponse(cmd *cobra.Command, resp *http.Response, output stri
 	if err := json.Unmarshal(body, &tags); err != nil {
 		return fmt.Errorf("failed to parse tags: %w", err)
 	}
+	tags = opts.apply(tags)
+
+	if output == "json" {
+		return outfmt.FormatJSON(cmd.OutOrStdout(), tags, outfmt.GetQuery(cmd.Context()))
+	}
 
 	totalCount := len(tags)
 
@@ -252,20 +348,25 @@ func formatTagColor(color interface{}) string {
 	return s
 }
 
-// formatTagLinkCount extracts the link count from tag data.
-// The API returns link count in _count.links nested structure.
+// formatTagLinkCount formats the link count from tag data.
 func formatTagLinkCount(tag map[string]interface{}) string {
+	return formatClicks(tagLinkCount(tag))
+}
+
+// tagLinkCount extracts the link count from tag data.
+// The API returns link count in _count.links nested structure.
+func tagLinkCount(tag map[string]interface{}) int {
 	// Try _count.links nested structure first
 	if countObj, ok := tag["_count"].(map[string]interface{}); ok {
 		if links, ok := countObj["links"]; ok {
-			return formatClicks(outfmt.SafeInt(links))
+			return outfmt.SafeInt(links)
 		}
 	}
 
 	// Fallback to direct links field
 	if links, ok := tag["links"]; ok {
-		return formatClicks(outfmt.SafeInt(links))
+		return outfmt.SafeInt(links)
 	}
 
-	return "0"
+	return 0
 }
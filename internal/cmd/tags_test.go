@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
 )
 
 // TestTagsCmd_Name verifies the tags command has the correct name
@@ -16,7 +22,7 @@ func TestTagsCmd_Name(t *testing.T) {
 // TestTagsCmd_SubCommands verifies all required subcommands exist
 func TestTagsCmd_SubCommands(t *testing.T) {
 	cmd := newTagsCmd()
-	subCmds := []string{"create", "list", "update"}
+	subCmds := []string{"create", "list", "update", "rename", "merge"}
 
 	for _, name := range subCmds {
 		found := false
@@ -251,3 +257,59 @@ func TestFormatTagLinkCount(t *testing.T) {
 		})
 	}
 }
+
+func runTagsList(t *testing.T, output string, opts tagListOptions) string {
+	t.Helper()
+	body := `[
+		{"id": "t1", "name": "beta", "_count": {"links": 5}},
+		{"id": "t2", "name": "Alpha", "_count": {"links": 0}},
+		{"id": "t3", "name": "gamma", "_count": {"links": 12}}
+	]`
+	resp := &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	if err := handleTagsListResponse(cmd, resp, output, 25, false, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestHandleTagsListResponse_Sort(t *testing.T) {
+	tests := []struct {
+		sort  string
+		order []string
+	}{
+		{"name", []string{"Alpha", "beta", "gamma"}},
+		{"links", []string{"gamma", "beta", "Alpha"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			output := runTagsList(t, "table", tagListOptions{sort: tt.sort, minLinks: -1, maxLinks: -1})
+			last := -1
+			for _, name := range tt.order {
+				idx := strings.Index(output, name)
+				if idx <= last {
+					t.Fatalf("expected order %v, got:\n%s", tt.order, output)
+				}
+				last = idx
+			}
+		})
+	}
+}
+
+func TestHandleTagsListResponse_LinkFilters(t *testing.T) {
+	output := runTagsList(t, "table", tagListOptions{minLinks: -1, maxLinks: 0})
+	if !strings.Contains(output, "Alpha") || strings.Contains(output, "beta") || strings.Contains(output, "gamma") {
+		t.Errorf("expected only unused tags, got:\n%s", output)
+	}
+
+	output = runTagsList(t, "json", tagListOptions{minLinks: 5, maxLinks: -1})
+	if strings.Contains(output, "Alpha") || !strings.Contains(output, "beta") || !strings.Contains(output, "gamma") {
+		t.Errorf("expected JSON filtered to tags with at least 5 links, got:\n%s", output)
+	}
+}
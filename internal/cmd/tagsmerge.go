@@ -0,0 +1,185 @@
+// internal/cmd/tagsmerge.go
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+)
+
+func newTagsRenameCmd() *cobra.Command {
+	var (
+		from        string
+		to          string
+		merge       bool
+		dryRun      bool
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rename",
+		Short: "Rename a tag, or merge it into an existing one",
+		Long: `Rename a tag. If a tag with the new name already exists, pass --merge to
+merge the old tag into it instead (see dub tags merge).
+
+Examples:
+  dub tags rename --from promo --to promotion
+  dub tags rename --from promo --to promotion --merge --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			fromID, err := resolveTagName(cmd, client, from)
+			if err != nil {
+				return err
+			}
+			toID, err := resolveTagName(cmd, client, to)
+			if err != nil && !strings.Contains(err.Error(), "not found") {
+				return err
+			}
+			if toID != "" && toID != fromID {
+				if !merge {
+					return fmt.Errorf("tag %q already exists; pass --merge to merge %q into it", to, from)
+				}
+				return mergeTags(cmd, client, from, fromID, to, toID, concurrency, dryRun)
+			}
+
+			if dryRun {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Would rename tag %s to %s\n", from, to)
+				return nil
+			}
+			invalidateNameCache(client, cache.KindTag)
+			if err := discardResponse(client.Patch(cmd.Context(), "/tags/"+url.PathEscape(fromID), map[string]interface{}{"name": to})); err != nil {
+				return fmt.Errorf("failed to rename tag: %w", err)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Renamed tag %s to %s\n", from, to)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Current tag name (required)")
+	cmd.Flags().StringVar(&to, "to", "", "New tag name (required)")
+	cmd.Flags().BoolVar(&merge, "merge", false, "Merge into the tag named --to if it exists")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without changing anything")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func newTagsMergeCmd() *cobra.Command {
+	var (
+		from        string
+		to          string
+		dryRun      bool
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "merge",
+		Short: "Merge one tag into another",
+		Long: `Retag every link carrying the --from tag with the --to tag, then delete
+the --from tag. The deleted tag can be recreated with dub undo, but links
+are not retagged back.
+
+Examples:
+  dub tags merge --from promo --to promotion --dry-run
+  dub tags merge --from promo --to promotion`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateConcurrency(concurrency); err != nil {
+				return err
+			}
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			fromID, err := resolveTagName(cmd, client, from)
+			if err != nil {
+				return err
+			}
+			toID, err := resolveTagName(cmd, client, to)
+			if err != nil {
+				return err
+			}
+			if fromID == toID {
+				return NewUsageErrorf("--from and --to are the same tag")
+			}
+			return mergeTags(cmd, client, from, fromID, to, toID, concurrency, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Tag to merge and delete (required)")
+	cmd.Flags().StringVar(&to, "to", "", "Tag to keep (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show how many links would be retagged without changing anything")
+	addConcurrencyFlag(cmd, &concurrency)
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func resolveTagName(cmd *cobra.Command, client *api.Client, name string) (string, error) {
+	ids, err := resolveNamesToIDs(cmd.Context(), client, cache.KindTag, []string{name})
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// mergeTags moves every link from tag fromID to tag toID, then deletes
+// fromID. The tag is kept if any link failed to update.
+func mergeTags(cmd *cobra.Command, client *api.Client, from, fromID, to, toID string, concurrency int, dryRun bool) error {
+	links, err := fetchAllLinks[map[string]interface{}](cmd.Context(), client, url.Values{"tagIds": {fromID}})
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if dryRun {
+		_, _ = fmt.Fprintf(w, "Would retag %d link(s) from %s to %s and delete tag %s\n", len(links), from, to, from)
+		return nil
+	}
+
+	if len(links) > 0 {
+		err := updateLinks(cmd, client, links, concurrency, "Retagged", func(link map[string]interface{}) map[string]interface{} {
+			tagIDs := mergeTagIDs(link, []string{fromID}, true)
+			if !slices.Contains(tagIDs, toID) {
+				tagIDs = append(tagIDs, toID)
+			}
+			return map[string]interface{}{"tagIds": tagIDs}
+		})
+		if err != nil {
+			return fmt.Errorf("%w\ntag %s was kept; run the merge again to retry", err, from)
+		}
+	}
+
+	snapshot, snapErr := snapshotListed(cmd.Context(), client, "/tags", fromID)
+	invalidateNameCache(client, cache.KindTag)
+	if err := discardResponse(client.Delete(cmd.Context(), "/tags/"+url.PathEscape(fromID))); err != nil {
+		return fmt.Errorf("retagged %d link(s), but failed to delete tag %s: %w", len(links), from, err)
+	}
+	if snapErr != nil {
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), snapshotWarning("tag", fromID, snapErr))
+	}
+	recordHistory(cmd, newHistoryEntry("delete", "tag", fromID, from, snapshot))
+
+	_, _ = fmt.Fprintf(w, "Merged tag %s into %s (%d link(s) retagged)\n", from, to, len(links))
+	return nil
+}
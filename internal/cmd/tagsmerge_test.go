@@ -0,0 +1,123 @@
+// internal/cmd/tagsmerge_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// tagsMergeServer serves two tags and two links tagged "old", recording
+// every write it receives.
+func tagsMergeServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var writes []string
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tags":
+			_, _ = w.Write([]byte(`[{"id": "tag_old", "name": "old"}, {"id": "tag_new", "name": "new"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/links":
+			if got := r.URL.Query().Get("tagIds"); got != "tag_old" {
+				t.Errorf("tagIds = %q, want tag_old", got)
+			}
+			if r.URL.Query().Get("page") != "1" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[
+				{"id": "link_1", "shortLink": "https://dub.sh/a", "tags": [{"id": "tag_old"}, {"id": "tag_x"}]},
+				{"id": "link_2", "shortLink": "https://dub.sh/b", "tags": [{"id": "tag_old"}, {"id": "tag_new"}]}
+			]`))
+		default:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			data, _ := json.Marshal(body)
+			writes = append(writes, r.Method+" "+r.URL.Path+" "+string(data))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+	return server, &writes
+}
+
+func runTagsCmd(t *testing.T, cmd *cobra.Command, serverURL string, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, serverURL))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestTagsMerge(t *testing.T) {
+	server, writes := tagsMergeServer(t)
+
+	out, err := runTagsCmd(t, newTagsMergeCmd(), server.URL, "--from", "old", "--to", "new", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "Would retag 2 link(s) from old to new and delete tag old\n" {
+		t.Errorf("unexpected dry-run output %q", out)
+	}
+	if len(*writes) != 0 {
+		t.Fatalf("dry run sent %v", *writes)
+	}
+
+	out, err = runTagsCmd(t, newTagsMergeCmd(), server.URL, "--from", "old", "--to", "new")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Merged tag old into new (2 link(s) retagged)") {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	got := append([]string(nil), *writes...)
+	sort.Strings(got)
+	want := []string{
+		`DELETE /tags/tag_old null`,
+		`PATCH /links/link_1 {"tagIds":["tag_x","tag_new"]}`,
+		`PATCH /links/link_2 {"tagIds":["tag_new"]}`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("writes = %v, want %v", got, want)
+	}
+	if last := (*writes)[len(*writes)-1]; !strings.HasPrefix(last, "DELETE") {
+		t.Errorf("tag deleted before links were retagged: %v", *writes)
+	}
+}
+
+func TestTagsRename(t *testing.T) {
+	server, writes := tagsMergeServer(t)
+
+	if _, err := runTagsCmd(t, newTagsRenameCmd(), server.URL, "--from", "old", "--to", "new"); err == nil || !strings.Contains(err.Error(), "--merge") {
+		t.Fatalf("expected conflict error mentioning --merge, got %v", err)
+	}
+
+	out, err := runTagsCmd(t, newTagsRenameCmd(), server.URL, "--from", "old", "--to", "fresh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "Renamed tag old to fresh\n" {
+		t.Errorf("unexpected output %q", out)
+	}
+	if len(*writes) != 1 || (*writes)[0] != `PATCH /tags/tag_old {"name":"fresh"}` {
+		t.Errorf("writes = %v", *writes)
+	}
+
+	*writes = nil
+	out, err = runTagsCmd(t, newTagsRenameCmd(), server.URL, "--from", "old", "--to", "new", "--merge", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(out, "Would retag 2 link(s)") || len(*writes) != 0 {
+		t.Errorf("unexpected dry-run %q, writes %v", out, *writes)
+	}
+}
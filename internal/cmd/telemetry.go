@@ -0,0 +1,125 @@
+// internal/cmd/telemetry.go
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/debug"
+	"github.com/salmonumbrella/dub-cli/internal/telemetry"
+)
+
+func newTelemetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage anonymous usage telemetry",
+		Long: `Manage opt-in anonymous usage telemetry.
+
+When enabled, the CLI reports the command name, duration, success or
+failure, version, and OS/arch to the configured endpoint. No arguments,
+workspace names, API keys, or response data are ever sent.
+
+Telemetry is disabled by default. DUB_TELEMETRY=true|false and
+DUB_TELEMETRY_ENDPOINT override the config file.`,
+	}
+
+	cmd.AddCommand(newTelemetryStatusCmd())
+	cmd.AddCommand(newTelemetryEnableCmd())
+	cmd.AddCommand(newTelemetryDisableCmd())
+
+	return cmd
+}
+
+func newTelemetryStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show telemetry status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, err := telemetry.Resolve()
+			if err != nil {
+				return err
+			}
+
+			state := "disabled"
+			if settings.Enabled {
+				state = "enabled"
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Telemetry: %s (source: %s)\n", state, settings.Source)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Endpoint:  %s\n", valueOrUnset(settings.Endpoint))
+			if settings.Enabled && settings.Endpoint == "" {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No endpoint configured; nothing will be sent.")
+			}
+			return nil
+		},
+	}
+}
+
+func newTelemetryEnableCmd() *cobra.Command {
+	var endpoint string
+
+	cmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Opt in to anonymous usage telemetry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if endpoint != "" {
+				u, err := url.Parse(endpoint)
+				if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					return NewUsageErrorf("invalid --endpoint %q: must be an http(s) URL", endpoint)
+				}
+			} else {
+				cfg, err := config.Load()
+				if err != nil {
+					return err
+				}
+				if cfg.TelemetryEndpoint == "" {
+					return fmt.Errorf("--endpoint is required when no endpoint is configured")
+				}
+			}
+
+			if err := config.SetTelemetry(true, endpoint); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Telemetry enabled. Thank you!")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&endpoint, "endpoint", "", "URL that receives telemetry events")
+
+	return cmd
+}
+
+func newTelemetryDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Opt out of usage telemetry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.SetTelemetry(false, ""); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Telemetry disabled.")
+			return nil
+		},
+	}
+}
+
+// reportTelemetry sends a usage event for an executed command when telemetry
+// is enabled. Failures are logged in debug mode and otherwise ignored.
+func reportTelemetry(ctx context.Context, executed *cobra.Command, duration time.Duration, err error) {
+	settings, resolveErr := telemetry.Resolve()
+	if resolveErr != nil || !settings.Active() || executed == nil {
+		return
+	}
+
+	event := telemetry.NewEvent(executed.CommandPath(), Version, duration, err == nil)
+	if sendErr := telemetry.Send(context.WithoutCancel(ctx), settings.Endpoint, event); sendErr != nil {
+		debug.Log("telemetry send failed", "error", sendErr)
+	}
+}
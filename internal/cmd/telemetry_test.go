@@ -0,0 +1,110 @@
+// internal/cmd/telemetry_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTelemetryCmd_SubCommands(t *testing.T) {
+	cmd := newTelemetryCmd()
+
+	subCmds := []string{"status", "enable", "disable"}
+	for _, name := range subCmds {
+		found := false
+		for _, sub := range cmd.Commands() {
+			if sub.Name() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected subcommand %q to exist", name)
+		}
+	}
+}
+
+func TestTelemetryEnable_RequiresEndpoint(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cmd := newTelemetryEnableCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("expected error when no endpoint is configured")
+	}
+	if !IsUsageError(err) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
+
+func TestTelemetryEnable_InvalidEndpoint(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cmd := newTelemetryEnableCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--endpoint", "not a url"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for invalid endpoint")
+	}
+}
+
+func TestTelemetry_EnableStatusDisable(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_TELEMETRY", "")
+	t.Setenv("DUB_TELEMETRY_ENDPOINT", "")
+
+	run := func(args ...string) string {
+		cmd := newTelemetryCmd()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return buf.String()
+	}
+
+	run("enable", "--endpoint", "https://telemetry.example.com")
+	if out := run("status"); !strings.Contains(out, "Telemetry: enabled (source: config)") {
+		t.Errorf("expected enabled status, got %q", out)
+	}
+
+	run("disable")
+	if out := run("status"); !strings.Contains(out, "Telemetry: disabled") {
+		t.Errorf("expected disabled status, got %q", out)
+	}
+}
+
+func TestReportTelemetry_SendsWhenEnabled(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_TELEMETRY_ENDPOINT", server.URL)
+
+	t.Setenv("DUB_TELEMETRY", "false")
+	reportTelemetry(context.Background(), newVersionCmd(), time.Second, nil)
+	if atomic.LoadInt32(&hits) != 0 {
+		t.Fatal("expected no event when telemetry is disabled")
+	}
+
+	t.Setenv("DUB_TELEMETRY", "true")
+	reportTelemetry(context.Background(), newVersionCmd(), time.Second, nil)
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("expected 1 event, got %d", atomic.LoadInt32(&hits))
+	}
+}
@@ -0,0 +1,112 @@
+// internal/cmd/template.go
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// bodyTemplateFlags render a request body from a Go template instead of
+// reading JSON from stdin.
+type bodyTemplateFlags struct {
+	path string
+	vars []string
+}
+
+func (f *bodyTemplateFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.path, "data-template", "", "Render the JSON body from this Go template file instead of reading stdin")
+	cmd.Flags().StringArrayVar(&f.vars, "var", nil, "Template variable as key=value (repeatable)")
+}
+
+// readBody returns the decoded JSON body, rendered from the template when
+// --data-template is set and read from stdin otherwise.
+func (f *bodyTemplateFlags) readBody(cmd *cobra.Command) (interface{}, error) {
+	if f.path == "" && len(f.vars) > 0 {
+		return nil, NewUsageErrorf("--var requires --data-template")
+	}
+
+	var input []byte
+	if f.path != "" {
+		vars, err := parseTemplateVars(f.vars)
+		if err != nil {
+			return nil, err
+		}
+		input, err = renderBodyTemplate(f.path, vars)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		input, err = io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(input, &body); err != nil {
+		if f.path != "" {
+			return nil, fmt.Errorf("template %s did not render valid JSON: %w", f.path, err)
+		}
+		return nil, fmt.Errorf("invalid JSON input: %w", err)
+	}
+	return body, nil
+}
+
+// parseTemplateVars parses key=value pairs from --var.
+func parseTemplateVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, NewUsageErrorf("--var %q: expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// bodyTemplateFuncs help templates produce valid JSON.
+var bodyTemplateFuncs = template.FuncMap{
+	// json encodes a value, e.g. "url": {{json .url}}
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	// split turns a delimited variable into a list for range
+	"split": func(s, sep string) []string {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, sep)
+	},
+	"env": os.Getenv,
+}
+
+// renderBodyTemplate executes the template at path with vars. Referencing
+// a variable that wasn't given is an error.
+func renderBodyTemplate(path string, vars map[string]string) ([]byte, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(bodyTemplateFuncs).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+// internal/cmd/template_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLinksBulkCreate_DataTemplate(t *testing.T) {
+	var body []map[string]interface{}
+	server := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	tmpl := filepath.Join(t.TempDir(), "links.tmpl")
+	text := `[{{range $i, $key := split .keys ","}}{{if $i}},{{end}}
+  {"url": {{json $.url}}, "key": {{json $key}}}{{end}}]`
+	if err := os.WriteFile(tmpl, []byte(text), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newLinksBulkCreateCmd()
+	cmd.SetContext(context.WithValue(context.Background(), apiURLKey, server.URL))
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--data-template", tmpl, "--var", `url=https://example.com/?q="x"`, "--var", "keys=spring,summer"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []map[string]interface{}{
+		{"url": `https://example.com/?q="x"`, "key": "spring"},
+		{"url": `https://example.com/?q="x"`, "key": "summer"},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %v, want %v", body, want)
+	}
+}
+
+func TestRenderBodyTemplate_MissingVar(t *testing.T) {
+	tmpl := filepath.Join(t.TempDir(), "body.tmpl")
+	if err := os.WriteFile(tmpl, []byte(`{"url": {{json .url}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := renderBodyTemplate(tmpl, map[string]string{}); err == nil || !strings.Contains(err.Error(), "url") {
+		t.Errorf("expected missing variable error, got %v", err)
+	}
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	vars, err := parseTemplateVars([]string{"a=1", "b=x=y"})
+	if err != nil || vars["a"] != "1" || vars["b"] != "x=y" {
+		t.Errorf("unexpected vars %v (err %v)", vars, err)
+	}
+	if _, err := parseTemplateVars([]string{"novalue"}); !IsUsageError(err) {
+		t.Errorf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+// internal/cmd/testapi_test.go
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/cache"
+)
+
+// newTestAPI starts a fake Dub API serving handler for the length of the
+// test. Requests are handled one at a time, so handlers can record what they
+// receive without locking. See setTestEnv for the environment it sets up.
+func newTestAPI(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	setTestEnv(t)
+
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// setTestEnv sets an API key, disables the daemon and points the cache and
+// state directories at temporary ones, so a test neither reads nor writes
+// the user's files. Tests whose handlers must run concurrently call it
+// before starting their own server.
+func setTestEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("DUB_API_KEY", "dub_test")
+	t.Setenv("DUB_NO_DAEMON", "1")
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	_ = cache.Clear()
+}
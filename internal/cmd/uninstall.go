@@ -0,0 +1,306 @@
+// internal/cmd/uninstall.go
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
+)
+
+// uninstallItem is one thing dub uninstall removes, or leaves in place with
+// the reason why.
+type uninstallItem struct {
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	Kept   string `json:"kept,omitempty"`
+	Error  string `json:"error,omitempty"`
+	remove func() error
+}
+
+func newUninstallCmd() *cobra.Command {
+	var (
+		dryRun          bool
+		keepCredentials bool
+		keepBinary      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove dub and everything it stored",
+		Long: `Remove the dub binary, the API keys stored in the keyring, the config,
+state, and cache directories, and any shell completions installed with
+dub completion install, printing each thing removed.
+
+Stored API keys are only removed after confirmation (or with --yes). The
+binary is left in place when a package manager such as Homebrew owns it,
+or when the current user can't write to its directory.
+
+Examples:
+  dub uninstall --dry-run
+  dub uninstall
+  dub uninstall --keep-credentials --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			items, err := planUninstall(keepCredentials, keepBinary)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if dryRun {
+				if outfmt.GetFormat(cmd.Context()) == "json" {
+					return outfmt.FormatJSON(out, items, outfmt.GetQuery(cmd.Context()))
+				}
+				for _, item := range items {
+					if item.Kept != "" {
+						_, _ = fmt.Fprintf(out, "Would keep %s %s (%s)\n", item.Kind, item.Path, item.Kept)
+						continue
+					}
+					_, _ = fmt.Fprintf(out, "Would remove %s %s\n", item.Kind, item.Path)
+				}
+				return nil
+			}
+
+			if err := confirmCredentialRemoval(cmd, items); err != nil {
+				return err
+			}
+			keepKeyringUnlessRemoved(items)
+
+			failed := 0
+			for i := range items {
+				item := &items[i]
+				if item.Kept != "" {
+					continue
+				}
+				if err := item.remove(); err != nil {
+					item.Error = err.Error()
+					failed++
+				}
+			}
+
+			if outfmt.GetFormat(cmd.Context()) == "json" {
+				if err := outfmt.FormatJSON(out, items, outfmt.GetQuery(cmd.Context())); err != nil {
+					return err
+				}
+			} else {
+				if len(items) == 0 {
+					_, _ = fmt.Fprintln(out, "Nothing to remove.")
+				}
+				for _, item := range items {
+					switch {
+					case item.Error != "":
+						_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(fmt.Sprintf("failed to remove %s %s: %s", item.Kind, item.Path, item.Error)))
+					case item.Kept != "":
+						_, _ = fmt.Fprintf(out, "Kept %s %s (%s)\n", item.Kind, item.Path, item.Kept)
+					default:
+						_, _ = fmt.Fprintf(out, "Removed %s %s\n", item.Kind, item.Path)
+					}
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("failed to remove %d item(s)", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without removing anything")
+	cmd.Flags().BoolVar(&keepCredentials, "keep-credentials", false, "Leave the API keys in the keyring")
+	cmd.Flags().BoolVar(&keepBinary, "keep-binary", false, "Leave the dub binary in place")
+
+	return cmd
+}
+
+// planUninstall lists what uninstall removes, in the order it removes it:
+// credentials first, since the file keyring lives in the config directory,
+// and the running binary last.
+func planUninstall(keepCredentials, keepBinary bool) ([]uninstallItem, error) {
+	var items []uninstallItem
+
+	if !keepCredentials {
+		if item, ok := planCredentialRemoval(); ok {
+			items = append(items, item)
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, planCompletionRemoval(home, brewPrefix())...)
+
+	for _, dir := range []struct {
+		kind string
+		path func() (string, error)
+	}{
+		{"cache directory", config.CacheDir},
+		{"state directory", config.StateDir},
+		{"config directory", config.Dir},
+	} {
+		path, err := dir.path()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		items = append(items, uninstallItem{Kind: dir.kind, Path: path, remove: func() error { return os.RemoveAll(path) }})
+	}
+
+	keepKeyringUnlessRemoved(items)
+
+	if !keepBinary {
+		if path, err := upgradeTarget(""); err == nil {
+			item := uninstallItem{Kind: "binary", Path: path, remove: func() error { return os.Remove(path) }}
+			item.Kept = binaryKeepReason(path)
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// keepKeyringUnlessRemoved narrows removing the config directory to
+// everything but the file keyring, which keeps its keys there, unless stored
+// keys are being removed: not when they're kept, unconfirmed, or couldn't be
+// listed.
+func keepKeyringUnlessRemoved(items []uninstallItem) {
+	for _, item := range items {
+		if item.Kind == "credentials" && item.Kept == "" {
+			return
+		}
+	}
+	for i := range items {
+		if items[i].Kind != "config directory" {
+			continue
+		}
+		path := items[i].Path
+		items[i] = uninstallItem{Kind: "config directory (except keyring)", Path: path, remove: func() error {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				if e.Name() == "keyring" {
+					continue
+				}
+				if err := os.RemoveAll(filepath.Join(path, e.Name())); err != nil {
+					return err
+				}
+			}
+			return nil
+		}}
+	}
+}
+
+// planCredentialRemoval lists the workspaces with keys in the keyring.
+func planCredentialRemoval() (uninstallItem, bool) {
+	store, err := storeOpener()
+	if err != nil {
+		return uninstallItem{}, false
+	}
+	creds, err := store.List()
+	if err != nil || len(creds) == 0 {
+		return uninstallItem{}, false
+	}
+
+	seen := map[string]bool{}
+	var workspaces []string
+	for _, c := range creds {
+		if !seen[c.Name] {
+			seen[c.Name] = true
+			workspaces = append(workspaces, c.Name)
+		}
+	}
+	sort.Strings(workspaces)
+
+	return uninstallItem{
+		Kind: "credentials",
+		Path: "keyring: " + strings.Join(workspaces, ", "),
+		remove: func() error {
+			for _, name := range workspaces {
+				if err := store.Delete(name); err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+			}
+			return nil
+		},
+	}, true
+}
+
+// planCompletionRemoval lists completion scripts and rc file lines written by
+// dub completion install, for every shell.
+func planCompletionRemoval(home, brew string) []uninstallItem {
+	var items []uninstallItem
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		plan, err := planCompletionInstall(shell, home, brew)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(plan.ScriptPath); err == nil {
+			script := plan.ScriptPath
+			items = append(items, uninstallItem{Kind: shell + " completion", Path: script, remove: func() error { return os.Remove(script) }})
+		}
+		if plan.RCFile != "" && rcFileContains(plan.RCFile, plan.RCLines) {
+			rcFile, lines := plan.RCFile, plan.RCLines
+			items = append(items, uninstallItem{Kind: shell + " completion lines in", Path: rcFile, remove: func() error {
+				_, err := removeRCLines(rcFile, lines)
+				return err
+			}})
+		}
+	}
+	return items
+}
+
+// binaryKeepReason returns why the binary at path shouldn't be removed, or ""
+// if it can be.
+func binaryKeepReason(path string) string {
+	if filepath.Base(path) != binaryName() {
+		return "not a dub binary"
+	}
+	slash := filepath.ToSlash(path)
+	for _, managed := range []string{"/Cellar/", "/homebrew/", "/linuxbrew/", "/nix/store/", "/scoop/"} {
+		if strings.Contains(slash, managed) {
+			return "installed by a package manager; uninstall it with that"
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "Windows can't delete a running program; delete it after dub exits"
+	}
+	if err := checkWritableDir(filepath.Dir(path)); err != nil {
+		return "not writable by the current user; remove it with sudo"
+	}
+	return ""
+}
+
+// confirmCredentialRemoval asks before removing stored API keys, keeping
+// them unless the answer is yes. --yes skips the question.
+func confirmCredentialRemoval(cmd *cobra.Command, items []uninstallItem) error {
+	for i := range items {
+		item := &items[i]
+		if item.Kind != "credentials" || outfmt.GetYes(cmd.Context()) {
+			continue
+		}
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Remove stored API keys (%s)? They can't be recovered. [y/N]: ", strings.TrimPrefix(item.Path, "keyring: "))
+		line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+			item.Kept = "not confirmed"
+		}
+	}
+	return nil
+}
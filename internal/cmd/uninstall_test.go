@@ -0,0 +1,188 @@
+// internal/cmd/uninstall_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+	"github.com/salmonumbrella/dub-cli/internal/secrets"
+)
+
+// setupUninstallHome creates a home directory with everything dub writes and
+// a keyring holding a key for the acme workspace.
+func setupUninstallHome(t *testing.T) (string, *mockStore) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("HOMEBREW_PREFIX", filepath.Join(home, "brew"))
+
+	files := map[string]string{
+		".config/dub-cli/config.yaml":       "default_workspace: acme\n",
+		".config/dub-cli/keyring/acme":      "secret",
+		"state/dub-cli/history.json":        "[]",
+		"cache/dub-cli/tags.json":           "[]",
+		"brew/etc/bash_completion.d/dub":    "# completion",
+		".config/fish/completions/dub.fish": "# completion",
+		".zsh/completions/_dub":             "# completion",
+		".zshrc":                            "export EDITOR=vim\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(home, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plan, err := planCompletionInstall("zsh", home, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := appendRCLines(plan.RCFile, plan.RCLines); err != nil {
+		t.Fatal(err)
+	}
+
+	store := newMockStore()
+	_ = store.Set("acme", secrets.Credentials{Name: "acme", APIKey: "dub_test"})
+	origOpener := storeOpener
+	storeOpener = func() (secrets.Store, error) { return store, nil }
+	t.Cleanup(func() { storeOpener = origOpener })
+
+	return home, store
+}
+
+func runUninstall(t *testing.T, yes bool, stdin string, args ...string) (string, error) {
+	t.Helper()
+	cmd := newUninstallCmd()
+	cmd.SetContext(outfmt.WithYes(context.Background(), yes))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestUninstall_RemovesEverything(t *testing.T) {
+	home, store := setupUninstallHome(t)
+
+	out, err := runUninstall(t, true, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{".config/dub-cli", "state/dub-cli", "cache/dub-cli", "brew/etc/bash_completion.d/dub", ".config/fish/completions/dub.fish", ".zsh/completions/_dub"} {
+		if _, err := os.Stat(filepath.Join(home, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", name)
+		}
+		if !strings.Contains(out, filepath.Join(home, name)) {
+			t.Errorf("expected output to list %s, got:\n%s", name, out)
+		}
+	}
+	if len(store.creds) != 0 {
+		t.Errorf("expected credentials to be removed, got %v", store.creds)
+	}
+
+	zshrc, err := os.ReadFile(filepath.Join(home, ".zshrc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(zshrc) != "export EDITOR=vim\n" {
+		t.Errorf("expected .zshrc to be restored, got %q", zshrc)
+	}
+
+	// The test binary isn't named dub, so it must be left alone
+	if !strings.Contains(out, "Kept binary") || !strings.Contains(out, "not a dub binary") {
+		t.Errorf("expected the test binary to be kept, got:\n%s", out)
+	}
+}
+
+func TestUninstall_CredentialsNeedConfirmation(t *testing.T) {
+	home, store := setupUninstallHome(t)
+
+	out, err := runUninstall(t, false, "n\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.creds) != 1 {
+		t.Errorf("expected credentials to be kept, got %v", store.creds)
+	}
+	if !strings.Contains(out, "Kept credentials keyring: acme (not confirmed)") {
+		t.Errorf("expected credentials to be reported as kept, got:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(home, "cache", "dub-cli")); !os.IsNotExist(err) {
+		t.Error("expected the cache to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "dub-cli", "keyring", "acme")); err != nil {
+		t.Errorf("expected the file keyring to be kept: %v", err)
+	}
+}
+
+func TestUninstall_KeepCredentialsKeepsFileKeyring(t *testing.T) {
+	home, store := setupUninstallHome(t)
+
+	if _, err := runUninstall(t, true, "", "--keep-credentials"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.creds) != 1 {
+		t.Errorf("expected credentials to be kept, got %v", store.creds)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "dub-cli", "keyring", "acme")); err != nil {
+		t.Errorf("expected the file keyring to be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "dub-cli", "config.yaml")); !os.IsNotExist(err) {
+		t.Error("expected the config file to be removed")
+	}
+}
+
+func TestUninstall_DryRun(t *testing.T) {
+	home, store := setupUninstallHome(t)
+
+	out, err := runUninstall(t, false, "", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Would remove config directory") {
+		t.Errorf("expected planned removals, got:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "dub-cli")); err != nil {
+		t.Errorf("dry run removed the config directory: %v", err)
+	}
+	if len(store.creds) != 1 {
+		t.Error("dry run removed credentials")
+	}
+}
+
+func TestRemoveRCLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".bashrc")
+	lines := []string{"source dub"}
+	if err := os.WriteFile(path, []byte("alias ll='ls -l'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := appendRCLines(path, lines); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := removeRCLines(path, lines)
+	if err != nil || !removed {
+		t.Fatalf("expected lines to be removed, got %v, %v", removed, err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "alias ll='ls -l'\n" {
+		t.Errorf("unexpected rc file %q", data)
+	}
+
+	if removed, _ := removeRCLines(path, lines); removed {
+		t.Error("expected no change on a second removal")
+	}
+}
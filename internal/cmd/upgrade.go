@@ -4,7 +4,10 @@ package cmd
 import (
 	"archive/tar"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,6 +19,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"golang.org/x/mod/semver"
+
+	"github.com/salmonumbrella/dub-cli/internal/bspatch"
+	"github.com/salmonumbrella/dub-cli/internal/ui"
 )
 
 const (
@@ -33,15 +39,22 @@ const (
 
 // GitHubRelease represents a release from the GitHub API
 type GitHubRelease struct {
-	TagName string `json:"tag_name"`
-	Assets  []struct {
-		Name               string `json:"name"`
-		BrowserDownloadURL string `json:"browser_download_url"`
-	} `json:"assets"`
+	TagName string         `json:"tag_name"`
+	Assets  []ReleaseAsset `json:"assets"`
+}
+
+// ReleaseAsset is a file attached to a GitHub release
+type ReleaseAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
 }
 
 func newUpgradeCmd() *cobra.Command {
-	var checkOnly bool
+	var (
+		checkOnly  bool
+		noDelta    bool
+		installDir string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "upgrade",
@@ -51,20 +64,32 @@ func newUpgradeCmd() *cobra.Command {
 This command fetches the latest release from GitHub and replaces the
 current binary if a newer version is available.
 
+If the current binary lives in a directory you can't write to, such as
+/usr/local/bin, use --install-dir to install a copy somewhere you own
+instead. The copy only takes effect if that directory comes first in PATH.
+
+When the release publishes a binary patch from your version, only the patch
+is downloaded and applied to the current binary. If there is none, or the
+patched binary doesn't match its published checksum, the full archive is
+downloaded instead.
+
 Examples:
-  dub upgrade          # Upgrade to latest version
-  dub upgrade --check  # Only check for updates, don't install`,
+  dub upgrade                              # Upgrade to latest version
+  dub upgrade --check                      # Only check for updates, don't install
+  dub upgrade --install-dir ~/.local/bin   # Install a copy without root`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpgrade(cmd, checkOnly)
+			return runUpgrade(cmd, checkOnly, noDelta, installDir)
 		},
 	}
 
 	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for updates, don't install")
+	cmd.Flags().BoolVar(&noDelta, "no-delta", false, "Always download the full archive instead of a binary patch")
+	cmd.Flags().StringVar(&installDir, "install-dir", "", "Install the new binary into this directory instead of replacing the current one")
 
 	return cmd
 }
 
-func runUpgrade(cmd *cobra.Command, checkOnly bool) error {
+func runUpgrade(cmd *cobra.Command, checkOnly, noDelta bool, installDir string) error {
 	currentVersion := normalizeVersion(Version)
 
 	// dev builds can't be compared
@@ -97,31 +122,140 @@ func runUpgrade(cmd *cobra.Command, checkOnly bool) error {
 		return nil
 	}
 
-	// Find the appropriate asset for current OS/arch
-	assetName := buildAssetName(release.TagName)
-	var downloadURL string
-	for _, asset := range release.Assets {
-		if asset.Name == assetName {
-			downloadURL = asset.BrowserDownloadURL
-			break
+	// Check the install location before downloading anything
+	target, err := upgradeTarget(installDir)
+	if err != nil {
+		return err
+	}
+	if err := checkWritableDir(filepath.Dir(target)); err != nil {
+		if installDir != "" {
+			return fmt.Errorf("cannot install into %s: %w", filepath.Dir(target), err)
 		}
+		return fmt.Errorf("cannot replace %s: %w\nRerun with sudo, or install a copy you own with: dub upgrade --install-dir ~/.local/bin", target, err)
 	}
 
-	if downloadURL == "" {
-		return fmt.Errorf("no release asset found for %s/%s (looking for %s)", runtime.GOOS, runtime.GOARCH, assetName)
+	installed := false
+	if !noDelta {
+		current, err := upgradeTarget("")
+		if err != nil {
+			return err
+		}
+		switch err := deltaUpgrade(cmd, release, current, target); {
+		case err == nil:
+			installed = true
+		case errors.Is(err, errNoPatch):
+		default:
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(fmt.Sprintf("patch upgrade failed (%v); downloading the full archive", err)))
+		}
 	}
 
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nDownloading %s...\n", assetName)
+	if !installed {
+		// Find the appropriate asset for current OS/arch
+		assetName := buildAssetName(release.TagName)
+		downloadURL := findAsset(release, assetName)
+		if downloadURL == "" {
+			return fmt.Errorf("no release asset found for %s/%s (looking for %s)", runtime.GOOS, runtime.GOARCH, assetName)
+		}
+
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nDownloading %s...\n", assetName)
 
-	// Download and install
-	if err := downloadAndInstall(downloadURL); err != nil {
-		return fmt.Errorf("failed to upgrade: %w", err)
+		// Download and install
+		if err := downloadAndInstall(downloadURL, target); err != nil {
+			return fmt.Errorf("failed to upgrade: %w", err)
+		}
 	}
 
+	if installDir != "" {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Successfully installed %s to %s\n", release.TagName, target)
+		if warning := pathWarning(target, os.Getenv("PATH")); warning != "" {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), ui.Warning(warning))
+		}
+		return nil
+	}
 	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Successfully upgraded to %s\n", release.TagName)
 	return nil
 }
 
+// upgradeTarget returns the path the new binary is written to: the running
+// executable, or with installDir a binary of the same name in that directory,
+// which is created if needed.
+func upgradeTarget(installDir string) (string, error) {
+	if installDir == "" {
+		execPath, err := os.Executable()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine current executable path: %w", err)
+		}
+		execPath, err = filepath.EvalSymlinks(execPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve executable path: %w", err)
+		}
+		return execPath, nil
+	}
+
+	if installDir == "~" || strings.HasPrefix(installDir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		installDir = filepath.Join(home, strings.TrimPrefix(installDir, "~"))
+	}
+	dir, err := filepath.Abs(installDir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create install directory: %w", err)
+	}
+	return filepath.Join(dir, binaryName()), nil
+}
+
+// checkWritableDir reports whether files can be created in dir, which both
+// the temp file and the final rename need.
+func checkWritableDir(dir string) error {
+	f, err := os.CreateTemp(dir, ".dub-upgrade-check-*")
+	if err != nil {
+		if os.IsPermission(err) {
+			return fmt.Errorf("%s is not writable by the current user", dir)
+		}
+		return err
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+	return nil
+}
+
+// pathWarning explains why running dub might not pick up the binary
+// installed at target: its directory isn't on PATH, or another dub comes
+// before it.
+func pathWarning(target, path string) string {
+	dir := filepath.Dir(target)
+	var before []string
+	for _, entry := range filepath.SplitList(path) {
+		if entry == "" {
+			continue
+		}
+		if filepath.Clean(entry) == dir {
+			for _, earlier := range before {
+				candidate := filepath.Join(earlier, binaryName())
+				if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+					return fmt.Sprintf("%s comes before %s in PATH, so dub still runs the old version; move %s earlier in PATH or remove %s", candidate, target, dir, candidate)
+				}
+			}
+			return ""
+		}
+		before = append(before, entry)
+	}
+	return fmt.Sprintf("%s is not in PATH; add it to run the new version as dub", dir)
+}
+
+// binaryName is the file name of the dub executable on this platform.
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "dub.exe"
+	}
+	return "dub"
+}
+
 func fetchLatestRelease() (*GitHubRelease, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", githubAPI, repoOwner, repoName)
 
@@ -174,38 +308,123 @@ func buildAssetName(version string) string {
 	return fmt.Sprintf("dub-cli_%s_%s_%s.tar.gz", ver, runtime.GOOS, runtime.GOARCH)
 }
 
-func downloadAndInstall(downloadURL string) error {
-	// Get current executable path
-	execPath, err := os.Executable()
+// findAsset returns the download URL of the named release asset, or "".
+func findAsset(release *GitHubRelease, name string) string {
+	for _, asset := range release.Assets {
+		if asset.Name == name {
+			return asset.BrowserDownloadURL
+		}
+	}
+	return ""
+}
+
+// buildPatchName is the asset holding the bsdiff patch from one release's
+// binary to another's for this OS/arch. It's published alongside a
+// PATCH.sha256 asset with the checksum of the patched binary.
+func buildPatchName(from, to string) string {
+	return fmt.Sprintf("dub-cli_%s_to_%s_%s_%s.bsdiff", strings.TrimPrefix(from, "v"), strings.TrimPrefix(to, "v"), runtime.GOOS, runtime.GOARCH)
+}
+
+// errNoPatch means the release has no patch from the running version.
+var errNoPatch = errors.New("no patch available")
+
+// deltaUpgrade installs the release at target by patching the current
+// binary, verifying the result against the published checksum.
+func deltaUpgrade(cmd *cobra.Command, release *GitHubRelease, current, target string) error {
+	patchName := buildPatchName(Version, release.TagName)
+	patchURL := findAsset(release, patchName)
+	sumURL := findAsset(release, patchName+".sha256")
+	if patchURL == "" || sumURL == "" {
+		return errNoPatch
+	}
+
+	old, err := os.ReadFile(current)
+	if err != nil {
+		return fmt.Errorf("failed to read current binary: %w", err)
+	}
+
+	sum, err := downloadBytes(sumURL)
 	if err != nil {
-		return fmt.Errorf("failed to determine current executable path: %w", err)
+		return fmt.Errorf("failed to download checksum: %w", err)
+	}
+	fields := strings.Fields(string(sum))
+	if len(fields) == 0 {
+		return fmt.Errorf("empty checksum file")
 	}
-	execPath, err = filepath.EvalSymlinks(execPath)
+	want := strings.ToLower(fields[0])
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nDownloading %s...\n", patchName)
+	patch, err := downloadBytes(patchURL)
 	if err != nil {
-		return fmt.Errorf("failed to resolve executable path: %w", err)
+		return fmt.Errorf("failed to download patch: %w", err)
+	}
+
+	binary, err := bspatch.Apply(old, patch, maxDownloadSize)
+	if err != nil {
+		return err
+	}
+	if got := sha256.Sum256(binary); hex.EncodeToString(got[:]) != want {
+		return fmt.Errorf("patched binary doesn't match the published checksum")
 	}
 
-	// Download the archive with timeout and User-Agent
+	return installBinary(target, func(w io.Writer) error {
+		_, err := w.Write(binary)
+		return err
+	})
+}
+
+// download GETs url, returning a body limited to maxDownloadSize.
+func download(url string) (io.ReadCloser, error) {
 	client := &http.Client{Timeout: httpTimeout}
-	req, err := http.NewRequest("GET", downloadURL, nil)
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("User-Agent", "dub-cli/"+Version)
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
+		return nil, fmt.Errorf("failed to download: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
 	}
 
 	// Limit response body size to prevent unbounded memory usage
-	limitedReader := io.LimitReader(resp.Body, maxDownloadSize)
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, maxDownloadSize), resp.Body}, nil
+}
+
+func downloadBytes(url string) ([]byte, error) {
+	body, err := download(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+	return io.ReadAll(body)
+}
 
+func downloadAndInstall(downloadURL, execPath string) error {
+	body, err := download(downloadURL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = body.Close() }()
+
+	return installBinary(execPath, func(w io.Writer) error {
+		// Extract binary from tar.gz
+		if err := extractBinary(body, w); err != nil {
+			return fmt.Errorf("failed to extract binary: %w", err)
+		}
+		return nil
+	})
+}
+
+// installBinary replaces execPath with the executable that write produces.
+func installBinary(execPath string, write func(w io.Writer) error) error {
 	// Create temp file in same directory as executable to avoid cross-filesystem rename issues
 	tmpFile, err := os.CreateTemp(filepath.Dir(execPath), "dub-upgrade-*")
 	if err != nil {
@@ -214,10 +433,9 @@ func downloadAndInstall(downloadURL string) error {
 	tmpPath := tmpFile.Name()
 	defer func() { _ = os.Remove(tmpPath) }() // Clean up temp file
 
-	// Extract binary from tar.gz
-	if err := extractBinary(limitedReader, tmpFile); err != nil {
+	if err := write(tmpFile); err != nil {
 		_ = tmpFile.Close()
-		return fmt.Errorf("failed to extract binary: %w", err)
+		return err
 	}
 	_ = tmpFile.Close()
 
@@ -232,7 +450,8 @@ func downloadAndInstall(downloadURL string) error {
 	if runtime.GOOS == "windows" {
 		oldPath := execPath + ".old"
 		_ = os.Remove(oldPath) // Remove any previous .old file
-		if err := os.Rename(execPath, oldPath); err != nil {
+		// With --install-dir there may be no old binary to move aside
+		if err := os.Rename(execPath, oldPath); err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("failed to move old binary: %w", err)
 		}
 		if err := os.Rename(tmpPath, execPath); err != nil {
@@ -250,7 +469,7 @@ func downloadAndInstall(downloadURL string) error {
 	return nil
 }
 
-func extractBinary(r io.Reader, dst *os.File) error {
+func extractBinary(r io.Reader, dst io.Writer) error {
 	gzr, err := gzip.NewReader(r)
 	if err != nil {
 		return err
@@ -260,10 +479,7 @@ func extractBinary(r io.Reader, dst *os.File) error {
 	tr := tar.NewReader(gzr)
 
 	// Look for the dub binary in the archive
-	binaryName := "dub"
-	if runtime.GOOS == "windows" {
-		binaryName = "dub.exe"
-	}
+	binaryName := binaryName()
 
 	for {
 		header, err := tr.Next()
@@ -3,6 +3,16 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -116,3 +126,138 @@ func TestUpgradeCmd_DevVersion(t *testing.T) {
 		t.Errorf("expected output to contain %q, got %q", expected, output)
 	}
 }
+
+func TestUpgradeTarget_InstallDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	target, err := upgradeTarget("~/.local/bin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := filepath.Join(home, ".local", "bin", binaryName())
+	if target != want {
+		t.Errorf("expected %s, got %s", want, target)
+	}
+	if info, err := os.Stat(filepath.Dir(target)); err != nil || !info.IsDir() {
+		t.Errorf("expected install directory to be created, got %v", err)
+	}
+}
+
+func TestCheckWritableDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := checkWritableDir(dir); err != nil {
+		t.Errorf("expected %s to be writable, got %v", dir, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected the check to clean up, found %d file(s)", len(entries))
+	}
+
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions aren't enforced")
+	}
+	readOnly := filepath.Join(dir, "ro")
+	if err := os.Mkdir(readOnly, 0o555); err != nil {
+		t.Fatal(err)
+	}
+	err := checkWritableDir(readOnly)
+	if err == nil || !strings.Contains(err.Error(), "not writable") {
+		t.Errorf("expected not writable error, got %v", err)
+	}
+}
+
+func TestPathWarning(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	dir := t.TempDir()
+	shadow := t.TempDir()
+	target := filepath.Join(dir, binaryName())
+	if err := os.WriteFile(filepath.Join(shadow, binaryName()), []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if w := pathWarning(target, dir+sep+shadow); w != "" {
+		t.Errorf("expected no warning when the install dir comes first, got %q", w)
+	}
+	if w := pathWarning(target, shadow+sep+dir); !strings.Contains(w, "comes before") {
+		t.Errorf("expected a shadowing warning, got %q", w)
+	}
+	if w := pathWarning(target, shadow); !strings.Contains(w, "not in PATH") {
+		t.Errorf("expected a not in PATH warning, got %q", w)
+	}
+}
+
+// upgradeTestPatch is a bsdiff patch from "hello world, this is dub v1.0" to
+// "hello world, this is dub v1.1 (patched)".
+const upgradeTestPatch = "42534449464634302b0000000000000029000000000000002700000000000000" +
+	"425a6839314159265359eb5289ae000005e0004818000220002186819a0c56c9b8bb9229c284875a944d70" +
+	"425a683931415926535960623767000000600060000040200030cc0cf505ce2ee48a70a120c0c46ece" +
+	"425a68393141592653597a499344000000118040602e404400200031003020036a4c621023c5dc914e14241e9264d100"
+
+func runDeltaUpgrade(t *testing.T, checksum string) (string, error) {
+	t.Helper()
+	originalVersion := Version
+	defer func() { Version = originalVersion }()
+	Version = "1.0.0"
+
+	patch, err := hex.DecodeString(upgradeTestPatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = fmt.Fprintf(w, "%s  dub\n", checksum)
+			return
+		}
+		_, _ = w.Write(patch)
+	}))
+	defer server.Close()
+
+	name := buildPatchName("v1.0.0", "v1.1.0")
+	release := &GitHubRelease{TagName: "v1.1.0", Assets: []ReleaseAsset{
+		{Name: name, BrowserDownloadURL: server.URL + "/" + name},
+		{Name: name + ".sha256", BrowserDownloadURL: server.URL + "/" + name + ".sha256"},
+	}}
+
+	dir := t.TempDir()
+	current := filepath.Join(dir, "current")
+	if err := os.WriteFile(current, []byte("hello world, this is dub v1.0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(dir, "dub")
+
+	cmd := newUpgradeCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	if err := deltaUpgrade(cmd, release, current, target); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), nil
+}
+
+func TestDeltaUpgrade(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello world, this is dub v1.1 (patched)"))
+	got, err := runDeltaUpgrade(t, hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "hello world, this is dub v1.1 (patched)" {
+		t.Errorf("unexpected patched binary %q", got)
+	}
+}
+
+func TestDeltaUpgrade_ChecksumMismatch(t *testing.T) {
+	_, err := runDeltaUpgrade(t, strings.Repeat("0", 64))
+	if err == nil || errors.Is(err, errNoPatch) || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("expected a checksum error, got %v", err)
+	}
+}
+
+func TestDeltaUpgrade_NoPatch(t *testing.T) {
+	err := deltaUpgrade(newUpgradeCmd(), &GitHubRelease{TagName: "v1.1.0"}, "current", "target")
+	if !errors.Is(err, errNoPatch) {
+		t.Errorf("expected errNoPatch, got %v", err)
+	}
+}
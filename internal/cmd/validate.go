@@ -0,0 +1,180 @@
+// internal/cmd/validate.go
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
+)
+
+// validateProblem is an invalid entry in a link file. Row is the CSV row
+// below the header or the NDJSON line, and 0 for problems with the whole file.
+type validateProblem struct {
+	Row   int    `json:"row,omitempty"`
+	Error string `json:"error"`
+}
+
+// validateResult is the outcome of checking one file.
+type validateResult struct {
+	File     string            `json:"file"`
+	Links    int               `json:"links"`
+	Problems []validateProblem `json:"problems"`
+}
+
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <file>...",
+		Short: "Check link files without touching the API",
+		Long: `Check files of links to create, running the same local checks as the
+importer: known fields and their types, required fields, absolute http(s)
+destination URLs, and RFC 3339 expiration timestamps. Nothing is sent to the
+API, so no API key is needed.
+
+Files are read by extension:
+  .csv             the columns of dub links import
+  .ndjson, .jsonl  one POST /links body per line, as for links create --from-stdin
+
+Every problem is reported, and the command fails if there are any, so it
+can run as a CI check.
+
+Examples:
+  dub validate links.csv
+  dub validate links/*.ndjson
+  dub validate links.csv -o json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results := make([]validateResult, 0, len(args))
+			for _, path := range args {
+				result, err := validateLinkFile(path)
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+			}
+
+			if err := writeValidateResults(cmd, results); err != nil {
+				return err
+			}
+			problems, files := 0, 0
+			for _, r := range results {
+				problems += len(r.Problems)
+				if len(r.Problems) > 0 {
+					files++
+				}
+			}
+			if problems > 0 {
+				return fmt.Errorf("found %d problem(s) in %d of %d file(s)", problems, files, len(results))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// validateLinkFile checks the links in path. Invalid links are reported in
+// the result; the error is for files that can't be checked at all.
+func validateLinkFile(path string) (validateResult, error) {
+	result := validateResult{File: path, Problems: []validateProblem{}}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".csv" && ext != ".ndjson" && ext != ".jsonl" {
+		return result, NewUsageErrorf("%s: unsupported file type %q (expected .csv, .ndjson, or .jsonl)", path, ext)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if ext == ".csv" {
+		rows, err := parseImportCSV(bytes.NewReader(data))
+		result.Links = len(rows)
+		var errs []error
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		} else if err != nil {
+			errs = []error{err}
+		}
+		for _, err := range errs {
+			var rowErr *importRowError
+			if errors.As(err, &rowErr) {
+				result.Links++
+				result.Problems = append(result.Problems, validateProblem{Row: rowErr.Row, Error: rowErr.Err.Error()})
+			} else {
+				result.Problems = append(result.Problems, validateProblem{Error: err.Error()})
+			}
+		}
+		return result, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		result.Links++
+		if err := validateLinkBody(text); err != nil {
+			result.Problems = append(result.Problems, validateProblem{Row: line, Error: err.Error()})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		result.Problems = append(result.Problems, validateProblem{Error: err.Error()})
+	}
+	return result, nil
+}
+
+// validateLinkBody checks one JSON body for POST /links.
+func validateLinkBody(text string) error {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var body map[string]interface{}
+	if err := dec.Decode(&body); err != nil || body == nil {
+		return fmt.Errorf("not a JSON object")
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("more than one JSON value on the line")
+	}
+	if err := linkCreateSchema.validate(body, true); err != nil {
+		return err
+	}
+	return validateImportRow(importRow{Body: body})
+}
+
+func writeValidateResults(cmd *cobra.Command, results []validateResult) error {
+	w := cmd.OutOrStdout()
+	if outfmt.GetFormat(cmd.Context()) == "json" {
+		return outfmt.FormatJSON(w, results, outfmt.GetQuery(cmd.Context()))
+	}
+
+	for _, r := range results {
+		if len(r.Problems) == 0 {
+			_, _ = fmt.Fprintf(w, "%s: %d link(s), OK\n", r.File, r.Links)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s: %d link(s), %d problem(s)\n", r.File, r.Links, len(r.Problems))
+		unit := "row"
+		if !strings.EqualFold(filepath.Ext(r.File), ".csv") {
+			unit = "line"
+		}
+		for _, p := range r.Problems {
+			if p.Row == 0 {
+				_, _ = fmt.Fprintf(w, "  %s\n", p.Error)
+			} else {
+				_, _ = fmt.Fprintf(w, "  %s %d: %s\n", unit, p.Row, p.Error)
+			}
+		}
+	}
+	return nil
+}
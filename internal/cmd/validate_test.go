@@ -0,0 +1,92 @@
+// internal/cmd/validate_test.go
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateCmd(t *testing.T) {
+	t.Setenv("DUB_NO_DAEMON", "1")
+	t.Setenv("DUB_API_KEY", "")
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	good := write("good.csv", "url,key,tags\nhttps://example.com,a,docs\nhttps://example.org,b,\n")
+	badCSV := write("bad.csv", "url,expiresAt\nftp://example.com,\nhttps://example.com,tomorrow\nhttps://example.org,\n")
+	badNDJSON := write("links.ndjson", `{"url": "https://example.com", "key": "a"}
+
+{"url": "https://example.com", "clicks": 3}
+{"key": "b"}
+[1, 2]
+{"url": "https://example.com", "expiresAt": "2030-01-01T00:00:00Z"}
+`)
+
+	var stdout bytes.Buffer
+	if err := execute(context.Background(), []string{"validate", good}, nil, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "good.csv: 2 link(s), OK") {
+		t.Errorf("unexpected output %q", stdout.String())
+	}
+
+	stdout.Reset()
+	err := execute(context.Background(), []string{"validate", good, badCSV, badNDJSON}, nil, &stdout, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "found 5 problem(s) in 2 of 3 file(s)") {
+		t.Errorf("expected problems to fail the command, got %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"bad.csv: 3 link(s), 2 problem(s)",
+		`  row 1: invalid url "ftp://example.com"`,
+		`  row 2: invalid expiresAt "tomorrow"`,
+		"links.ndjson: 5 link(s), 3 problem(s)",
+		`  line 3: invalid request body: unknown field "clicks"`,
+		"  line 4: invalid request body: url is required",
+		"  line 5: not a JSON object",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	_ = execute(context.Background(), []string{"validate", badCSV, "-o", "json"}, nil, &stdout, &bytes.Buffer{})
+	var results []validateResult
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout.String(), err)
+	}
+	if len(results) != 1 || results[0].Links != 3 || len(results[0].Problems) != 2 || results[0].Problems[1].Row != 2 {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	err = execute(context.Background(), []string{"validate", write("links.txt", "")}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+	if err == nil || !IsUsageError(err) {
+		t.Errorf("expected usage error for an unknown file type, got %v", err)
+	}
+}
+
+func TestValidateCmd_BadHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.csv")
+	if err := os.WriteFile(path, []byte("link\nhttps://example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result, err := validateLinkFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Problems) != 1 || result.Problems[0].Row != 0 || !strings.Contains(result.Problems[0].Error, `unknown CSV column "link"`) {
+		t.Errorf("unexpected problems %+v", result.Problems)
+	}
+}
@@ -2,10 +2,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/dub-cli/internal/api"
+	"github.com/salmonumbrella/dub-cli/internal/outfmt"
 )
 
 func newWorkspacesCmd() *cobra.Command {
@@ -17,6 +23,7 @@ func newWorkspacesCmd() *cobra.Command {
 
 	cmd.AddCommand(newWorkspacesGetCmd())
 	cmd.AddCommand(newWorkspacesUpdateCmd())
+	cmd.AddCommand(newWorkspacesMembersCmd())
 
 	return cmd
 }
@@ -56,9 +63,10 @@ func newWorkspacesGetCmd() *cobra.Command {
 
 func newWorkspacesUpdateCmd() *cobra.Command {
 	var (
-		id   string
-		name string
-		slug string
+		id    string
+		name  string
+		slug  string
+		patch patchFlag
 	)
 
 	cmd := &cobra.Command{
@@ -83,8 +91,11 @@ func newWorkspacesUpdateCmd() *cobra.Command {
 				body["slug"] = slug
 			}
 
+			if err := patch.apply(body); err != nil {
+				return err
+			}
 			if len(body) == 0 {
-				return fmt.Errorf("at least one of --name or --slug must be specified")
+				return fmt.Errorf("at least one of --name, --slug, or --patch must be specified")
 			}
 
 			resp, err := client.Patch(cmd.Context(), "/workspaces/"+url.PathEscape(id), body)
@@ -99,8 +110,142 @@ func newWorkspacesUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&id, "id", "", "Workspace ID or slug (required)")
 	cmd.Flags().StringVar(&name, "name", "", "New workspace name")
 	cmd.Flags().StringVar(&slug, "slug", "", "New workspace slug")
+	patch.register(cmd)
 
 	_ = cmd.MarkFlagRequired("id")
 
 	return cmd
 }
+
+func newWorkspacesMembersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "members",
+		Short: "Manage workspace members",
+		Long:  "List the members of a workspace and their roles.",
+	}
+
+	cmd.AddCommand(newWorkspacesMembersListCmd())
+
+	return cmd
+}
+
+func newWorkspacesMembersListCmd() *cobra.Command {
+	var (
+		id     string
+		output string
+		limit  int
+		all    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List workspace members",
+		Long: `List the members of a workspace.
+
+Combine with "dub links list --show-user" or "--user-id" to audit who
+created which links.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if id == "" {
+				return fmt.Errorf("--id is required")
+			}
+
+			client, err := getClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(cmd.Context(), "/workspaces/"+url.PathEscape(id)+"/users")
+			if err != nil {
+				return err
+			}
+
+			return handleMembersListResponse(cmd, resp, output, limit, all)
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Workspace ID or slug (required)")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of members to show")
+	cmd.Flags().BoolVar(&all, "all", false, "Show all members (ignore limit)")
+
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+// handleMembersListResponse handles the response for workspaces members list,
+// formatting output as table or JSON based on the output flag.
+func handleMembersListResponse(cmd *cobra.Command, resp *http.Response, output string, limit int, all bool) error {
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := api.ParseAPIError(body)
+		return fmt.Errorf("%s", apiErr.Error())
+	}
+
+	if body, err = sortListBody(cmd, body); err != nil {
+		return err
+	}
+
+	// For JSON output, use the existing handler
+	if output == "json" {
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(body))
+			return nil
+		}
+		query := outfmt.GetQuery(cmd.Context())
+		return outfmt.FormatJSON(cmd.OutOrStdout(), data, query)
+	}
+
+	var members []map[string]interface{}
+	if err := json.Unmarshal(body, &members); err != nil {
+		return fmt.Errorf("failed to parse members: %w", err)
+	}
+
+	totalCount := len(members)
+
+	// Apply limit unless --all is set
+	displayLimit := limit
+	if all {
+		displayLimit = totalCount
+	}
+	if displayLimit > totalCount {
+		displayLimit = totalCount
+	}
+
+	displayMembers := members[:displayLimit]
+
+	columns := []outfmt.Column{
+		{Name: "ID", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Name", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Email", Width: 0, Align: outfmt.AlignLeft},
+		{Name: "Role", Width: 0, Align: outfmt.AlignLeft},
+	}
+
+	rows := make([][]string, len(displayMembers))
+	for i, m := range displayMembers {
+		rows[i] = []string{
+			dashIfEmpty(outfmt.SafeString(m["id"])),
+			dashIfEmpty(outfmt.SafeString(m["name"])),
+			dashIfEmpty(outfmt.SafeString(m["email"])),
+			dashIfEmpty(outfmt.SafeString(m["role"])),
+		}
+	}
+
+	if err := outfmt.FormatTable(cmd.OutOrStdout(), columns, rows); err != nil {
+		return err
+	}
+
+	// Show pagination message if limited
+	if displayLimit < totalCount {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "\nShowing %d of %d members. Use --limit or --all for more.\n", displayLimit, totalCount)
+	}
+
+	return nil
+}
@@ -1,7 +1,16 @@
 // internal/cmd/workspaces_test.go
 package cmd
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
 
 func TestWorkspacesCmd_Name(t *testing.T) {
 	cmd := newWorkspacesCmd()
@@ -13,7 +22,7 @@ func TestWorkspacesCmd_Name(t *testing.T) {
 func TestWorkspacesCmd_SubCommands(t *testing.T) {
 	cmd := newWorkspacesCmd()
 
-	subCmds := []string{"get", "update"}
+	subCmds := []string{"get", "update", "members"}
 	for _, name := range subCmds {
 		found := false
 		for _, sub := range cmd.Commands() {
@@ -79,3 +88,41 @@ func TestWorkspacesUpdateCmd_Flags(t *testing.T) {
 		}
 	}
 }
+
+func TestWorkspacesMembersListCmd_RequiresID(t *testing.T) {
+	cmd := newWorkspacesMembersListCmd()
+	cmd.SetArgs([]string{})
+
+	err := cmd.Execute()
+	if err == nil {
+		t.Error("expected error when --id is not provided")
+	}
+}
+
+func TestHandleMembersListResponse_TableOutput(t *testing.T) {
+	jsonBody := `[
+		{"id": "user_1", "name": "Ada", "email": "ada@example.com", "role": "owner"},
+		{"id": "user_2", "name": null, "email": "bob@example.com", "role": "member"}
+	]`
+
+	resp := &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(jsonBody)),
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	if err := handleMembersListResponse(cmd, resp, "table", 1, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"EMAIL", "ROLE", "ada@example.com", "owner", "Showing 1 of 2 members"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -4,25 +4,51 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // ErrNoDefaultWorkspace is returned when no default workspace is configured
 var ErrNoDefaultWorkspace = errors.New("no default workspace configured")
 
+// ErrContextNotFound is returned when a named context does not exist
+var ErrContextNotFound = errors.New("context not found")
+
+// Context bundles settings applied together, similar to kubectl contexts
+type Context struct {
+	Workspace     string `json:"workspace,omitempty"`
+	APIURL        string `json:"api_url,omitempty"`
+	DefaultDomain string `json:"default_domain,omitempty"`
+}
+
 // Config represents the CLI configuration stored on disk
 type Config struct {
-	DefaultWorkspace string `json:"default_workspace,omitempty"`
+	DefaultWorkspace  string `json:"default_workspace,omitempty"`
+	Telemetry         bool   `json:"telemetry,omitempty"`
+	TelemetryEndpoint string `json:"telemetry_endpoint,omitempty"`
+	// CacheTTL is how long resolved name→ID lookups are reused (e.g. "10m", "0" to disable)
+	CacheTTL string `json:"cache_ttl,omitempty"`
+	// Contexts are named bundles of workspace, API URL, and defaults
+	Contexts       map[string]Context `json:"contexts,omitempty"`
+	CurrentContext string             `json:"current_context,omitempty"`
+	// AuditLog appends a record of every mutating API request to audit.jsonl
+	AuditLog bool `json:"audit_log,omitempty"`
+	// Defaults are flag values keyed by command path, e.g.
+	// {"links": {"list": {"limit": 100}}} or {"links.list": {...}}
+	Defaults map[string]interface{} `json:"defaults,omitempty"`
 }
 
 // configPath returns the path to the config file (~/.config/dub-cli/config.json)
 func configPath() (string, error) {
-	home, err := os.UserHomeDir()
+	dir, err := Dir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".config", AppName, "config.json"), nil
+	return filepath.Join(dir, "config.json"), nil
 }
 
 // Load reads the configuration from disk
@@ -100,3 +126,164 @@ func ClearDefaultWorkspace() error {
 	cfg.DefaultWorkspace = ""
 	return cfg.Save()
 }
+
+// SetTelemetry enables or disables anonymous usage telemetry.
+// An empty endpoint keeps the previously configured endpoint.
+func SetTelemetry(enabled bool, endpoint string) error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	cfg.Telemetry = enabled
+	if endpoint != "" {
+		cfg.TelemetryEndpoint = endpoint
+	}
+	return cfg.Save()
+}
+
+// GetContext returns the named context.
+// Returns ErrContextNotFound if it does not exist.
+func GetContext(name string) (Context, error) {
+	cfg, err := Load()
+	if err != nil {
+		return Context{}, err
+	}
+	c, ok := cfg.Contexts[name]
+	if !ok {
+		return Context{}, ErrContextNotFound
+	}
+	return c, nil
+}
+
+// SetContext creates or replaces the named context
+func SetContext(name string, c Context) error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	if cfg.Contexts == nil {
+		cfg.Contexts = map[string]Context{}
+	}
+	cfg.Contexts[name] = c
+	return cfg.Save()
+}
+
+// DeleteContext removes the named context, clearing it if it is current.
+// Returns ErrContextNotFound if it does not exist.
+func DeleteContext(name string) error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Contexts[name]; !ok {
+		return ErrContextNotFound
+	}
+	delete(cfg.Contexts, name)
+	if cfg.CurrentContext == name {
+		cfg.CurrentContext = ""
+	}
+	return cfg.Save()
+}
+
+// UseContext makes the named context current. An empty name clears it.
+// Returns ErrContextNotFound if the context does not exist.
+func UseContext(name string) error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		if _, ok := cfg.Contexts[name]; !ok {
+			return ErrContextNotFound
+		}
+	}
+	cfg.CurrentContext = name
+	return cfg.Save()
+}
+
+// FlagDefaults resolves the flag values that defaults assigns to the command
+// at path, e.g. ["links", "list"]. Values set directly under defaults apply
+// to every command, and deeper tables override shallower ones. Nested
+// tables and dotted keys ("links.list") are equivalent.
+func FlagDefaults(defaults map[string]interface{}, path []string) (map[string]string, error) {
+	out := map[string]string{}
+	table := expandDottedKeys(defaults)
+	for depth := 0; table != nil; depth++ {
+		for key, v := range table {
+			if _, ok := v.(map[string]interface{}); ok {
+				continue
+			}
+			value, err := flagValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("defaults.%s: %w", strings.Join(append(slices.Clone(path[:depth]), key), "."), err)
+			}
+			out[key] = value
+		}
+		if depth == len(path) {
+			break
+		}
+		table, _ = table[path[depth]].(map[string]interface{})
+	}
+	return out, nil
+}
+
+// expandDottedKeys turns {"links.list": {...}} into {"links": {"list": {...}}}.
+func expandDottedKeys(table map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for key, v := range table {
+		if sub, ok := v.(map[string]interface{}); ok {
+			v = expandDottedKeys(sub)
+		}
+		parts := strings.Split(key, ".")
+		dest := out
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := dest[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				dest[part] = next
+			}
+			dest = next
+		}
+		mergeTable(dest, parts[len(parts)-1], v)
+	}
+	return out
+}
+
+// mergeTable sets dest[key] to v, merging tables present in both.
+func mergeTable(dest map[string]interface{}, key string, v interface{}) {
+	existing, ok := dest[key].(map[string]interface{})
+	sub, isTable := v.(map[string]interface{})
+	if !ok || !isTable {
+		dest[key] = v
+		return
+	}
+	for k, sv := range sub {
+		mergeTable(existing, k, sv)
+	}
+}
+
+// flagValue renders a config value the way it would be typed as a flag.
+func flagValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			s, err := flagValue(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value %v", v)
+	}
+}
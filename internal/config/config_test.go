@@ -2,9 +2,13 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestConfig_SaveLoad(t *testing.T) {
@@ -162,3 +166,203 @@ func TestConfig_FilePermissions(t *testing.T) {
 		t.Errorf("expected file permissions 0600, got %04o", perm)
 	}
 }
+
+func TestSetTelemetry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := SetTelemetry(true, "https://telemetry.example.com/v1"); err != nil {
+		t.Fatalf("failed to enable telemetry: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.Telemetry || cfg.TelemetryEndpoint != "https://telemetry.example.com/v1" {
+		t.Errorf("unexpected telemetry config: %+v", cfg)
+	}
+
+	// Disabling without an endpoint keeps the configured endpoint
+	if err := SetTelemetry(false, ""); err != nil {
+		t.Fatalf("failed to disable telemetry: %v", err)
+	}
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Telemetry {
+		t.Error("expected telemetry to be disabled")
+	}
+	if cfg.TelemetryEndpoint != "https://telemetry.example.com/v1" {
+		t.Errorf("expected endpoint to be preserved, got %q", cfg.TelemetryEndpoint)
+	}
+}
+
+func TestContexts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := UseContext("prod"); err != ErrContextNotFound {
+		t.Errorf("expected ErrContextNotFound, got %v", err)
+	}
+
+	prod := Context{Workspace: "acme", APIURL: "https://api.example.com", DefaultDomain: "go.acme.com"}
+	if err := SetContext("prod", prod); err != nil {
+		t.Fatalf("failed to set context: %v", err)
+	}
+	if err := UseContext("prod"); err != nil {
+		t.Fatalf("failed to use context: %v", err)
+	}
+
+	got, err := GetContext("prod")
+	if err != nil || got != prod {
+		t.Errorf("expected %+v, got %+v (err %v)", prod, got, err)
+	}
+
+	if err := DeleteContext("prod"); err != nil {
+		t.Fatalf("failed to delete context: %v", err)
+	}
+	cfg, _ := Load()
+	if cfg.CurrentContext != "" || len(cfg.Contexts) != 0 {
+		t.Errorf("expected context removed and cleared, got %+v", cfg)
+	}
+}
+
+func TestCursors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if got, err := GetCursor("events:acme"); err != nil || got != "" {
+		t.Fatalf("expected empty cursor, got %q (err %v)", got, err)
+	}
+
+	if err := SetCursor("events:acme", "2024-01-15T10:30:00Z"); err != nil {
+		t.Fatalf("failed to set cursor: %v", err)
+	}
+	if err := SetCursor("events:other", "2024-02-01T00:00:00Z"); err != nil {
+		t.Fatalf("failed to set cursor: %v", err)
+	}
+
+	if got, _ := GetCursor("events:acme"); got != "2024-01-15T10:30:00Z" {
+		t.Errorf("expected stored cursor, got %q", got)
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "docs", "site")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if p, err := FindProject(nested); err != nil || p != nil {
+		t.Fatalf("expected no project, got %+v (err %v)", p, err)
+	}
+
+	content := "workspace = \"acme\"\ndefault_domain = \"go.acme.com\"\ndefault_tags = [\"docs\", \"launch\"]\n\n[defaults.links.list]\nlimit = 100\n"
+	if err := os.WriteFile(filepath.Join(root, ProjectFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := FindProject(nested)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Workspace != "acme" || p.DefaultDomain != "go.acme.com" || len(p.DefaultTags) != 2 || p.Defaults == nil || p.Path != filepath.Join(root, ProjectFileName) {
+		t.Errorf("unexpected project %+v", p)
+	}
+
+	if err := os.WriteFile(filepath.Join(nested, ProjectFileName), []byte("workspce = \"typo\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindProject(nested); err == nil {
+		t.Error("expected error for unknown key")
+	}
+}
+
+func TestFlagDefaults(t *testing.T) {
+	var defaults map[string]interface{}
+	data := `{"output": "json", "links": {"limit": 50, "list": {"limit": 100, "archived": true}}, "links.list": {"tag-name": ["a", "b"]}}`
+	if err := json.Unmarshal([]byte(data), &defaults); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FlagDefaults(defaults, []string{"links", "list"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"output": "json", "limit": "100", "archived": "true", "tag-name": "a,b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FlagDefaults(links list) = %v, want %v", got, want)
+	}
+
+	got, _ = FlagDefaults(defaults, []string{"links", "create"})
+	if want := map[string]string{"output": "json", "limit": "50"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FlagDefaults(links create) = %v, want %v", got, want)
+	}
+}
+
+func TestHistory_KeepsMostRecent(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	for i := 0; i < HistoryLimit+5; i++ {
+		if err := AppendHistory(HistoryEntry{Action: "delete", Kind: "tag"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := LoadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != HistoryLimit {
+		t.Fatalf("expected %d entries, got %d", HistoryLimit, len(history))
+	}
+	if first, last := history[0].Seq, history[len(history)-1].Seq; first != 6 || last != HistoryLimit+5 {
+		t.Errorf("expected entries 6..%d, got %d..%d", HistoryLimit+5, first, last)
+	}
+}
+
+func TestLinkHistory_PerLink(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	for i := 0; i < LinkHistoryLimit+2; i++ {
+		if err := AppendLinkHistory("link_1", LinkChange{Command: "dub links update"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := AppendLinkHistory("link_2", LinkChange{Command: "dub links update"}); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := LoadLinkHistory("link_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != LinkHistoryLimit || changes[0].Seq != 3 || changes[len(changes)-1].Seq != LinkHistoryLimit+2 {
+		t.Errorf("expected changes 3..%d, got %d entries", LinkHistoryLimit+2, len(changes))
+	}
+	if other, _ := LoadLinkHistory("link_2"); len(other) != 1 || other[0].Seq != 1 {
+		t.Errorf("expected link_2 to be numbered separately, got %+v", other)
+	}
+}
+
+func TestAppendLinkHistories_CapsLinks(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	changes := map[string]LinkChange{}
+	for i := 0; i <= LinkHistoryMaxLinks; i++ {
+		changes[fmt.Sprintf("link_%d", i)] = LinkChange{Time: start.Add(time.Duration(i) * time.Minute)}
+	}
+	if err := AppendLinkHistories(changes); err != nil {
+		t.Fatal(err)
+	}
+
+	histories, err := loadLinkHistories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(histories) != LinkHistoryMaxLinks || histories["link_0"] != nil || histories["link_1"] == nil {
+		t.Errorf("expected only the oldest link dropped, got %d link(s)", len(histories))
+	}
+}
@@ -0,0 +1,71 @@
+// internal/config/cursors.go
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// cursorsPath returns the path of the file holding incremental fetch cursors
+// (~/.config/dub-cli/cursors.json).
+func cursorsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cursors.json"), nil
+}
+
+func loadCursors() (map[string]string, error) {
+	path, err := cursorsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	cursors := map[string]string{}
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, err
+	}
+	return cursors, nil
+}
+
+// GetCursor returns the stored cursor for key, or "" if none is stored.
+func GetCursor(key string) (string, error) {
+	cursors, err := loadCursors()
+	if err != nil {
+		return "", err
+	}
+	return cursors[key], nil
+}
+
+// SetCursor stores the cursor for key.
+func SetCursor(key, value string) error {
+	cursors, err := loadCursors()
+	if err != nil {
+		return err
+	}
+	cursors[key] = value
+
+	path, err := cursorsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
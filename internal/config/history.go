@@ -0,0 +1,145 @@
+// internal/config/history.go
+package config
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryLimit is how many operations the history keeps; older ones can no
+// longer be undone.
+const HistoryLimit = 50
+
+// HistoryEntry records a destructive operation so it can be undone.
+type HistoryEntry struct {
+	Seq        int       `json:"seq"`
+	Time       time.Time `json:"time"`
+	Workspace  string    `json:"workspace,omitempty"`
+	Action     string    `json:"action"` // "delete" or "archive"
+	Kind       string    `json:"kind"`   // "link", "tag", or "folder"
+	ResourceID string    `json:"resourceId"`
+	Label      string    `json:"label,omitempty"`
+	// Snapshot is the resource as it was before a delete
+	Snapshot map[string]interface{} `json:"snapshot,omitempty"`
+	// RestoredID is the ID of the resource recreated by undo, or the
+	// original ID for an unarchive. Empty until undone.
+	RestoredID string `json:"restoredId,omitempty"`
+	Undone     bool   `json:"undone,omitempty"`
+}
+
+// historyPath returns the path of the undo history
+// (~/.local/state/dub-cli/history.json).
+func historyPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.json"), nil
+}
+
+// Redacted stands in for secret fields, such as a link's password, in the
+// snapshots kept by the history files.
+const Redacted = "[redacted]"
+
+// secretFields are snapshot fields never written to the history files.
+var secretFields = []string{"password"}
+
+// redactSecrets returns snapshot with the secret fields that are set
+// replaced by Redacted, copying it only when there are any.
+func redactSecrets(snapshot map[string]interface{}) map[string]interface{} {
+	var redacted map[string]interface{}
+	for _, field := range secretFields {
+		if v := snapshot[field]; v == nil || v == "" || v == Redacted {
+			continue
+		}
+		if redacted == nil {
+			redacted = maps.Clone(snapshot)
+		}
+		redacted[field] = Redacted
+	}
+	if redacted == nil {
+		return snapshot
+	}
+	return redacted
+}
+
+// readState decodes the JSON state file at path into dest, leaving dest
+// unchanged if the file doesn't exist yet.
+func readState(path string, dest interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// writeState writes v as indented JSON to the state file at path, readable
+// only by the user.
+func writeState(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadHistory returns the recorded operations, oldest first.
+func LoadHistory() ([]HistoryEntry, error) {
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	if err := readState(path, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// SaveHistory writes entries, keeping only the most recent HistoryLimit,
+// with the secret fields of their snapshots redacted.
+func SaveHistory(entries []HistoryEntry) error {
+	if len(entries) > HistoryLimit {
+		entries = entries[len(entries)-HistoryLimit:]
+	}
+	saved := make([]HistoryEntry, len(entries))
+	for i, e := range entries {
+		e.Snapshot = redactSecrets(e.Snapshot)
+		saved[i] = e
+	}
+
+	path, err := historyPath()
+	if err != nil {
+		return err
+	}
+	return writeState(path, saved)
+}
+
+// AppendHistory records entries, numbering them after the latest one.
+func AppendHistory(entries ...HistoryEntry) error {
+	history, err := LoadHistory()
+	if err != nil {
+		return err
+	}
+
+	seq := 0
+	if len(history) > 0 {
+		seq = history[len(history)-1].Seq
+	}
+	for _, e := range entries {
+		seq++
+		e.Seq = seq
+		history = append(history, e)
+	}
+	return SaveHistory(history)
+}
@@ -0,0 +1,117 @@
+// internal/config/linkhistory.go
+package config
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// LinkHistoryLimit is how many changes are kept per link.
+const LinkHistoryLimit = 20
+
+// LinkHistoryMaxLinks is how many links have their changes kept; the links
+// changed longest ago are dropped first.
+const LinkHistoryMaxLinks = 500
+
+// LinkChange records a link as it was before and after one update made by
+// this CLI. Secret fields, such as a password, are kept only as Redacted.
+type LinkChange struct {
+	Seq       int                    `json:"seq"`
+	Time      time.Time              `json:"time"`
+	Workspace string                 `json:"workspace,omitempty"`
+	Command   string                 `json:"command"`
+	Before    map[string]interface{} `json:"before"`
+	After     map[string]interface{} `json:"after,omitempty"`
+}
+
+// linkHistoryPath returns the path of the per-link change log
+// (~/.local/state/dub-cli/link-history.json).
+func linkHistoryPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "link-history.json"), nil
+}
+
+func loadLinkHistories() (map[string][]LinkChange, error) {
+	path, err := linkHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	histories := map[string][]LinkChange{}
+	if err := readState(path, &histories); err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// LoadLinkHistory returns the recorded changes to the link with id, oldest
+// first.
+func LoadLinkHistory(id string) ([]LinkChange, error) {
+	histories, err := loadLinkHistories()
+	if err != nil {
+		return nil, err
+	}
+	return histories[id], nil
+}
+
+// AppendLinkHistory records a change to the link with id, numbering it after
+// the link's latest change and keeping only the most recent
+// LinkHistoryLimit.
+func AppendLinkHistory(id string, change LinkChange) error {
+	return AppendLinkHistories(map[string]LinkChange{id: change})
+}
+
+// AppendLinkHistories records a change to each of several links, keyed by
+// link ID, as AppendLinkHistory does, reading and writing the file once.
+// Secret fields are redacted, and only the LinkHistoryMaxLinks most
+// recently changed links are kept.
+func AppendLinkHistories(changes map[string]LinkChange) error {
+	histories, err := loadLinkHistories()
+	if err != nil {
+		return err
+	}
+
+	for id, change := range changes {
+		history := histories[id]
+		change.Seq = 1
+		if len(history) > 0 {
+			change.Seq = history[len(history)-1].Seq + 1
+		}
+		change.Before = redactSecrets(change.Before)
+		change.After = redactSecrets(change.After)
+		history = append(history, change)
+		if len(history) > LinkHistoryLimit {
+			history = history[len(history)-LinkHistoryLimit:]
+		}
+		histories[id] = history
+	}
+	pruneLinkHistories(histories)
+
+	path, err := linkHistoryPath()
+	if err != nil {
+		return err
+	}
+	return writeState(path, histories)
+}
+
+// pruneLinkHistories drops the histories of the links changed longest ago
+// until at most LinkHistoryMaxLinks remain.
+func pruneLinkHistories(histories map[string][]LinkChange) {
+	if len(histories) <= LinkHistoryMaxLinks {
+		return
+	}
+	ids := slices.Collect(maps.Keys(histories))
+	latest := func(id string) time.Time {
+		h := histories[id]
+		return h[len(h)-1].Time
+	}
+	slices.SortFunc(ids, func(a, b string) int { return latest(a).Compare(latest(b)) })
+	for _, id := range ids[:len(ids)-LinkHistoryMaxLinks] {
+		delete(histories, id)
+	}
+}
@@ -1,6 +1,95 @@
 // internal/config/paths.go
 package config
 
+import (
+	"os"
+	"path/filepath"
+)
+
 const (
 	AppName = "dub-cli"
 )
+
+// Dir returns the directory holding the CLI configuration (~/.config/dub-cli).
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", AppName), nil
+}
+
+// StateDir returns the directory for local runtime state such as logs
+// (~/.local/state/dub-cli). XDG_STATE_HOME is honored when set.
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, AppName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", AppName), nil
+}
+
+// CacheDir returns the directory for disposable cached data
+// (~/.cache/dub-cli). XDG_CACHE_HOME is honored when set.
+func CacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, AppName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cache", AppName), nil
+}
+
+// DebugLogPath returns the path of the debug log written when --debug is set.
+func DebugLogPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "debug.log"), nil
+}
+
+// AuditLogPath returns the path of the audit log written when audit_log is
+// enabled in the config.
+func AuditLogPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "audit.jsonl"), nil
+}
+
+// MirrorPath returns the path of the SQLite mirror of a workspace, named by
+// scope, kept by dub mirror sync.
+func MirrorPath(scope string) (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mirror", scope+".db"), nil
+}
+
+// CronLockPath returns the path of the lock file that keeps runs of the dub
+// cron job called name from overlapping.
+func CronLockPath(name string) (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cron", name+".lock"), nil
+}
+
+// KeyringMigratedPath returns the path of the marker recording that keys
+// stored by older versions were moved to the current keyring backend.
+func KeyringMigratedPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "keyring-migrated"), nil
+}
@@ -0,0 +1,75 @@
+// internal/config/project.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProjectFileName is the project-local config file looked up from the
+// working directory upwards.
+const ProjectFileName = ".dub.toml"
+
+// Project holds settings from a .dub.toml file. They take precedence over
+// the user config, but not over flags or environment variables.
+type Project struct {
+	Workspace     string   `toml:"workspace"`
+	DefaultDomain string   `toml:"default_domain"`
+	DefaultTags   []string `toml:"default_tags"`
+	// Defaults are per-command flag values, as in Config.Defaults
+	Defaults map[string]interface{} `toml:"defaults"`
+
+	// Path is the file the settings were read from
+	Path string `toml:"-"`
+}
+
+// FindProject walks up from dir looking for a .dub.toml file.
+// Returns nil without error if there is none.
+func FindProject(dir string) (*Project, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if _, err := os.Stat(path); err == nil {
+			return LoadProject(path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProject reads a .dub.toml file, rejecting unknown keys so typos
+// don't go unnoticed.
+func LoadProject(path string) (*Project, error) {
+	var p Project
+	meta, err := toml.DecodeFile(path, &p)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	var unknown []string
+	for _, k := range meta.Undecoded() {
+		// Keys under defaults are decoded generically but still reported
+		if k[0] != "defaults" {
+			unknown = append(unknown, k.String())
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%s: unknown key(s): %s", path, strings.Join(unknown, ", "))
+	}
+	p.Path = path
+	return &p, nil
+}
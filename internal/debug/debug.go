@@ -3,8 +3,10 @@
 package debug
 
 import (
+	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,25 +15,76 @@ import (
 var (
 	enabled  atomic.Bool
 	initOnce sync.Once
+
+	// mu guards logFile, the debug log opened by the last configuration
+	mu      sync.Mutex
+	logFile *os.File
 )
 
 // Init configures the logging level based on the debug flag.
 // When debug is true, sets log level to Debug; otherwise Error (suppresses info/debug).
 // Init is safe to call multiple times; only the first call takes effect.
 func Init(debug bool) {
+	InitWithLog(debug, "")
+}
+
+// InitWithLog behaves like Init and, when debug is enabled and logPath is
+// non-empty, also writes log output to logPath (truncated on each run) so
+// the last debug session can be attached to bug reports.
+// A log file that cannot be created is silently skipped.
+func InitWithLog(debug bool, logPath string) {
 	initOnce.Do(func() {
-		enabled.Store(debug)
-		var level slog.Level
-		if debug {
-			level = slog.LevelDebug
-		} else {
-			level = slog.LevelError
+		configure(debug, logPath, os.Stderr)
+	})
+}
+
+// Configure behaves like InitWithLog but writes log output to stderr and
+// replaces any earlier configuration. A long-lived process running several
+// commands, like the daemon, calls it for each one.
+func Configure(debug bool, logPath string, stderr io.Writer) {
+	initOnce.Do(func() {})
+	configure(debug, logPath, stderr)
+}
+
+func configure(debug bool, logPath string, stderr io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	if logFile != nil {
+		_ = logFile.Close()
+		logFile = nil
+	}
+
+	enabled.Store(debug)
+	var level slog.Level
+	if debug {
+		level = slog.LevelDebug
+	} else {
+		level = slog.LevelError
+	}
+	w := stderr
+	if debug && logPath != "" {
+		if f := openLogFile(logPath); f != nil {
+			logFile = f
+			w = io.MultiWriter(stderr, f)
 		}
-		handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-			Level: level,
-		})
-		slog.SetDefault(slog.New(handler))
+	}
+	handler := slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
 	})
+	slog.SetDefault(slog.New(handler))
+}
+
+// openLogFile creates the log file and its parent directory.
+// Returns nil if the file cannot be opened.
+func openLogFile(path string) *os.File {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil
+	}
+	return f
 }
 
 // Enabled returns true if debug logging is enabled.
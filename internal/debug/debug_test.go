@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -157,3 +158,53 @@ func TestLogDisabledNoOutput(t *testing.T) {
 		t.Error("disabled debug should not output debug/info messages")
 	}
 }
+
+func TestInitWithLog_WritesFile(t *testing.T) {
+	resetForTesting()
+	defer resetForTesting()
+
+	path := filepath.Join(t.TempDir(), "state", "debug.log")
+	InitWithLog(true, path)
+	Log("written to file", "key", "value")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected log file to exist: %v", err)
+	}
+	if !strings.Contains(string(data), "written to file") {
+		t.Errorf("log file should contain message, got: %s", data)
+	}
+}
+
+func TestInitWithLog_DisabledSkipsFile(t *testing.T) {
+	resetForTesting()
+	defer resetForTesting()
+
+	path := filepath.Join(t.TempDir(), "debug.log")
+	InitWithLog(false, path)
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no log file when debug is disabled, got err=%v", err)
+	}
+}
+
+func TestConfigure_ReplacesEarlierConfiguration(t *testing.T) {
+	resetForTesting()
+	defer resetForTesting()
+
+	InitWithLog(false, "")
+	var first, second bytes.Buffer
+	Configure(true, "", &first)
+	Log("to the first writer")
+	Configure(true, "", &second)
+	Log("to the second writer")
+	Configure(false, "", &second)
+	Log("not logged")
+
+	if !strings.Contains(first.String(), "to the first writer") || strings.Contains(first.String(), "second") {
+		t.Errorf("unexpected first output: %s", first.String())
+	}
+	if !strings.Contains(second.String(), "to the second writer") || strings.Contains(second.String(), "not logged") {
+		t.Errorf("unexpected second output: %s", second.String())
+	}
+}
@@ -0,0 +1,132 @@
+package dnsprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// CloudflareBaseURL is the Cloudflare API v4 endpoint.
+const CloudflareBaseURL = "https://api.cloudflare.com/client/v4"
+
+// Cloudflare manages records through the Cloudflare API. Records are
+// created unproxied, since Dub must terminate TLS for the domain itself.
+type Cloudflare struct {
+	Token string
+	// ZoneID is looked up from the record's zone when empty
+	ZoneID     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+type cloudflareRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl"`
+	Proxied bool   `json:"proxied"`
+}
+
+func (c *Cloudflare) Name() string { return "Cloudflare" }
+
+func (c *Cloudflare) Apply(ctx context.Context, rec Record) (Result, error) {
+	zoneID := c.ZoneID
+	if zoneID == "" {
+		var zones []struct {
+			ID string `json:"id"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/zones?"+url.Values{"name": {rec.Zone}}.Encode(), nil, &zones); err != nil {
+			return "", fmt.Errorf("failed to look up zone %s: %w", rec.Zone, err)
+		}
+		if len(zones) == 0 {
+			return "", fmt.Errorf("zone %s not found in this Cloudflare account", rec.Zone)
+		}
+		zoneID = zones[0].ID
+	}
+
+	var existing []cloudflareRecord
+	query := url.Values{"name": {rec.Name}, "type": {rec.Type}}
+	if err := c.do(ctx, http.MethodGet, "/zones/"+url.PathEscape(zoneID)+"/dns_records?"+query.Encode(), nil, &existing); err != nil {
+		return "", fmt.Errorf("failed to list records: %w", err)
+	}
+
+	want := cloudflareRecord{Type: rec.Type, Name: rec.Name, Content: rec.Value, TTL: 1}
+	if len(existing) > 0 {
+		current := existing[0]
+		sameContent := strings.EqualFold(strings.TrimSuffix(current.Content, "."), rec.Value)
+		if sameContent && !current.Proxied {
+			return Unchanged, nil
+		}
+		if !sameContent && !rec.Overwrite {
+			return "", &ConflictError{Name: rec.Name, Type: rec.Type, Current: current.Content}
+		}
+		path := "/zones/" + url.PathEscape(zoneID) + "/dns_records/" + url.PathEscape(current.ID)
+		if err := c.do(ctx, http.MethodPut, path, want, nil); err != nil {
+			return "", fmt.Errorf("failed to update record: %w", err)
+		}
+		return Updated, nil
+	}
+
+	if err := c.do(ctx, http.MethodPost, "/zones/"+url.PathEscape(zoneID)+"/dns_records", want, nil); err != nil {
+		return "", fmt.Errorf("failed to create record: %w", err)
+	}
+	return Created, nil
+}
+
+// do sends a request and decodes the "result" of Cloudflare's response
+// envelope into dest, if given.
+func (c *Cloudflare) do(ctx context.Context, method, path string, body, dest interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var envelope struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("invalid Cloudflare response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if !envelope.Success || resp.StatusCode >= 400 {
+		msgs := make([]string, len(envelope.Errors))
+		for i, e := range envelope.Errors {
+			msgs[i] = fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+		}
+		if len(msgs) == 0 {
+			msgs = append(msgs, fmt.Sprintf("HTTP %d", resp.StatusCode))
+		}
+		return fmt.Errorf("cloudflare: %s", strings.Join(msgs, "; "))
+	}
+	if dest != nil {
+		return json.Unmarshal(envelope.Result, dest)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package dnsprovider creates DNS records through the APIs of DNS hosts, so
+// custom domains can be pointed at Dub without visiting a dashboard.
+package dnsprovider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Record is a DNS record to create. Name is fully qualified without a
+// trailing dot, e.g. "go.acme.com". Overwrite lets Apply replace a record of
+// the same name and type that points elsewhere.
+type Record struct {
+	Zone      string
+	Name      string
+	Type      string
+	Value     string
+	Overwrite bool
+}
+
+// ConflictError is returned by Apply when a record of the same name and type
+// already points elsewhere and Overwrite isn't set.
+type ConflictError struct {
+	Name    string
+	Type    string
+	Current string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s already has a %s record pointing at %s", e.Name, e.Type, e.Current)
+}
+
+// Result says what Apply changed.
+type Result string
+
+const (
+	Created   Result = "created"
+	Updated   Result = "updated"
+	Unchanged Result = "unchanged"
+)
+
+// Provider manages records at a DNS host.
+type Provider interface {
+	// Name is the provider's display name, e.g. "Cloudflare".
+	Name() string
+	// Apply creates rec, or updates the record of the same name and type
+	// to match it. A record pointing elsewhere is only replaced when
+	// rec.Overwrite is set; otherwise Apply returns a *ConflictError.
+	Apply(ctx context.Context, rec Record) (Result, error)
+}
+
+// Supported lists the providers FromEnv can configure.
+var Supported = []string{"cloudflare", "route53"}
+
+// requestTimeout bounds each request to a provider API.
+const requestTimeout = 30 * time.Second
+
+// FromEnv returns the named provider using credentials from the
+// environment. zoneID skips looking the zone up by name when set.
+func FromEnv(name, zoneID string) (Provider, error) {
+	httpClient := &http.Client{Timeout: requestTimeout}
+
+	switch name {
+	case "cloudflare":
+		token := os.Getenv("CLOUDFLARE_API_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("set CLOUDFLARE_API_TOKEN to a token with Zone:DNS:Edit permission")
+		}
+		return &Cloudflare{Token: token, ZoneID: zoneID, BaseURL: CloudflareBaseURL, HTTPClient: httpClient}, nil
+	case "route53":
+		accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if accessKey == "" || secretKey == "" {
+			return nil, fmt.Errorf("set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY (and AWS_SESSION_TOKEN for temporary credentials)")
+		}
+		return &Route53{
+			AccessKeyID:     accessKey,
+			SecretAccessKey: secretKey,
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			ZoneID:          zoneID,
+			BaseURL:         Route53BaseURL,
+			HTTPClient:      httpClient,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported provider %q (supported: %s)", name, strings.Join(Supported, ", "))
+	}
+}
@@ -0,0 +1,132 @@
+package dnsprovider
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignV4(t *testing.T) {
+	// "get-vanilla" from the AWS Signature Version 4 test suite
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	signV4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "", "us-east-1", "service", now)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCloudflare_Apply(t *testing.T) {
+	var created map[string]interface{}
+	existing := `[]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer cf_token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success": false, "errors": [{"code": 10000, "message": "Authentication error"}]}`))
+			return
+		}
+		switch {
+		case r.URL.Path == "/zones":
+			if r.URL.Query().Get("name") != "acme.com" {
+				t.Errorf("unexpected zone lookup %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"success": true, "result": [{"id": "zone_1"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/zones/zone_1/dns_records":
+			_, _ = w.Write([]byte(`{"success": true, "result": ` + existing + `}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/zones/zone_1/dns_records":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"success": true, "result": {}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cf := &Cloudflare{Token: "cf_token", BaseURL: server.URL, HTTPClient: server.Client()}
+	rec := Record{Zone: "acme.com", Name: "go.acme.com", Type: "CNAME", Value: "cname.dub.co"}
+
+	result, err := cf.Apply(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != Created || created["content"] != "cname.dub.co" || created["proxied"] != false {
+		t.Errorf("expected an unproxied record created, got %s %v", result, created)
+	}
+
+	existing = `[{"id": "rec_1", "type": "CNAME", "name": "go.acme.com", "content": "cname.dub.co", "proxied": false}]`
+	if result, err := cf.Apply(context.Background(), rec); err != nil || result != Unchanged {
+		t.Errorf("expected unchanged, got %s, %v", result, err)
+	}
+
+	// a record pointing elsewhere is left alone without Overwrite
+	existing = `[{"id": "rec_1", "type": "CNAME", "name": "go.acme.com", "content": "shop.example.com", "proxied": false}]`
+	if _, err := cf.Apply(context.Background(), rec); err == nil || err.Error() != "go.acme.com already has a CNAME record pointing at shop.example.com" {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+
+	cf.Token = "wrong"
+	if _, err := cf.Apply(context.Background(), rec); err == nil || !strings.Contains(err.Error(), "Authentication error") {
+		t.Errorf("expected the Cloudflare error message, got %v", err)
+	}
+}
+
+func TestRoute53_Apply(t *testing.T) {
+	var change route53ChangeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/20260101/us-east-1/route53/aws4_request") {
+			t.Errorf("unexpected Authorization %q", r.Header.Get("Authorization"))
+		}
+		switch {
+		case r.URL.Path == "/2013-04-01/hostedzonesbyname":
+			_, _ = w.Write([]byte(`<ListHostedZonesByNameResponse><HostedZones><HostedZone><Id>/hostedzone/Z1</Id><Name>acme.com.</Name></HostedZone></HostedZones></ListHostedZonesByNameResponse>`))
+		case r.Method == http.MethodGet && r.URL.Path == "/2013-04-01/hostedzone/Z1/rrset":
+			_, _ = w.Write([]byte(`<ListResourceRecordSetsResponse><ResourceRecordSets><ResourceRecordSet><Name>go.acme.com.</Name><Type>CNAME</Type><TTL>60</TTL>` +
+				`<ResourceRecords><ResourceRecord><Value>old.example.com</Value></ResourceRecord></ResourceRecords></ResourceRecordSet></ResourceRecordSets></ListResourceRecordSetsResponse>`))
+		case r.Method == http.MethodPost && r.URL.Path == "/2013-04-01/hostedzone/Z1/rrset/":
+			body, _ := io.ReadAll(r.Body)
+			if err := xml.Unmarshal(body, &change); err != nil {
+				t.Errorf("invalid change batch: %v\n%s", err, body)
+			}
+			_, _ = w.Write([]byte(`<ChangeResourceRecordSetsResponse/>`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	r53 := &Route53{
+		AccessKeyID: "AKID", SecretAccessKey: "secret",
+		BaseURL: server.URL, HTTPClient: server.Client(),
+		now: func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+	rec := Record{Zone: "acme.com", Name: "go.acme.com", Type: "CNAME", Value: "cname.dub.co"}
+	var conflict *ConflictError
+	if _, err := r53.Apply(context.Background(), rec); !errors.As(err, &conflict) || conflict.Current != "old.example.com" {
+		t.Fatalf("expected a conflict with the existing record, got %v", err)
+	}
+	if change.Action != "" {
+		t.Fatalf("expected no change without Overwrite, got %+v", change)
+	}
+
+	rec.Overwrite = true
+	result, err := r53.Apply(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != Updated {
+		t.Errorf("expected the differing record to be updated, got %s", result)
+	}
+	if change.Action != "UPSERT" || change.Set.Name != "go.acme.com" || len(change.Set.Records) != 1 || change.Set.Records[0] != "cname.dub.co" {
+		t.Errorf("unexpected change batch: %+v", change)
+	}
+}
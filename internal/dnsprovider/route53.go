@@ -0,0 +1,154 @@
+package dnsprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Route53BaseURL is the global Route 53 API endpoint.
+const Route53BaseURL = "https://route53.amazonaws.com"
+
+// Route53TTL is the TTL of records created in Route 53.
+const Route53TTL = 300
+
+// Route53 manages records through the Route 53 API, signing requests with
+// static AWS credentials.
+type Route53 struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// ZoneID is the hosted zone ID, looked up from the record's zone when
+	// empty
+	ZoneID     string
+	BaseURL    string
+	HTTPClient *http.Client
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+type route53RecordSet struct {
+	Name    string   `xml:"Name"`
+	Type    string   `xml:"Type"`
+	TTL     int      `xml:"TTL"`
+	Records []string `xml:"ResourceRecords>ResourceRecord>Value"`
+}
+
+type route53ChangeRequest struct {
+	XMLName xml.Name         `xml:"https://route53.amazonaws.com/doc/2013-04-01/ ChangeResourceRecordSetsRequest"`
+	Comment string           `xml:"ChangeBatch>Comment"`
+	Action  string           `xml:"ChangeBatch>Changes>Change>Action"`
+	Set     route53RecordSet `xml:"ChangeBatch>Changes>Change>ResourceRecordSet"`
+}
+
+func (r *Route53) Name() string { return "Route 53" }
+
+func (r *Route53) Apply(ctx context.Context, rec Record) (Result, error) {
+	zoneID := strings.TrimPrefix(r.ZoneID, "/hostedzone/")
+	if zoneID == "" {
+		var zones struct {
+			Zones []struct {
+				ID   string `xml:"Id"`
+				Name string `xml:"Name"`
+			} `xml:"HostedZones>HostedZone"`
+		}
+		query := url.Values{"dnsname": {rec.Zone}, "maxitems": {"1"}}
+		if err := r.do(ctx, http.MethodGet, "/2013-04-01/hostedzonesbyname?"+query.Encode(), nil, &zones); err != nil {
+			return "", fmt.Errorf("failed to look up hosted zone %s: %w", rec.Zone, err)
+		}
+		if len(zones.Zones) == 0 || strings.TrimSuffix(zones.Zones[0].Name, ".") != rec.Zone {
+			return "", fmt.Errorf("hosted zone %s not found in this AWS account", rec.Zone)
+		}
+		zoneID = strings.TrimPrefix(zones.Zones[0].ID, "/hostedzone/")
+	}
+	zonePath := "/2013-04-01/hostedzone/" + url.PathEscape(zoneID) + "/rrset"
+
+	var sets struct {
+		Sets []route53RecordSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+	}
+	query := url.Values{"name": {rec.Name + "."}, "type": {rec.Type}, "maxitems": {"1"}}
+	if err := r.do(ctx, http.MethodGet, zonePath+"?"+query.Encode(), nil, &sets); err != nil {
+		return "", fmt.Errorf("failed to list records: %w", err)
+	}
+
+	result := Created
+	if len(sets.Sets) > 0 && strings.TrimSuffix(sets.Sets[0].Name, ".") == rec.Name && sets.Sets[0].Type == rec.Type {
+		current := sets.Sets[0].Records
+		if len(current) == 1 && strings.EqualFold(strings.TrimSuffix(current[0], "."), rec.Value) {
+			return Unchanged, nil
+		}
+		if !rec.Overwrite {
+			return "", &ConflictError{Name: rec.Name, Type: rec.Type, Current: strings.Join(current, ", ")}
+		}
+		result = Updated
+	}
+
+	change := route53ChangeRequest{
+		Comment: "Point " + rec.Name + " at Dub",
+		Action:  "UPSERT",
+		Set:     route53RecordSet{Name: rec.Name, Type: rec.Type, TTL: Route53TTL, Records: []string{rec.Value}},
+	}
+	if err := r.do(ctx, http.MethodPost, zonePath+"/", change, nil); err != nil {
+		return "", fmt.Errorf("failed to change records: %w", err)
+	}
+	return result, nil
+}
+
+// do sends a signed request and decodes the XML response into dest, if
+// given.
+func (r *Route53) do(ctx context.Context, method, path string, body, dest interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = xml.Marshal(body)
+		if err != nil {
+			return err
+		}
+		data = append([]byte(xml.Header), data...)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.BaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+	signV4(req, data, r.AccessKeyID, r.SecretAccessKey, r.SessionToken, "us-east-1", "route53", now())
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		var apiErr struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if xml.Unmarshal(respBody, &apiErr) == nil && apiErr.Code != "" {
+			return fmt.Errorf("route53: %s: %s", apiErr.Code, apiErr.Message)
+		}
+		return fmt.Errorf("route53: HTTP %d", resp.StatusCode)
+	}
+	if dest != nil {
+		return xml.Unmarshal(respBody, dest)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+// Package i18n translates the CLI's help text, errors, and table headers.
+// Each locale is a JSON file in locales/ mapping English messages to their
+// translation, so a language is added by contributing one file. Messages
+// may contain fmt verbs (e.g. "failed to list %s: %v") to match text built
+// at runtime; anything without a translation stays in English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// English is the language messages are written in.
+const English = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	mu      sync.RWMutex
+	locale  = English
+	catalog *Catalog
+)
+
+// verbPattern matches a fmt verb, with an optional explicit argument index.
+var verbPattern = regexp.MustCompile(`%%|%(\[(\d+)\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z]`)
+
+// Catalog holds the translations of one locale.
+type Catalog struct {
+	exact    map[string]string
+	patterns []pattern
+}
+
+// pattern is a message with fmt verbs, matched against formatted text.
+type pattern struct {
+	re          *regexp.Regexp
+	verbs       []byte // verb letter of each capture
+	translation string
+	literal     int // length of the text around the verbs, to try longer patterns first
+}
+
+// ParseCatalog reads a locale file: a JSON object of English messages to
+// translations.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, err
+	}
+
+	c := &Catalog{exact: make(map[string]string, len(messages))}
+	for msg, translation := range messages {
+		if translation == "" {
+			continue
+		}
+		c.exact[msg] = translation
+
+		verbs := verbPattern.FindAllStringSubmatchIndex(msg, -1)
+		if len(verbs) == 0 {
+			continue
+		}
+		p := pattern{translation: translation}
+		var expr strings.Builder
+		expr.WriteString("^")
+		last := 0
+		for _, loc := range verbs {
+			expr.WriteString(regexp.QuoteMeta(msg[last:loc[0]]))
+			p.literal += loc[0] - last
+			last = loc[1]
+			if msg[loc[0]:loc[1]] == "%%" {
+				expr.WriteString("%")
+				p.literal++
+				continue
+			}
+			expr.WriteString("(.+?)")
+			p.verbs = append(p.verbs, msg[loc[1]-1])
+		}
+		expr.WriteString(regexp.QuoteMeta(msg[last:]))
+		p.literal += len(msg) - last
+		expr.WriteString("$")
+		if p.literal == 0 || len(p.verbs) == 0 {
+			// A message of only verbs would match anything
+			continue
+		}
+		p.re = regexp.MustCompile("(?s)" + expr.String())
+		c.patterns = append(c.patterns, p)
+	}
+	sort.SliceStable(c.patterns, func(i, j int) bool {
+		if c.patterns[i].literal != c.patterns[j].literal {
+			return c.patterns[i].literal > c.patterns[j].literal
+		}
+		return c.patterns[i].re.String() < c.patterns[j].re.String()
+	})
+	return c, nil
+}
+
+// Translate returns the translation of msg, or msg itself if there's none.
+// Text matching a message with verbs is translated with the matched values
+// put in place; values matched by %v or %w, usually wrapped errors, are
+// translated in turn.
+func (c *Catalog) Translate(msg string) string {
+	if c == nil || msg == "" {
+		return msg
+	}
+	if t, ok := c.exact[msg]; ok {
+		return t
+	}
+	for _, p := range c.patterns {
+		m := p.re.FindStringSubmatch(msg)
+		if m == nil {
+			continue
+		}
+		args := m[1:]
+		for i, verb := range p.verbs {
+			if verb == 'v' || verb == 'w' {
+				args[i] = c.Translate(args[i])
+			}
+		}
+		return substitute(p.translation, args)
+	}
+	return msg
+}
+
+// substitute replaces the verbs of a translation with args, in order or by
+// explicit index (%[2]s), so translations can reorder values.
+func substitute(translation string, args []string) string {
+	next := 0
+	return verbPattern.ReplaceAllStringFunc(translation, func(verb string) string {
+		if verb == "%%" {
+			return "%"
+		}
+		i := next
+		if m := verbPattern.FindStringSubmatch(verb); m[2] != "" {
+			n, _ := strconv.Atoi(m[2])
+			i = n - 1
+		}
+		next = i + 1
+		if i < 0 || i >= len(args) {
+			return verb
+		}
+		return args[i]
+	})
+}
+
+// Locales returns the available locales, English first.
+func Locales() []string {
+	locales := []string{English}
+	entries, _ := localeFiles.ReadDir("locales")
+	for _, e := range entries {
+		locales = append(locales, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	return locales
+}
+
+// Match returns the available locale for a language tag such as "de",
+// "de-AT", or "de_DE.UTF-8", falling back from region to language.
+func Match(tag string) (string, bool) {
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	tag = strings.ReplaceAll(tag, "_", "-")
+	if tag == "" || tag == "C" || tag == "POSIX" {
+		return English, tag != ""
+	}
+
+	available := Locales()
+	for _, candidate := range []string{tag, strings.SplitN(tag, "-", 2)[0]} {
+		for _, l := range available {
+			if strings.EqualFold(l, candidate) {
+				return l, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Detect picks the locale from lang, e.g. the --lang flag, then from the
+// LC_ALL, LC_MESSAGES, and LANG environment variables. It returns English
+// when none names an available locale.
+func Detect(lang string) string {
+	for _, tag := range []string{lang, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		if tag == "" {
+			continue
+		}
+		if l, ok := Match(tag); ok {
+			return l
+		}
+	}
+	return English
+}
+
+// SetLocale makes T translate into locale.
+func SetLocale(l string) error {
+	var c *Catalog
+	if l != English {
+		data, err := localeFiles.ReadFile(path.Join("locales", l+".json"))
+		if err != nil {
+			return fmt.Errorf("unsupported language %q (available: %s)", l, strings.Join(Locales(), ", "))
+		}
+		if c, err = ParseCatalog(data); err != nil {
+			return fmt.Errorf("invalid %s catalog: %w", l, err)
+		}
+	}
+
+	mu.Lock()
+	locale, catalog = l, c
+	mu.Unlock()
+	return nil
+}
+
+// Locale returns the current locale.
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// T translates msg into the current locale.
+func T(msg string) string {
+	mu.RLock()
+	c := catalog
+	mu.RUnlock()
+	return c.Translate(msg)
+}
+
+// Sprintf formats a translation of format.
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
@@ -0,0 +1,142 @@
+// internal/i18n/i18n_test.go
+package i18n
+
+import (
+	"encoding/json"
+	"path"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	c, err := ParseCatalog([]byte(`{
+		"Clicks": "Klicks",
+		"unknown command %q for %q": "unbekannter Befehl %q für %q",
+		"failed to list %s: %v": "%[2]v (beim Auflisten von %[1]s)",
+		"context canceled": "abgebrochen",
+		"%s": "ignored",
+		"untranslated": ""
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"Clicks", "Klicks"},
+		{`unknown command "foo" for "dub"`, `unbekannter Befehl "foo" für "dub"`},
+		{"failed to list tags: context canceled", "abgebrochen (beim Auflisten von tags)"},
+		{"failed to list tags: boom", "boom (beim Auflisten von tags)"},
+		{"Leads", "Leads"},
+		{"untranslated", "untranslated"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := c.Translate(tt.msg); got != tt.want {
+			t.Errorf("Translate(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+
+	var nilCatalog *Catalog
+	if got := nilCatalog.Translate("Clicks"); got != "Clicks" {
+		t.Errorf("English should leave messages alone, got %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{"de", "de", true},
+		{"de_DE.UTF-8", "de", true},
+		{"es-MX", "es", true},
+		{"ES", "es", true},
+		{"C", English, true},
+		{"POSIX", English, true},
+		{"en_US.UTF-8", English, true},
+		{"xx_YY", "", false},
+		{"", English, false},
+	}
+	for _, tt := range tests {
+		got, ok := Match(tt.tag)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Match(%q) = %q, %v; want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "es_ES.UTF-8")
+
+	if got := Detect(""); got != "es" {
+		t.Errorf("expected es from LANG, got %q", got)
+	}
+	if got := Detect("de"); got != "de" {
+		t.Errorf("expected the explicit language to win, got %q", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := Detect(""); got != English {
+		t.Errorf("expected LC_ALL=C to select English, got %q", got)
+	}
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "xx_YY")
+	if got := Detect(""); got != English {
+		t.Errorf("expected English for an unavailable language, got %q", got)
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Cleanup(func() { _ = SetLocale(English) })
+
+	if err := SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+	if Locale() != "de" || T("Clicks") != "Klicks" {
+		t.Errorf("expected German, got %s: %q", Locale(), T("Clicks"))
+	}
+	if got := Sprintf("\nShowing %d of %d items. Use --limit or --all for more.\n", 25, 100); got != "\n25 von 100 Einträgen angezeigt. Mehr mit --limit oder --all.\n" {
+		t.Errorf("unexpected Sprintf result %q", got)
+	}
+
+	if err := SetLocale("xx"); err == nil {
+		t.Error("expected an error for an unavailable locale")
+	}
+	if err := SetLocale(English); err != nil || T("Clicks") != "Clicks" {
+		t.Errorf("expected English again, got %q (%v)", T("Clicks"), err)
+	}
+}
+
+// Every locale file must parse and keep the values of each message.
+func TestLocaleFiles(t *testing.T) {
+	for _, l := range Locales()[1:] {
+		data, err := localeFiles.ReadFile(path.Join("locales", l+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			t.Errorf("%s: %v", l, err)
+			continue
+		}
+		for msg, translation := range messages {
+			if got, want := len(verbs(translation)), len(verbs(msg)); got != want {
+				t.Errorf("%s: %q has %d values, its translation %q has %d", l, msg, want, translation, got)
+			}
+		}
+	}
+}
+
+func verbs(s string) []string {
+	var out []string
+	for _, v := range verbPattern.FindAllString(s, -1) {
+		if v != "%%" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
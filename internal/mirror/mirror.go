@@ -0,0 +1,494 @@
+// Package mirror keeps a local SQLite copy of a workspace's links, tags, and
+// domains so they can be queried with SQL instantly and offline.
+//
+// Each resource is a table with its commonly queried fields as snake_case
+// columns and the full API object as JSON in a raw column, so anything else
+// is reachable with json_extract. Link tags are also in a link_tags join
+// table, and link keys, URLs, titles, and comments are indexed for
+// full-text search in links_fts.
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	// Registers the pure-Go "sqlite" driver, so builds don't need cgo
+	_ "modernc.org/sqlite"
+)
+
+// ErrNotSynced is returned when opening a mirror that hasn't been synced.
+var ErrNotSynced = errors.New("no mirror found; run: dub mirror sync")
+
+const schema = `
+CREATE TABLE IF NOT EXISTS links (
+	id           TEXT PRIMARY KEY,
+	domain       TEXT,
+	key          TEXT,
+	url          TEXT,
+	short_link   TEXT,
+	title        TEXT,
+	description  TEXT,
+	comments     TEXT,
+	archived     INTEGER,
+	clicks       INTEGER,
+	leads        INTEGER,
+	sales        INTEGER,
+	sale_amount  INTEGER,
+	folder_id    TEXT,
+	user_id      TEXT,
+	external_id  TEXT,
+	created_at   TEXT,
+	updated_at   TEXT,
+	last_clicked TEXT,
+	expires_at   TEXT,
+	raw          TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS links_domain_key ON links (domain, key);
+CREATE TABLE IF NOT EXISTS link_tags (
+	link_id TEXT NOT NULL,
+	tag_id  TEXT NOT NULL,
+	PRIMARY KEY (link_id, tag_id)
+);
+CREATE TABLE IF NOT EXISTS tags (
+	id    TEXT PRIMARY KEY,
+	name  TEXT,
+	color TEXT,
+	raw   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS domains (
+	id          TEXT PRIMARY KEY,
+	slug        TEXT,
+	verified    INTEGER,
+	is_primary  INTEGER,
+	archived    INTEGER,
+	placeholder TEXT,
+	created_at  TEXT,
+	raw         TEXT NOT NULL
+);
+CREATE VIRTUAL TABLE IF NOT EXISTS links_fts USING fts5 (
+	id UNINDEXED, key, url, title, description, comments,
+	tokenize = 'unicode61 remove_diacritics 2'
+);
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT
+);
+`
+
+// Store is an open mirror database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the mirror at path for syncing, creating it if needed.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create mirror directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// One connection keeps transactions and pragmas on the same handle
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA journal_mode = WAL; PRAGMA busy_timeout = 5000;" + schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open mirror %s: %w", path, err)
+	}
+	s := &Store{db: db}
+	if err := s.reindex(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to index mirror %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// OpenExisting opens a mirror like Open, but returns ErrNotSynced instead
+// of creating one.
+func OpenExisting(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotSynced
+		}
+		return nil, err
+	}
+	return Open(path)
+}
+
+// reindex rebuilds the search index when it's out of step with the links,
+// as in mirrors created before it existed.
+func (s *Store) reindex() error {
+	var links, indexed int
+	err := s.db.QueryRow("SELECT (SELECT count(*) FROM links), (SELECT count(*) FROM links_fts)").Scan(&links, &indexed)
+	if err != nil || links == indexed {
+		return err
+	}
+	_, err = s.db.Exec(`DELETE FROM links_fts;
+		INSERT INTO links_fts (id, key, url, title, description, comments)
+		SELECT id, key, url, title, description, comments FROM links`)
+	return err
+}
+
+// OpenReadOnly opens an existing mirror for queries, which can't modify it.
+func OpenReadOnly(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotSynced
+		}
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout = 5000; PRAGMA query_only = ON"); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to open mirror %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SyncStats counts the changes a sync made.
+type SyncStats struct {
+	Added     int `json:"added"`
+	Updated   int `json:"updated"`
+	Removed   int `json:"removed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// HasLinks reports how many of ids are already in the mirror.
+func (s *Store) HasLinks(ids []string) (int, error) {
+	n := 0
+	for _, id := range ids {
+		var one int
+		err := s.db.QueryRow("SELECT 1 FROM links WHERE id = ?", id).Scan(&one)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// SyncLinks writes links to the mirror, only touching rows whose content
+// changed. With prune, links is taken to be every link in the workspace and
+// mirrored links missing from it are removed.
+func (s *Store) SyncLinks(links []map[string]interface{}, prune bool) (SyncStats, error) {
+	var stats SyncStats
+
+	existing := map[string]string{}
+	rows, err := s.db.Query("SELECT id, raw FROM links")
+	if err != nil {
+		return stats, err
+	}
+	for rows.Next() {
+		var id, raw string
+		if err := rows.Scan(&id, &raw); err != nil {
+			_ = rows.Close()
+			return stats, err
+		}
+		existing[id] = raw
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return stats, err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return stats, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	seen := make(map[string]bool, len(links))
+	for _, link := range links {
+		id := str(link["id"])
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		raw, err := json.Marshal(link)
+		if err != nil {
+			return stats, err
+		}
+		old, ok := existing[id]
+		switch {
+		case !ok:
+			stats.Added++
+		case old == string(raw):
+			stats.Unchanged++
+			continue
+		default:
+			stats.Updated++
+		}
+		if err := upsertLink(tx, id, link, string(raw)); err != nil {
+			return stats, fmt.Errorf("failed to store link %s: %w", id, err)
+		}
+	}
+
+	if prune {
+		for id := range existing {
+			if seen[id] {
+				continue
+			}
+			if err := deleteLink(tx, id); err != nil {
+				return stats, err
+			}
+			stats.Removed++
+		}
+	}
+
+	if err := setMeta(tx, "links_synced_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return stats, err
+	}
+	return stats, tx.Commit()
+}
+
+func upsertLink(tx *sql.Tx, id string, link map[string]interface{}, raw string) error {
+	_, err := tx.Exec(`INSERT OR REPLACE INTO links (id, domain, key, url, short_link, title, description,
+		comments, archived, clicks, leads, sales, sale_amount, folder_id, user_id, external_id,
+		created_at, updated_at, last_clicked, expires_at, raw)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, col(link["domain"]), col(link["key"]), col(link["url"]), col(link["shortLink"]), col(link["title"]), col(link["description"]),
+		col(link["comments"]), flag(link["archived"]), col(link["clicks"]), col(link["leads"]), col(link["sales"]), col(link["saleAmount"]),
+		col(link["folderId"]), col(link["userId"]), col(link["externalId"]),
+		col(link["createdAt"]), col(link["updatedAt"]), col(link["lastClicked"]), col(link["expiresAt"]), raw)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM links_fts WHERE id = ?", id); err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO links_fts (id, key, url, title, description, comments) VALUES (?, ?, ?, ?, ?, ?)",
+		id, col(link["key"]), col(link["url"]), col(link["title"]), col(link["description"]), col(link["comments"]))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM link_tags WHERE link_id = ?", id); err != nil {
+		return err
+	}
+	tags, _ := link["tags"].([]interface{})
+	for _, tag := range tags {
+		t, ok := tag.(map[string]interface{})
+		if !ok || str(t["id"]) == "" {
+			continue
+		}
+		if _, err := tx.Exec("INSERT OR IGNORE INTO link_tags (link_id, tag_id) VALUES (?, ?)", id, str(t["id"])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteLink(tx *sql.Tx, id string) error {
+	for _, table := range []string{"links", "links_fts"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE id = ?", id); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec("DELETE FROM link_tags WHERE link_id = ?", id)
+	return err
+}
+
+// LinksSyncedAt returns when links were last synced, or false if never.
+func (s *Store) LinksSyncedAt() (time.Time, bool, error) {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM meta WHERE key = 'links_synced_at'").Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, nil
+	}
+	return t, true, nil
+}
+
+// SearchLinks returns the links whose key, URL, title, description, or
+// comments contain every word of text, best matches first. Words match as
+// prefixes, ignoring case and accents. A non-empty domain limits results to
+// that domain.
+func (s *Store) SearchLinks(ctx context.Context, text, domain string) ([]map[string]interface{}, error) {
+	match := ftsQuery(text)
+	if match == "" {
+		return nil, errors.New("search text is empty")
+	}
+
+	query := `SELECT l.raw FROM links_fts f JOIN links l ON l.id = f.id WHERE links_fts MATCH ?`
+	args := []interface{}{match}
+	if domain != "" {
+		query += " AND l.domain = ?"
+		args = append(args, domain)
+	}
+	query += " ORDER BY bm25(links_fts), l.id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	links := []map[string]interface{}{}
+	for rows.Next() {
+		var raw string
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+		var link map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &link); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// ftsQuery turns free text into an FTS5 query matching every word as a
+// prefix, quoting words so that punctuation and FTS operators are literal.
+func ftsQuery(text string) string {
+	var terms []string
+	for _, word := range strings.Fields(text) {
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
+// ReplaceTags replaces the mirrored tags.
+func (s *Store) ReplaceTags(tags []map[string]interface{}) error {
+	return s.replace("tags", tags, func(tx *sql.Tx, tag map[string]interface{}, raw string) error {
+		_, err := tx.Exec("INSERT OR REPLACE INTO tags (id, name, color, raw) VALUES (?, ?, ?, ?)",
+			str(tag["id"]), col(tag["name"]), col(tag["color"]), raw)
+		return err
+	})
+}
+
+// ReplaceDomains replaces the mirrored domains.
+func (s *Store) ReplaceDomains(domains []map[string]interface{}) error {
+	return s.replace("domains", domains, func(tx *sql.Tx, d map[string]interface{}, raw string) error {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO domains (id, slug, verified, is_primary, archived, placeholder, created_at, raw)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			str(d["id"]), col(d["slug"]), flag(d["verified"]), flag(d["primary"]), flag(d["archived"]),
+			col(d["placeholder"]), col(d["createdAt"]), raw)
+		return err
+	})
+}
+
+// replace swaps the contents of a small table in one transaction.
+func (s *Store) replace(table string, items []map[string]interface{}, insert func(*sql.Tx, map[string]interface{}, string) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+		return err
+	}
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if err := insert(tx, item, string(raw)); err != nil {
+			return fmt.Errorf("failed to store %s: %w", table, err)
+		}
+	}
+	if err := setMeta(tx, table+"_synced_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func setMeta(tx *sql.Tx, key, value string) error {
+	_, err := tx.Exec("INSERT OR REPLACE INTO meta (key, value) VALUES (?, ?)", key, value)
+	return err
+}
+
+// Result is the outcome of a query: column names and rows of values, with
+// text as strings and numbers as int64 or float64.
+type Result struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Query runs a SQL query against the mirror.
+func (s *Store) Query(ctx context.Context, query string) (*Result, error) {
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Columns: columns}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	return result, rows.Err()
+}
+
+// str returns v if it's a string, or "".
+func str(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// col passes scalar JSON values through to SQLite, storing anything else
+// as NULL.
+func col(v interface{}) interface{} {
+	switch v.(type) {
+	case string, float64, bool:
+		return v
+	}
+	return nil
+}
+
+// flag stores a JSON boolean as 0 or 1, and anything else as NULL.
+func flag(v interface{}) interface{} {
+	b, ok := v.(bool)
+	if !ok {
+		return nil
+	}
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func link(id, key string, clicks float64, tagIDs ...string) map[string]interface{} {
+	tags := make([]interface{}, len(tagIDs))
+	for i, tagID := range tagIDs {
+		tags[i] = map[string]interface{}{"id": tagID, "name": tagID}
+	}
+	return map[string]interface{}{
+		"id": id, "domain": "dub.sh", "key": key, "url": "https://example.com/" + key,
+		"clicks": clicks, "archived": false, "lastClicked": nil, "tags": tags,
+		"geo": map[string]interface{}{"US": "https://example.com/us"},
+	}
+}
+
+func TestSyncLinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "mirror.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	stats, err := store.SyncLinks([]map[string]interface{}{
+		link("l1", "a", 5, "t1"), link("l2", "b", 150, "t1", "t2"), link("l3", "c", 0),
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (SyncStats{Added: 3}) {
+		t.Errorf("first sync stats = %+v", stats)
+	}
+
+	// l1 changed, l2 unchanged, l3 deleted upstream, l4 new
+	stats, err = store.SyncLinks([]map[string]interface{}{
+		link("l1", "a", 6, "t1"), link("l2", "b", 150, "t1", "t2"), link("l4", "d", 300),
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (SyncStats{Added: 1, Updated: 1, Removed: 1, Unchanged: 1}); stats != want {
+		t.Errorf("second sync stats = %+v, want %+v", stats, want)
+	}
+
+	// Without pruning, links missing from the batch are kept
+	stats, err = store.SyncLinks([]map[string]interface{}{link("l5", "e", 1)}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (SyncStats{Added: 1}) {
+		t.Errorf("unpruned sync stats = %+v", stats)
+	}
+
+	n, err := store.HasLinks([]string{"l1", "l3", "l5"})
+	if err != nil || n != 2 {
+		t.Errorf("HasLinks = %d, %v; want 2", n, err)
+	}
+
+	result, err := store.Query(context.Background(),
+		"SELECT key, clicks, archived, json_extract(raw, '$.geo.US') AS us FROM links WHERE clicks > 100 ORDER BY clicks DESC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 2 || result.Rows[0][0] != "d" || result.Rows[0][1] != int64(300) || result.Rows[1][0] != "b" {
+		t.Errorf("unexpected rows: %v", result.Rows)
+	}
+	if result.Rows[0][2] != int64(0) || result.Rows[0][3] != "https://example.com/us" {
+		t.Errorf("unexpected archived/raw values: %v", result.Rows[0])
+	}
+
+	result, err = store.Query(context.Background(), "SELECT link_id FROM link_tags WHERE tag_id = 't1' ORDER BY link_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Rows) != 2 || result.Rows[0][0] != "l1" || result.Rows[1][0] != "l2" {
+		t.Errorf("unexpected link_tags: %v", result.Rows)
+	}
+}
+
+func TestReplaceTagsAndDomains(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "mirror.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if err := store.ReplaceTags([]map[string]interface{}{{"id": "t1", "name": "old"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ReplaceTags([]map[string]interface{}{{"id": "t2", "name": "new", "color": "red"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ReplaceDomains([]map[string]interface{}{{"id": "d1", "slug": "go.acme.com", "verified": true, "primary": false}}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := store.Query(context.Background(), "SELECT (SELECT group_concat(name) FROM tags), slug, verified, is_primary FROM domains")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{"new", "go.acme.com", int64(1), int64(0)}
+	for i, v := range want {
+		if result.Rows[0][i] != v {
+			t.Errorf("column %s = %v, want %v", result.Columns[i], result.Rows[0][i], v)
+		}
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mirror.db")
+	if _, err := OpenReadOnly(path); !errors.Is(err, ErrNotSynced) {
+		t.Fatalf("expected ErrNotSynced, got %v", err)
+	}
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+
+	ro, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ro.Close() }()
+	if _, err := ro.Query(context.Background(), "DELETE FROM links"); err == nil {
+		t.Error("expected writes to fail on a read-only mirror")
+	}
+}
+
+func TestSearchLinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mirror.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.SyncLinks([]map[string]interface{}{
+		{"id": "l1", "key": "a", "url": "https://shop.com/summer-sale"},
+		{"id": "l2", "key": "summer", "url": "https://shop.com", "comments": `50% "off" sale`},
+		{"id": "l3", "key": "b", "url": "https://shop.com/winter"},
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Mirrors from before the index existed are reindexed on open
+	if _, err := store.db.Exec("DELETE FROM links_fts"); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.Close()
+	if store, err = Open(path); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = store.Close() }()
+
+	for text, want := range map[string]string{
+		"summer sale": "l1,l2",
+		`"off" sale`:  "l2",
+		"shop":        "l1,l2,l3",
+		"nothing":     "",
+	} {
+		links, err := store.SearchLinks(context.Background(), text, "")
+		if err != nil {
+			t.Fatalf("SearchLinks(%q): %v", text, err)
+		}
+		var ids []string
+		for _, link := range links {
+			ids = append(ids, str(link["id"]))
+		}
+		sort.Strings(ids)
+		if got := strings.Join(ids, ","); got != want {
+			t.Errorf("SearchLinks(%q) = %s, want %s", text, got, want)
+		}
+	}
+
+	if _, err := store.SearchLinks(context.Background(), "  ", ""); err == nil {
+		t.Error("expected an error for empty search text")
+	}
+
+	syncedAt, ok, err := store.LinksSyncedAt()
+	if err != nil || !ok || time.Since(syncedAt) > time.Minute {
+		t.Errorf("LinksSyncedAt = %v, %v, %v", syncedAt, ok, err)
+	}
+}
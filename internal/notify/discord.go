@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+)
+
+// Discord posts to a Discord webhook, with the message as an embed.
+type Discord struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+type discordPayload struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+var discordColors = map[Level]int{
+	OK:      0x2eb67d,
+	Warning: 0xecb22e,
+	Alert:   0xe01e5a,
+}
+
+// discordMaxFields is the most fields Discord accepts in one embed.
+const discordMaxFields = 25
+
+func (d *Discord) Name() string { return "Discord" }
+
+func (d *Discord) Notify(ctx context.Context, msg Message) error {
+	embed := discordEmbed{Title: msg.Title, Description: msg.Text, Color: discordColors[msg.Level]}
+	for _, f := range msg.Fields {
+		if len(embed.Fields) == discordMaxFields {
+			break
+		}
+		embed.Fields = append(embed.Fields, discordField{Name: f.Name, Value: f.Value, Inline: len(f.Value) <= 40})
+	}
+	return post(ctx, d.HTTPClient, d.WebhookURL, discordPayload{Embeds: []discordEmbed{embed}})
+}
@@ -0,0 +1,105 @@
+// Package notify posts alerts from monitoring commands to chat services
+// through their incoming webhooks, so a cron job can ping a channel without
+// any other infrastructure.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Level is how a message should be highlighted.
+type Level string
+
+const (
+	OK      Level = "ok"
+	Warning Level = "warning"
+	Alert   Level = "alert"
+)
+
+// Field is a labelled value shown with a message.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Message is a notification, formatted by each Notifier for its service.
+type Message struct {
+	Title  string
+	Text   string
+	Fields []Field
+	Level  Level
+}
+
+// Notifier delivers messages to a chat service.
+type Notifier interface {
+	// Name is the service's display name, e.g. "Slack".
+	Name() string
+	// Notify posts msg.
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Supported lists the services Parse can configure.
+var Supported = []string{"slack", "discord"}
+
+// requestTimeout bounds each delivery.
+const requestTimeout = 15 * time.Second
+
+// Parse returns the notifier for a service:webhook-url spec, e.g.
+// "slack:https://hooks.slack.com/services/...".
+func Parse(spec string) (Notifier, error) {
+	service, webhook, ok := strings.Cut(spec, ":")
+	if !ok || webhook == "" {
+		return nil, fmt.Errorf("expected service:webhook-url, e.g. slack:https://hooks.slack.com/services/...")
+	}
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", webhook)
+	}
+
+	httpClient := &http.Client{Timeout: requestTimeout}
+	switch strings.ToLower(service) {
+	case "slack":
+		return &Slack{WebhookURL: webhook, HTTPClient: httpClient}, nil
+	case "discord":
+		return &Discord{WebhookURL: webhook, HTTPClient: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("unsupported service %q (supported: %s)", service, strings.Join(Supported, ", "))
+	}
+}
+
+// post sends payload as JSON to a webhook, treating any non-2xx status as
+// a failure.
+func post(ctx context.Context, client *http.Client, webhook string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		if msg := strings.TrimSpace(string(body)); msg != "" {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, msg)
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
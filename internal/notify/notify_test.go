@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	n, err := Parse("slack:https://hooks.slack.com/services/T0/B0/x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, ok := n.(*Slack); !ok || s.WebhookURL != "https://hooks.slack.com/services/T0/B0/x" {
+		t.Errorf("expected a Slack notifier, got %#v", n)
+	}
+	if n, err := Parse("Discord:https://discord.com/api/webhooks/1/abc"); err != nil || n.Name() != "Discord" {
+		t.Errorf("expected a Discord notifier, got %v, %v", n, err)
+	}
+
+	for _, spec := range []string{"slack", "slack:", "slack:hooks.slack.com/x", "teams:https://example.com/hook"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestNotify(t *testing.T) {
+	var got map[string]interface{}
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+		got = nil
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("invalid_payload"))
+	}))
+	defer server.Close()
+
+	msg := Message{
+		Title:  "Click anomaly",
+		Text:   "1 day strayed from the baseline.",
+		Fields: []Field{{Name: "2026-01-08", Value: "60 clicks"}},
+		Level:  Alert,
+	}
+
+	slack := &Slack{WebhookURL: server.URL, HTTPClient: server.Client()}
+	if err := slack.Notify(context.Background(), msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	attachment := got["attachments"].([]interface{})[0].(map[string]interface{})
+	if got["text"] != "*Click anomaly*" || attachment["color"] != "danger" ||
+		attachment["fields"].([]interface{})[0].(map[string]interface{})["value"] != "60 clicks" {
+		t.Errorf("unexpected Slack payload %v", got)
+	}
+
+	discord := &Discord{WebhookURL: server.URL, HTTPClient: server.Client()}
+	if err := discord.Notify(context.Background(), msg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	embed := got["embeds"].([]interface{})[0].(map[string]interface{})
+	if embed["title"] != "Click anomaly" || embed["color"] != float64(0xe01e5a) || embed["description"] != msg.Text {
+		t.Errorf("unexpected Discord payload %v", got)
+	}
+
+	status = http.StatusBadRequest
+	if err := slack.Notify(context.Background(), msg); err == nil || !strings.Contains(err.Error(), "HTTP 400: invalid_payload") {
+		t.Errorf("expected the webhook's error, got %v", err)
+	}
+}
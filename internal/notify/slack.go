@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+)
+
+// Slack posts to a Slack incoming webhook, with the message as a colored
+// attachment.
+type Slack struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Text   string       `json:"text,omitempty"`
+	Fields []slackField `json:"fields,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+var slackColors = map[Level]string{
+	OK:      "good",
+	Warning: "warning",
+	Alert:   "danger",
+}
+
+func (s *Slack) Name() string { return "Slack" }
+
+func (s *Slack) Notify(ctx context.Context, msg Message) error {
+	attachment := slackAttachment{Color: slackColors[msg.Level], Text: msg.Text}
+	for _, f := range msg.Fields {
+		attachment.Fields = append(attachment.Fields, slackField{Title: f.Name, Value: f.Value, Short: len(f.Value) <= 40})
+	}
+	payload := slackPayload{Text: "*" + msg.Title + "*"}
+	if attachment.Text != "" || len(attachment.Fields) > 0 {
+		payload.Attachments = []slackAttachment{attachment}
+	}
+	return post(ctx, s.HTTPClient, s.WebhookURL, payload)
+}
@@ -0,0 +1,172 @@
+// internal/outfmt/autotable.go
+package outfmt
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// autoTableSample is the number of items inspected to infer columns.
+const autoTableSample = 20
+
+// autoTableMaxColumns caps the inferred columns to keep rows on one line.
+const autoTableMaxColumns = 6
+
+// autoTableCellWidth is the width values are truncated to.
+const autoTableCellWidth = 40
+
+// autoTableLeading are fields shown first, in this order, when present.
+var autoTableLeading = []string{"workspace", "id", "name", "slug", "domain", "key", "email", "url", "status", "type"}
+
+// autoTableTrailing are fields shown last, in this order, when present.
+var autoTableTrailing = []string{"createdAt", "updatedAt"}
+
+// InferColumns picks the fields to show for a list of objects from the
+// first items: scalar fields set in at least half of them, identifying
+// fields first and timestamps last, capped at a handful of columns. It
+// returns nil when items aren't objects.
+func InferColumns(items []interface{}) []string {
+	sample := items
+	if len(sample) > autoTableSample {
+		sample = sample[:autoTableSample]
+	}
+
+	var order []string
+	counts := map[string]int{}
+	for _, item := range sample {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, key := range SortedKeys(obj) {
+			switch obj[key].(type) {
+			case string, float64, bool:
+				if counts[key] == 0 {
+					order = append(order, key)
+				}
+				counts[key]++
+			}
+		}
+	}
+
+	common := map[string]bool{}
+	for _, key := range order {
+		if counts[key]*2 >= len(sample) {
+			common[key] = true
+		}
+	}
+
+	var columns []string
+	take := func(key string) {
+		if common[key] {
+			columns = append(columns, key)
+			delete(common, key)
+		}
+	}
+	for _, key := range autoTableLeading {
+		take(key)
+	}
+	var trailing []string
+	for _, key := range autoTableTrailing {
+		if common[key] {
+			trailing = append(trailing, key)
+			delete(common, key)
+		}
+	}
+	for _, key := range order {
+		if len(columns)+len(trailing) >= autoTableMaxColumns {
+			break
+		}
+		take(key)
+	}
+	columns = append(columns, trailing...)
+	if len(columns) > autoTableMaxColumns {
+		columns = columns[:autoTableMaxColumns]
+	}
+	return columns
+}
+
+// FormatAutoTable writes a list of objects as a table with columns from
+// InferColumns. Numeric columns are right-aligned and values are formatted
+// as in FormatDetail. It returns false without writing anything when no
+// columns can be inferred or any item isn't an object, so the caller can
+// fall back to JSON.
+func FormatAutoTable(w io.Writer, items []interface{}) (bool, error) {
+	fields := InferColumns(items)
+	if len(fields) == 0 {
+		return false, nil
+	}
+	objs := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return false, nil
+		}
+		objs[i] = obj
+	}
+
+	columns := make([]Column, len(fields))
+	for i, field := range fields {
+		align := AlignRight
+		for _, obj := range objs {
+			if v, ok := obj[field]; ok && v != nil {
+				if _, isNum := v.(float64); !isNum {
+					align = AlignLeft
+					break
+				}
+			}
+		}
+		columns[i] = Column{Name: ColumnTitle(field), Width: autoTableCellWidth, Align: align}
+	}
+
+	rows := make([][]string, len(objs))
+	for i, obj := range objs {
+		rows[i] = make([]string, len(fields))
+		for j, field := range fields {
+			value := "-"
+			switch v := obj[field].(type) {
+			case string, float64, bool:
+				value = formatDetailValue(v)
+			}
+			rows[i][j] = Truncate(value, autoTableCellWidth)
+		}
+	}
+
+	return true, FormatTable(w, columns, rows)
+}
+
+// ColumnTitle splits a camelCase field name into words for a column
+// header, e.g. "createdAt" -> "Created At".
+func ColumnTitle(field string) string {
+	if field == "" {
+		return ""
+	}
+	var words []string
+	start := 0
+	runes := []rune(field)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// SortedKeys returns the keys of obj in order, since JSON object order is
+// lost when decoding.
+func SortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
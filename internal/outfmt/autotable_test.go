@@ -0,0 +1,102 @@
+// internal/outfmt/autotable_test.go
+package outfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decodeItems(t *testing.T, body string) []interface{} {
+	t.Helper()
+	var items []interface{}
+	if err := json.Unmarshal([]byte(body), &items); err != nil {
+		t.Fatal(err)
+	}
+	return items
+}
+
+func TestInferColumns(t *testing.T) {
+	items := decodeItems(t, `[
+		{"createdAt": "2025-01-02T00:00:00Z", "name": "Spring", "id": "bnty_1", "rewardAmount": 5000, "meta": {"a": 1}, "tags": ["x"], "rare": "only here"},
+		{"createdAt": "2025-01-03T00:00:00Z", "name": "Summer", "id": "bnty_2", "rewardAmount": 7500, "meta": null},
+		{"createdAt": "2025-01-04T00:00:00Z", "name": "Fall", "id": "bnty_3", "rewardAmount": null}
+	]`)
+
+	got := InferColumns(items)
+	want := []string{"id", "name", "rewardAmount", "createdAt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestInferColumns_CapsColumns(t *testing.T) {
+	items := decodeItems(t, `[{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6, "g": 7, "id": "x", "updatedAt": "2025-01-01T00:00:00Z"}]`)
+
+	got := InferColumns(items)
+	if len(got) != autoTableMaxColumns || got[0] != "id" || got[len(got)-1] != "updatedAt" {
+		t.Errorf("expected id first, updatedAt last, and %d columns, got %v", autoTableMaxColumns, got)
+	}
+}
+
+func TestInferColumns_NotObjects(t *testing.T) {
+	if got := InferColumns(decodeItems(t, `["a", "b"]`)); got != nil {
+		t.Errorf("expected no columns for scalars, got %v", got)
+	}
+	if got := InferColumns(nil); got != nil {
+		t.Errorf("expected no columns for an empty list, got %v", got)
+	}
+}
+
+func TestFormatAutoTable(t *testing.T) {
+	items := decodeItems(t, `[
+		{"id": "bnty_1", "name": "Spring", "rewardAmount": 5000, "archived": false},
+		{"id": "bnty_2", "name": "Summer", "rewardAmount": 12345678}
+	]`)
+
+	var buf bytes.Buffer
+	ok, err := FormatAutoTable(&buf, items)
+	if !ok || err != nil {
+		t.Fatalf("expected a table, got ok=%v err=%v", ok, err)
+	}
+	out := buf.String()
+	for _, want := range []string{"ID", "NAME", "REWARD AMOUNT", "ARCHIVED", "12,345,678", "Spring"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if !strings.Contains(lines[len(lines)-1], " - ") {
+		t.Errorf("expected a dash for the missing value, got %q", lines[len(lines)-1])
+	}
+}
+
+func TestFormatAutoTable_NonObjectPastSample(t *testing.T) {
+	items := make([]interface{}, autoTableSample+1)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": "x"}
+	}
+	items[autoTableSample] = "not an object"
+
+	var buf bytes.Buffer
+	if ok, err := FormatAutoTable(&buf, items); ok || err != nil || buf.Len() != 0 {
+		t.Errorf("expected no table so the caller falls back to JSON, got ok=%v err=%v:\n%s", ok, err, buf.String())
+	}
+}
+
+func TestColumnTitle(t *testing.T) {
+	tests := map[string]string{
+		"id":           "Id",
+		"createdAt":    "Created At",
+		"rewardAmount": "Reward Amount",
+		"programID":    "Program ID",
+		"":             "",
+	}
+	for in, want := range tests {
+		if got := ColumnTitle(in); got != want {
+			t.Errorf("ColumnTitle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// internal/outfmt/countries_test.go
+package outfmt
+
+import "testing"
+
+func TestFormatCountry(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"US", "🇺🇸 United States"},
+		{"de", "🇩🇪 Germany"},
+		{"GB", "🇬🇧 United Kingdom"},
+		{"UK", "UK"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := FormatCountry(tt.code); got != tt.want {
+			t.Errorf("FormatCountry(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestCountryFlag_Invalid(t *testing.T) {
+	for _, code := range []string{"", "U", "USA", "1A"} {
+		if got := CountryFlag(code); got != "" {
+			t.Errorf("CountryFlag(%q) = %q, want empty", code, got)
+		}
+	}
+}
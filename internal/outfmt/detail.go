@@ -0,0 +1,139 @@
+// internal/outfmt/detail.go
+package outfmt
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DetailField is one flattened "Field: value" line of a detail view.
+type DetailField struct {
+	Name  string
+	Value string
+}
+
+// FormatDetail writes a single resource as aligned "Field: value" lines.
+// Nested objects are flattened into dotted names (e.g. "user.email"),
+// timestamps are shown as readable dates, and whole numbers get comma
+// separators.
+func FormatDetail(w io.Writer, data map[string]interface{}) error {
+	fields := FlattenDetail(data)
+
+	width := 0
+	for _, f := range fields {
+		if n := DisplayWidth(f.Name); n > width {
+			width = n
+		}
+	}
+
+	for _, f := range fields {
+		padding := strings.Repeat(" ", width-DisplayWidth(f.Name))
+		if _, err := fmt.Fprintf(w, "%s:%s  %s\n", f.Name, padding, f.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlattenDetail flattens data into fields sorted by name, with "id" first.
+func FlattenDetail(data map[string]interface{}) []DetailField {
+	var fields []DetailField
+	flattenDetail("", data, &fields)
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		if (fields[i].Name == "id") != (fields[j].Name == "id") {
+			return fields[i].Name == "id"
+		}
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
+
+func flattenDetail(prefix string, v interface{}, fields *[]DetailField) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			*fields = append(*fields, DetailField{Name: prefix, Value: "-"})
+		}
+		for key, child := range v {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			flattenDetail(name, child, fields)
+		}
+	case []interface{}:
+		if isScalarList(v) {
+			*fields = append(*fields, DetailField{Name: prefix, Value: formatDetailList(v)})
+			return
+		}
+		for i, child := range v {
+			flattenDetail(fmt.Sprintf("%s[%d]", prefix, i), child, fields)
+		}
+	default:
+		*fields = append(*fields, DetailField{Name: prefix, Value: formatDetailValue(v)})
+	}
+}
+
+func isScalarList(items []interface{}) bool {
+	for _, item := range items {
+		switch item.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+func formatDetailList(items []interface{}) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = formatDetailValue(item)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatDetailValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" {
+			return "-"
+		}
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t.Format("Jan 2, 2006 15:04 MST")
+		}
+		return v
+	case bool:
+		return FormatBool(v)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return formatInt(int64(v))
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatInt formats n with comma separators, e.g. 1234567 -> "1,234,567".
+func formatInt(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
@@ -0,0 +1,56 @@
+// internal/outfmt/detail_test.go
+package outfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestFormatDetail(t *testing.T) {
+	var data map[string]interface{}
+	body := `{
+		"url": "https://example.com",
+		"id": "link_123",
+		"clicks": 1234567,
+		"archived": false,
+		"createdAt": "2024-01-15T10:30:00.000Z",
+		"tags": [{"id": "tag_1", "name": "docs"}],
+		"user": {"name": "Ada", "email": null},
+		"geo": {},
+		"rate": 0.25
+	}`
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := FormatDetail(&buf, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `id:            link_123
+archived:      No
+clicks:        1,234,567
+createdAt:     Jan 15, 2024 10:30 UTC
+geo:           -
+rate:          0.25
+tags[0].id:    tag_1
+tags[0].name:  docs
+url:           https://example.com
+user.email:    -
+user.name:     Ada
+`
+	if got := buf.String(); got != want {
+		t.Errorf("FormatDetail output mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatInt(t *testing.T) {
+	tests := map[int64]string{0: "0", 999: "999", 1000: "1,000", -1234567: "-1,234,567"}
+	for n, want := range tests {
+		if got := formatInt(n); got != want {
+			t.Errorf("formatInt(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
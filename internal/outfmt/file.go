@@ -0,0 +1,132 @@
+// internal/outfmt/file.go
+package outfmt
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileWriter writes command output to a file. Output goes to a temp file
+// beside it that replaces the file on Commit, so a command that fails midway
+// leaves the previous contents alone. In append mode writes go straight to
+// the end of the file instead, for NDJSON streams collected over many runs.
+type FileWriter struct {
+	path    string
+	f       *os.File
+	append  bool
+	created bool        // append mode created the file
+	perm    os.FileMode // mode of the new file; 0 keeps the old file's, or 0644
+}
+
+// CreateFile starts output to path, appending to it if append is set.
+func CreateFile(path string, append bool) (*FileWriter, error) {
+	if append {
+		// A new file stays private until its mode is settled on Commit, in
+		// case SetPerm asks for that
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+		created := err == nil
+		if errors.Is(err, fs.ErrExist) {
+			f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		return &FileWriter{path: path, f: f, append: true, created: created}, nil
+	}
+
+	// The temp file must be in the same directory for the rename to be atomic
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	return &FileWriter{path: path, f: f}, nil
+}
+
+func (w *FileWriter) Write(p []byte) (int, error) {
+	return w.f.Write(p)
+}
+
+// Path returns the file the output is for.
+func (w *FileWriter) Path() string {
+	return w.path
+}
+
+// Commit finishes the output, moving it into place.
+func (w *FileWriter) Commit() error {
+	if w.append {
+		err := w.f.Close()
+		w.settleAppendPerm()
+		return err
+	}
+	tmp := w.f.Name()
+	if err := w.f.Sync(); err != nil {
+		_ = w.f.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", w.path, err)
+	}
+	if err := w.f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", w.path, err)
+	}
+	// CreateTemp makes the file private; output files get the usual mode
+	mode := w.perm
+	if mode == 0 {
+		mode = 0o644
+		if info, err := os.Stat(w.path); err == nil {
+			mode = info.Mode().Perm()
+		}
+	}
+	_ = os.Chmod(tmp, mode)
+	if err := os.Rename(tmp, w.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", w.path, err)
+	}
+	return nil
+}
+
+// Abort discards the output, leaving the file as it was. Appended output
+// that was already written stays.
+func (w *FileWriter) Abort() {
+	_ = w.f.Close()
+	if !w.append {
+		_ = os.Remove(w.f.Name())
+		return
+	}
+	w.settleAppendPerm()
+}
+
+// settleAppendPerm gives a file created in append mode its final mode.
+func (w *FileWriter) settleAppendPerm() {
+	if !w.created {
+		return
+	}
+	mode := w.perm
+	if mode == 0 {
+		mode = 0o644
+	}
+	_ = os.Chmod(w.path, mode)
+}
+
+// SetPerm sets the mode of a newly written file, e.g. 0o600 for output
+// that shouldn't be readable by other users.
+func (w *FileWriter) SetPerm(perm os.FileMode) {
+	w.perm = perm
+}
+
+// WriteFileAtomic writes data to path through a temp file and rename, so
+// readers never see a partly written file.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	w, err := CreateFile(path, false)
+	if err != nil {
+		return err
+	}
+	w.SetPerm(perm)
+	if _, err := w.Write(data); err != nil {
+		w.Abort()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return w.Commit()
+}
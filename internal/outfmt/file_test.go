@@ -0,0 +1,137 @@
+// internal/outfmt/file_test.go
+package outfmt
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFileWriter_CommitReplaces(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := CreateFile(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("new"))
+	if got := readFile(t, path); got != "old" {
+		t.Errorf("file changed before commit: %q", got)
+	}
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); got != "new" {
+		t.Errorf("expected new contents, got %q", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected the temp file to be gone, found %d entries", len(entries))
+	}
+}
+
+func TestFileWriter_AbortKeepsOld(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := CreateFile(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("partial"))
+	w.Abort()
+
+	if got := readFile(t, path); got != "old" {
+		t.Errorf("expected old contents, got %q", got)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected the temp file to be gone, found %d entries", len(entries))
+	}
+}
+
+func TestFileWriter_Append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	for _, line := range []string{"{\"n\":1}\n", "{\"n\":2}\n"} {
+		w, err := CreateFile(path, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(line))
+		if err := w.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := readFile(t, path); got != "{\"n\":1}\n{\"n\":2}\n" {
+		t.Errorf("unexpected contents %q", got)
+	}
+}
+
+func TestWriteFileAtomic_Perm(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes aren't enforced")
+	}
+	path := filepath.Join(t.TempDir(), "bundle.txt")
+	if err := WriteFileAtomic(path, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+func TestFileWriter_AppendPerm(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes aren't enforced")
+	}
+	path := filepath.Join(t.TempDir(), "bundle.md")
+	w, err := CreateFile(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.SetPerm(0o600)
+	_, _ = w.Write([]byte("secret"))
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected a new appended file to get mode 0600, got %v", info.Mode().Perm())
+	}
+
+	path = filepath.Join(t.TempDir(), "events.ndjson")
+	if w, err = CreateFile(path, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = os.Stat(path); err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o644 {
+		t.Errorf("expected a new appended file to default to 0644, got %v", info.Mode().Perm())
+	}
+}
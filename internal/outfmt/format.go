@@ -2,6 +2,7 @@
 package outfmt
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -87,11 +88,30 @@ func GetDesc(ctx context.Context) bool {
 	return false
 }
 
+// compactJSON makes FormatJSON write minified JSON.
+var compactJSON bool
+
+// SetCompactJSON sets whether FormatJSON writes minified JSON, from the
+// --compact flag.
+func SetCompactJSON(compact bool) {
+	compactJSON = compact
+}
+
+// FormatJSON writes data as JSON, or the results of the jq query on it one
+// per line. Object keys are always sorted, so output is stable between runs.
 func FormatJSON(w io.Writer, data interface{}, query string) error {
 	if query == "" {
+		// Struct fields would keep their declaration order, and raw API
+		// responses the server's order; maps are encoded with sorted keys
+		sorted, err := sortKeys(data)
+		if err != nil {
+			return err
+		}
 		enc := json.NewEncoder(w)
-		enc.SetIndent("", "  ")
-		return enc.Encode(data)
+		if !compactJSON {
+			enc.SetIndent("", "  ")
+		}
+		return enc.Encode(sorted)
 	}
 
 	// Apply jq query
@@ -138,3 +158,19 @@ func normalizeForJQ(data interface{}) (interface{}, error) {
 	}
 	return normalized, nil
 }
+
+// sortKeys converts data to generic JSON values, keeping numbers exact, so
+// that encoding it sorts the keys of every object.
+func sortKeys(data interface{}) (interface{}, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(jsonBytes))
+	dec.UseNumber()
+	var sorted interface{}
+	if err := dec.Decode(&sorted); err != nil {
+		return nil, err
+	}
+	return sorted, nil
+}
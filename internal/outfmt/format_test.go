@@ -35,3 +35,54 @@ func TestFormatJSON_WithQuery(t *testing.T) {
 		t.Errorf("expected '\"123\"\\n', got: %q", output)
 	}
 }
+
+func TestFormatJSON_SortedKeys(t *testing.T) {
+	type link struct {
+		URL    string         `json:"url"`
+		ID     string         `json:"id"`
+		Clicks int64          `json:"clicks"`
+		Meta   map[string]int `json:"meta"`
+	}
+	data := []link{{URL: "https://dub.sh/a", ID: "1", Clicks: 9007199254740993, Meta: map[string]int{"b": 2, "a": 1}}}
+	buf := new(bytes.Buffer)
+
+	if err := FormatJSON(buf, data, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `[
+  {
+    "clicks": 9007199254740993,
+    "id": "1",
+    "meta": {
+      "a": 1,
+      "b": 2
+    },
+    "url": "https://dub.sh/a"
+  }
+]
+`
+	if buf.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestFormatJSON_Compact(t *testing.T) {
+	SetCompactJSON(true)
+	defer SetCompactJSON(false)
+
+	data := struct {
+		URL string `json:"url"`
+		ID  string `json:"id"`
+	}{URL: "https://dub.sh/test", ID: "123"}
+	buf := new(bytes.Buffer)
+
+	if err := FormatJSON(buf, data, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `{"id":"123","url":"https://dub.sh/test"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
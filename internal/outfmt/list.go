@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/i18n"
 )
 
 // RowMapper converts a single item from the API response into table row values.
@@ -18,12 +20,21 @@ type ListConfig struct {
 	All       bool   // if true, ignore limit
 	Output    string // "table" or "json"
 	Query     string // jq query for JSON output
+	SortBy    string // field to sort by before limiting; see SortItems
+	Desc      bool   // sort descending
 }
 
 // HandleListResponse processes a list API response and formats it as table or JSON.
 // The data parameter should be a slice of items from the API response.
 // The total parameter is the total count of items available (for pagination message).
 func HandleListResponse(w io.Writer, data []interface{}, total int, cfg ListConfig) error {
+	if cfg.SortBy != "" {
+		data = append([]interface{}(nil), data...)
+		if err := SortItems(data, cfg.SortBy, cfg.Desc); err != nil {
+			return err
+		}
+	}
+
 	if cfg.Output == "json" {
 		return FormatJSON(w, data, cfg.Query)
 	}
@@ -58,7 +69,7 @@ func HandleListResponse(w io.Writer, data []interface{}, total int, cfg ListConf
 		if total > available {
 			available = total
 		}
-		if _, err := fmt.Fprintf(w, "\nShowing %d of %d items. Use --limit or --all for more.\n", showing, available); err != nil {
+		if _, err := fmt.Fprint(w, i18n.Sprintf("\nShowing %d of %d items. Use --limit or --all for more.\n", showing, available)); err != nil {
 			return err
 		}
 	}
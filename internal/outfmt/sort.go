@@ -0,0 +1,241 @@
+// internal/outfmt/sort.go
+package outfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// SortItems stably sorts a list of objects by field, ascending or
+// descending. Field may be a JSON field ("lastClicked"), a column title
+// ("Last Clicked"), or a dotted path into nested objects ("user.name"), and
+// is matched ignoring case and separators.
+//
+// Numbers compare numerically, timestamps chronologically, and other
+// strings by the collation of the user's locale (LC_ALL, LC_COLLATE, or
+// LANG), with digit runs compared as numbers so "link2" sorts before
+// "link10". Items without the field sort last in either direction. It
+// errors when no item has the field.
+func SortItems(items []interface{}, field string, desc bool) error {
+	if field == "" || len(items) == 0 {
+		return nil
+	}
+
+	keys := make([]interface{}, len(items))
+	present := false
+	for i, item := range items {
+		v, ok := lookupField(item, field)
+		keys[i] = v
+		present = present || ok
+	}
+	if !present {
+		return unknownSortField(items[0], field)
+	}
+
+	c := newCollator()
+	idx := make([]int, len(items))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := keys[idx[i]], keys[idx[j]]
+		if a == nil || b == nil {
+			return a != nil
+		}
+		cmp := compareValues(c, a, b)
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	sorted := make([]interface{}, len(items))
+	for i, j := range idx {
+		sorted[i] = items[j]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+// SortJSON sorts a JSON array of objects with SortItems, returning body
+// unchanged when field is empty or body isn't an array. Numbers keep their
+// original precision.
+func SortJSON(body []byte, field string, desc bool) ([]byte, error) {
+	if field == "" || !bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		return body, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var items []interface{}
+	if err := dec.Decode(&items); err != nil {
+		return body, nil
+	}
+	if err := SortItems(items, field, desc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(items)
+}
+
+// lookupField returns the value at a possibly dotted field path in item.
+func lookupField(item interface{}, field string) (interface{}, bool) {
+	value := item
+	for _, part := range strings.Split(field, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = obj[part]; ok {
+			continue
+		}
+		want := normalizeFieldName(part)
+		found := false
+		for _, key := range SortedKeys(obj) {
+			if normalizeFieldName(key) == want {
+				value, found = obj[key], true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// normalizeFieldName folds case and drops separators so that "lastClicked",
+// "last_clicked", and "Last Clicked" all match.
+func normalizeFieldName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+func unknownSortField(item interface{}, field string) error {
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("cannot sort by %q: items are not objects", field)
+	}
+	var fields []string
+	for _, key := range SortedKeys(obj) {
+		switch obj[key].(type) {
+		case string, float64, json.Number, bool, nil:
+			fields = append(fields, key)
+		}
+	}
+	return fmt.Errorf("unknown sort field %q (available: %s)", field, strings.Join(fields, ", "))
+}
+
+// compareValues orders two non-nil values, returning -1, 0, or 1.
+func compareValues(c *collate.Collator, a, b interface{}) int {
+	if x, ok := numericValue(a); ok {
+		if y, ok := numericValue(b); ok {
+			return compareFloats(x, y)
+		}
+	}
+
+	sa, aok := a.(string)
+	sb, bok := b.(string)
+	if aok && bok {
+		if x, ok := parseTimestamp(sa); ok {
+			if y, ok := parseTimestamp(sb); ok {
+				return x.Compare(y)
+			}
+		}
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok {
+			return compareFloats(boolRank(x), boolRank(y))
+		}
+	}
+
+	if !aok {
+		sa = SafeString(a)
+	}
+	if !bok {
+		sb = SafeString(b)
+	}
+	return c.CompareString(sa, sb)
+}
+
+// numericValue returns v as a number if it is one, or is a string holding
+// one, like the amounts some endpoints return as strings.
+func numericValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolRank(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func parseTimestamp(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
+
+// newCollator returns a collator for the user's locale, falling back to
+// the root collation when it's unset or unrecognized.
+func newCollator() *collate.Collator {
+	return collate.New(localeTag(), collate.Numeric)
+}
+
+// localeTag reads the collation locale from the environment the way libc
+// does, e.g. "sv_SE.UTF-8" -> sv-SE.
+func localeTag() language.Tag {
+	for _, name := range []string{"LC_ALL", "LC_COLLATE", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if i := strings.IndexAny(value, ".@"); i >= 0 {
+			value = value[:i]
+		}
+		if value == "C" || value == "POSIX" {
+			return language.Und
+		}
+		tag, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+		if err != nil {
+			return language.Und
+		}
+		return tag
+	}
+	return language.Und
+}
@@ -0,0 +1,139 @@
+// internal/outfmt/sort_test.go
+package outfmt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// itemIDs returns the ids of items, in order.
+func itemIDs(items []interface{}) string {
+	var got []string
+	for _, item := range items {
+		got = append(got, SafeString(item.(map[string]interface{})["id"]))
+	}
+	return strings.Join(got, ",")
+}
+
+func TestSortItems(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_COLLATE", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	items := func() []interface{} {
+		return []interface{}{
+			map[string]interface{}{"id": "a", "name": "banana", "clicks": float64(10), "createdAt": "2024-03-01T00:00:00Z", "user": map[string]interface{}{"name": "Zoe"}},
+			map[string]interface{}{"id": "b", "name": "Apple", "clicks": float64(9), "createdAt": "2024-01-15T12:00:00.5Z", "user": map[string]interface{}{"name": "amy"}},
+			map[string]interface{}{"id": "c", "name": "Éclair", "clicks": float64(100), "createdAt": "2023-12-31T23:00:00-05:00"},
+			map[string]interface{}{"id": "d", "name": "link10", "clicks": nil, "createdAt": "2024-01-15T12:00:00Z", "user": map[string]interface{}{"name": "Bob"}},
+			map[string]interface{}{"id": "e", "name": "link2", "clicks": float64(9), "createdAt": "2024-02-01T00:00:00Z"},
+		}
+	}
+
+	tests := []struct {
+		field string
+		desc  bool
+		want  string
+	}{
+		// numbers by value, not as text; ties keep their order; nulls last
+		{"clicks", false, "b,e,a,c,d"},
+		{"clicks", true, "c,a,b,e,d"},
+		// case- and accent-insensitive, digit runs as numbers
+		{"name", false, "b,a,c,e,d"},
+		// timestamps chronologically, whatever the offset or precision
+		{"createdAt", false, "c,d,b,e,a"},
+		{"Created At", true, "a,e,b,d,c"},
+		// nested fields, missing ones last in either direction
+		{"user.name", false, "b,d,a,c,e"},
+		{"user.name", true, "a,d,b,c,e"},
+	}
+	for _, tt := range tests {
+		got := items()
+		if err := SortItems(got, tt.field, tt.desc); err != nil {
+			t.Fatalf("SortItems(%q): %v", tt.field, err)
+		}
+		if ids := itemIDs(got); ids != tt.want {
+			t.Errorf("SortItems(%q, desc=%v) = %s, want %s", tt.field, tt.desc, ids, tt.want)
+		}
+	}
+
+	err := SortItems(items(), "bogus", false)
+	if err == nil || !strings.Contains(err.Error(), "available: clicks, createdAt, id, name") {
+		t.Errorf("unknown field error = %v", err)
+	}
+}
+
+func TestSortItems_Locale(t *testing.T) {
+	t.Setenv("LC_ALL", "sv_SE.UTF-8")
+
+	// Swedish sorts ö after z; the root collation sorts it with o
+	items := []interface{}{
+		map[string]interface{}{"id": "ö", "name": "öl"},
+		map[string]interface{}{"id": "z", "name": "zebra"},
+		map[string]interface{}{"id": "o", "name": "ost"},
+	}
+	if err := SortItems(items, "name", false); err != nil {
+		t.Fatal(err)
+	}
+	if got := itemIDs(items); got != "o,z,ö" {
+		t.Errorf("sv_SE order = %s, want o,z,ö", got)
+	}
+
+	t.Setenv("LC_ALL", "C")
+	if err := SortItems(items, "name", false); err != nil {
+		t.Fatal(err)
+	}
+	if got := itemIDs(items); got != "ö,o,z" {
+		t.Errorf("C order = %s, want ö,o,z", got)
+	}
+}
+
+func TestSortJSON(t *testing.T) {
+	body := []byte(`[{"id":"a","n":12345678901234567890},{"id":"b","n":2}]`)
+	got, err := SortJSON(body, "n", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"id":"b","n":2},{"id":"a","n":12345678901234567890}]`; string(got) != want {
+		t.Errorf("SortJSON = %s, want %s", got, want)
+	}
+
+	for _, unchanged := range []string{`{"id":"a"}`, `not json`} {
+		got, err := SortJSON([]byte(unchanged), "id", false)
+		if err != nil || !bytes.Equal(got, []byte(unchanged)) {
+			t.Errorf("SortJSON(%s) = %s, %v; want it unchanged", unchanged, got, err)
+		}
+	}
+	if got, _ := SortJSON(body, "", false); !bytes.Equal(got, body) {
+		t.Errorf("SortJSON without a field changed the body")
+	}
+}
+
+func TestHandleListResponse_SortBy(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{"name": "b", "count": float64(2)},
+		map[string]interface{}{"name": "a", "count": float64(10)},
+		map[string]interface{}{"name": "c", "count": float64(1)},
+	}
+	cfg := ListConfig{
+		Columns:   []Column{{Name: "Name", Width: 10, Align: AlignLeft}},
+		RowMapper: func(item map[string]interface{}) []string { return []string{SafeString(item["name"])} },
+		Limit:     2,
+		Output:    "table",
+		SortBy:    "count",
+		Desc:      true,
+	}
+
+	var buf bytes.Buffer
+	if err := HandleListResponse(&buf, data, 3, cfg); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if strings.TrimSpace(lines[1]) != "a" || strings.TrimSpace(lines[2]) != "b" {
+		t.Errorf("expected the two highest counts first, got:\n%s", buf.String())
+	}
+	if SafeString(data[0].(map[string]interface{})["name"]) != "b" {
+		t.Error("HandleListResponse reordered the caller's slice")
+	}
+}
@@ -0,0 +1,33 @@
+// internal/outfmt/sparkline.go
+package outfmt
+
+// sparkTicks are the block characters used by Sparkline, lowest to highest.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a compact unicode bar chart, one character per
+// value, scaled between the smallest and largest value.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+
+	out := make([]rune, len(values))
+	for i, v := range values {
+		idx := 0
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparkTicks)-1))
+		}
+		out[i] = sparkTicks[idx]
+	}
+	return string(out)
+}
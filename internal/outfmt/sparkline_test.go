@@ -0,0 +1,22 @@
+// internal/outfmt/sparkline_test.go
+package outfmt
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   string
+	}{
+		{nil, ""},
+		{[]float64{5, 5, 5}, "▁▁▁"},
+		{[]float64{0, 7}, "▁█"},
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, "▁▂▃▄▅▆▇█"},
+	}
+
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
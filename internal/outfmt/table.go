@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"io"
 	"strings"
-	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+
+	"github.com/salmonumbrella/dub-cli/internal/i18n"
 )
 
 // Align specifies text alignment within a column.
@@ -26,28 +29,89 @@ type Column struct {
 // columnGap is the minimum spacing between columns.
 const columnGap = 2
 
-// Truncate shortens a string to maxLen characters, appending "..." if truncated.
-// If maxLen is less than 4, the string is truncated without ellipsis.
-// If maxLen is 0 or negative, the original string is returned unchanged.
+// TableLayout controls how FormatTable fits cells into width-limited
+// columns.
+type TableLayout struct {
+	// Wrap continues long cells on further lines instead of truncating them
+	Wrap bool
+	// MaxWidth replaces the width of every width-limited column when
+	// positive
+	MaxWidth int
+}
+
+var tableLayout TableLayout
+
+// SetTableLayout sets the layout used by FormatTable, from the --wrap and
+// --max-width flags.
+func SetTableLayout(layout TableLayout) {
+	tableLayout = layout
+}
+
+// columnWidth returns the maximum width of col under the current layout.
+func columnWidth(col Column) int {
+	if col.Width > 0 && tableLayout.MaxWidth > 0 {
+		return tableLayout.MaxWidth
+	}
+	return col.Width
+}
+
+// Truncate shortens a string to maxLen display columns, appending "..." if
+// truncated. Wide characters like CJK and emoji count as two columns and
+// are never split. If maxLen is less than 4, the string is truncated
+// without ellipsis. If maxLen is 0 or negative, the original string is
+// returned unchanged.
 func Truncate(s string, maxLen int) string {
 	if maxLen <= 0 {
 		return s
 	}
 
-	runeCount := utf8.RuneCountInString(s)
-	if runeCount <= maxLen {
+	if DisplayWidth(s) <= maxLen {
 		return s
 	}
 
 	// If maxLen is too short for ellipsis, just truncate
 	if maxLen < 4 {
-		runes := []rune(s)
-		return string(runes[:maxLen])
+		return fitWidth(s, maxLen)
 	}
 
 	// Truncate with ellipsis
-	runes := []rune(s)
-	return string(runes[:maxLen-3]) + "..."
+	return fitWidth(s, maxLen-3) + "..."
+}
+
+// DisplayWidth returns the number of terminal columns s occupies.
+func DisplayWidth(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// fitWidth returns the longest prefix of s, in whole grapheme clusters,
+// that fits in width columns.
+func fitWidth(s string, width int) string {
+	end, used, state := 0, 0, -1
+	rest := s
+	for len(rest) > 0 {
+		var cluster string
+		var w int
+		cluster, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > width {
+			break
+		}
+		used += w
+		end += len(cluster)
+	}
+	return s[:end]
+}
+
+// graphemes splits s into grapheme clusters and their display widths.
+func graphemes(s string) (clusters []string, widths []int) {
+	state := -1
+	for len(s) > 0 {
+		var cluster string
+		var w int
+		cluster, s, w, state = uniseg.FirstGraphemeClusterInString(s, state)
+		clusters = append(clusters, cluster)
+		widths = append(widths, w)
+	}
+	return clusters, widths
 }
 
 // FormatTable renders structured data as an aligned ASCII table.
@@ -58,26 +122,28 @@ func FormatTable(w io.Writer, columns []Column, rows [][]string) error {
 		return nil
 	}
 
+	headers := headerRow(columns)
+
 	// Calculate actual column widths based on content
 	widths := make([]int, len(columns))
 	for i, col := range columns {
 		// Start with header width
-		widths[i] = utf8.RuneCountInString(col.Name)
+		widths[i] = DisplayWidth(headers[i])
 
 		// Check if column has a fixed max width
-		if col.Width > 0 && widths[i] > col.Width {
-			widths[i] = col.Width
+		if maxWidth := columnWidth(col); maxWidth > 0 && widths[i] > maxWidth {
+			widths[i] = maxWidth
 		}
 	}
 
 	// Expand widths based on row content (up to column max width)
 	for _, row := range rows {
 		for i := 0; i < len(columns) && i < len(row); i++ {
-			cellWidth := utf8.RuneCountInString(row[i])
+			cellWidth := DisplayWidth(row[i])
 
 			// Apply column max width constraint
-			if columns[i].Width > 0 && cellWidth > columns[i].Width {
-				cellWidth = columns[i].Width
+			if maxWidth := columnWidth(columns[i]); maxWidth > 0 && cellWidth > maxWidth {
+				cellWidth = maxWidth
 			}
 
 			if cellWidth > widths[i] {
@@ -87,7 +153,7 @@ func FormatTable(w io.Writer, columns []Column, rows [][]string) error {
 	}
 
 	// Write header row
-	if err := writeRow(w, columns, widths, headerRow(columns)); err != nil {
+	if err := writeRow(w, columns, widths, headers); err != nil {
 		return err
 	}
 
@@ -101,50 +167,116 @@ func FormatTable(w io.Writer, columns []Column, rows [][]string) error {
 	return nil
 }
 
-// headerRow creates a row of uppercase column names.
+// headerRow creates a row of uppercase column names, translated into the
+// current language.
 func headerRow(columns []Column) []string {
 	headers := make([]string, len(columns))
 	for i, col := range columns {
-		headers[i] = strings.ToUpper(col.Name)
+		headers[i] = strings.ToUpper(i18n.T(col.Name))
 	}
 	return headers
 }
 
-// writeRow writes a single row with proper alignment and spacing.
+// writeRow writes a single row with proper alignment and spacing. With
+// wrapping enabled, a row spans as many lines as its tallest cell.
 func writeRow(w io.Writer, columns []Column, widths []int, row []string) error {
-	var sb strings.Builder
-
+	cells := make([][]string, len(columns))
+	height := 1
 	for i, col := range columns {
 		var cell string
 		if i < len(row) {
 			cell = row[i]
 		}
 
-		// Apply truncation if column has max width
-		if col.Width > 0 {
-			cell = Truncate(cell, col.Width)
+		// Apply truncation or wrapping if column has max width
+		maxWidth := columnWidth(col)
+		switch {
+		case maxWidth > 0 && tableLayout.Wrap:
+			cells[i] = wrapCell(cell, maxWidth)
+		case maxWidth > 0:
+			cells[i] = []string{Truncate(cell, maxWidth)}
+		default:
+			cells[i] = []string{cell}
 		}
+		if len(cells[i]) > height {
+			height = len(cells[i])
+		}
+	}
+
+	for line := 0; line < height; line++ {
+		var sb strings.Builder
+		for i, col := range columns {
+			var cell string
+			if line < len(cells[i]) {
+				cell = cells[i][line]
+			}
+
+			// Pad and align
+			cellWidth := DisplayWidth(cell)
+			padding := widths[i] - cellWidth
 
-		// Pad and align
-		cellWidth := utf8.RuneCountInString(cell)
-		padding := widths[i] - cellWidth
+			if col.Align == AlignRight {
+				sb.WriteString(strings.Repeat(" ", padding))
+				sb.WriteString(cell)
+			} else {
+				sb.WriteString(cell)
+				sb.WriteString(strings.Repeat(" ", padding))
+			}
 
-		if col.Align == AlignRight {
-			sb.WriteString(strings.Repeat(" ", padding))
-			sb.WriteString(cell)
-		} else {
-			sb.WriteString(cell)
-			sb.WriteString(strings.Repeat(" ", padding))
+			// Add column gap (except for last column)
+			if i < len(columns)-1 {
+				sb.WriteString(strings.Repeat(" ", columnGap))
+			}
 		}
 
-		// Add column gap (except for last column)
-		if i < len(columns)-1 {
-			sb.WriteString(strings.Repeat(" ", columnGap))
+		// Trim trailing whitespace and write
+		if _, err := fmt.Fprintln(w, strings.TrimRight(sb.String(), " ")); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	// Trim trailing whitespace and write
-	line := strings.TrimRight(sb.String(), " ")
-	_, err := fmt.Fprintln(w, line)
-	return err
+// wrapBreaks are the characters a wrapped line may end after, so URLs
+// break between path segments and query parameters where possible.
+const wrapBreaks = " /?&=-,"
+
+// wrapCell splits s into lines of at most width display columns, breaking
+// after a space or URL separator in the latter half of a line when there
+// is one.
+func wrapCell(s string, width int) []string {
+	clusters, widths := graphemes(s)
+	var lines []string
+	for {
+		n, used := 0, 0
+		for n < len(clusters) && used+widths[n] <= width {
+			used += widths[n]
+			n++
+		}
+		if n == len(clusters) {
+			break
+		}
+		if n == 0 {
+			// A character wider than the column gets a line of its own
+			n, used = 1, widths[0]
+		}
+
+		cut := n
+		for i := n; i > 0 && used > width/2; i-- {
+			if strings.Contains(wrapBreaks, clusters[i-1]) {
+				cut = i
+				break
+			}
+			used -= widths[i-1]
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(clusters[:cut], ""), " "))
+		clusters, widths = clusters[cut:], widths[cut:]
+		for len(clusters) > 0 && clusters[0] == " " {
+			clusters, widths = clusters[1:], widths[1:]
+		}
+		if len(clusters) == 0 {
+			return lines
+		}
+	}
+	return append(lines, strings.Join(clusters, ""))
 }
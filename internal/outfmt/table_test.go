@@ -68,6 +68,30 @@ func TestTruncate(t *testing.T) {
 			maxLen: 4,
 			want:   "h...",
 		},
+		{
+			name:   "wide characters count as two columns",
+			input:  "日本語のリンク",
+			maxLen: 8,
+			want:   "日本...",
+		},
+		{
+			name:   "wide character not split",
+			input:  "日本語",
+			maxLen: 3,
+			want:   "日",
+		},
+		{
+			name:   "wide characters that fit",
+			input:  "日本語",
+			maxLen: 6,
+			want:   "日本語",
+		},
+		{
+			name:   "emoji grapheme kept whole",
+			input:  "🇺🇸 launch 👩‍💻 team",
+			maxLen: 10,
+			want:   "🇺🇸 laun...",
+		},
 	}
 
 	for _, tt := range tests {
@@ -190,6 +214,103 @@ func TestFormatTable_Truncation(t *testing.T) {
 	}
 }
 
+func TestFormatTable_Wrap(t *testing.T) {
+	SetTableLayout(TableLayout{Wrap: true})
+	defer SetTableLayout(TableLayout{})
+
+	columns := []Column{
+		{Name: "URL", Width: 20, Align: AlignLeft},
+		{Name: "Clicks", Width: 0, Align: AlignRight},
+	}
+	rows := [][]string{
+		{"https://example.com/very/long/path?utm_source=x", "1,234"},
+		{"short", "5"},
+	}
+
+	var buf bytes.Buffer
+	if err := FormatTable(&buf, columns, rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "URL                   CLICKS\n" +
+		"https://example.com/   1,234\n" +
+		"very/long/path?\n" +
+		"utm_source=x\n" +
+		"short                      5\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestFormatTable_MaxWidth(t *testing.T) {
+	SetTableLayout(TableLayout{MaxWidth: 30})
+	defer SetTableLayout(TableLayout{})
+
+	columns := []Column{
+		{Name: "URL", Width: 15, Align: AlignLeft},
+		{Name: "Key", Width: 0, Align: AlignLeft},
+	}
+	rows := [][]string{{"https://example.com/long/path/here", "abcdefghijklmnopqrstuvwxyz0123456789"}}
+
+	var buf bytes.Buffer
+	if err := FormatTable(&buf, columns, rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "https://example.com/long/pa...") {
+		t.Errorf("expected the URL column widened to 30, got:\n%s", out)
+	}
+	if !strings.Contains(out, "abcdefghijklmnopqrstuvwxyz0123456789") {
+		t.Errorf("expected unlimited columns untouched, got:\n%s", out)
+	}
+}
+
+func TestFormatTable_WideCharacters(t *testing.T) {
+	columns := []Column{
+		{Name: "Tag", Width: 0, Align: AlignLeft},
+		{Name: "Clicks", Width: 0, Align: AlignRight},
+	}
+	rows := [][]string{
+		{"日本語", "1"},
+		{"🚀 launch", "22"},
+		{"plain", "333"},
+	}
+
+	var buf bytes.Buffer
+	if err := FormatTable(&buf, columns, rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	for _, line := range lines {
+		if got := DisplayWidth(line); got != DisplayWidth(lines[0]) {
+			t.Errorf("expected every line %d columns wide, got %d for %q", DisplayWidth(lines[0]), got, line)
+		}
+	}
+}
+
+func TestWrapCell(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  []string
+	}{
+		{"short", 10, []string{"short"}},
+		{"hello wide world", 10, []string{"hello", "wide world"}},
+		{"abcdefghijklmnop", 5, []string{"abcde", "fghij", "klmno", "p"}},
+		{"https://a.co/xy/zzzzzz", 10, []string{"https://", "a.co/xy/", "zzzzzz"}},
+		{"日本語のリンク", 5, []string{"日本", "語の", "リン", "ク"}},
+		{"日本", 1, []string{"日", "本"}},
+	}
+	for _, tt := range tests {
+		got := wrapCell(tt.in, tt.width)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("wrapCell(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
 func TestFormatTable_EmptyRows(t *testing.T) {
 	columns := []Column{
 		{Name: "Name", Width: 10, Align: AlignLeft},
@@ -3,7 +3,12 @@ package secrets
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,16 +20,23 @@ type Store interface {
 	Keys() ([]string, error)
 	Set(name string, creds Credentials) error
 	Get(name string) (Credentials, error)
+	GetRole(name, role string) (Credentials, error)
 	Delete(name string) error
+	DeleteRole(name, role string) error
 	List() ([]Credentials, error)
 }
 
 type KeyringStore struct {
 	ring keyring.Keyring
+	info BackendInfo
+	// legacy is the keyring of older versions while it still holds keys
+	// that couldn't be migrated; reads fall back to it
+	legacy keyring.Keyring
 }
 
 type Credentials struct {
 	Name      string    `json:"name"`
+	Role      string    `json:"role,omitempty"` // empty for the workspace's default key
 	APIKey    string    `json:"-"`
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -34,25 +46,229 @@ type storedCredentials struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Environment variables that control which keyring backend is used.
+const (
+	BackendEnv      = "DUB_KEYRING_BACKEND"
+	FilePasswordEnv = "DUB_KEYRING_PASSWORD"
+)
+
+// BackendInfo describes the keyring backend a store uses and why it was
+// chosen.
+type BackendInfo struct {
+	Backend string `json:"backend"`
+	Reason  string `json:"reason"`
+	WSL     bool   `json:"wsl"`
+}
+
 func OpenDefault() (Store, error) {
-	ring, err := keyring.Open(keyring.Config{
-		ServiceName: config.AppName,
-	})
+	wsl := IsWSL()
+	backends, reason, err := chooseBackends(runtime.GOOS, wsl, os.Getenv(BackendEnv))
 	if err != nil {
 		return nil, err
 	}
-	return &KeyringStore{ring: ring}, nil
+
+	cfg := keyring.Config{
+		ServiceName: config.AppName,
+		FilePasswordFunc: func(prompt string) (string, error) {
+			if password := os.Getenv(FilePasswordEnv); password != "" {
+				return password, nil
+			}
+			return keyring.TerminalPrompt(prompt)
+		},
+	}
+	if dir, err := config.Dir(); err == nil {
+		cfg.FileDir = filepath.Join(dir, "keyring")
+	}
+
+	// Open backends one at a time so we know which one is in use
+	for _, backend := range backends {
+		cfg.AllowedBackends = []keyring.BackendType{backend}
+		ring, err := keyring.Open(cfg)
+		if err != nil {
+			continue
+		}
+		store := &KeyringStore{ring: ring, info: BackendInfo{Backend: string(backend), Reason: reason, WSL: wsl}}
+		store.adoptLegacy(backend)
+		return store, nil
+	}
+	return nil, keyring.ErrNoAvailImpl
+}
+
+// adoptLegacy moves keys stored by older versions, which used the first
+// available backend with the library defaults, to the backend in use now.
+// It runs until every key has moved; keys that couldn't be moved are still
+// read from the old backend.
+func (s *KeyringStore) adoptLegacy(current keyring.BackendType) {
+	marker, err := config.KeyringMigratedPath()
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(marker); err == nil {
+		return
+	}
+
+	if legacy := openLegacyKeyring(current); legacy != nil {
+		if remaining, err := migrateKeys(s.ring, legacy); err != nil || remaining > 0 {
+			s.legacy = legacy
+			return
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(marker), 0o700); err == nil {
+		_ = os.WriteFile(marker, nil, 0o600)
+	}
+}
+
+// openLegacyKeyring opens the keyring older versions used. It returns nil
+// when that is the backend in use now, or the file backend, which had no
+// directory then and so never held keys.
+func openLegacyKeyring(current keyring.BackendType) keyring.Keyring {
+	for _, backend := range keyring.AvailableBackends() {
+		ring, err := keyring.Open(keyring.Config{
+			ServiceName:     config.AppName,
+			AllowedBackends: []keyring.BackendType{backend},
+		})
+		if err != nil {
+			continue
+		}
+		if backend == current || backend == keyring.FileBackend {
+			return nil
+		}
+		return ring
+	}
+	return nil
+}
+
+// migrateKeys moves the credentials in src that dst lacks to dst, and
+// returns how many are left in src because they couldn't be moved.
+func migrateKeys(dst, src keyring.Keyring) (int, error) {
+	keys, err := src.Keys()
+	if err != nil {
+		return 0, err
+	}
+	remaining := 0
+	for _, k := range keys {
+		if _, ok := ParseCredentialKey(k); !ok {
+			continue
+		}
+		if _, err := dst.Get(k); err == nil {
+			// Already migrated, or set again since; the newer key wins
+			_ = src.Remove(k)
+			continue
+		}
+		item, err := src.Get(k)
+		if err == nil {
+			err = dst.Set(item)
+		}
+		if err != nil {
+			remaining++
+			continue
+		}
+		_ = src.Remove(k)
+	}
+	return remaining, nil
+}
+
+// chooseBackends returns the backends to try, in order, and the reason for
+// the choice. WSL rarely has a Linux keyring, so it uses the encrypted file
+// backend directly rather than failing over slowly.
+func chooseBackends(goos string, wsl bool, override string) ([]keyring.BackendType, string, error) {
+	available := keyring.AvailableBackends()
+
+	if override != "" {
+		backend := keyring.BackendType(strings.ToLower(strings.TrimSpace(override)))
+		if !slices.Contains(available, backend) {
+			names := make([]string, len(available))
+			for i, b := range available {
+				names[i] = string(b)
+			}
+			return nil, "", fmt.Errorf("%s=%q is not available on this system (available: %s)", BackendEnv, override, strings.Join(names, ", "))
+		}
+		return []keyring.BackendType{backend}, "set by " + BackendEnv, nil
+	}
+
+	switch {
+	case goos == "windows":
+		return []keyring.BackendType{keyring.WinCredBackend, keyring.FileBackend}, "Windows Credential Manager", nil
+	case wsl:
+		return []keyring.BackendType{keyring.FileBackend}, "WSL detected; using the encrypted file store since the Linux keyring is usually unavailable", nil
+	default:
+		return available, "first available system keyring", nil
+	}
+}
+
+// IsWSL reports whether the CLI is running under Windows Subsystem for Linux.
+func IsWSL() bool {
+	return isWSL(runtime.GOOS, os.Getenv, os.ReadFile)
+}
+
+func isWSL(goos string, getenv func(string) string, readFile func(string) ([]byte, error)) bool {
+	if goos != "linux" {
+		return false
+	}
+	if getenv("WSL_DISTRO_NAME") != "" || getenv("WSL_INTEROP") != "" {
+		return true
+	}
+	release, err := readFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(release)), "microsoft")
+}
+
+// Backend describes the keyring backend in use.
+func (s *KeyringStore) Backend() BackendInfo {
+	return s.info
 }
 
 func (s *KeyringStore) Keys() ([]string, error) {
-	return s.ring.Keys()
+	keys, err := s.ring.Keys()
+	if err != nil || s.legacy == nil {
+		return keys, err
+	}
+	if legacy, err := s.legacy.Keys(); err == nil {
+		for _, k := range legacy {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// get reads key from the keyring, falling back to the legacy keyring.
+func (s *KeyringStore) get(key string) (keyring.Item, error) {
+	item, err := s.ring.Get(key)
+	if errors.Is(err, keyring.ErrKeyNotFound) && s.legacy != nil {
+		return s.legacy.Get(key)
+	}
+	return item, err
+}
+
+// remove deletes key from the keyring and the legacy keyring.
+func (s *KeyringStore) remove(key string) error {
+	if s.legacy != nil {
+		if _, err := s.legacy.Get(key); err == nil {
+			if err := s.legacy.Remove(key); err != nil {
+				return err
+			}
+			if _, err := s.ring.Get(key); err != nil {
+				return nil
+			}
+		}
+	}
+	return s.ring.Remove(key)
 }
 
+// Set stores creds for workspace name under creds.Role, which is empty for
+// the workspace's default key.
 func (s *KeyringStore) Set(name string, creds Credentials) error {
 	name = normalize(name)
 	if name == "" {
 		return fmt.Errorf("missing workspace name")
 	}
+	if strings.Contains(name, roleSeparator) || strings.Contains(creds.Role, roleSeparator) {
+		return fmt.Errorf("workspace and role names must not contain %q", roleSeparator)
+	}
 	if creds.APIKey == "" {
 		return fmt.Errorf("missing API key")
 	}
@@ -69,17 +285,23 @@ func (s *KeyringStore) Set(name string, creds Credentials) error {
 	}
 
 	return s.ring.Set(keyring.Item{
-		Key:  credentialKey(name),
+		Key:  credentialKey(name, normalize(creds.Role)),
 		Data: payload,
 	})
 }
 
+// Get returns the default key for workspace name.
 func (s *KeyringStore) Get(name string) (Credentials, error) {
-	name = normalize(name)
+	return s.GetRole(name, "")
+}
+
+// GetRole returns the key stored for workspace name under role.
+func (s *KeyringStore) GetRole(name, role string) (Credentials, error) {
+	name, role = normalize(name), normalize(role)
 	if name == "" {
 		return Credentials{}, fmt.Errorf("missing workspace name")
 	}
-	item, err := s.ring.Get(credentialKey(name))
+	item, err := s.get(credentialKey(name, role))
 	if err != nil {
 		return Credentials{}, err
 	}
@@ -90,19 +312,50 @@ func (s *KeyringStore) Get(name string) (Credentials, error) {
 
 	return Credentials{
 		Name:      name,
+		Role:      role,
 		APIKey:    stored.APIKey,
 		CreatedAt: stored.CreatedAt,
 	}, nil
 }
 
+// Delete removes every key stored for workspace name.
 func (s *KeyringStore) Delete(name string) error {
 	name = normalize(name)
 	if name == "" {
 		return fmt.Errorf("missing workspace name")
 	}
-	return s.ring.Remove(credentialKey(name))
+	keys, err := s.Keys()
+	if err != nil {
+		return err
+	}
+
+	removed := false
+	for _, k := range keys {
+		if rest, ok := ParseCredentialKey(k); ok {
+			if ws, _ := SplitRole(rest); ws == name {
+				if err := s.remove(k); err != nil {
+					return err
+				}
+				removed = true
+			}
+		}
+	}
+	if !removed {
+		return keyring.ErrKeyNotFound
+	}
+	return nil
 }
 
+// DeleteRole removes the key stored for workspace name under role.
+func (s *KeyringStore) DeleteRole(name, role string) error {
+	name = normalize(name)
+	if name == "" {
+		return fmt.Errorf("missing workspace name")
+	}
+	return s.remove(credentialKey(name, normalize(role)))
+}
+
+// List returns every stored key, including each workspace's role keys.
 func (s *KeyringStore) List() ([]Credentials, error) {
 	keys, err := s.Keys()
 	if err != nil {
@@ -110,11 +363,12 @@ func (s *KeyringStore) List() ([]Credentials, error) {
 	}
 	var out []Credentials
 	for _, k := range keys {
-		name, ok := ParseCredentialKey(k)
+		rest, ok := ParseCredentialKey(k)
 		if !ok {
 			continue
 		}
-		creds, err := s.Get(name)
+		name, role := SplitRole(rest)
+		creds, err := s.GetRole(name, role)
 		if err != nil {
 			return nil, err
 		}
@@ -135,7 +389,19 @@ func ParseCredentialKey(k string) (name string, ok bool) {
 	return rest, true
 }
 
-func credentialKey(name string) string {
+// SplitRole splits a parsed credential key such as "acme#read-only" into
+// the workspace name and role. The default key has no role.
+func SplitRole(key string) (name, role string) {
+	name, role, _ = strings.Cut(key, roleSeparator)
+	return name, role
+}
+
+const roleSeparator = "#"
+
+func credentialKey(name, role string) string {
+	if role != "" {
+		return fmt.Sprintf("workspace:%s%s%s", name, roleSeparator, role)
+	}
 	return fmt.Sprintf("workspace:%s", name)
 }
 
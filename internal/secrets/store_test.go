@@ -2,8 +2,11 @@
 package secrets
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/99designs/keyring"
 )
 
 func TestCredentials_Fields(t *testing.T) {
@@ -41,3 +44,117 @@ func TestParseCredentialKey(t *testing.T) {
 		}
 	}
 }
+
+func TestIsWSL(t *testing.T) {
+	noEnv := func(string) string { return "" }
+	release := func(s string) func(string) ([]byte, error) {
+		return func(string) ([]byte, error) { return []byte(s), nil }
+	}
+
+	tests := []struct {
+		name     string
+		goos     string
+		getenv   func(string) string
+		readFile func(string) ([]byte, error)
+		want     bool
+	}{
+		{"distro env", "linux", func(k string) string {
+			if k == "WSL_DISTRO_NAME" {
+				return "Ubuntu"
+			}
+			return ""
+		}, release(""), true},
+		{"kernel release", "linux", noEnv, release("5.15.153.1-microsoft-standard-WSL2\n"), true},
+		{"plain linux", "linux", noEnv, release("6.8.0-45-generic\n"), false},
+		{"darwin", "darwin", noEnv, release("microsoft"), false},
+	}
+	for _, tt := range tests {
+		if got := isWSL(tt.goos, tt.getenv, tt.readFile); got != tt.want {
+			t.Errorf("%s: isWSL = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestChooseBackends(t *testing.T) {
+	backends, _, err := chooseBackends("linux", true, "")
+	if err != nil || len(backends) != 1 || backends[0] != keyring.FileBackend {
+		t.Errorf("expected WSL to use the file backend, got %v (%v)", backends, err)
+	}
+
+	backends, reason, err := chooseBackends("linux", false, "FILE")
+	if err != nil || len(backends) != 1 || backends[0] != keyring.FileBackend {
+		t.Errorf("expected override to select the file backend, got %v (%v)", backends, err)
+	}
+	if !strings.Contains(reason, BackendEnv) {
+		t.Errorf("expected reason to mention %s, got %q", BackendEnv, reason)
+	}
+
+	if _, _, err := chooseBackends("linux", false, "nope"); err == nil {
+		t.Error("expected unknown backend override to fail")
+	}
+}
+
+func TestCredentialKeyRoles(t *testing.T) {
+	tests := []struct {
+		name, role, key string
+	}{
+		{"production", "", "workspace:production"},
+		{"production", "read-only", "workspace:production#read-only"},
+	}
+	for _, tt := range tests {
+		key := credentialKey(tt.name, tt.role)
+		if key != tt.key {
+			t.Errorf("credentialKey(%q, %q) = %q, want %q", tt.name, tt.role, key, tt.key)
+		}
+		rest, _ := ParseCredentialKey(key)
+		if name, role := SplitRole(rest); name != tt.name || role != tt.role {
+			t.Errorf("SplitRole(%q) = (%q, %q), want (%q, %q)", rest, name, role, tt.name, tt.role)
+		}
+	}
+}
+
+func TestMigrateKeys(t *testing.T) {
+	legacy := keyring.NewArrayKeyring([]keyring.Item{
+		{Key: "workspace:acme", Data: []byte(`{"api_key":"dub_old"}`)},
+		{Key: "workspace:beta", Data: []byte(`{"api_key":"dub_stale"}`)},
+		{Key: "unrelated", Data: []byte("x")},
+	})
+	current := keyring.NewArrayKeyring([]keyring.Item{
+		{Key: "workspace:beta", Data: []byte(`{"api_key":"dub_new"}`)},
+	})
+
+	remaining, err := migrateKeys(current, legacy)
+	if err != nil || remaining != 0 {
+		t.Fatalf("migrateKeys = %d, %v", remaining, err)
+	}
+	store := &KeyringStore{ring: current}
+	if creds, err := store.Get("acme"); err != nil || creds.APIKey != "dub_old" {
+		t.Errorf("expected the legacy key to be migrated, got %+v, %v", creds, err)
+	}
+	if creds, err := store.Get("beta"); err != nil || creds.APIKey != "dub_new" {
+		t.Errorf("expected the current key to win, got %+v, %v", creds, err)
+	}
+	if keys, _ := legacy.Keys(); len(keys) != 1 || keys[0] != "unrelated" {
+		t.Errorf("expected migrated keys removed from the legacy keyring, left %v", keys)
+	}
+}
+
+func TestKeyringStore_LegacyFallback(t *testing.T) {
+	store := &KeyringStore{
+		ring:   keyring.NewArrayKeyring(nil),
+		legacy: keyring.NewArrayKeyring([]keyring.Item{{Key: "workspace:acme", Data: []byte(`{"api_key":"dub_old"}`)}}),
+	}
+
+	if creds, err := store.Get("acme"); err != nil || creds.APIKey != "dub_old" {
+		t.Fatalf("expected to read the legacy key, got %+v, %v", creds, err)
+	}
+	if list, err := store.List(); err != nil || len(list) != 1 {
+		t.Errorf("expected the legacy key listed, got %v, %v", list, err)
+	}
+	if err := store.Delete("acme"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get("acme"); err == nil {
+		t.Error("expected the legacy key to be deleted")
+	}
+}
@@ -0,0 +1,125 @@
+// Package telemetry records anonymous, opt-in usage metrics.
+// Nothing is sent unless telemetry has been explicitly enabled via
+// `dub telemetry enable` or the DUB_TELEMETRY environment variable.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+// SendTimeout bounds how long a command may be delayed by reporting.
+const SendTimeout = 2 * time.Second
+
+// Source describes where the effective telemetry setting came from.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceConfig  Source = "config"
+	SourceEnv     Source = "env"
+)
+
+// Settings is the effective telemetry configuration.
+type Settings struct {
+	Enabled  bool
+	Endpoint string
+	Source   Source
+}
+
+// Active reports whether events should be sent.
+func (s Settings) Active() bool {
+	return s.Enabled && s.Endpoint != ""
+}
+
+// Event is a single anonymous usage record. It deliberately carries no
+// workspace, argument, or user identifiers.
+type Event struct {
+	Command    string `json:"command"`
+	DurationMs int64  `json:"duration_ms"`
+	Success    bool   `json:"success"`
+	Version    string `json:"version"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+}
+
+// NewEvent builds an event for a finished command.
+func NewEvent(command, version string, duration time.Duration, success bool) Event {
+	return Event{
+		Command:    command,
+		DurationMs: duration.Milliseconds(),
+		Success:    success,
+		Version:    version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+}
+
+// Resolve returns the effective telemetry settings.
+// DUB_TELEMETRY (1/0/true/false) and DUB_TELEMETRY_ENDPOINT take precedence
+// over the config file. Telemetry is disabled by default.
+func Resolve() (Settings, error) {
+	settings := Settings{Source: SourceDefault}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return settings, err
+	}
+	if cfg.Telemetry {
+		settings.Enabled = true
+		settings.Source = SourceConfig
+	}
+	settings.Endpoint = cfg.TelemetryEndpoint
+
+	if v := os.Getenv("DUB_TELEMETRY"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return settings, fmt.Errorf("invalid DUB_TELEMETRY value %q: expected true or false", v)
+		}
+		settings.Enabled = enabled
+		settings.Source = SourceEnv
+	}
+	if v := os.Getenv("DUB_TELEMETRY_ENDPOINT"); v != "" {
+		settings.Endpoint = v
+	}
+
+	return settings, nil
+}
+
+// Send posts an event to the endpoint. The request is bounded by SendTimeout.
+func Send(ctx context.Context, endpoint string, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, SendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "dub-cli/"+event.Version)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/dub-cli/internal/config"
+)
+
+func TestResolve_DisabledByDefault(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_TELEMETRY", "")
+	t.Setenv("DUB_TELEMETRY_ENDPOINT", "")
+
+	s, err := Resolve()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Enabled || s.Active() {
+		t.Errorf("expected telemetry disabled by default, got %+v", s)
+	}
+	if s.Source != SourceDefault {
+		t.Errorf("expected source %q, got %q", SourceDefault, s.Source)
+	}
+}
+
+func TestResolve_ConfigAndEnv(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_TELEMETRY", "")
+	t.Setenv("DUB_TELEMETRY_ENDPOINT", "")
+
+	if err := config.SetTelemetry(true, "https://config.example.com"); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	s, err := Resolve()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.Active() || s.Source != SourceConfig || s.Endpoint != "https://config.example.com" {
+		t.Errorf("unexpected settings from config: %+v", s)
+	}
+
+	// Environment overrides config
+	t.Setenv("DUB_TELEMETRY", "false")
+	t.Setenv("DUB_TELEMETRY_ENDPOINT", "https://env.example.com")
+
+	s, err = Resolve()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Enabled || s.Source != SourceEnv || s.Endpoint != "https://env.example.com" {
+		t.Errorf("unexpected settings from env: %+v", s)
+	}
+}
+
+func TestResolve_InvalidEnv(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("DUB_TELEMETRY", "maybe")
+
+	if _, err := Resolve(); err == nil {
+		t.Error("expected error for invalid DUB_TELEMETRY value")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	event := NewEvent("dub links list", "1.2.3", 1500*time.Millisecond, true)
+	if err := Send(context.Background(), server.URL, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Command != "dub links list" || got.DurationMs != 1500 || !got.Success || got.Version != "1.2.3" {
+		t.Errorf("unexpected event received: %+v", got)
+	}
+}
+
+func TestSend_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := Send(context.Background(), server.URL, Event{}); err == nil {
+		t.Error("expected error for 500 response")
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrCanceled is returned when the user cancels a prompt.
+var ErrCanceled = errors.New("canceled")
+
+// multiSelectPageSize is the number of options visible at once.
+const multiSelectPageSize = 15
+
+// MultiSelect shows options as a checklist on the terminal f and returns the
+// indexes of those the user selects, in order. Arrow keys (or j/k) move,
+// space toggles, a toggles all, enter confirms, and q or ctrl-c cancels.
+func MultiSelect(f *os.File, out io.Writer, title string, options []string) ([]int, error) {
+	if !term.IsTerminal(int(f.Fd())) {
+		return nil, errors.New("interactive selection requires a terminal")
+	}
+	state, err := term.MakeRaw(int(f.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = term.Restore(int(f.Fd()), state) }()
+
+	return multiSelect(f, out, title, options)
+}
+
+// multiSelect runs the checklist over raw key input from in.
+func multiSelect(in io.Reader, out io.Writer, title string, options []string) ([]int, error) {
+	keys := bufio.NewReader(in)
+	selected := make([]bool, len(options))
+	cursor, drawn := 0, 0
+
+	for {
+		drawn = drawMultiSelect(out, title, options, selected, cursor, drawn)
+
+		b, err := keys.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch b {
+		case 'k':
+			cursor = max(cursor-1, 0)
+		case 'j':
+			cursor = min(cursor+1, len(options)-1)
+		case 0x1b: // ESC [ A / ESC [ B are the up and down arrows
+			if next, _ := keys.ReadByte(); next != '[' {
+				break
+			}
+			switch arrow, _ := keys.ReadByte(); arrow {
+			case 'A':
+				cursor = max(cursor-1, 0)
+			case 'B':
+				cursor = min(cursor+1, len(options)-1)
+			}
+		case ' ':
+			if len(options) > 0 {
+				selected[cursor] = !selected[cursor]
+			}
+		case 'a':
+			all := true
+			for _, s := range selected {
+				all = all && s
+			}
+			for i := range selected {
+				selected[i] = !all
+			}
+		case '\r', '\n':
+			var picked []int
+			for i, s := range selected {
+				if s {
+					picked = append(picked, i)
+				}
+			}
+			_, _ = fmt.Fprint(out, "\r\n")
+			return picked, nil
+		case 'q', 0x03:
+			_, _ = fmt.Fprint(out, "\r\n")
+			return nil, ErrCanceled
+		}
+	}
+}
+
+// drawMultiSelect redraws the checklist over the previous drawing of
+// drawn lines and returns the number of lines now drawn. Lines end in
+// \r\n since the terminal is in raw mode.
+func drawMultiSelect(out io.Writer, title string, options []string, selected []bool, cursor, drawn int) int {
+	var sb strings.Builder
+	if drawn > 0 {
+		fmt.Fprintf(&sb, "\x1b[%dA\r\x1b[J", drawn)
+	}
+
+	count := 0
+	for _, s := range selected {
+		if s {
+			count++
+		}
+	}
+	fmt.Fprintf(&sb, "%s %s\r\n", Bold(title), Dim(fmt.Sprintf("(%d of %d selected; space toggles, a all, enter confirms, q cancels)", count, len(options))))
+	lines := 1
+
+	start := max(0, min(cursor-multiSelectPageSize/2, len(options)-multiSelectPageSize))
+	end := min(len(options), start+multiSelectPageSize)
+	for i := start; i < end; i++ {
+		pointer, box := "  ", "[ ]"
+		if i == cursor {
+			pointer = Cyan("> ")
+		}
+		if selected[i] {
+			box = Success("[x]")
+		}
+		fmt.Fprintf(&sb, "%s%s %s\r\n", pointer, box, options[i])
+		lines++
+	}
+	if end-start < len(options) {
+		fmt.Fprintf(&sb, "%s\r\n", Dim(fmt.Sprintf("  %d-%d of %d", start+1, end, len(options))))
+		lines++
+	}
+
+	_, _ = io.WriteString(out, sb.String())
+	return lines
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMultiSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want []int
+	}{
+		{"toggle and move", " j\x1b[B \r", []int{0, 2}},
+		{"toggle off", "  j \r", []int{1}},
+		{"select all", "a\r", []int{0, 1, 2}},
+		{"select all then deselect", "aa\r", nil},
+		{"cursor stops at ends", "kkk jjjjj \r", []int{0, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := multiSelect(strings.NewReader(tt.keys), &out, "Pick", []string{"one", "two", "three"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiSelect_Cancel(t *testing.T) {
+	for _, key := range []string{"q", "\x03"} {
+		if _, err := multiSelect(strings.NewReader(" "+key), &bytes.Buffer{}, "Pick", []string{"one"}); !errors.Is(err, ErrCanceled) {
+			t.Errorf("key %q: expected ErrCanceled, got %v", key, err)
+		}
+	}
+}
+
+func TestMultiSelect_Pages(t *testing.T) {
+	options := make([]string, 40)
+	for i := range options {
+		options[i] = "option"
+	}
+	var out bytes.Buffer
+	if _, err := multiSelect(strings.NewReader("\r"), &out, "Pick", options); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(out.String(), "[ ]"); got != multiSelectPageSize {
+		t.Errorf("expected %d visible options, got %d", multiSelectPageSize, got)
+	}
+	if !strings.Contains(out.String(), "1-15 of 40") {
+		t.Errorf("expected page indicator in %q", out.String())
+	}
+}
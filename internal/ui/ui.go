@@ -4,6 +4,7 @@
 package ui
 
 import (
+	"io"
 	"os"
 	"sync"
 
@@ -22,19 +23,28 @@ var (
 func Init(color string) {
 	initOnce.Do(func() {
 		colorMode = color
-		output = createOutput(color)
+		output = createOutput(color, os.Stdout)
 	})
 }
 
-// createOutput creates a termenv.Output based on color mode.
-func createOutput(color string) *termenv.Output {
+// Configure sets the color mode for output written to w, replacing any
+// earlier configuration. A long-lived process running several commands,
+// like the daemon, calls it for each one.
+func Configure(color string, w io.Writer) {
+	initOnce.Do(func() {})
+	colorMode = color
+	output = createOutput(color, w)
+}
+
+// createOutput creates a termenv.Output for w based on color mode.
+func createOutput(color string, w io.Writer) *termenv.Output {
 	switch color {
 	case "always":
-		return termenv.NewOutput(os.Stdout, termenv.WithProfile(termenv.TrueColor))
+		return termenv.NewOutput(w, termenv.WithProfile(termenv.TrueColor))
 	case "never":
-		return termenv.NewOutput(os.Stdout, termenv.WithProfile(termenv.Ascii))
+		return termenv.NewOutput(w, termenv.WithProfile(termenv.Ascii))
 	default: // "auto"
-		return termenv.NewOutput(os.Stdout)
+		return termenv.NewOutput(w)
 	}
 }
 
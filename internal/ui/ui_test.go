@@ -106,3 +106,16 @@ func TestDefaultInit(t *testing.T) {
 		t.Errorf("Success without Init should still work, got %q", result)
 	}
 }
+
+func TestConfigureReplacesInit(t *testing.T) {
+	Reset()
+	Init("always")
+	Configure("never", &strings.Builder{})
+	if ColorMode() != "never" || HasColors() {
+		t.Errorf("expected Configure to replace the earlier mode, got %q", ColorMode())
+	}
+	Configure("always", &strings.Builder{})
+	if !HasColors() {
+		t.Error("expected Configure to apply again")
+	}
+}